@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readEvents(t *testing.T, path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+	ids := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	line, err := json.Marshal(&Event{ID: "0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// two events fit in a file
+	sink, err := NewFileSink(path, int64(2*(len(line)+1)), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sink.Close()
+
+	for _, id := range []string{"0", "1", "2", "3", "4", "5", "6"} {
+		if err := sink.Write(&Event{ID: id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := map[string][]string{
+		path:        {"6"},
+		path + ".1": {"4", "5"},
+		path + ".2": {"2", "3"},
+	}
+	for file, ids := range expected {
+		if actual := readEvents(t, file); !reflect.DeepEqual(actual, ids) {
+			t.Errorf("%s: expected %v, got %v", file, ids, actual)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, got %v", err)
+	}
+}
+
+func TestFileSinkAppend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	for _, id := range []string{"0", "1"} {
+		sink, err := NewFileSink(path, 0, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := sink.Write(&Event{ID: id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sink.Close()
+	}
+	if ids := readEvents(t, path); len(ids) != 2 {
+		t.Errorf("expected the events to be appended, got %v", ids)
+	}
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"strings"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+// sensitiveResources hold credentials or values that may be secret, such as template parameters and webhook secrets,
+// so their bodies are never recorded
+var sensitiveResources = sets.NewString(
+	"secrets",
+	"users",
+	"oauthaccesstokens",
+	"oauthauthorizetokens",
+	"oauthclients",
+	"personalaccesstokens",
+	"useroauthaccesstokens",
+	"templateinstances",
+	"processedtemplates",
+	"templateconfigs",
+	"buildconfigs",
+)
+
+// tokenResources are named by the token they hold, so their names are never recorded
+var tokenResources = sets.NewString(
+	"oauthaccesstokens",
+	"oauthauthorizetokens",
+	"useroauthaccesstokens",
+)
+
+// redactedName replaces the names of tokenResources in events and the URIs of requests
+const redactedName = "<redacted>"
+
+// Redact returns the name and the URI of a request for the named resource, with the name replaced if it is a token
+func Redact(resource, name, uri string) (string, string) {
+	resource = strings.SplitN(resource, "/", 2)[0]
+	if len(name) == 0 || !tokenResources.Has(resource) {
+		return name, uri
+	}
+	return redactedName, strings.Replace(uri, name, redactedName, -1)
+}
+
+type policyRule struct {
+	level      Level
+	users      sets.String
+	groups     sets.String
+	verbs      sets.String
+	resources  sets.String
+	namespaces sets.String
+}
+
+// Policy selects the level requests are recorded at
+type Policy struct {
+	rules []policyRule
+}
+
+// NewPolicy returns the policy of the given rules. An empty policy records all requests at the Metadata level.
+func NewPolicy(rules []configapi.AuditPolicyRule) *Policy {
+	p := &Policy{}
+	for _, rule := range rules {
+		p.rules = append(p.rules, policyRule{
+			level:      Level(rule.Level),
+			users:      sets.NewString(rule.Users...),
+			groups:     sets.NewString(rule.Groups...),
+			verbs:      sets.NewString(rule.Verbs...),
+			resources:  sets.NewString(rule.Resources...),
+			namespaces: sets.NewString(rule.Namespaces...),
+		})
+	}
+	return p
+}
+
+// Level returns the level of the first rule matching the request, or LevelNone if no rule matches. Requests for
+// subresources, such as pods/log, match the rules of their resource. The level of requests for sensitive resources
+// is lowered to Metadata.
+func (p *Policy) Level(u user.Info, verb, namespace, resource string) Level {
+	level := LevelNone
+	if len(p.rules) == 0 {
+		level = LevelMetadata
+	}
+	resource = strings.SplitN(resource, "/", 2)[0]
+	for _, rule := range p.rules {
+		if rule.matches(u, verb, namespace, resource) {
+			level = rule.level
+			break
+		}
+	}
+	if LevelMetadata.Less(level) && sensitiveResources.Has(resource) {
+		level = LevelMetadata
+	}
+	return level
+}
+
+func (r policyRule) matches(u user.Info, verb, namespace, resource string) bool {
+	if len(r.users) > 0 && (u == nil || !r.users.Has(u.GetName())) {
+		return false
+	}
+	if len(r.groups) > 0 && (u == nil || !r.groups.HasAny(u.GetGroups()...)) {
+		return false
+	}
+	if len(r.verbs) > 0 && !r.verbs.Has(verb) {
+		return false
+	}
+	if len(r.resources) > 0 && !r.resources.Has(resource) {
+		return false
+	}
+	if len(r.namespaces) > 0 && !r.namespaces.Has(namespace) {
+		return false
+	}
+	return true
+}
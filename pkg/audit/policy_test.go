@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	configapi "github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+func TestPolicyLevel(t *testing.T) {
+	policy := NewPolicy([]configapi.AuditPolicyRule{
+		{Level: configapi.AuditLevelNone, Users: []string{"system:kube-proxy"}},
+		{Level: configapi.AuditLevelNone, Verbs: []string{"get", "list", "watch"}, Groups: []string{"system:nodes"}},
+		{Level: configapi.AuditLevelRequestResponse, Resources: []string{"rolebindings", "secrets"}},
+		{Level: configapi.AuditLevelRequest, Namespaces: []string{"production"}},
+		{Level: configapi.AuditLevelMetadata, Verbs: []string{"create", "update", "patch", "delete"}},
+	})
+
+	alice := &user.DefaultInfo{Name: "alice", Groups: []string{"system:authenticated"}}
+	node := &user.DefaultInfo{Name: "system:node:a", Groups: []string{"system:nodes"}}
+	proxy := &user.DefaultInfo{Name: "system:kube-proxy"}
+
+	testCases := map[string]struct {
+		user      user.Info
+		verb      string
+		namespace string
+		resource  string
+		expected  Level
+	}{
+		"user rule":                  {user: proxy, verb: "update", namespace: "ns", resource: "rolebindings", expected: LevelNone},
+		"group rule":                 {user: node, verb: "get", namespace: "ns", resource: "pods", expected: LevelNone},
+		"group rule other verb":      {user: node, verb: "update", namespace: "ns", resource: "pods/status", expected: LevelMetadata},
+		"resource rule":              {user: alice, verb: "create", namespace: "ns", resource: "rolebindings", expected: LevelRequestResponse},
+		"sensitive resource capped":  {user: alice, verb: "get", namespace: "ns", resource: "secrets", expected: LevelMetadata},
+		"template parameters capped": {user: alice, verb: "create", namespace: "production", resource: "processedtemplates", expected: LevelMetadata},
+		"namespace rule":             {user: alice, verb: "get", namespace: "production", resource: "pods", expected: LevelRequest},
+		"subresource":                {user: alice, verb: "get", namespace: "production", resource: "pods/log", expected: LevelRequest},
+		"verb rule":                  {user: alice, verb: "delete", namespace: "ns", resource: "pods", expected: LevelMetadata},
+		"no match":                   {user: alice, verb: "get", namespace: "ns", resource: "pods", expected: LevelNone},
+	}
+	for name, tc := range testCases {
+		if level := policy.Level(tc.user, tc.verb, tc.namespace, tc.resource); level != tc.expected {
+			t.Errorf("%s: expected %s, got %s", name, tc.expected, level)
+		}
+	}
+
+	if level := NewPolicy(nil).Level(alice, "get", "ns", "pods"); level != LevelMetadata {
+		t.Errorf("expected an empty policy to record metadata, got %s", level)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	testCases := map[string]struct {
+		resource, name, uri       string
+		expectedName, expectedURI string
+	}{
+		"access token": {
+			resource: "oauthaccesstokens", name: "secret-token", uri: "/oapi/v1/oauthaccesstokens/secret-token?pretty=true",
+			expectedName: "<redacted>", expectedURI: "/oapi/v1/oauthaccesstokens/<redacted>?pretty=true",
+		},
+		"user access token": {
+			resource: "useroauthaccesstokens", name: "secret-token", uri: "/oapi/v1/useroauthaccesstokens/secret-token",
+			expectedName: "<redacted>", expectedURI: "/oapi/v1/useroauthaccesstokens/<redacted>",
+		},
+		"token list": {
+			resource: "oauthaccesstokens", uri: "/oapi/v1/oauthaccesstokens",
+			expectedURI: "/oapi/v1/oauthaccesstokens",
+		},
+		"other resource": {
+			resource: "secrets", name: "builder-token", uri: "/api/v1/namespaces/ns/secrets/builder-token",
+			expectedName: "builder-token", expectedURI: "/api/v1/namespaces/ns/secrets/builder-token",
+		},
+	}
+	for name, tc := range testCases {
+		redactedName, redactedURI := Redact(tc.resource, tc.name, tc.uri)
+		if redactedName != tc.expectedName || redactedURI != tc.expectedURI {
+			t.Errorf("%s: expected %s %s, got %s %s", name, tc.expectedName, tc.expectedURI, redactedName, redactedURI)
+		}
+	}
+}
+
+func TestBodyEncode(t *testing.T) {
+	testCases := map[string]struct {
+		max               int64
+		data              string
+		expected          string
+		expectedTruncated bool
+	}{
+		"empty":     {max: 10, data: "", expected: ""},
+		"json":      {max: 100, data: "{\n  \"kind\": \"RoleBinding\"\n}", expected: `{"kind":"RoleBinding"}`},
+		"text":      {max: 100, data: "not json", expected: `"not json"`},
+		"truncated": {max: 5, data: `{"kind":"RoleBinding"}`, expected: `"{\"kin"`, expectedTruncated: true},
+	}
+	for name, tc := range testCases {
+		body := NewBody(tc.max)
+		// write in two parts, as readers and writers do
+		body.Write([]byte(tc.data[:len(tc.data)/2]))
+		body.Write([]byte(tc.data[len(tc.data)/2:]))
+		encoded, truncated := body.Encode()
+		if string(encoded) != tc.expected || truncated != tc.expectedTruncated {
+			t.Errorf("%s: expected %s %v, got %s %v", name, tc.expected, tc.expectedTruncated, string(encoded), truncated)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package audit records the requests made to the API as structured events, selected by a policy, and writes them to
+// a file or a remote server.
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Level is the detail recorded for a request
+type Level string
+
+const (
+	// LevelNone does not record the request
+	LevelNone Level = "None"
+	// LevelMetadata records who made the request, what it was made against and the response code
+	LevelMetadata Level = "Metadata"
+	// LevelRequest records the metadata and the request body
+	LevelRequest Level = "Request"
+	// LevelRequestResponse records the metadata, the request body and the response body
+	LevelRequestResponse Level = "RequestResponse"
+)
+
+var levelOrder = map[Level]int{
+	LevelNone:            0,
+	LevelMetadata:        1,
+	LevelRequest:         2,
+	LevelRequestResponse: 3,
+}
+
+// Less returns true if l records less than other
+func (l Level) Less(other Level) bool {
+	return levelOrder[l] < levelOrder[other]
+}
+
+// DefaultMaxBodyBytes is the maximum size of the bodies recorded in events, if none is configured
+const DefaultMaxBodyBytes = 64 * 1024
+
+// Event is the record of a request, written as a JSON object
+type Event struct {
+	// ID identifies the request, and matches the id of the AUDIT lines of the server log
+	ID string `json:"id"`
+	// Timestamp is the time the request was received
+	Timestamp time.Time `json:"timestamp"`
+	Level     Level     `json:"level"`
+
+	SourceIP string `json:"sourceIP"`
+	Method   string `json:"method"`
+	URI      string `json:"uri"`
+
+	// User and Groups are the authenticated user, before impersonation
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+	// ImpersonatedUser and ImpersonatedGroups are the user and groups the request is made as, if any
+	ImpersonatedUser   string   `json:"impersonatedUser,omitempty"`
+	ImpersonatedGroups []string `json:"impersonatedGroups,omitempty"`
+
+	Verb      string `json:"verb,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	APIGroup  string `json:"apiGroup,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	Name      string `json:"name,omitempty"`
+
+	ResponseCode int `json:"responseCode"`
+
+	// RequestBody and ResponseBody are recorded as JSON if they are complete JSON documents, and as strings otherwise
+	RequestBody           json.RawMessage `json:"requestBody,omitempty"`
+	RequestBodyTruncated  bool            `json:"requestBodyTruncated,omitempty"`
+	ResponseBody          json.RawMessage `json:"responseBody,omitempty"`
+	ResponseBodyTruncated bool            `json:"responseBodyTruncated,omitempty"`
+}
+
+// Body records up to a maximum number of the bytes written to it
+type Body struct {
+	max       int64
+	buf       bytes.Buffer
+	truncated bool
+}
+
+// NewBody returns a Body recording up to max bytes
+func NewBody(max int64) *Body {
+	return &Body{max: max}
+}
+
+// Write records the bytes that fit in the body. It never fails, so it can be used with io.TeeReader and
+// io.MultiWriter.
+func (b *Body) Write(p []byte) (int, error) {
+	if remaining := b.max - int64(b.buf.Len()); int64(len(p)) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+// Encode returns the recorded bytes as a JSON value, and whether they were truncated
+func (b *Body) Encode() (json.RawMessage, bool) {
+	data := b.buf.Bytes()
+	if len(data) == 0 {
+		return nil, b.truncated
+	}
+	if !b.truncated {
+		compacted := &bytes.Buffer{}
+		if err := json.Compact(compacted, data); err == nil {
+			return compacted.Bytes(), false
+		}
+	}
+	encoded, _ := json.Marshal(string(data))
+	return encoded, b.truncated
+}
@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// webhookBufferSize is the number of events waiting to be sent before new events are dropped
+	webhookBufferSize = 10000
+	// webhookMaxBatchSize is the maximum number of events sent in one request
+	webhookMaxBatchSize = 400
+	// webhookTimeout is the timeout of the requests to the server
+	webhookTimeout = 30 * time.Second
+)
+
+var droppedEventCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "audit_webhook_dropped_event_count",
+		Help: "Counter of the audit events the webhook dropped, broken out by the reason they were dropped",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	prometheus.MustRegister(droppedEventCounter)
+}
+
+// WebhookSink sends events to a remote server, as JSON arrays of events POSTed to its URL. Events are buffered and
+// sent in the background by Run, so a slow server does not slow down the API. Events are dropped when the buffer is
+// full or the server fails to accept them, which is logged and counted.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	events chan *Event
+	// dropped is the number of events dropped so far, accessed atomically
+	dropped uint64
+}
+
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink returns a sink sending events to url through the given transport
+func NewWebhookSink(url string, transport http.RoundTripper) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Transport: transport, Timeout: webhookTimeout},
+		events: make(chan *Event, webhookBufferSize),
+	}
+}
+
+// Write queues the event to be sent, or drops it and returns an error if the buffer is full
+func (s *WebhookSink) Write(event *Event) error {
+	select {
+	case s.events <- event:
+		return nil
+	default:
+		dropped := s.drop("buffer_full", 1)
+		return fmt.Errorf("the audit webhook buffer is full, dropping event %s (%d events dropped so far)", event.ID, dropped)
+	}
+}
+
+// Dropped returns the number of events dropped so far
+func (s *WebhookSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// drop counts n events dropped for the reason, and returns the number of events dropped so far
+func (s *WebhookSink) drop(reason string, n int) uint64 {
+	droppedEventCounter.WithLabelValues(reason).Add(float64(n))
+	return atomic.AddUint64(&s.dropped, uint64(n))
+}
+
+// Run sends the queued events in batches until stopCh is closed
+func (s *WebhookSink) Run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case event := <-s.events:
+			batch := []*Event{event}
+		fill:
+			for len(batch) < webhookMaxBatchSize {
+				select {
+				case event := <-s.events:
+					batch = append(batch, event)
+				default:
+					break fill
+				}
+			}
+			if err := s.send(batch); err != nil {
+				dropped := s.drop("send_failed", len(batch))
+				glog.Errorf("Unable to send %d audit events to %s, dropping them (%d events dropped so far): %v", len(batch), s.url, dropped, err)
+			}
+		}
+	}
+}
+
+func (s *WebhookSink) send(events []*Event) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response %d", resp.StatusCode)
+	}
+	return nil
+}
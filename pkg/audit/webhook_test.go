@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+func TestWebhookSink(t *testing.T) {
+	received := make(chan []*Event, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %v", req.Method, req.Header)
+		}
+		events := []*Event{}
+		if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		received <- events
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, http.DefaultTransport)
+	for _, id := range []string{"0", "1", "2"} {
+		if err := sink.Write(&Event{ID: id, Resource: "rolebindings"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go sink.Run(stopCh)
+
+	ids := []string{}
+	for len(ids) < 3 {
+		select {
+		case events := <-received:
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("timed out waiting for events, got %v", ids)
+		}
+	}
+	for i, id := range ids {
+		if expected := []string{"0", "1", "2"}[i]; id != expected {
+			t.Errorf("expected event %s, got %s", expected, id)
+		}
+	}
+}
+
+func TestWebhookSinkFull(t *testing.T) {
+	sink := NewWebhookSink("http://localhost", http.DefaultTransport)
+	for i := 0; i < webhookBufferSize; i++ {
+		if err := sink.Write(&Event{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := sink.Write(&Event{}); err == nil {
+		t.Errorf("expected an error when the buffer is full")
+	}
+	if dropped := sink.Dropped(); dropped != 1 {
+		t.Errorf("expected one dropped event, got %d", dropped)
+	}
+}
@@ -32,6 +32,15 @@ type UserIdentityInfo interface {
 	GetExtra() map[string]string
 }
 
+// UserIdentityInfoGroups is implemented by identities whose provider also describes the groups the
+// identity is a member of.
+type UserIdentityInfoGroups interface {
+	UserIdentityInfo
+	// GetProviderGroups returns the names of the groups the identity is a member of, or nil if the provider did
+	// not describe the groups of this identity. An empty list means the identity is not a member of any group.
+	GetProviderGroups() []string
+}
+
 // UserIdentityMapper maps UserIdentities into user.Info objects to allow different user abstractions within auth code.
 type UserIdentityMapper interface {
 	// UserFor takes an identity, ignores the passed identity.Provider, forces the provider value to some other value and then creates the mapping.
@@ -57,6 +66,7 @@ type DefaultUserIdentityInfo struct {
 	ProviderName     string
 	ProviderUserName string
 	Extra            map[string]string
+	ProviderGroups   []string
 }
 
 // NewDefaultUserIdentityInfo returns a DefaultUserIdentityInfo with a non-nil Extra component
@@ -83,3 +93,7 @@ func (i *DefaultUserIdentityInfo) GetProviderUserName() string {
 func (i *DefaultUserIdentityInfo) GetExtra() map[string]string {
 	return i.Extra
 }
+
+func (i *DefaultUserIdentityInfo) GetProviderGroups() []string {
+	return i.ProviderGroups
+}
@@ -19,6 +19,12 @@ type Password interface {
 	AuthenticatePassword(user, password string) (user.Info, bool, error)
 }
 
+// SourcePassword is implemented by password authenticators that take the network address of the client into account.
+// The source is returned by RequestSource.
+type SourcePassword interface {
+	AuthenticatePasswordFromSource(source, user, password string) (user.Info, bool, error)
+}
+
 type Assertion interface {
 	AuthenticateAssertion(assertionType, data string) (user.Info, bool, error)
 }
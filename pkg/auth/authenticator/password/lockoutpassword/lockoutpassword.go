@@ -0,0 +1,229 @@
+package lockoutpassword
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/record"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+)
+
+const (
+	// pruneInterval is how often the attempts of unlocked users and expired periods are forgotten
+	pruneInterval = time.Minute
+	// maxLoggedNameLength is the length login names are truncated to in logs and events, since they are chosen by the
+	// client
+	maxLoggedNameLength = 64
+)
+
+// Attempts tracks the failed logins of users and client addresses. It is shared by the password identity providers,
+// so failures against any of them count against the same client address. The attempts are kept in memory: with
+// several masters, each of them counts the failed logins it receives.
+type Attempts struct {
+	lock sync.Mutex
+
+	maxUserFailures   int
+	userLockout       time.Duration
+	maxSourceFailures int
+	sourceWindow      time.Duration
+	trustedProxies    []*net.IPNet
+
+	users     map[string]*userAttempts
+	sources   map[string]*sourceAttempts
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+type userAttempts struct {
+	// failures is the number of consecutive failures
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+type sourceAttempts struct {
+	// failures is the number of failures since windowStart
+	failures    int
+	windowStart time.Time
+}
+
+// NewAttempts returns Attempts locking out users after maxUserFailures consecutive failures for userLockout, and
+// throttling client addresses after maxSourceFailures failures within sourceWindow until the window ends. A maximum of
+// 0 disables the corresponding limit. The client address of requests received from trustedProxies is the address they
+// forwarded the request for.
+func NewAttempts(maxUserFailures int, userLockout time.Duration, maxSourceFailures int, sourceWindow time.Duration, trustedProxies []*net.IPNet) *Attempts {
+	return &Attempts{
+		maxUserFailures:   maxUserFailures,
+		userLockout:       userLockout,
+		maxSourceFailures: maxSourceFailures,
+		sourceWindow:      sourceWindow,
+		trustedProxies:    trustedProxies,
+		users:             map[string]*userAttempts{},
+		sources:           map[string]*sourceAttempts{},
+		now:               time.Now,
+	}
+}
+
+// denied returns whether the user is locked out, and whether the source is throttled
+func (a *Attempts) denied(user, source string) (bool, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+
+	locked := false
+	if u, ok := a.users[user]; ok && now.Before(u.lockedUntil) {
+		locked = true
+	}
+	throttled := false
+	if s, ok := a.sources[source]; ok && a.maxSourceFailures > 0 && s.failures >= a.maxSourceFailures && now.Before(s.windowStart.Add(a.sourceWindow)) {
+		throttled = true
+	}
+	return locked, throttled
+}
+
+// failed records a failed login of the user from the source. It returns whether the failure locked out the user, and
+// whether it throttled the source.
+func (a *Attempts) failed(user, source string) (bool, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+	a.prune(now)
+
+	locked := false
+	if a.maxUserFailures > 0 {
+		u, ok := a.users[user]
+		if !ok || now.Sub(u.lastFailure) > a.userLockout {
+			// failures further apart than the lockout are not consecutive attempts to guess the password
+			u = &userAttempts{}
+			a.users[user] = u
+		}
+		u.failures++
+		u.lastFailure = now
+		if u.failures >= a.maxUserFailures {
+			u.failures = 0
+			u.lockedUntil = now.Add(a.userLockout)
+			locked = true
+		}
+	}
+
+	throttled := false
+	if a.maxSourceFailures > 0 && len(source) > 0 {
+		s, ok := a.sources[source]
+		if !ok || !now.Before(s.windowStart.Add(a.sourceWindow)) {
+			s = &sourceAttempts{windowStart: now}
+			a.sources[source] = s
+		}
+		s.failures++
+		throttled = s.failures == a.maxSourceFailures
+	}
+
+	return locked, throttled
+}
+
+// succeeded resets the failures of the user. The failures of the source are kept, since a valid login to one account
+// does not make guessing the passwords of others less likely.
+func (a *Attempts) succeeded(user string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.users, user)
+}
+
+// prune forgets the users and sources whose failures no longer matter. It must be called with the lock held.
+func (a *Attempts) prune(now time.Time) {
+	if now.Sub(a.lastPrune) < pruneInterval {
+		return
+	}
+	a.lastPrune = now
+	for name, u := range a.users {
+		if !now.Before(u.lockedUntil) && now.Sub(u.lastFailure) > a.userLockout {
+			delete(a.users, name)
+		}
+	}
+	for source, s := range a.sources {
+		if !now.Before(s.windowStart.Add(a.sourceWindow)) {
+			delete(a.sources, source)
+		}
+	}
+}
+
+// lockoutPasswordAuthenticator denies the logins of locked out users and throttled client addresses
+type lockoutPasswordAuthenticator struct {
+	provider    string
+	delegate    authenticator.Password
+	attempts    *Attempts
+	recorder    record.EventRecorder
+	eventObject *kapi.ObjectReference
+}
+
+// New returns a password authenticator recording the failed logins of the delegate in attempts, and denying the
+// logins of the users and client addresses that exceeded their limits, even with a valid password. Lockouts and
+// throttling are recorded as events on eventObject, since the login names do not necessarily belong to a user.
+func New(provider string, delegate authenticator.Password, attempts *Attempts, recorder record.EventRecorder, eventObject *kapi.ObjectReference) authenticator.Password {
+	return &lockoutPasswordAuthenticator{
+		provider:    provider,
+		delegate:    delegate,
+		attempts:    attempts,
+		recorder:    recorder,
+		eventObject: eventObject,
+	}
+}
+
+// AuthenticatePassword authenticates the password without tracking the client address, which is unknown
+func (a *lockoutPasswordAuthenticator) AuthenticatePassword(username, password string) (user.Info, bool, error) {
+	return a.AuthenticatePasswordFromSource("", username, password)
+}
+
+// AuthenticatePasswordFromSource authenticates the password if neither the user nor the client address are locked
+// out, and records failures
+func (a *lockoutPasswordAuthenticator) AuthenticatePasswordFromSource(source, username, password string) (user.Info, bool, error) {
+	// users are tracked per provider, since the same login can belong to different people in different providers
+	key := a.provider + "/" + username
+	if len(source) > 0 {
+		source = authenticator.ClientAddress(source, a.attempts.trustedProxies)
+	}
+
+	if locked, throttled := a.attempts.denied(key, source); locked || throttled {
+		glog.V(4).Infof("Login of %s with provider %q from %q denied: user locked out %v, source throttled %v", quoteUserName(username), a.provider, source, locked, throttled)
+		return nil, false, nil
+	}
+
+	info, ok, err := a.delegate.AuthenticatePassword(username, password)
+	if err != nil {
+		// errors such as an unreachable LDAP server are not failed logins
+		return nil, false, err
+	}
+	if ok {
+		a.attempts.succeeded(key)
+		return info, true, nil
+	}
+
+	locked, throttled := a.attempts.failed(key, source)
+	if len(source) == 0 {
+		source = "an unknown address"
+	}
+	if locked {
+		glog.Warningf("User %s of provider %q locked out for %v after %d failed logins, the last from %s", quoteUserName(username), a.provider, a.attempts.userLockout, a.attempts.maxUserFailures, source)
+		a.recorder.Eventf(a.eventObject, kapi.EventTypeWarning, "LoginLockout", "User %s locked out of identity provider %s for %v after %d failed logins, the last from %s", quoteUserName(username), a.provider, a.attempts.userLockout, a.attempts.maxUserFailures, source)
+	}
+	if throttled {
+		glog.Warningf("Logins from %s throttled for up to %v after %d failed logins, the last as %s with provider %q", source, a.attempts.sourceWindow, a.attempts.maxSourceFailures, quoteUserName(username), a.provider)
+		a.recorder.Eventf(a.eventObject, kapi.EventTypeWarning, "LoginThrottled", "Logins from %s throttled for up to %v after %d failed logins, the last as %s to identity provider %s", source, a.attempts.sourceWindow, a.attempts.maxSourceFailures, quoteUserName(username), a.provider)
+	}
+	return nil, false, nil
+}
+
+// quoteUserName returns the login name chosen by the client truncated and quoted, with special characters escaped
+func quoteUserName(name string) string {
+	if len(name) > maxLoggedNameLength {
+		return strconv.Quote(name[:maxLoggedNameLength]) + "..."
+	}
+	return strconv.Quote(name)
+}
@@ -0,0 +1,170 @@
+package lockoutpassword
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/record"
+)
+
+type testPasswordAuthenticator map[string]string
+
+func (a testPasswordAuthenticator) AuthenticatePassword(username, password string) (user.Info, bool, error) {
+	if username == "error" {
+		return nil, false, errors.New("unavailable")
+	}
+	if expected, ok := a[username]; !ok || expected != password {
+		return nil, false, nil
+	}
+	return &user.DefaultInfo{Name: username}, true, nil
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestAuthenticator(maxUserFailures, maxSourceFailures int) (*lockoutPasswordAuthenticator, *testClock, *record.FakeRecorder) {
+	clock := &testClock{now: time.Unix(1111111111, 0)}
+	_, proxy, _ := net.ParseCIDR("192.168.0.0/16")
+	attempts := NewAttempts(maxUserFailures, 10*time.Minute, maxSourceFailures, time.Hour, []*net.IPNet{proxy})
+	attempts.now = clock.Now
+	recorder := record.NewFakeRecorder(10)
+	delegate := testPasswordAuthenticator{"alice": "secret", "bob": "secret"}
+	eventObject := &kapi.ObjectReference{Kind: "Namespace", Name: "openshift-infra", Namespace: "openshift-infra"}
+	return New("htpasswd", delegate, attempts, recorder, eventObject).(*lockoutPasswordAuthenticator), clock, recorder
+}
+
+func expectLogin(t *testing.T, a *lockoutPasswordAuthenticator, source, username, password string, expected bool) {
+	_, ok, err := a.AuthenticatePasswordFromSource(source, username, password)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok != expected {
+		t.Fatalf("expected the login of %s from %s with %s to be %v, got %v", username, source, password, expected, ok)
+	}
+}
+
+func TestUserLockout(t *testing.T) {
+	a, clock, recorder := newTestAuthenticator(3, 0)
+
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	// a successful login resets the consecutive failures
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", true)
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.2", "alice", "wrong", false)
+	if len(recorder.Events) != 0 {
+		t.Fatalf("unexpected events before the lockout: %d", len(recorder.Events))
+	}
+	expectLogin(t, a, "10.0.0.3", "alice", "wrong", false)
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected a lockout event, got %d events", len(recorder.Events))
+	}
+
+	// locked out users are denied with a valid password, other users are not
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", false)
+	expectLogin(t, a, "10.0.0.1", "bob", "secret", true)
+
+	clock.now = clock.now.Add(11 * time.Minute)
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", true)
+}
+
+func TestUserFailuresExpire(t *testing.T) {
+	a, clock, _ := newTestAuthenticator(2, 0)
+
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	// failures further apart than the lockout are not consecutive
+	clock.now = clock.now.Add(11 * time.Minute)
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", true)
+}
+
+func TestSourceThrottling(t *testing.T) {
+	a, clock, recorder := newTestAuthenticator(0, 3)
+
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.1", "bob", "wrong", false)
+	// a successful login does not reset the failures of the source
+	expectLogin(t, a, "10.0.0.1", "bob", "secret", true)
+	expectLogin(t, a, "10.0.0.1", "carol", "wrong", false)
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected a throttling event, got %d events", len(recorder.Events))
+	}
+
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", false)
+	expectLogin(t, a, "10.0.0.2", "alice", "secret", true)
+	// logins without a known source are not throttled
+	if _, ok, _ := a.AuthenticatePassword("alice", "secret"); !ok {
+		t.Errorf("expected the login without a source to succeed")
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", true)
+}
+
+func TestTrustedProxies(t *testing.T) {
+	a, _, _ := newTestAuthenticator(0, 2)
+
+	// the client address is taken from the forwarded addresses of trusted proxies only
+	expectLogin(t, a, "10.0.0.1, 192.168.0.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.2, 10.0.0.1, 192.168.0.2", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.1, 192.168.0.1", "alice", "secret", false)
+	expectLogin(t, a, "10.0.0.3, 192.168.0.1", "alice", "secret", true)
+
+	// addresses forwarded through an untrusted address are ignored
+	expectLogin(t, a, "10.0.0.4, 10.0.1.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.5, 10.0.1.1", "alice", "wrong", false)
+	expectLogin(t, a, "10.0.0.6, 10.0.1.1", "alice", "secret", false)
+}
+
+func TestEventsQuoteUserNames(t *testing.T) {
+	a, _, recorder := newTestAuthenticator(1, 0)
+
+	name := "mallory\nUser \"admin\" logged in" + strings.Repeat("x", 100)
+	expectLogin(t, a, "10.0.0.1", name, "wrong", false)
+	event := <-recorder.Events
+	if strings.Contains(event, "\n") || strings.Contains(event, strings.Repeat("x", 100)) {
+		t.Errorf("expected the user name to be escaped and truncated: %s", event)
+	}
+	if !strings.Contains(event, `"mallory\nUser \"admin\" logged in`) {
+		t.Errorf("expected the quoted user name in the event: %s", event)
+	}
+}
+
+func TestErrorsAreNotFailures(t *testing.T) {
+	a, _, _ := newTestAuthenticator(1, 1)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := a.AuthenticatePasswordFromSource("10.0.0.1", "error", "secret"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+	expectLogin(t, a, "10.0.0.1", "alice", "secret", true)
+}
+
+func TestPrune(t *testing.T) {
+	a, clock, _ := newTestAuthenticator(2, 2)
+
+	expectLogin(t, a, "10.0.0.1", "alice", "wrong", false)
+	clock.now = clock.now.Add(2 * time.Hour)
+	expectLogin(t, a, "10.0.0.2", "bob", "wrong", false)
+
+	if _, ok := a.attempts.users["htpasswd/alice"]; ok {
+		t.Errorf("expected the failures of alice to be forgotten")
+	}
+	if _, ok := a.attempts.sources["10.0.0.1"]; ok {
+		t.Errorf("expected the failures of 10.0.0.1 to be forgotten")
+	}
+	if _, ok := a.attempts.users["htpasswd/bob"]; !ok {
+		t.Errorf("expected the failures of bob to be kept")
+	}
+}
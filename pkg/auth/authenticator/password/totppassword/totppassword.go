@@ -0,0 +1,150 @@
+package totppassword
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+// SecretGetter retrieves the Secrets of the totp.SecretNamespace holding the TOTP secrets of the enrolled users
+type SecretGetter interface {
+	Get(name string) (*kapi.Secret, error)
+}
+
+// UsedCodes remembers the last code accepted for each user, so a code can not be used twice. It is kept in memory:
+// with several masters, a code can be used once against each of them within its validity period.
+type UsedCodes struct {
+	lock     sync.Mutex
+	counters map[string]uint64
+}
+
+// NewUsedCodes returns an empty UsedCodes
+func NewUsedCodes() *UsedCodes {
+	return &UsedCodes{counters: map[string]uint64{}}
+}
+
+// use records the use of the code of the given counter by the user. It returns false if the code, or a later one, was
+// already used.
+func (u *UsedCodes) use(name string, counter uint64) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if last, ok := u.counters[name]; ok && counter <= last {
+		return false
+	}
+	u.counters[name] = counter
+	return true
+}
+
+// totpPasswordAuthenticator requires the users enrolled in TOTP to append a valid code to their password
+type totpPasswordAuthenticator struct {
+	delegate authenticator.Password
+	secrets  SecretGetter
+	required bool
+	used     *UsedCodes
+	now      func() time.Time
+}
+
+// New returns a password authenticator requiring the users enrolled in TOTP to append the current code of their
+// authenticator app to the password checked by the delegate. Users are enrolled under their user name, which must be
+// the name they log in with. If required is true, users who are not enrolled are denied.
+func New(delegate authenticator.Password, secrets SecretGetter, required bool, used *UsedCodes) authenticator.Password {
+	return &totpPasswordAuthenticator{
+		delegate: delegate,
+		secrets:  secrets,
+		required: required,
+		used:     used,
+		now:      time.Now,
+	}
+}
+
+// AuthenticatePassword authenticates the password, and the code appended to it for enrolled users. The delegate is
+// called once, with the password stripped of the code when the user logging in is enrolled.
+func (a *totpPasswordAuthenticator) AuthenticatePassword(username, password string) (user.Info, bool, error) {
+	enrolled, err := a.secret(username)
+	if err != nil {
+		return nil, false, err
+	}
+	code := ""
+	if len(enrolled) > 0 {
+		if len(password) <= totp.Digits || !isDigits(password[len(password)-totp.Digits:]) {
+			glog.V(4).Infof("Verification code required for %q", username)
+			return nil, false, nil
+		}
+		code = password[len(password)-totp.Digits:]
+		password = password[:len(password)-totp.Digits]
+	}
+
+	info, ok, err := a.delegate.AuthenticatePassword(username, password)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	// the identity may be mapped to a user of another name, whose enrollment is the one that counts
+	secret := enrolled
+	if info.GetName() != username {
+		if secret, err = a.secret(info.GetName()); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(secret) == 0 {
+		if len(code) > 0 {
+			glog.V(4).Infof("User %q is not enrolled in TOTP under the name %q used to log in", info.GetName(), username)
+			return nil, false, nil
+		}
+		if a.required {
+			glog.V(4).Infof("User %q is not enrolled in TOTP, which is required", info.GetName())
+			return nil, false, nil
+		}
+		return info, true, nil
+	}
+	if len(code) == 0 {
+		glog.V(4).Infof("Verification code required for %q", info.GetName())
+		return nil, false, nil
+	}
+	return a.checkCode(info, secret, code)
+}
+
+// secret returns the TOTP secret of the named user, or an empty string if the user is not enrolled
+func (a *totpPasswordAuthenticator) secret(name string) (string, error) {
+	secret, err := a.secrets.Get(totp.SecretName(name))
+	if kerrs.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[totp.SecretKey]), nil
+}
+
+func (a *totpPasswordAuthenticator) checkCode(info user.Info, secret, code string) (user.Info, bool, error) {
+	counter, ok, err := totp.Validate(secret, code, a.now())
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		glog.V(4).Infof("Invalid verification code for %q", info.GetName())
+		return nil, false, nil
+	}
+	if !a.used.use(info.GetName(), counter) {
+		glog.V(4).Infof("Verification code for %q was already used", info.GetName())
+		return nil, false, nil
+	}
+	return info, true, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package totppassword
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// testPasswordAuthenticator checks the passwords of its users, and counts the calls
+type testPasswordAuthenticator struct {
+	passwords map[string]string
+	// users maps the names users log in with to their user names, which default to the same name
+	users map[string]string
+	calls int
+}
+
+func (a *testPasswordAuthenticator) AuthenticatePassword(username, password string) (user.Info, bool, error) {
+	a.calls++
+	if expected, ok := a.passwords[username]; !ok || expected != password {
+		return nil, false, nil
+	}
+	if name, ok := a.users[username]; ok {
+		return &user.DefaultInfo{Name: name}, true, nil
+	}
+	return &user.DefaultInfo{Name: username}, true, nil
+}
+
+type testSecretGetter map[string]string
+
+func (g testSecretGetter) Get(name string) (*kapi.Secret, error) {
+	for user, secret := range g {
+		if totp.SecretName(user) == name {
+			return &kapi.Secret{
+				ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: totp.SecretNamespace},
+				Data:       map[string][]byte{totp.SecretKey: []byte(secret)},
+			}, nil
+		}
+	}
+	return nil, kerrs.NewNotFound(kapi.Resource("secrets"), name)
+}
+
+func TestAuthenticatePassword(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := totp.Code(testSecret, totp.Counter(now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oldCode, err := totp.Code(testSecret, totp.Counter(now)-5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		username string
+		password string
+		required bool
+		ok       bool
+	}{
+		"enrolled with code": {
+			username: "enrolled",
+			password: "secret" + code,
+			ok:       true,
+		},
+		"enrolled without code": {
+			username: "enrolled",
+			password: "secret",
+			ok:       false,
+		},
+		"enrolled with invalid code": {
+			username: "enrolled",
+			password: "secret" + oldCode,
+			ok:       false,
+		},
+		"enrolled with code and invalid password": {
+			username: "enrolled",
+			password: "wrong" + code,
+			ok:       false,
+		},
+		"not enrolled": {
+			username: "notenrolled",
+			password: "secret",
+			ok:       true,
+		},
+		"not enrolled with a password ending in digits": {
+			username: "digits",
+			password: "secret123456",
+			ok:       true,
+		},
+		"not enrolled when required": {
+			username: "notenrolled",
+			password: "secret",
+			required: true,
+			ok:       false,
+		},
+		"enrolled when required": {
+			username: "enrolled",
+			password: "secret" + code,
+			required: true,
+			ok:       true,
+		},
+		"mapped to an enrolled user with code": {
+			username: "login",
+			password: "secret" + code,
+			ok:       false,
+		},
+		"mapped to an enrolled user without code": {
+			username: "login",
+			password: "secret",
+			ok:       false,
+		},
+		"enrolled and mapped to a user who is not enrolled": {
+			username: "enrolledlogin",
+			password: "secret" + code,
+			ok:       false,
+		},
+	}
+
+	for name, tc := range testCases {
+		delegate := &testPasswordAuthenticator{
+			passwords: map[string]string{"enrolled": "secret", "notenrolled": "secret", "digits": "secret123456", "login": "secret", "enrolledlogin": "secret"},
+			users:     map[string]string{"login": "enrolled", "enrolledlogin": "notenrolled"},
+		}
+		secrets := testSecretGetter{"enrolled": testSecret, "enrolledlogin": testSecret}
+		a := New(delegate, secrets, tc.required, NewUsedCodes()).(*totpPasswordAuthenticator)
+		a.now = func() time.Time { return now }
+
+		info, ok, err := a.AuthenticatePassword(tc.username, tc.password)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if delegate.calls > 1 {
+			t.Errorf("%s: expected the password to be checked at most once, got %d checks", name, delegate.calls)
+		}
+		if ok != tc.ok {
+			t.Errorf("%s: expected %v, got %v", name, tc.ok, ok)
+			continue
+		}
+		if ok && info.GetName() != tc.username {
+			t.Errorf("%s: unexpected user %#v", name, info)
+		}
+	}
+}
+
+func TestAuthenticatePasswordReplay(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := totp.Code(testSecret, totp.Counter(now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delegate := &testPasswordAuthenticator{passwords: map[string]string{"enrolled": "secret"}}
+	a := New(delegate, testSecretGetter{"enrolled": testSecret}, false, NewUsedCodes()).(*totpPasswordAuthenticator)
+	a.now = func() time.Time { return now }
+
+	if _, ok, err := a.AuthenticatePassword("enrolled", "secret"+code); !ok || err != nil {
+		t.Fatalf("expected the first use of the code to succeed, got %v %v", ok, err)
+	}
+	if _, ok, err := a.AuthenticatePassword("enrolled", "secret"+code); ok || err != nil {
+		t.Errorf("expected the second use of the code to fail, got %v %v", ok, err)
+	}
+}
@@ -31,7 +31,7 @@ func (authHandler *basicAuthRequestHandler) AuthenticateRequest(req *http.Reques
 		return nil, false, nil
 	}
 
-	user, ok, err := authHandler.passwordAuthenticator.AuthenticatePassword(username, password)
+	user, ok, err := authenticator.AuthenticatePasswordFromSource(authHandler.passwordAuthenticator, authenticator.RequestSource(req), username, password)
 	if ok && authHandler.removeHeader {
 		req.Header.Del("Authorization")
 	}
@@ -43,6 +43,34 @@ func TestAuthenticateRequestValid(t *testing.T) {
 	}
 }
 
+type mockSourcePasswordAuthenticator struct {
+	mockPasswordAuthenticator
+	passedSource string
+}
+
+func (mock *mockSourcePasswordAuthenticator) AuthenticatePasswordFromSource(source, username, password string) (user.Info, bool, error) {
+	mock.passedSource = source
+	return mock.AuthenticatePassword(username, password)
+}
+
+func TestAuthenticateRequestSource(t *testing.T) {
+	passwordAuthenticator := &mockSourcePasswordAuthenticator{}
+	authRequestHandler := NewBasicAuthAuthentication("example", passwordAuthenticator, true)
+	req, _ := http.NewRequest("GET", "http://example.org", nil)
+	req.RemoteAddr = "10.0.0.1:43210"
+	// forwarded addresses are passed on, to be trusted as far as they were added by trusted proxies
+	req.Header.Set("X-Forwarded-For", "10.0.0.2")
+	req.SetBasicAuth(Username, Password)
+
+	_, _, _ = authRequestHandler.AuthenticateRequest(req)
+	if passwordAuthenticator.passedSource != "10.0.0.2, 10.0.0.1" {
+		t.Errorf("Expected %v, got %v", "10.0.0.2, 10.0.0.1", passwordAuthenticator.passedSource)
+	}
+	if passwordAuthenticator.passedUser != Username {
+		t.Errorf("Expected %v, got %v", Username, passwordAuthenticator.passedUser)
+	}
+}
+
 func TestAuthenticateRequestInvalid(t *testing.T) {
 	const (
 		ExpectedError = "No valid base64 data in basic auth scheme found"
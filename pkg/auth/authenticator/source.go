@@ -0,0 +1,65 @@
+package authenticator
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+)
+
+// RequestSource returns the addresses the request was sent from, separated by ", ": the addresses in the
+// X-Forwarded-For header, followed by the network address of the client that sent the request, without the port. Any
+// client can set the header, so the client address must be resolved with ClientAddress.
+func RequestSource(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	addresses := []string{}
+	for _, header := range req.Header[http.CanonicalHeaderKey("X-Forwarded-For")] {
+		for _, address := range strings.Split(header, ",") {
+			addresses = append(addresses, strings.TrimSpace(address))
+		}
+	}
+	return strings.Join(append(addresses, host), ", ")
+}
+
+// ClientAddress returns the address of the client in a source returned by RequestSource. The forwarded addresses are
+// only followed back from the address the request was received from while they belong to trusted proxies, since
+// only the addresses added by those can be trusted.
+func ClientAddress(source string, trustedProxies []*net.IPNet) string {
+	addresses := strings.Split(source, ",")
+	client := strings.TrimSpace(addresses[len(addresses)-1])
+	for i := len(addresses) - 2; i >= 0 && isTrustedProxy(client, trustedProxies); i-- {
+		forwarded := strings.TrimSpace(addresses[i])
+		if net.ParseIP(forwarded) == nil {
+			// a trusted proxy does not forward invalid addresses, so the proxy is treated as the client
+			break
+		}
+		client = forwarded
+	}
+	return client
+}
+
+func isTrustedProxy(address string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthenticatePasswordFromSource authenticates the password with the network address of the client if the
+// authenticator takes it into account
+func AuthenticatePasswordFromSource(auth Password, source, username, password string) (user.Info, bool, error) {
+	if sourcePassword, ok := auth.(SourcePassword); ok && len(source) > 0 {
+		return sourcePassword.AuthenticatePasswordFromSource(source, username, password)
+	}
+	return auth.AuthenticatePassword(username, password)
+}
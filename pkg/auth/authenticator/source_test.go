@@ -0,0 +1,65 @@
+package authenticator
+
+import (
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestRequestSource(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://example.org", nil)
+	req.RemoteAddr = "10.0.0.1:43210"
+	if source := RequestSource(req); source != "10.0.0.1" {
+		t.Errorf("unexpected source: %s", source)
+	}
+
+	req.Header.Add("X-Forwarded-For", "10.0.0.2,10.0.0.3")
+	req.Header.Add("X-Forwarded-For", "10.0.0.4")
+	if source := RequestSource(req); source != "10.0.0.2, 10.0.0.3, 10.0.0.4, 10.0.0.1" {
+		t.Errorf("unexpected source: %s", source)
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("192.168.0.0/16")
+	trusted := []*net.IPNet{proxies}
+
+	testCases := map[string]struct {
+		source   string
+		expected string
+	}{
+		"direct": {
+			source:   "10.0.0.1",
+			expected: "10.0.0.1",
+		},
+		"forwarded by an untrusted address": {
+			source:   "10.0.0.2, 10.0.0.1",
+			expected: "10.0.0.1",
+		},
+		"forwarded by a trusted proxy": {
+			source:   "10.0.0.2, 192.168.0.1",
+			expected: "10.0.0.2",
+		},
+		"forwarded by trusted proxies": {
+			source:   "10.0.0.3, 10.0.0.2, 192.168.0.2, 192.168.0.1",
+			expected: "10.0.0.2",
+		},
+		"all trusted": {
+			source:   "192.168.0.2, 192.168.0.1",
+			expected: "192.168.0.2",
+		},
+		"invalid forwarded address": {
+			source:   "unknown, 192.168.0.1",
+			expected: "192.168.0.1",
+		},
+	}
+	for name, tc := range testCases {
+		if client := ClientAddress(tc.source, trusted); client != tc.expected {
+			t.Errorf("%s: expected %s, got %s", name, tc.expected, client)
+		}
+	}
+
+	if client := ClientAddress("10.0.0.2, 192.168.0.1", nil); client != "192.168.0.1" {
+		t.Errorf("expected forwarded addresses to be ignored without trusted proxies, got %s", client)
+	}
+}
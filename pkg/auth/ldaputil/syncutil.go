@@ -13,4 +13,7 @@ const (
 	LDAPUIDAnnotation string = "openshift.io/ldap.uid"
 	// LDAPSyncTime is the Annotation value that stores the last time this Group was synced with LDAP
 	LDAPSyncTimeAnnotation string = "openshift.io/ldap.sync-time"
+	// LDAPSyncErrorAnnotation is the Annotation value that stores the error of the last failed sync of this Group
+	// with LDAP. It is removed by the next successful sync
+	LDAPSyncErrorAnnotation string = "openshift.io/ldap.sync-error"
 )
@@ -44,6 +44,7 @@ type Config struct {
 	PreferredUsernameClaims []string
 	EmailClaims             []string
 	NameClaims              []string
+	GroupsClaims            []string
 
 	IDTokenValidator TokenValidator
 }
@@ -211,6 +212,14 @@ func (p provider) GetUserIdentity(data *osincli.AccessData) (authapi.UserIdentit
 		identity.Extra[authapi.IdentityDisplayNameKey] = name
 	}
 
+	if len(p.GroupsClaims) > 0 {
+		groups, err := getClaimValues(claims, p.GroupsClaims)
+		if err != nil {
+			return nil, false, err
+		}
+		identity.ProviderGroups = groups
+	}
+
 	glog.V(4).Infof("identity=%v", identity)
 
 	return identity, true, nil
@@ -233,6 +242,41 @@ func getClaimValue(data map[string]interface{}, claims []string) (string, error)
 	return "", errors.New("No value found")
 }
 
+// getClaimValues returns the values of all the given claims, which may be strings or lists of strings.
+// It returns nil if none of the claims is present, and an empty list if the claims have no values.
+func getClaimValues(data map[string]interface{}, claims []string) ([]string, error) {
+	var values []string
+	for _, claim := range claims {
+		value, ok := data[claim]
+		if !ok {
+			continue
+		}
+		if values == nil {
+			values = []string{}
+		}
+		switch value := value.(type) {
+		case nil:
+		case string:
+			if len(value) > 0 {
+				values = append(values, value)
+			}
+		case []interface{}:
+			for _, item := range value {
+				stringItem, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("Claim %s was not a list of strings", claim)
+				}
+				if len(stringItem) > 0 {
+					values = append(values, stringItem)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("Claim %s was not a string or a list of strings", claim)
+		}
+	}
+	return values, nil
+}
+
 // fetch and decode JSON from the given UserInfo URL
 func fetchUserInfo(url, accessToken string, transport http.RoundTripper) (map[string]interface{}, error) {
 	req, _ := http.NewRequest("GET", url, nil)
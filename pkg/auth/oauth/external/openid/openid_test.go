@@ -1,8 +1,13 @@
 package openid
 
 import (
+	"encoding/base64"
+	"reflect"
 	"testing"
 
+	"github.com/RangelReale/osincli"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/oauth/external"
 )
 
@@ -21,3 +26,73 @@ func TestOpenID(t *testing.T) {
 	_ = external.Provider(p)
 
 }
+
+func TestOpenIDGroups(t *testing.T) {
+	testCases := map[string]struct {
+		groupsClaims []string
+		payload      string
+		groups       []string
+		err          bool
+	}{
+		"no groups claims": {
+			payload: `{"sub":"bob","groups":["admins"]}`,
+			groups:  nil,
+		},
+		"list claim": {
+			groupsClaims: []string{"groups"},
+			payload:      `{"sub":"bob","groups":["admins","","developers"]}`,
+			groups:       []string{"admins", "developers"},
+		},
+		"string and list claims": {
+			groupsClaims: []string{"role", "groups"},
+			payload:      `{"sub":"bob","role":"testers","groups":["admins"]}`,
+			groups:       []string{"testers", "admins"},
+		},
+		"missing claim": {
+			groupsClaims: []string{"groups"},
+			payload:      `{"sub":"bob"}`,
+			groups:       nil,
+		},
+		"empty claim": {
+			groupsClaims: []string{"role", "groups"},
+			payload:      `{"sub":"bob","groups":[]}`,
+			groups:       []string{},
+		},
+		"invalid claim": {
+			groupsClaims: []string{"groups"},
+			payload:      `{"sub":"bob","groups":[1]}`,
+			err:          true,
+		},
+	}
+
+	for name, tc := range testCases {
+		p, err := NewProvider("openid", nil, Config{
+			ClientID:     "foo",
+			ClientSecret: "secret",
+			AuthorizeURL: "https://foo",
+			TokenURL:     "https://foo",
+			Scopes:       []string{"openid"},
+			IDClaims:     []string{"sub"},
+			GroupsClaims: tc.groupsClaims,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		idToken := "header." + base64.StdEncoding.EncodeToString([]byte(tc.payload)) + ".signature"
+		identity, _, err := p.GetUserIdentity(&osincli.AccessData{ResponseData: osincli.ResponseData{"id_token": idToken}})
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if groups := identity.(authapi.UserIdentityInfoGroups).GetProviderGroups(); !reflect.DeepEqual(groups, tc.groups) {
+			t.Errorf("%s: expected groups %v, got %v", name, tc.groups, groups)
+		}
+	}
+}
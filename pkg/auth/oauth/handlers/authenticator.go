@@ -66,7 +66,11 @@ func (h *AccessAuthenticator) HandleAccess(ar *osin.AccessRequest, w http.Respon
 		// auth codes and refresh tokens are assumed allowed
 		ok = true
 	case osin.PASSWORD:
-		info, ok, err = h.password.AuthenticatePassword(ar.Username, ar.Password)
+		if ar.HttpRequest != nil {
+			info, ok, err = authenticator.AuthenticatePasswordFromSource(h.password, authenticator.RequestSource(ar.HttpRequest), ar.Username, ar.Password)
+		} else {
+			info, ok, err = h.password.AuthenticatePassword(ar.Username, ar.Password)
+		}
 	case osin.ASSERTION:
 		info, ok, err = h.assertion.AuthenticateAssertion(ar.AssertionType, ar.Assertion)
 	case osin.CLIENT_CREDENTIALS:
@@ -0,0 +1,277 @@
+// Package saml implements the SAML 2.0 web browser single sign-on profile with an external identity provider, using the
+// HTTP-Redirect binding for authentication requests and the HTTP-POST binding for responses.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang/glog"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/oauth/external"
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
+)
+
+// Config describes a SAML identity provider and how its assertions are mapped to identities
+type Config struct {
+	// EntityID is the entity ID of the master as a service provider
+	EntityID string
+	// SSOURL is the single sign-on URL of the identity provider
+	SSOURL string
+	// Certificates are the certificates the identity provider signs its responses with
+	Certificates []*x509.Certificate
+
+	// IDAttributes are the attributes whose first non-empty value is the user ID. If empty, the subject NameID is used
+	IDAttributes []string
+	// PreferredUsernameAttributes are the attributes whose first non-empty value is the preferred username
+	PreferredUsernameAttributes []string
+	// NameAttributes are the attributes whose first non-empty value is the display name
+	NameAttributes []string
+	// EmailAttributes are the attributes whose first non-empty value is the email address
+	EmailAttributes []string
+	// GroupsAttributes are the attributes whose values are the groups of the user. If empty, no groups are returned
+	GroupsAttributes []string
+}
+
+// Handler exposes a SAML identity provider flow (including the assertion consumer service) as an
+// oauth.handlers.AuthenticationHandler
+type Handler struct {
+	providerName string
+	config       Config
+	acsURL       string
+	validator    *responseValidator
+	state        external.State
+	success      handlers.AuthenticationSuccessHandler
+	errorHandler handlers.AuthenticationErrorHandler
+	mapper       authapi.UserIdentityMapper
+}
+
+// NewSAMLRedirector returns a redirector sending unauthenticated users to the identity provider, and the handler of the
+// assertion consumer service at acsURL. GET requests to the handler return the service provider metadata.
+func NewSAMLRedirector(providerName string, config Config, state external.State, acsURL string, success handlers.AuthenticationSuccessHandler, errorHandler handlers.AuthenticationErrorHandler, mapper authapi.UserIdentityMapper) (handlers.AuthenticationRedirector, http.Handler, error) {
+	if len(config.EntityID) == 0 {
+		return nil, nil, errors.New("an entity ID is required")
+	}
+	if _, err := url.Parse(config.SSOURL); err != nil || len(config.SSOURL) == 0 {
+		return nil, nil, fmt.Errorf("invalid single sign-on URL %q", config.SSOURL)
+	}
+	if len(config.Certificates) == 0 {
+		return nil, nil, errors.New("at least one identity provider certificate is required")
+	}
+
+	handler := &Handler{
+		providerName: providerName,
+		config:       config,
+		acsURL:       acsURL,
+		validator:    newResponseValidator(config.EntityID, acsURL, config.Certificates),
+		state:        state,
+		success:      success,
+		errorHandler: errorHandler,
+		mapper:       mapper,
+	}
+	return handler, handler, nil
+}
+
+// AuthenticationRedirect implements oauth.handlers.RedirectAuthHandler
+func (h *Handler) AuthenticationRedirect(w http.ResponseWriter, req *http.Request) error {
+	glog.V(4).Infof("Authentication needed for %v", h.providerName)
+
+	state, err := h.state.Generate(w, req)
+	if err != nil {
+		glog.V(4).Infof("Error generating state: %v", err)
+		return err
+	}
+
+	request, err := h.authnRequest()
+	if err != nil {
+		return err
+	}
+	ssoURL, err := url.Parse(h.config.SSOURL)
+	if err != nil {
+		return err
+	}
+	query := ssoURL.Query()
+	query.Set("SAMLRequest", request)
+	query.Set("RelayState", state)
+	ssoURL.RawQuery = query.Encode()
+
+	glog.V(4).Infof("redirect to %v", ssoURL)
+	http.Redirect(w, req, ssoURL.String(), http.StatusFound)
+	return nil
+}
+
+// authnRequest returns a deflated and base64 encoded AuthnRequest, as sent by the HTTP-Redirect binding
+func (h *Handler) authnRequest() (string, error) {
+	id := make([]byte, 20)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<samlp:AuthnRequest xmlns:samlp="%s" xmlns:saml="%s" ID="_%s" Version="2.0" IssueInstant="%s"`,
+		protocolNamespace, assertionNamespace, hex.EncodeToString(id), time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(buf, ` Destination="%s" AssertionConsumerServiceURL="%s" ProtocolBinding="%s">`,
+		escapeAttr(h.config.SSOURL), escapeAttr(h.acsURL), postBinding)
+	fmt.Fprintf(buf, `<saml:Issuer>%s</saml:Issuer><samlp:NameIDPolicy AllowCreate="true"/></samlp:AuthnRequest>`, escapeText(h.config.EntityID))
+
+	deflated := &bytes.Buffer{}
+	writer, err := flate.NewWriter(deflated, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(deflated.Bytes()), nil
+}
+
+const (
+	postBinding        = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	metadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	metadataHeaderType = "application/samlmetadata+xml"
+)
+
+// ServeHTTP handles the responses posted by the identity provider, and returns the service provider metadata
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case "GET":
+		w.Header().Set("Content-Type", metadataHeaderType)
+		fmt.Fprintf(w, `<md:EntityDescriptor xmlns:md="%s" entityID="%s">`, metadataNamespace, escapeAttr(h.config.EntityID))
+		fmt.Fprintf(w, `<md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="%s">`, protocolNamespace)
+		fmt.Fprintf(w, `<md:AssertionConsumerService Binding="%s" Location="%s" index="0"/>`, postBinding, escapeAttr(h.acsURL))
+		fmt.Fprintf(w, `</md:SPSSODescriptor></md:EntityDescriptor>`)
+	case "POST":
+		h.handleResponse(w, req)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) handleResponse(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		h.handleError(err, w, req)
+		return
+	}
+	relayState := req.PostForm.Get("RelayState")
+
+	// Validate state before processing the response
+	ok, err := h.state.Check(relayState, req)
+	if !ok {
+		glog.V(4).Infof("State is invalid")
+		err := errors.New("State is invalid")
+		h.handleError(err, w, req)
+		return
+	}
+	if err != nil {
+		glog.V(4).Infof("Error verifying state: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	data, err := decodeBase64(req.PostForm.Get("SAMLResponse"))
+	if err != nil {
+		h.handleError(fmt.Errorf("invalid SAML response encoding: %v", err), w, req)
+		return
+	}
+	assertion, err := h.validator.validate(data)
+	if err != nil {
+		glog.V(4).Infof("Error validating SAML response: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	identity, err := h.identity(assertion)
+	if err != nil {
+		glog.V(4).Infof("Error getting userIdentityInfo info: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	user, err := h.mapper.UserFor(identity)
+	glog.V(5).Infof("Got userIdentityMapping: %#v", user)
+	if err != nil {
+		glog.V(4).Infof("Error creating or updating mapping for: %#v due to %v", identity, err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	_, err = h.success.AuthenticationSucceeded(user, relayState, w, req)
+	if err != nil {
+		glog.V(4).Infof("Error calling success handler: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+}
+
+// identity maps a verified assertion to an identity
+func (h *Handler) identity(a *assertion) (*authapi.DefaultUserIdentityInfo, error) {
+	id := a.NameID
+	if len(h.config.IDAttributes) > 0 {
+		id = firstValue(a.Attributes, h.config.IDAttributes)
+	}
+	if len(id) == 0 {
+		return nil, errors.New("SAML assertion does not contain a user ID")
+	}
+
+	identity := authapi.NewDefaultUserIdentityInfo(h.providerName, id)
+	if preferredUsername := firstValue(a.Attributes, h.config.PreferredUsernameAttributes); len(preferredUsername) > 0 {
+		identity.Extra[authapi.IdentityPreferredUsernameKey] = preferredUsername
+	}
+	if name := firstValue(a.Attributes, h.config.NameAttributes); len(name) > 0 {
+		identity.Extra[authapi.IdentityDisplayNameKey] = name
+	}
+	if email := firstValue(a.Attributes, h.config.EmailAttributes); len(email) > 0 {
+		identity.Extra[authapi.IdentityEmailKey] = email
+	}
+	// the groups are left unchanged when the assertion has none of the groups attributes
+	for _, attribute := range h.config.GroupsAttributes {
+		values, ok := a.Attributes[attribute]
+		if !ok {
+			continue
+		}
+		if identity.ProviderGroups == nil {
+			identity.ProviderGroups = []string{}
+		}
+		for _, group := range values {
+			if len(group) > 0 {
+				identity.ProviderGroups = append(identity.ProviderGroups, group)
+			}
+		}
+	}
+	return identity, nil
+}
+
+// firstValue returns the first non-empty value of the given attributes
+func firstValue(attributes map[string][]string, names []string) string {
+	for _, name := range names {
+		for _, value := range attributes[name] {
+			if len(value) > 0 {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+func (h *Handler) handleError(err error, w http.ResponseWriter, req *http.Request) {
+	handled, err := h.errorHandler.AuthenticationError(err, w, req)
+	if handled {
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte(`An error occurred`))
+}
@@ -0,0 +1,123 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+type testState struct{}
+
+func (testState) Generate(w http.ResponseWriter, req *http.Request) (string, error) {
+	return "teststate", nil
+}
+
+func (testState) Check(state string, req *http.Request) (bool, error) {
+	return state == "teststate", nil
+}
+
+func newTestHandler(t *testing.T, config Config) *Handler {
+	config.EntityID = testEntityID
+	config.SSOURL = "https://idp.example.com/sso?tenant=1"
+	config.Certificates = []*x509.Certificate{{}}
+	_, handler, err := NewSAMLRedirector("saml", config, testState{}, testACSURL, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return handler.(*Handler)
+}
+
+func TestAuthenticationRedirect(t *testing.T) {
+	handler := newTestHandler(t, Config{})
+	recorder := httptest.NewRecorder()
+	if err := handler.AuthenticationRedirect(recorder, &http.Request{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := location.Query()
+	if location.Host != "idp.example.com" || query.Get("tenant") != "1" || query.Get("RelayState") != "teststate" {
+		t.Errorf("unexpected redirect %s", location)
+	}
+	deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	request, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{`AssertionConsumerServiceURL="` + testACSURL + `"`, `<saml:Issuer>` + testEntityID + `</saml:Issuer>`} {
+		if !strings.Contains(string(request), expected) {
+			t.Errorf("expected %s in request %s", expected, request)
+		}
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	a := &assertion{
+		NameID: "bob@example.com",
+		Attributes: map[string][]string{
+			"uid":         {"bob"},
+			"displayName": {"", "Bob"},
+			"mail":        {"bob@example.com"},
+			"memberOf":    {"admins", ""},
+			"roles":       {"developers"},
+		},
+	}
+
+	identity, err := newTestHandler(t, Config{}).identity(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.GetProviderUserName() != "bob@example.com" || len(identity.GetExtra()) != 0 || identity.GetProviderGroups() != nil {
+		t.Errorf("unexpected identity %#v", identity)
+	}
+
+	identity, err = newTestHandler(t, Config{
+		IDAttributes:                []string{"uid"},
+		PreferredUsernameAttributes: []string{"uid"},
+		NameAttributes:              []string{"displayName"},
+		EmailAttributes:             []string{"mail"},
+		GroupsAttributes:            []string{"memberOf", "roles"},
+	}).identity(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedExtra := map[string]string{
+		authapi.IdentityPreferredUsernameKey: "bob",
+		authapi.IdentityDisplayNameKey:       "Bob",
+		authapi.IdentityEmailKey:             "bob@example.com",
+	}
+	if identity.GetProviderUserName() != "bob" || !reflect.DeepEqual(identity.GetExtra(), expectedExtra) {
+		t.Errorf("unexpected identity %#v", identity)
+	}
+	if groups := identity.GetProviderGroups(); !reflect.DeepEqual(groups, []string{"admins", "developers"}) {
+		t.Errorf("unexpected groups %v", groups)
+	}
+
+	identity, err = newTestHandler(t, Config{GroupsAttributes: []string{"missing"}}).identity(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if groups := identity.GetProviderGroups(); groups != nil {
+		t.Errorf("expected no groups when the assertion has no groups attribute, got %v", groups)
+	}
+
+	if _, err := newTestHandler(t, Config{IDAttributes: []string{"missing"}}).identity(a); err == nil {
+		t.Errorf("expected an error for a missing ID attribute")
+	}
+}
@@ -0,0 +1,250 @@
+package saml
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	statusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearerConfirmation = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// clockSkew is the tolerated difference between the clocks of the identity provider and the master
+	clockSkew = 3 * time.Minute
+)
+
+// assertion is the verified content of a SAML assertion
+type assertion struct {
+	// ID is the ID of the assertion
+	ID string
+	// NameID is the name identifier of the subject
+	NameID string
+	// Attributes maps the names and friendly names of the attributes of the
+	// subject to their values
+	Attributes map[string][]string
+	// NotOnOrAfter is the time the assertion expires
+	NotOnOrAfter time.Time
+}
+
+// responseValidator checks SAML responses sent to an assertion consumer service
+type responseValidator struct {
+	// entityID is the entity ID of the service provider, the audience of the assertions
+	entityID string
+	// acsURL is the URL of the assertion consumer service, the destination of the responses
+	acsURL string
+	// certs are the certificates the identity provider signs with
+	certs []*x509.Certificate
+	// now returns the current time
+	now func() time.Time
+
+	lock sync.Mutex
+	// used maps the IDs of the accepted assertions to their expiry, to
+	// reject replayed assertions. It is kept in memory: with several masters,
+	// an assertion can be replayed once against each of them before it expires.
+	used map[string]time.Time
+}
+
+func newResponseValidator(entityID, acsURL string, certs []*x509.Certificate) *responseValidator {
+	return &responseValidator{
+		entityID: entityID,
+		acsURL:   acsURL,
+		certs:    certs,
+		now:      time.Now,
+		used:     map[string]time.Time{},
+	}
+}
+
+// validate verifies the signature and the conditions of a SAML response and
+// returns its assertion. Only the content covered by a verified signature is
+// returned.
+func (v *responseValidator) validate(data []byte) (*assertion, error) {
+	response, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the SAML response: %v", err)
+	}
+	if !response.is(protocolNamespace, "Response") {
+		return nil, errors.New("not a SAML response")
+	}
+	if destination := response.attr("Destination"); destination != v.acsURL {
+		return nil, fmt.Errorf("SAML response destination %q does not match %q", destination, v.acsURL)
+	}
+	status := response.childElement(protocolNamespace, "Status")
+	if status == nil {
+		return nil, errors.New("SAML response has no status")
+	}
+	statusCode := status.childElement(protocolNamespace, "StatusCode")
+	if statusCode == nil || statusCode.attr("Value") != statusSuccess {
+		value := ""
+		if statusCode != nil {
+			value = statusCode.attr("Value")
+		}
+		message := ""
+		if statusMessage := status.childElement(protocolNamespace, "StatusMessage"); statusMessage != nil {
+			message = statusMessage.text()
+		}
+		return nil, fmt.Errorf("SAML authentication failed with status %q: %s", value, message)
+	}
+
+	if len(response.childElements(assertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted SAML assertions are not supported")
+	}
+	assertionElement := response.childElement(assertionNamespace, "Assertion")
+	if assertionElement == nil {
+		return nil, errors.New("SAML response must contain exactly one assertion")
+	}
+
+	responseSigned, assertionSigned := hasSignature(response), hasSignature(assertionElement)
+	if !responseSigned && !assertionSigned {
+		return nil, errors.New("SAML response is not signed")
+	}
+	if responseSigned {
+		if err := verifySignature(response, v.certs); err != nil {
+			return nil, fmt.Errorf("invalid SAML response signature: %v", err)
+		}
+	}
+	if assertionSigned {
+		if err := verifySignature(assertionElement, v.certs); err != nil {
+			return nil, fmt.Errorf("invalid SAML assertion signature: %v", err)
+		}
+	}
+
+	result, err := v.validateAssertion(assertionElement)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkReplay(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v *responseValidator) validateAssertion(e *element) (*assertion, error) {
+	now := v.now()
+	result := &assertion{ID: e.attr("ID"), Attributes: map[string][]string{}}
+
+	conditions := e.childElement(assertionNamespace, "Conditions")
+	if conditions == nil {
+		return nil, errors.New("SAML assertion has no conditions")
+	}
+	notBefore, notOnOrAfter, err := validityPeriod(conditions)
+	if err != nil {
+		return nil, err
+	}
+	if !notBefore.IsZero() && now.Add(clockSkew).Before(notBefore) {
+		return nil, errors.New("SAML assertion is not yet valid")
+	}
+	if !notOnOrAfter.IsZero() {
+		if !now.Add(-clockSkew).Before(notOnOrAfter) {
+			return nil, errors.New("SAML assertion has expired")
+		}
+		result.NotOnOrAfter = notOnOrAfter
+	}
+	// every audience restriction must include the master, and there must be at least one
+	restrictions := conditions.childElements(assertionNamespace, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return nil, errors.New("SAML assertion has no audience restriction")
+	}
+	for _, restriction := range restrictions {
+		found := false
+		for _, audience := range restriction.childElements(assertionNamespace, "Audience") {
+			if audience.text() == v.entityID {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("SAML assertion is not intended for %q", v.entityID)
+		}
+	}
+
+	subject := e.childElement(assertionNamespace, "Subject")
+	if subject == nil {
+		return nil, errors.New("SAML assertion has no subject")
+	}
+	if nameID := subject.childElement(assertionNamespace, "NameID"); nameID != nil {
+		result.NameID = nameID.text()
+	}
+	confirmed := false
+	for _, confirmation := range subject.childElements(assertionNamespace, "SubjectConfirmation") {
+		if confirmation.attr("Method") != bearerConfirmation {
+			continue
+		}
+		data := confirmation.childElement(assertionNamespace, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if data.attr("Recipient") != v.acsURL {
+			continue
+		}
+		_, notOnOrAfter, err := validityPeriod(data)
+		if err != nil {
+			return nil, err
+		}
+		if notOnOrAfter.IsZero() || !now.Add(-clockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		if result.NotOnOrAfter.IsZero() || notOnOrAfter.Before(result.NotOnOrAfter) {
+			result.NotOnOrAfter = notOnOrAfter
+		}
+		confirmed = true
+	}
+	if !confirmed {
+		return nil, errors.New("SAML assertion has no valid bearer subject confirmation")
+	}
+
+	for _, statement := range e.childElements(assertionNamespace, "AttributeStatement") {
+		for _, attribute := range statement.childElements(assertionNamespace, "Attribute") {
+			values := []string{}
+			for _, value := range attribute.childElements(assertionNamespace, "AttributeValue") {
+				values = append(values, value.text())
+			}
+			for _, name := range []string{attribute.attr("Name"), attribute.attr("FriendlyName")} {
+				if len(name) > 0 {
+					result.Attributes[name] = append(result.Attributes[name], values...)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// checkReplay rejects assertions that were already accepted and records the
+// ID of a until it expires.
+func (v *responseValidator) checkReplay(a *assertion) error {
+	if len(a.ID) == 0 {
+		return errors.New("SAML assertion has no ID")
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	now := v.now()
+	for id, expiry := range v.used {
+		if now.After(expiry) {
+			delete(v.used, id)
+		}
+	}
+	if _, used := v.used[a.ID]; used {
+		return fmt.Errorf("SAML assertion %q was already used", a.ID)
+	}
+	v.used[a.ID] = a.NotOnOrAfter.Add(clockSkew)
+	return nil
+}
+
+// validityPeriod parses the NotBefore and NotOnOrAfter attributes of e.
+func validityPeriod(e *element) (notBefore, notOnOrAfter time.Time, err error) {
+	if value := e.attr("NotBefore"); len(value) > 0 {
+		if notBefore, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return notBefore, notOnOrAfter, fmt.Errorf("invalid NotBefore %q: %v", value, err)
+		}
+	}
+	if value := e.attr("NotOnOrAfter"); len(value) > 0 {
+		if notOnOrAfter, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return notBefore, notOnOrAfter, fmt.Errorf("invalid NotOnOrAfter %q: %v", value, err)
+		}
+	}
+	return notBefore, notOnOrAfter, nil
+}
@@ -0,0 +1,255 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testEntityID = "https://master.example.com"
+	testACSURL   = "https://master.example.com/oauth2callback/saml"
+)
+
+var testNow = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestKey(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key, cert
+}
+
+// testResponse returns a response with an assertion, with a SIGNATURE placeholder in the assertion
+func testResponse(audience string, notOnOrAfter time.Time) string {
+	expiry := notOnOrAfter.Format(time.RFC3339)
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0" Destination="` + testACSURL + `">` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" Version="2.0">` +
+		`<saml:Issuer>https://idp.example.com</saml:Issuer>SIGNATURE` +
+		`<saml:Subject><saml:NameID>bob@example.com</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData Recipient="` + testACSURL + `" NotOnOrAfter="` + expiry + `"/></saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotOnOrAfter="` + expiry + `"><saml:AudienceRestriction><saml:Audience>` + audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AttributeStatement>` +
+		`<saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.1" FriendlyName="uid"><saml:AttributeValue>bob</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="groups"><saml:AttributeValue>admins</saml:AttributeValue><saml:AttributeValue>developers</saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement></saml:Assertion></samlp:Response>`
+}
+
+// sign replaces the SIGNATURE placeholder of document with an enveloped signature of the element with the given ID
+func sign(t *testing.T, document, id string, key *rsa.PrivateKey) string {
+	root, err := parseXML([]byte(strings.Replace(document, "SIGNATURE", "", 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var signed *element
+	root.walk(func(e *element) {
+		if e.attr("ID") == id {
+			signed = e
+		}
+	})
+	digest := sha256.Sum256(canonicalize(signed, nil, nil))
+
+	signedInfo := `<ds:SignedInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue></ds:Reference></ds:SignedInfo>`
+	signedInfoElement, err := parseXML([]byte(signedInfo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hashed := sha256.Sum256(canonicalize(signedInfoElement, nil, nil))
+	value, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	signature := fmt.Sprintf(`<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">%s<ds:SignatureValue>%s</ds:SignatureValue></ds:Signature>`,
+		strings.Replace(signedInfo, ` xmlns:ds="http://www.w3.org/2000/09/xmldsig#"`, "", 1), base64.StdEncoding.EncodeToString(value))
+	return strings.Replace(document, "SIGNATURE", signature, 1)
+}
+
+func newTestValidator(cert *x509.Certificate) *responseValidator {
+	v := newResponseValidator(testEntityID, testACSURL, []*x509.Certificate{cert})
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestValidateResponse(t *testing.T) {
+	key, cert := newTestKey(t)
+	response := sign(t, testResponse(testEntityID, testNow.Add(5*time.Minute)), "_assertion", key)
+
+	assertion, err := newTestValidator(cert).validate([]byte(response))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assertion.NameID != "bob@example.com" {
+		t.Errorf("unexpected name ID %q", assertion.NameID)
+	}
+	expectedAttributes := map[string][]string{
+		"urn:oid:0.9.2342.19200300.100.1.1": {"bob"},
+		"uid":                               {"bob"},
+		"groups":                            {"admins", "developers"},
+	}
+	if !reflect.DeepEqual(assertion.Attributes, expectedAttributes) {
+		t.Errorf("expected attributes %v, got %v", expectedAttributes, assertion.Attributes)
+	}
+}
+
+func TestValidateResponseReplay(t *testing.T) {
+	key, cert := newTestKey(t)
+	response := sign(t, testResponse(testEntityID, testNow.Add(5*time.Minute)), "_assertion", key)
+
+	v := newTestValidator(cert)
+	if _, err := v.validate([]byte(response)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := v.validate([]byte(response)); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("expected a replay error, got %v", err)
+	}
+}
+
+func TestValidateResponseErrors(t *testing.T) {
+	key, cert := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+	valid := testResponse(testEntityID, testNow.Add(5*time.Minute))
+
+	testCases := map[string]struct {
+		response string
+		err      string
+	}{
+		"unsigned": {
+			response: strings.Replace(valid, "SIGNATURE", "", 1),
+			err:      "not signed",
+		},
+		"untrusted key": {
+			response: sign(t, valid, "_assertion", otherKey),
+			err:      "trusted certificate",
+		},
+		"tampered": {
+			response: strings.Replace(sign(t, valid, "_assertion", key), "<saml:NameID>bob@", "<saml:NameID>alice@", 1),
+			err:      "digest",
+		},
+		"wrong audience": {
+			response: sign(t, testResponse("https://other.example.com", testNow.Add(5*time.Minute)), "_assertion", key),
+			err:      "not intended",
+		},
+		"expired": {
+			response: sign(t, testResponse(testEntityID, testNow.Add(-5*time.Minute)), "_assertion", key),
+			err:      "expired",
+		},
+		"wrong destination": {
+			response: strings.Replace(sign(t, valid, "_assertion", key), `Destination="`+testACSURL, `Destination="https://other.example.com`, 1),
+			err:      "destination",
+		},
+		"no destination": {
+			response: strings.Replace(sign(t, valid, "_assertion", key), ` Destination="`+testACSURL+`"`, "", 1),
+			err:      "destination",
+		},
+		"no audience restriction": {
+			response: sign(t, strings.Replace(valid, `<saml:AudienceRestriction><saml:Audience>`+testEntityID+`</saml:Audience></saml:AudienceRestriction>`, "", 1), "_assertion", key),
+			err:      "no audience restriction",
+		},
+		"no recipient": {
+			response: sign(t, strings.Replace(valid, ` Recipient="`+testACSURL+`"`, "", 1), "_assertion", key),
+			err:      "subject confirmation",
+		},
+		"duplicate ID": {
+			response: strings.Replace(sign(t, valid, "_assertion", key), `ID="_response"`, `ID="_assertion"`, 1),
+			err:      "not unique",
+		},
+	}
+	for name, tc := range testCases {
+		_, err := newTestValidator(cert).validate([]byte(tc.response))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.err, err)
+		}
+	}
+}
+
+func TestValidateResponseSignatureWrapping(t *testing.T) {
+	key, cert := newTestKey(t)
+	response := sign(t, testResponse(testEntityID, testNow.Add(5*time.Minute)), "_assertion", key)
+	start, end := strings.Index(response, "<saml:Assertion"), strings.Index(response, "</samlp:Response>")
+	signed := response[start:end]
+	forged := strings.Replace(signed, "<saml:NameID>bob@", "<saml:NameID>alice@", 1)
+	unsigned := forged[:strings.Index(forged, "<ds:Signature")] + forged[strings.Index(forged, "</ds:Signature>")+len("</ds:Signature>"):]
+
+	testCases := map[string]struct {
+		assertions string
+		err        string
+	}{
+		"signed assertion moved to the extensions": {
+			assertions: "<samlp:Extensions>" + signed + "</samlp:Extensions>" + unsigned,
+			err:        "not signed",
+		},
+		"signature copied to the forged assertion": {
+			assertions: "<samlp:Extensions>" + signed + "</samlp:Extensions>" + forged,
+			err:        "not unique",
+		},
+		"signature copied to a forged assertion with another ID": {
+			assertions: strings.Replace(forged, `ID="_assertion"`, `ID="_forged"`, 1),
+			err:        "does not match the signed element ID",
+		},
+		"forged assertion added": {
+			assertions: signed + strings.Replace(unsigned, `ID="_assertion"`, `ID="_forged"`, 1),
+			err:        "exactly one assertion",
+		},
+	}
+	for name, tc := range testCases {
+		wrapped := response[:start] + tc.assertions + response[end:]
+		_, err := newTestValidator(cert).validate([]byte(wrapped))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.err, err)
+		}
+	}
+}
+
+func TestValidateResponseCommentInjection(t *testing.T) {
+	key, cert := newTestKey(t)
+	// the comments are not signed, so they can be added to a signed assertion without invalidating it
+	response := strings.Replace(testResponse(testEntityID, testNow.Add(5*time.Minute)), "bob@example.com", "bob@example.com.evil.com", 1)
+	response = strings.Replace(sign(t, response, "_assertion", key), "bob@example.com.evil.com", "bob@example.com<!---->.evil.com", 1)
+	response = strings.Replace(response, "<saml:AttributeValue>bob</saml:AttributeValue>", "<saml:AttributeValue>b<!-- comment -->ob</saml:AttributeValue>", 1)
+
+	assertion, err := newTestValidator(cert).validate([]byte(response))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assertion.NameID != "bob@example.com.evil.com" {
+		t.Errorf("expected the whole signed name ID, got %q", assertion.NameID)
+	}
+	if uid := assertion.Attributes["uid"]; !reflect.DeepEqual(uid, []string{"bob"}) {
+		t.Errorf("expected the whole signed attribute value, got %v", uid)
+	}
+}
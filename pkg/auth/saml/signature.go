@@ -0,0 +1,175 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	// register the hashes used by the supported signature and digest methods
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	dsigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+
+	excC14NAlgorithm       = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	envelopedSigAlgorithm  = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	inclusiveNamespacesTag = "InclusiveNamespaces"
+)
+
+// signatureMethods maps the supported SignatureMethod algorithms to their hash
+var signatureMethods = map[string]crypto.Hash{
+	"http://www.w3.org/2000/09/xmldsig#rsa-sha1":        crypto.SHA1,
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256": crypto.SHA256,
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512": crypto.SHA512,
+}
+
+// digestMethods maps the supported DigestMethod algorithms to their hash
+var digestMethods = map[string]crypto.Hash{
+	"http://www.w3.org/2000/09/xmldsig#sha1":  crypto.SHA1,
+	"http://www.w3.org/2001/04/xmlenc#sha256": crypto.SHA256,
+	"http://www.w3.org/2001/04/xmlenc#sha512": crypto.SHA512,
+}
+
+// hasSignature returns true if e has an enveloped XML signature.
+func hasSignature(e *element) bool {
+	return len(e.childElements(dsigNamespace, "Signature")) > 0
+}
+
+// verifySignature verifies the enveloped XML signature of e against certs.
+// The signature must reference e by its ID, which must be unique in the
+// document, so that the verified content is the content that is used.
+func verifySignature(e *element, certs []*x509.Certificate) error {
+	signature := e.childElement(dsigNamespace, "Signature")
+	if signature == nil {
+		return errors.New("exactly one signature is required")
+	}
+	signedInfo := signature.childElement(dsigNamespace, "SignedInfo")
+	if signedInfo == nil {
+		return errors.New("signature has no SignedInfo")
+	}
+
+	id := e.attr("ID")
+	if len(id) == 0 {
+		return errors.New("signed element has no ID")
+	}
+	root := e
+	for root.parent != nil {
+		root = root.parent
+	}
+	count := 0
+	root.walk(func(el *element) {
+		if el.attr("ID") == id {
+			count++
+		}
+	})
+	if count != 1 {
+		return fmt.Errorf("ID %q is not unique", id)
+	}
+
+	// SignedInfo canonicalization and signature method
+	canonicalization := signedInfo.childElement(dsigNamespace, "CanonicalizationMethod")
+	if canonicalization == nil || canonicalization.attr("Algorithm") != excC14NAlgorithm {
+		return fmt.Errorf("only the %s canonicalization is supported", excC14NAlgorithm)
+	}
+	signatureMethod := signedInfo.childElement(dsigNamespace, "SignatureMethod")
+	if signatureMethod == nil {
+		return errors.New("signature has no SignatureMethod")
+	}
+	signatureHash, ok := signatureMethods[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported signature method %q", signatureMethod.attr("Algorithm"))
+	}
+
+	// the reference to e and its digest
+	reference := signedInfo.childElement(dsigNamespace, "Reference")
+	if reference == nil {
+		return errors.New("exactly one signature reference is required")
+	}
+	if reference.attr("URI") != "#"+id {
+		return fmt.Errorf("signature reference %q does not match the signed element ID %q", reference.attr("URI"), id)
+	}
+	var referencePrefixes []string
+	excC14N, enveloped := false, false
+	if transforms := reference.childElement(dsigNamespace, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(dsigNamespace, "Transform") {
+			switch transform.attr("Algorithm") {
+			case envelopedSigAlgorithm:
+				enveloped = true
+			case excC14NAlgorithm:
+				excC14N = true
+				referencePrefixes = inclusivePrefixList(transform)
+			default:
+				return fmt.Errorf("unsupported signature transform %q", transform.attr("Algorithm"))
+			}
+		}
+	}
+	if !excC14N || !enveloped {
+		return fmt.Errorf("the %s and %s transforms are required", envelopedSigAlgorithm, excC14NAlgorithm)
+	}
+	digestMethod := reference.childElement(dsigNamespace, "DigestMethod")
+	if digestMethod == nil {
+		return errors.New("signature reference has no DigestMethod")
+	}
+	digestHash, ok := digestMethods[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported digest method %q", digestMethod.attr("Algorithm"))
+	}
+	digestValue := reference.childElement(dsigNamespace, "DigestValue")
+	if digestValue == nil {
+		return errors.New("signature reference has no DigestValue")
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid digest value: %v", err)
+	}
+	digest := digestHash.New()
+	digest.Write(canonicalize(e, signature, referencePrefixes))
+	if string(digest.Sum(nil)) != string(expectedDigest) {
+		return errors.New("digest of the signed element does not match")
+	}
+
+	// the signature of SignedInfo
+	signatureValue := signature.childElement(dsigNamespace, "SignatureValue")
+	if signatureValue == nil {
+		return errors.New("signature has no SignatureValue")
+	}
+	value, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid signature value: %v", err)
+	}
+	signedHash := signatureHash.New()
+	signedHash.Write(canonicalize(signedInfo, nil, inclusivePrefixList(canonicalization)))
+	hashed := signedHash.Sum(nil)
+	for _, cert := range certs {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(key, signatureHash, hashed, value) == nil {
+			return nil
+		}
+	}
+	return errors.New("signature was not made by a trusted certificate")
+}
+
+// inclusivePrefixList returns the InclusiveNamespaces PrefixList of an
+// exclusive canonicalization method or transform.
+func inclusivePrefixList(method *element) []string {
+	inclusive := method.childElement(excC14NAlgorithm, inclusiveNamespacesTag)
+	if inclusive == nil {
+		return nil
+	}
+	return strings.Fields(inclusive.attr("PrefixList"))
+}
+
+// decodeBase64 decodes base64 data that may contain whitespace.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -0,0 +1,297 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element is a node of a parsed XML document. Unlike encoding/xml, it keeps
+// the namespace prefixes and declarations of the document, which are needed
+// to canonicalize the signed parts of a SAML response.
+type element struct {
+	parent *element
+	prefix string
+	local  string
+	// attrs contains the attributes and the namespace declarations, with
+	// their raw prefixes
+	attrs []xml.Attr
+	// children contains *element, xml.CharData and xml.ProcInst nodes
+	children []interface{}
+}
+
+// parseXML parses data into a tree of elements and returns the root element.
+// Documents with a DTD are rejected.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{parent: current, prefix: t.Name.Space, local: t.Name.Local, attrs: append([]xml.Attr{}, t.Attr...)}
+			if current != nil {
+				current.children = append(current.children, e)
+			} else if root != nil {
+				return nil, errors.New("multiple root elements")
+			} else {
+				root = e
+			}
+			current = e
+		case xml.EndElement:
+			if current == nil || current.prefix != t.Name.Space || current.local != t.Name.Local {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, xml.CharData(append([]byte{}, t...)))
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.children = append(current.children, xml.ProcInst{Target: t.Target, Inst: append([]byte{}, t.Inst...)})
+			}
+		case xml.Directive:
+			return nil, errors.New("documents with a DTD are not supported")
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("incomplete document")
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace URI bound to prefix in the scope of
+// e, "" for the default namespace when none is declared.
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for current := e; current != nil; current = current.parent {
+		for _, attr := range current.attrs {
+			if (len(prefix) == 0 && len(attr.Name.Space) == 0 && attr.Name.Local == "xmlns") ||
+				(len(prefix) > 0 && attr.Name.Space == "xmlns" && attr.Name.Local == prefix) {
+				return attr.Value, true
+			}
+		}
+	}
+	return "", len(prefix) == 0
+}
+
+// namespace returns the namespace URI of e.
+func (e *element) namespace() string {
+	ns, _ := e.lookupNamespace(e.prefix)
+	return ns
+}
+
+// is returns true if e has the given namespace and local name.
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+// attr returns the value of the unqualified attribute name.
+func (e *element) attr(name string) string {
+	for _, attr := range e.attrs {
+		if len(attr.Name.Space) == 0 && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// childElements returns the child elements of e with the given namespace and
+// local name.
+func (e *element) childElements(namespace, local string) []*element {
+	children := []*element{}
+	for _, child := range e.children {
+		if c, ok := child.(*element); ok && c.is(namespace, local) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// childElement returns the only child element of e with the given namespace
+// and local name, or nil if there is not exactly one.
+func (e *element) childElement(namespace, local string) *element {
+	if children := e.childElements(namespace, local); len(children) == 1 {
+		return children[0]
+	}
+	return nil
+}
+
+// text returns the concatenated character data of e and its descendants.
+func (e *element) text() string {
+	buf := &bytes.Buffer{}
+	for _, child := range e.children {
+		switch c := child.(type) {
+		case xml.CharData:
+			buf.Write(c)
+		case *element:
+			buf.WriteString(c.text())
+		}
+	}
+	return buf.String()
+}
+
+// walk calls fn for e and all its descendant elements.
+func (e *element) walk(fn func(*element)) {
+	fn(e)
+	for _, child := range e.children {
+		if c, ok := child.(*element); ok {
+			c.walk(fn)
+		}
+	}
+}
+
+// canonicalize returns the exclusive XML canonicalization without comments
+// (http://www.w3.org/2001/10/xml-exc-c14n#) of the subtree rooted at e,
+// leaving out the excluded element. inclusivePrefixes is the InclusiveNamespaces
+// PrefixList, where "#default" stands for the default namespace.
+func canonicalize(e *element, excluded *element, inclusivePrefixes []string) []byte {
+	inclusive := map[string]bool{}
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		inclusive[prefix] = true
+	}
+	buf := &bytes.Buffer{}
+	writeCanonical(buf, e, excluded, inclusive, map[string]string{})
+	return buf.Bytes()
+}
+
+type canonicalAttr struct {
+	namespace string
+	name      string
+	value     string
+}
+
+func writeCanonical(buf *bytes.Buffer, e *element, excluded *element, inclusive map[string]bool, rendered map[string]string) {
+	// namespaces visibly utilized by the element and its attributes, and the
+	// inclusive ones in scope
+	prefixes := map[string]bool{e.prefix: true}
+	attrs := []canonicalAttr{}
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "xmlns" || (len(attr.Name.Space) == 0 && attr.Name.Local == "xmlns") {
+			continue
+		}
+		name, namespace := attr.Name.Local, ""
+		if len(attr.Name.Space) > 0 {
+			name = attr.Name.Space + ":" + attr.Name.Local
+			namespace, _ = e.lookupNamespace(attr.Name.Space)
+			if attr.Name.Space != "xml" {
+				prefixes[attr.Name.Space] = true
+			}
+		}
+		attrs = append(attrs, canonicalAttr{namespace: namespace, name: name, value: attr.Value})
+	}
+	for prefix := range inclusive {
+		if _, ok := e.lookupNamespace(prefix); ok {
+			prefixes[prefix] = true
+		}
+	}
+
+	declarations := []string{}
+	scope := rendered
+	for prefix := range prefixes {
+		namespace, _ := e.lookupNamespace(prefix)
+		if previous, ok := rendered[prefix]; previous == namespace && (ok || len(prefix) == 0) {
+			continue
+		}
+		if len(prefix) > 0 && len(namespace) == 0 {
+			continue
+		}
+		if len(declarations) == 0 {
+			scope = make(map[string]string, len(rendered)+len(prefixes))
+			for k, v := range rendered {
+				scope[k] = v
+			}
+		}
+		scope[prefix] = namespace
+		declarations = append(declarations, prefix)
+	}
+	sort.Strings(declarations)
+	sort.Sort(canonicalAttrs(attrs))
+
+	buf.WriteString("<")
+	buf.WriteString(qualifiedName(e.prefix, e.local))
+	for _, prefix := range declarations {
+		if len(prefix) == 0 {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		buf.WriteString(escapeAttr(scope[prefix]))
+		buf.WriteString(`"`)
+	}
+	for _, attr := range attrs {
+		buf.WriteString(" " + attr.name + `="`)
+		buf.WriteString(escapeAttr(attr.value))
+		buf.WriteString(`"`)
+	}
+	buf.WriteString(">")
+	for _, child := range e.children {
+		switch c := child.(type) {
+		case *element:
+			if c != excluded {
+				writeCanonical(buf, c, excluded, inclusive, scope)
+			}
+		case xml.CharData:
+			buf.WriteString(escapeText(string(c)))
+		case xml.ProcInst:
+			buf.WriteString("<?" + c.Target)
+			if len(c.Inst) > 0 {
+				buf.WriteString(" " + string(c.Inst))
+			}
+			buf.WriteString("?>")
+		}
+	}
+	buf.WriteString("</" + qualifiedName(e.prefix, e.local) + ">")
+}
+
+func qualifiedName(prefix, local string) string {
+	if len(prefix) == 0 {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+// canonicalAttrs sorts attributes by namespace URI, then by local name
+type canonicalAttrs []canonicalAttr
+
+func (a canonicalAttrs) Len() int      { return len(a) }
+func (a canonicalAttrs) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a canonicalAttrs) Less(i, j int) bool {
+	if a[i].namespace != a[j].namespace {
+		return a[i].namespace < a[j].namespace
+	}
+	return localName(a[i].name) < localName(a[j].name)
+}
+
+func localName(name string) string {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string { return textEscaper.Replace(s) }
+func escapeAttr(s string) string { return attrEscaper.Replace(s) }
@@ -0,0 +1,68 @@
+package saml
+
+import (
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := map[string]struct {
+		document  string
+		id        string
+		inclusive []string
+		expected  string
+	}{
+		"unused namespaces": {
+			document: `<a:root xmlns:a="urn:a" xmlns:b="urn:b" xmlns="urn:default"><a:child ID="c"><b:leaf/></a:child></a:root>`,
+			id:       "c",
+			expected: `<a:child xmlns:a="urn:a" ID="c"><b:leaf xmlns:b="urn:b"></b:leaf></a:child>`,
+		},
+		"inclusive namespaces": {
+			document:  `<a:root xmlns:a="urn:a" xmlns:b="urn:b"><a:child ID="c"/></a:root>`,
+			id:        "c",
+			inclusive: []string{"b"},
+			expected:  `<a:child xmlns:a="urn:a" xmlns:b="urn:b" ID="c"></a:child>`,
+		},
+		"sorted attributes": {
+			document: `<root xmlns:z="urn:a" xmlns:a="urn:z" z:attr="1" a:attr="2" b="3" ID="c" a="4"/>`,
+			id:       "c",
+			expected: `<root xmlns:a="urn:z" xmlns:z="urn:a" ID="c" a="4" b="3" z:attr="1" a:attr="2"></root>`,
+		},
+		"escaping": {
+			document: "<root ID=\"c\" attr=\"&lt;&quot;&#9;&amp;\">&lt;&gt;&amp;\r\n<!-- comment --><![CDATA[<x>]]></root>",
+			id:       "c",
+			expected: "<root ID=\"c\" attr=\"&lt;&quot;&#x9;&amp;\">&lt;&gt;&amp;\n&lt;x&gt;</root>",
+		},
+		"redundant declarations": {
+			document: `<a:root xmlns:a="urn:a"><a:child xmlns:a="urn:a" ID="c"><a:leaf xmlns:a="urn:a"/></a:child></a:root>`,
+			id:       "c",
+			expected: `<a:child xmlns:a="urn:a" ID="c"><a:leaf></a:leaf></a:child>`,
+		},
+	}
+
+	for name, tc := range testCases {
+		root, err := parseXML([]byte(tc.document))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		var e *element
+		root.walk(func(el *element) {
+			if el.attr("ID") == tc.id {
+				e = el
+			}
+		})
+		if e == nil {
+			t.Errorf("%s: element %s not found", name, tc.id)
+			continue
+		}
+		if actual := string(canonicalize(e, nil, tc.inclusive)); actual != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestParseXMLRejectsDTD(t *testing.T) {
+	if _, err := parseXML([]byte(`<!DOCTYPE root [<!ENTITY x "y">]><root>&x;</root>`)); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -23,6 +23,7 @@ const (
 	csrfParam     = "csrf"
 	usernameParam = "username"
 	passwordParam = "password"
+	codeParam     = "code"
 
 	// these can be used by custom templates, and should not be changed
 	// these error codes are specific to the login flow.
@@ -66,6 +67,8 @@ type LoginFormFields struct {
 	CSRF     string
 	Username string
 	Password string
+	// Code is the optional TOTP verification code of the users enrolled in multi-factor authentication
+	Code string
 }
 
 type Login struct {
@@ -120,6 +123,7 @@ func (l *Login) handleLoginForm(w http.ResponseWriter, req *http.Request) {
 			CSRF:     csrfParam,
 			Username: usernameParam,
 			Password: passwordParam,
+			Code:     codeParam,
 		},
 	}
 	if then := req.URL.Query().Get("then"); then != "" {
@@ -157,7 +161,11 @@ func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
 		failed(errorCodeUserRequired, w, req)
 		return
 	}
-	user, ok, err := l.auth.AuthenticatePassword(username, password)
+	// verification codes are checked as a suffix of the password, the way they are sent with basic auth challenges
+	if code := strings.Replace(req.FormValue("code"), " ", "", -1); len(code) > 0 {
+		password += code
+	}
+	user, ok, err := authenticator.AuthenticatePasswordFromSource(l.auth, authenticator.RequestSource(req), username, password)
 	if err != nil {
 		glog.Errorf(`Error authenticating %q with provider %q: %v`, username, l.provider, err)
 		failed(errorpage.AuthenticationErrorCode(err), w, req)
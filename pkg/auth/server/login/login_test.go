@@ -49,6 +49,7 @@ func TestLogin(t *testing.T) {
 		ExpectRedirect   string
 		ExpectContains   []string
 		ExpectThen       string
+		ExpectPassword   string
 	}{
 		"display form": {
 			CSRF: &csrf.FakeCSRF{Token: "test"},
@@ -59,6 +60,7 @@ func TestLogin(t *testing.T) {
 			ExpectContains: []string{
 				`action="/login"`,
 				`name="csrf" value="test"`,
+				`name="code"`,
 			},
 		},
 		"display form with errors": {
@@ -158,6 +160,19 @@ func TestLogin(t *testing.T) {
 			},
 			ExpectThen: "done",
 		},
+		"login with verification code": {
+			CSRF: &csrf.FakeCSRF{Token: "test"},
+			Auth: &testAuth{Success: true, User: &user.DefaultInfo{Name: "user"}},
+			Path: "/login?then=done",
+			PostValues: url.Values{
+				"csrf":     []string{"test"},
+				"username": []string{"user"},
+				"password": []string{"password"},
+				"code":     []string{"123 456"},
+			},
+			ExpectThen:     "done",
+			ExpectPassword: "password123456",
+		},
 	}
 
 	for k, testCase := range testCases {
@@ -206,6 +221,10 @@ func TestLogin(t *testing.T) {
 			t.Errorf("%s: did not find expected 'then' value: %#v", k, testCase.Auth)
 		}
 
+		if testCase.ExpectPassword != "" && testCase.Auth.Password != testCase.ExpectPassword {
+			t.Errorf("%s: expected password %q, got %q", k, testCase.ExpectPassword, testCase.Auth.Password)
+		}
+
 		if len(testCase.ExpectContains) > 0 {
 			data, _ := ioutil.ReadAll(resp.Body)
 			body := string(data)
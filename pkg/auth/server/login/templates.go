@@ -64,6 +64,13 @@ oauthConfig:
         <input type="password" id="inputPassword" type="password" name="{{ .Names.Password }}" value="">
       </div>
 
+      <div>
+        <label for="inputCode">Verification code</label>
+      </div>
+      <div>
+        <input type="text" id="inputCode" autocomplete="off" name="{{ .Names.Code }}" value="">
+      </div>
+
       <button type="submit">Log In</button>
 
     </form>
@@ -2411,6 +2418,12 @@ hr {
                 <input type="password" class="form-control" id="inputPassword" placeholder="" tabindex="2" type="password" name="{{ .Names.Password }}" value="">
               </div>
             </div>
+            <div class="form-group">
+              <label for="inputCode" class="col-sm-2 col-md-2 control-label">Code</label>
+              <div class="col-sm-10 col-md-10">
+                <input type="text" class="form-control" id="inputCode" placeholder="Verification code, if enrolled" tabindex="3" autocomplete="off" name="{{ .Names.Code }}" value="">
+              </div>
+            </div>
             <div class="form-group">
               <div class="col-xs-8 col-sm-offset-2 col-sm-6 col-md-offset-2 col-md-6">
               <!--
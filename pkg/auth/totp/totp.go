@@ -0,0 +1,130 @@
+// Package totp implements the time-based one-time passwords of RFC 6238, as generated by authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// SecretNamespace is the namespace of the Secrets holding the TOTP secrets of the enrolled users. The Secrets of
+	// this namespace can only be read by cluster administrators and the master.
+	SecretNamespace = "openshift-infra"
+	// SecretKey is the key of the base32 encoded TOTP secret in the data of the Secret of an enrolled user
+	SecretKey = "totp-secret"
+	// UserAnnotation is set on the Secret of an enrolled user to the name of the user
+	UserAnnotation = "openshift.io/user"
+
+	// Digits is the number of digits of a code
+	Digits = 6
+	// Period is the time a code is valid for
+	Period = 30 * time.Second
+	// Skew is the number of periods before and after the current one whose codes are accepted, to allow for clock
+	// drift and typing delays
+	Skew = 1
+
+	secretSize = 20
+)
+
+// SecretName returns the name of the Secret holding the TOTP secret of the named user. Users without this Secret are
+// not enrolled. User names are hashed, as they are not all valid Secret names.
+func SecretName(user string) string {
+	return fmt.Sprintf("totp-%x", sha256.Sum256([]byte(user)))
+}
+
+// GenerateSecret returns a new random base32 encoded secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(base32.StdEncoding.EncodeToString(secret), "="), nil
+}
+
+// decodeSecret decodes a base32 secret, ignoring case, spaces and padding
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Replace(strings.TrimRight(secret, "="), " ", "", -1))
+	if padding := len(secret) % 8; padding != 0 {
+		secret += strings.Repeat("=", 8-padding)
+	}
+	key, err := base32.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %v", err)
+	}
+	if len(key) == 0 {
+		return nil, errors.New("invalid TOTP secret: empty")
+	}
+	return key, nil
+}
+
+// Counter returns the counter of the period containing t
+func Counter(t time.Time) uint64 {
+	return uint64(t.Unix() / int64(Period/time.Second))
+}
+
+// Code returns the code for the given secret and counter
+func Code(secret string, counter uint64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, counter), nil
+}
+
+func code(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, as described in RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
+
+// Validate checks the code against the codes of the periods around t. It returns the counter of the matching period,
+// so callers can reject codes that were already used.
+func Validate(secret, candidate string, t time.Time) (uint64, bool, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(candidate) != Digits {
+		return 0, false, nil
+	}
+	current := Counter(t)
+	for i := -Skew; i <= Skew; i++ {
+		counter := uint64(int64(current) + int64(i))
+		if hmac.Equal([]byte(code(key, counter)), []byte(candidate)) {
+			return counter, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// URI returns the otpauth:// URI used to enroll the secret in an authenticator app, usually displayed as a QR code
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("digits", fmt.Sprintf("%d", Digits))
+	query.Set("period", fmt.Sprintf("%d", int(Period/time.Second)))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
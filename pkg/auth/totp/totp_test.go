@@ -0,0 +1,88 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 secret of the test vectors of RFC 6238 appendix B
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the test vectors use 8 digits, the last 6 are the 6 digit codes
+	testCases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range testCases {
+		code, err := Code(rfcSecret, Counter(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code != expected {
+			t.Errorf("%d: expected %s, got %s", unix, expected, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Counter(now)
+
+	testCases := map[string]struct {
+		counter uint64
+		ok      bool
+	}{
+		"current":  {counter: current, ok: true},
+		"previous": {counter: current - 1, ok: true},
+		"next":     {counter: current + 1, ok: true},
+		"expired":  {counter: current - 2, ok: false},
+	}
+	for name, tc := range testCases {
+		code, err := Code(rfcSecret, tc.counter)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		counter, ok, err := Validate(rfcSecret, code, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if ok != tc.ok || (ok && counter != tc.counter) {
+			t.Errorf("%s: expected %v for counter %d, got %v for counter %d", name, tc.ok, tc.counter, ok, counter)
+		}
+	}
+
+	if _, ok, _ := Validate(rfcSecret, "12345", now); ok {
+		t.Errorf("expected a short code to be rejected")
+	}
+	if _, _, err := Validate("not base32!", "123456", now); err == nil {
+		t.Errorf("expected an error for an invalid secret")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := Code(secret, 0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// lowercase and spaced secrets, as typed by users, are accepted
+	if _, err := Code(strings.ToLower(secret[:4])+" "+secret[4:], 0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	expected := "otpauth://totp/openshift:bob?digits=6&issuer=openshift&period=30&secret=ABCD"
+	if uri := URI("openshift", "bob", "ABCD"); uri != expected {
+		t.Errorf("expected %s, got %s", expected, uri)
+	}
+}
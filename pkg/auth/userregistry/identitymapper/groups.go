@@ -0,0 +1,170 @@
+package identitymapper
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	kuser "k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
+	kvalidation "k8s.io/kubernetes/pkg/util/validation"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+	"github.com/openshift/origin/pkg/user/api/validation"
+	groupregistry "github.com/openshift/origin/pkg/user/registry/group"
+)
+
+const (
+	// IdentityProviderGroupAnnotation is set on the groups managed by an identity provider to the name of the provider
+	IdentityProviderGroupAnnotation = "openshift.io/identity-provider"
+	// IdentityProviderGroupLabel is set on the groups managed by an identity provider to select them. Its value is the
+	// name of the provider, or a hash of the name if the name is not a valid label value.
+	IdentityProviderGroupLabel = "openshift.io/identity-provider"
+)
+
+// identityProviderLabelValue returns the value of the IdentityProviderGroupLabel of the groups of the named provider
+func identityProviderLabelValue(providerName string) string {
+	if kvalidation.IsValidLabelValue(providerName) {
+		return providerName
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(providerName))
+	return fmt.Sprintf("%x", hash.Sum64())
+}
+
+var _ = authapi.UserIdentityMapper(&groupsIdentityMapper{})
+
+// groupsIdentityMapper implements api.UserIdentityMapper
+// After mapping an identity to a user, the user is added to the groups the identity provider describes the identity
+// as a member of, and removed from the other groups of the provider. The groups of a provider are created on demand and
+// carry the IdentityProviderGroupAnnotation, groups created by other means are never modified.
+type groupsIdentityMapper struct {
+	delegate authapi.UserIdentityMapper
+	groups   groupregistry.Registry
+	// prefix is prepended to the group names returned by the provider
+	prefix string
+}
+
+// NewGroupsIdentityMapper returns a UserIdentityMapper that synchronizes the members of the groups of identity providers
+// returning authapi.UserIdentityInfoGroups identities. The names of the groups are the names returned by the provider,
+// prefixed with prefix.
+func NewGroupsIdentityMapper(delegate authapi.UserIdentityMapper, groups groupregistry.Registry, prefix string) authapi.UserIdentityMapper {
+	return &groupsIdentityMapper{delegate: delegate, groups: groups, prefix: prefix}
+}
+
+// UserFor returns info about the user for whom identity info have been provided
+func (m *groupsIdentityMapper) UserFor(info authapi.UserIdentityInfo) (kuser.Info, error) {
+	user, err := m.delegate.UserFor(info)
+	if err != nil {
+		return nil, err
+	}
+	groupsInfo, ok := info.(authapi.UserIdentityInfoGroups)
+	if !ok || groupsInfo.GetProviderGroups() == nil {
+		// the provider did not describe the groups of the identity, the memberships are left unchanged
+		return user, nil
+	}
+	// A failure would leave the user in groups it is no longer a member of, so it fails the login
+	if err := m.syncGroups(info.GetProviderName(), user.GetName(), groupsInfo.GetProviderGroups()); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (m *groupsIdentityMapper) syncGroups(providerName, userName string, providerGroups []string) error {
+	ctx := kapi.NewContext()
+
+	desired := sets.NewString()
+	for _, providerGroup := range providerGroups {
+		name := m.prefix + providerGroup
+		if ok, msg := validation.ValidateGroupName(name, false); !ok {
+			glog.V(4).Infof("Ignoring group %q of identity provider %s: %s", name, providerName, msg)
+			continue
+		}
+		desired.Insert(name)
+	}
+
+	// the annotation is still checked, the label only narrows the list down to the groups of the provider
+	selector := labels.SelectorFromSet(labels.Set{IdentityProviderGroupLabel: identityProviderLabelValue(providerName)})
+	groups, err := m.groups.ListGroups(ctx, &kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	existing := sets.NewString()
+	for i := range groups.Items {
+		group := &groups.Items[i]
+		existing.Insert(group.Name)
+		if group.Annotations[IdentityProviderGroupAnnotation] != providerName {
+			if desired.Has(group.Name) {
+				glog.Warningf("Not adding user %s to group %s, the group is not managed by identity provider %s", userName, group.Name, providerName)
+			}
+			continue
+		}
+		member := sets.NewString(group.Users...).Has(userName)
+		if desired.Has(group.Name) != member {
+			if err := m.updateMembership(ctx, providerName, group.Name, userName, !member); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range desired.Difference(existing).List() {
+		group := &userapi.Group{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{IdentityProviderGroupLabel: identityProviderLabelValue(providerName)},
+				Annotations: map[string]string{IdentityProviderGroupAnnotation: providerName},
+			},
+			Users: []string{userName},
+		}
+		if _, err := m.groups.CreateGroup(ctx, group); err != nil {
+			if !kerrs.IsAlreadyExists(err) {
+				return err
+			}
+			// the group was created by another login in the meantime
+			if err := m.updateMembership(ctx, providerName, name, userName, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateMembership adds userName to or removes it from the named group of the provider, retrying on conflicts with
+// concurrent logins
+func (m *groupsIdentityMapper) updateMembership(ctx kapi.Context, providerName, name, userName string, add bool) error {
+	var err error
+	for i := 0; i < 3; i++ {
+		var group *userapi.Group
+		group, err = m.groups.GetGroup(ctx, name)
+		if err != nil {
+			return err
+		}
+		if group.Annotations[IdentityProviderGroupAnnotation] != providerName {
+			glog.Warningf("Not updating user %s in group %s, the group is not managed by identity provider %s", userName, name, providerName)
+			return nil
+		}
+		if sets.NewString(group.Users...).Has(userName) == add {
+			return nil
+		}
+		if add {
+			group.Users = append(group.Users, userName)
+		} else {
+			users := []string{}
+			for _, user := range group.Users {
+				if user != userName {
+					users = append(users, user)
+				}
+			}
+			group.Users = users
+		}
+		_, err = m.groups.UpdateGroup(ctx, group)
+		if !kerrs.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+package identitymapper
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrs "k8s.io/kubernetes/pkg/api/errors"
+	kuser "k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+type testGroupRegistry struct {
+	groups map[string]*userapi.Group
+}
+
+func (r *testGroupRegistry) ListGroups(ctx kapi.Context, options *kapi.ListOptions) (*userapi.GroupList, error) {
+	list := &userapi.GroupList{}
+	for _, group := range r.groups {
+		if options.LabelSelector != nil && !options.LabelSelector.Matches(labels.Set(group.Labels)) {
+			continue
+		}
+		list.Items = append(list.Items, *group)
+	}
+	return list, nil
+}
+
+func (r *testGroupRegistry) GetGroup(ctx kapi.Context, name string) (*userapi.Group, error) {
+	group, ok := r.groups[name]
+	if !ok {
+		return nil, kerrs.NewNotFound(userapi.Resource("group"), name)
+	}
+	copied := *group
+	return &copied, nil
+}
+
+func (r *testGroupRegistry) CreateGroup(ctx kapi.Context, group *userapi.Group) (*userapi.Group, error) {
+	if _, ok := r.groups[group.Name]; ok {
+		return nil, kerrs.NewAlreadyExists(userapi.Resource("group"), group.Name)
+	}
+	r.groups[group.Name] = group
+	return group, nil
+}
+
+func (r *testGroupRegistry) UpdateGroup(ctx kapi.Context, group *userapi.Group) (*userapi.Group, error) {
+	r.groups[group.Name] = group
+	return group, nil
+}
+
+func (r *testGroupRegistry) DeleteGroup(ctx kapi.Context, name string) error {
+	delete(r.groups, name)
+	return nil
+}
+
+func (r *testGroupRegistry) WatchGroups(ctx kapi.Context, options *kapi.ListOptions) (watch.Interface, error) {
+	return nil, nil
+}
+
+type testIdentityMapper struct{}
+
+func (testIdentityMapper) UserFor(info authapi.UserIdentityInfo) (kuser.Info, error) {
+	return &kuser.DefaultInfo{Name: "bob"}, nil
+}
+
+func providerGroup(name, provider string, users ...string) *userapi.Group {
+	group := &userapi.Group{ObjectMeta: kapi.ObjectMeta{Name: name}, Users: users}
+	if len(provider) > 0 {
+		group.Labels = map[string]string{IdentityProviderGroupLabel: identityProviderLabelValue(provider)}
+		group.Annotations = map[string]string{IdentityProviderGroupAnnotation: provider}
+	}
+	return group
+}
+
+func TestGroupsIdentityMapper(t *testing.T) {
+	registry := &testGroupRegistry{groups: map[string]*userapi.Group{
+		"admins":     providerGroup("admins", "saml", "alice", "bob"),
+		"developers": providerGroup("developers", "saml", "alice"),
+		"other":      providerGroup("other", "ldap", "bob"),
+		"manual":     providerGroup("manual", ""),
+	}}
+	mapper := NewGroupsIdentityMapper(testIdentityMapper{}, registry, "")
+
+	identity := authapi.NewDefaultUserIdentityInfo("saml", "bob")
+	identity.ProviderGroups = []string{"developers", "testers", "manual", "invalid/name"}
+	if _, err := mapper.UserFor(identity); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string][]string{
+		// removed, no longer asserted by the provider
+		"admins": {"alice"},
+		// added
+		"developers": {"alice", "bob"},
+		// created
+		"testers": {"bob"},
+		// managed by another provider
+		"other": {"bob"},
+		// not managed by a provider
+		"manual": nil,
+	}
+	if len(registry.groups) != len(expected) {
+		t.Errorf("expected groups %v, got %v", expected, registry.groups)
+	}
+	for name, users := range expected {
+		group, ok := registry.groups[name]
+		if !ok {
+			t.Errorf("expected group %s", name)
+			continue
+		}
+		sort.Strings(group.Users)
+		if !reflect.DeepEqual(group.Users, users) {
+			t.Errorf("expected users %v in group %s, got %v", users, name, group.Users)
+		}
+	}
+	if registry.groups["testers"].Annotations[IdentityProviderGroupAnnotation] != "saml" {
+		t.Errorf("expected the created group to be managed by the provider, got %v", registry.groups["testers"].Annotations)
+	}
+	if registry.groups["testers"].Labels[IdentityProviderGroupLabel] != "saml" {
+		t.Errorf("expected the created group to be labeled with the provider, got %v", registry.groups["testers"].Labels)
+	}
+}
+
+func TestIdentityProviderLabelValue(t *testing.T) {
+	if value := identityProviderLabelValue("my_provider.1"); value != "my_provider.1" {
+		t.Errorf("expected a valid label value to be used as is, got %q", value)
+	}
+	value := identityProviderLabelValue("my provider")
+	if value == "my provider" || value != identityProviderLabelValue("my provider") || value == identityProviderLabelValue("other provider") {
+		t.Errorf("expected a stable hash of an invalid label value, got %q", value)
+	}
+}
+
+func TestGroupsIdentityMapperPrefix(t *testing.T) {
+	registry := &testGroupRegistry{groups: map[string]*userapi.Group{
+		"oidc-admins": providerGroup("oidc-admins", "oidc", "bob"),
+		"developers":  providerGroup("developers", "", "alice"),
+	}}
+	mapper := NewGroupsIdentityMapper(testIdentityMapper{}, registry, "oidc-")
+
+	identity := authapi.NewDefaultUserIdentityInfo("oidc", "bob")
+	identity.ProviderGroups = []string{"developers"}
+	if _, err := mapper.UserFor(identity); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"oidc-admins":     {},
+		"oidc-developers": {"bob"},
+		"developers":      {"alice"},
+	}
+	if len(registry.groups) != len(expected) {
+		t.Errorf("expected groups %v, got %v", expected, registry.groups)
+	}
+	for name, users := range expected {
+		group, ok := registry.groups[name]
+		if !ok {
+			t.Errorf("expected group %s", name)
+			continue
+		}
+		if !reflect.DeepEqual(group.Users, users) {
+			t.Errorf("expected users %v in group %s, got %v", users, name, group.Users)
+		}
+	}
+}
+
+func TestGroupsIdentityMapperWithoutGroups(t *testing.T) {
+	registry := &testGroupRegistry{groups: map[string]*userapi.Group{
+		"admins": providerGroup("admins", "saml", "bob"),
+	}}
+	mapper := NewGroupsIdentityMapper(testIdentityMapper{}, registry, "")
+
+	// the provider did not describe the groups, the memberships are left unchanged
+	identity := authapi.NewDefaultUserIdentityInfo("saml", "bob")
+	if _, err := mapper.UserFor(identity); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if users := registry.groups["admins"].Users; !reflect.DeepEqual(users, []string{"bob"}) {
+		t.Errorf("expected the memberships to be left unchanged, got %v", users)
+	}
+
+	// the provider described the identity as a member of no group
+	identity.ProviderGroups = []string{}
+	if _, err := mapper.UserFor(identity); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if users := registry.groups["admins"].Users; len(users) != 0 {
+		t.Errorf("expected the user to be removed from the group, got %v", users)
+	}
+}
@@ -25,6 +25,7 @@ func init() {
 		DeepCopy_api_BuildOutput,
 		DeepCopy_api_BuildPostCommitSpec,
 		DeepCopy_api_BuildRequest,
+		DeepCopy_api_BuildRetryPolicy,
 		DeepCopy_api_BuildSource,
 		DeepCopy_api_BuildSpec,
 		DeepCopy_api_BuildStatus,
@@ -146,6 +147,15 @@ func DeepCopy_api_BuildConfigSpec(in BuildConfigSpec, out *BuildConfigSpec, c *c
 		out.Triggers = nil
 	}
 	out.RunPolicy = in.RunPolicy
+	if in.RetryPolicy != nil {
+		in, out := in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		if err := DeepCopy_api_BuildRetryPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetryPolicy = nil
+	}
 	if err := DeepCopy_api_CommonSpec(in.CommonSpec, &out.CommonSpec, c); err != nil {
 		return err
 	}
@@ -350,6 +360,13 @@ func DeepCopy_api_BuildRequest(in BuildRequest, out *BuildRequest, c *conversion
 	return nil
 }
 
+func DeepCopy_api_BuildRetryPolicy(in BuildRetryPolicy, out *BuildRetryPolicy, c *conversion.Cloner) error {
+	out.Limit = in.Limit
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.MaxDelaySeconds = in.MaxDelaySeconds
+	return nil
+}
+
 func DeepCopy_api_BuildSource(in BuildSource, out *BuildSource, c *conversion.Cloner) error {
 	if in.Binary != nil {
 		in, out := in.Binary, &out.Binary
@@ -46,6 +46,15 @@ const (
 	// BuildConfigPausedAnnotation is an annotation that marks a BuildConfig as paused.
 	// New Builds cannot be instantiated from a paused BuildConfig.
 	BuildConfigPausedAnnotation = "openshift.io/build-config.paused"
+	// BuildRetryAttemptAnnotation is an annotation whose value is the number of times
+	// the original build has been automatically retried to produce this build.
+	BuildRetryAttemptAnnotation = "openshift.io/build.retry-attempt"
+	// BuildRetriedByAnnotation is an annotation whose value is the name of the build
+	// that was created to automatically retry this failed build.
+	BuildRetriedByAnnotation = "openshift.io/build.retried-by"
+	// BuildRetryLimitAnnotation is an annotation whose value is the retry limit of the
+	// BuildConfig the build was created from. Builds without it are not retried.
+	BuildRetryLimitAnnotation = "openshift.io/build.retry-limit"
 )
 
 // +genclient=true
@@ -261,6 +270,15 @@ const (
 	// StatusReasonExceededRetryTimeout is an error condition when the build has
 	// not completed and retrying the build times out.
 	StatusReasonExceededRetryTimeout = "ExceededRetryTimeout"
+
+	// StatusReasonFetchSourceFailed is an error condition when the builder
+	// could not fetch the build source, for example because the source
+	// repository could not be reached in time.
+	StatusReasonFetchSourceFailed = "FetchSourceFailed"
+
+	// StatusReasonPushImageToRegistryFailed is an error condition when the
+	// builder could not push the output image to the registry.
+	StatusReasonPushImageToRegistryFailed = "PushImageToRegistryFailed"
 )
 
 // BuildSource is the input used for the build.
@@ -653,10 +671,33 @@ type BuildConfigSpec struct {
 	// This is optional, if not specified we default to "Serial".
 	RunPolicy BuildRunPolicy
 
+	// RetryPolicy describes how builds created from this build configuration
+	// that fail for a transient reason are automatically retried.
+	// This is optional, if not specified failed builds are not retried.
+	RetryPolicy *BuildRetryPolicy
+
 	// CommonSpec is the desired build specification
 	CommonSpec
 }
 
+// BuildRetryPolicy describes how failed builds are automatically retried.
+// Only builds that ended in the Failed or Error phase with a transient
+// StatusReason are retried.
+type BuildRetryPolicy struct {
+	// Limit is the maximum number of times a failed build is retried.
+	Limit int32
+
+	// InitialDelaySeconds is the number of seconds to wait after a build
+	// failed before it is retried for the first time. The delay doubles with
+	// every following retry.
+	InitialDelaySeconds int64
+
+	// MaxDelaySeconds is the upper bound for the number of seconds to wait
+	// before a failed build is retried.
+	// This is optional, if not specified the delay is capped at 24 hours.
+	MaxDelaySeconds int64
+}
+
 // BuildRunPolicy defines the behaviour of how the new builds are executed
 // from the existing build configuration.
 type BuildRunPolicy string
@@ -40,6 +40,8 @@ func init() {
 		Convert_api_BuildPostCommitSpec_To_v1_BuildPostCommitSpec,
 		Convert_v1_BuildRequest_To_api_BuildRequest,
 		Convert_api_BuildRequest_To_v1_BuildRequest,
+		Convert_v1_BuildRetryPolicy_To_api_BuildRetryPolicy,
+		Convert_api_BuildRetryPolicy_To_v1_BuildRetryPolicy,
 		Convert_v1_BuildSource_To_api_BuildSource,
 		Convert_api_BuildSource_To_v1_BuildSource,
 		Convert_v1_BuildSpec_To_api_BuildSpec,
@@ -302,6 +304,15 @@ func autoConvert_v1_BuildConfigSpec_To_api_BuildConfigSpec(in *BuildConfigSpec,
 		out.Triggers = nil
 	}
 	out.RunPolicy = build_api.BuildRunPolicy(in.RunPolicy)
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(build_api.BuildRetryPolicy)
+		if err := Convert_v1_BuildRetryPolicy_To_api_BuildRetryPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetryPolicy = nil
+	}
 	if err := Convert_v1_CommonSpec_To_api_CommonSpec(&in.CommonSpec, &out.CommonSpec, s); err != nil {
 		return err
 	}
@@ -325,6 +336,15 @@ func autoConvert_api_BuildConfigSpec_To_v1_BuildConfigSpec(in *build_api.BuildCo
 		out.Triggers = nil
 	}
 	out.RunPolicy = BuildRunPolicy(in.RunPolicy)
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		if err := Convert_api_BuildRetryPolicy_To_v1_BuildRetryPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetryPolicy = nil
+	}
 	if err := Convert_api_CommonSpec_To_v1_CommonSpec(&in.CommonSpec, &out.CommonSpec, s); err != nil {
 		return err
 	}
@@ -795,6 +815,28 @@ func Convert_api_BuildRequest_To_v1_BuildRequest(in *build_api.BuildRequest, out
 	return autoConvert_api_BuildRequest_To_v1_BuildRequest(in, out, s)
 }
 
+func autoConvert_v1_BuildRetryPolicy_To_api_BuildRetryPolicy(in *BuildRetryPolicy, out *build_api.BuildRetryPolicy, s conversion.Scope) error {
+	out.Limit = in.Limit
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.MaxDelaySeconds = in.MaxDelaySeconds
+	return nil
+}
+
+func Convert_v1_BuildRetryPolicy_To_api_BuildRetryPolicy(in *BuildRetryPolicy, out *build_api.BuildRetryPolicy, s conversion.Scope) error {
+	return autoConvert_v1_BuildRetryPolicy_To_api_BuildRetryPolicy(in, out, s)
+}
+
+func autoConvert_api_BuildRetryPolicy_To_v1_BuildRetryPolicy(in *build_api.BuildRetryPolicy, out *BuildRetryPolicy, s conversion.Scope) error {
+	out.Limit = in.Limit
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.MaxDelaySeconds = in.MaxDelaySeconds
+	return nil
+}
+
+func Convert_api_BuildRetryPolicy_To_v1_BuildRetryPolicy(in *build_api.BuildRetryPolicy, out *BuildRetryPolicy, s conversion.Scope) error {
+	return autoConvert_api_BuildRetryPolicy_To_v1_BuildRetryPolicy(in, out, s)
+}
+
 func autoConvert_v1_BuildSource_To_api_BuildSource(in *BuildSource, out *build_api.BuildSource, s conversion.Scope) error {
 	SetDefaults_BuildSource(in)
 	if in.Binary != nil {
@@ -26,6 +26,7 @@ func init() {
 		DeepCopy_v1_BuildOutput,
 		DeepCopy_v1_BuildPostCommitSpec,
 		DeepCopy_v1_BuildRequest,
+		DeepCopy_v1_BuildRetryPolicy,
 		DeepCopy_v1_BuildSource,
 		DeepCopy_v1_BuildSpec,
 		DeepCopy_v1_BuildStatus,
@@ -146,6 +147,15 @@ func DeepCopy_v1_BuildConfigSpec(in BuildConfigSpec, out *BuildConfigSpec, c *co
 		out.Triggers = nil
 	}
 	out.RunPolicy = in.RunPolicy
+	if in.RetryPolicy != nil {
+		in, out := in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		if err := DeepCopy_v1_BuildRetryPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetryPolicy = nil
+	}
 	if err := DeepCopy_v1_CommonSpec(in.CommonSpec, &out.CommonSpec, c); err != nil {
 		return err
 	}
@@ -350,6 +360,13 @@ func DeepCopy_v1_BuildRequest(in BuildRequest, out *BuildRequest, c *conversion.
 	return nil
 }
 
+func DeepCopy_v1_BuildRetryPolicy(in BuildRetryPolicy, out *BuildRetryPolicy, c *conversion.Cloner) error {
+	out.Limit = in.Limit
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.MaxDelaySeconds = in.MaxDelaySeconds
+	return nil
+}
+
 func DeepCopy_v1_BuildSource(in BuildSource, out *BuildSource, c *conversion.Cloner) error {
 	out.Type = in.Type
 	if in.Binary != nil {
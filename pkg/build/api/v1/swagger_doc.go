@@ -63,9 +63,10 @@ func (BuildConfigList) SwaggerDoc() map[string]string {
 }
 
 var map_BuildConfigSpec = map[string]string{
-	"":          "BuildConfigSpec describes when and how builds are created",
-	"triggers":  "triggers determine how new Builds can be launched from a BuildConfig. If no triggers are defined, a new build can only occur as a result of an explicit client build creation.",
-	"runPolicy": "RunPolicy describes how the new build created from this build configuration will be scheduled for execution. This is optional, if not specified we default to \"Serial\".",
+	"":            "BuildConfigSpec describes when and how builds are created",
+	"triggers":    "triggers determine how new Builds can be launched from a BuildConfig. If no triggers are defined, a new build can only occur as a result of an explicit client build creation.",
+	"runPolicy":   "RunPolicy describes how the new build created from this build configuration will be scheduled for execution. This is optional, if not specified we default to \"Serial\".",
+	"retryPolicy": "retryPolicy describes how builds created from this build configuration that fail for a transient reason are automatically retried. This is optional, if not specified failed builds are not retried.",
 }
 
 func (BuildConfigSpec) SwaggerDoc() map[string]string {
@@ -154,6 +155,17 @@ func (BuildRequest) SwaggerDoc() map[string]string {
 	return map_BuildRequest
 }
 
+var map_BuildRetryPolicy = map[string]string{
+	"":                    "BuildRetryPolicy describes how failed builds are automatically retried. Only builds that ended in the Failed or Error phase with a transient reason, such as a failed push to the registry, are retried.",
+	"limit":               "limit is the maximum number of times a failed build is retried.",
+	"initialDelaySeconds": "initialDelaySeconds is the number of seconds to wait after a build failed before it is retried for the first time. The delay doubles with every following retry.",
+	"maxDelaySeconds":     "maxDelaySeconds is the upper bound for the number of seconds to wait before a failed build is retried. This is optional, if not specified the delay is capped at 24 hours.",
+}
+
+func (BuildRetryPolicy) SwaggerDoc() map[string]string {
+	return map_BuildRetryPolicy
+}
+
 var map_BuildSource = map[string]string{
 	"":             "BuildSource is the SCM used for the build.",
 	"type":         "type of build input to accept",
@@ -582,10 +582,33 @@ type BuildConfigSpec struct {
 	// This is optional, if not specified we default to "Serial".
 	RunPolicy BuildRunPolicy `json:"runPolicy,omitempty"`
 
+	// retryPolicy describes how builds created from this build configuration
+	// that fail for a transient reason are automatically retried.
+	// This is optional, if not specified failed builds are not retried.
+	RetryPolicy *BuildRetryPolicy `json:"retryPolicy,omitempty"`
+
 	// CommonSpec is the desired build specification
 	CommonSpec `json:",inline"`
 }
 
+// BuildRetryPolicy describes how failed builds are automatically retried.
+// Only builds that ended in the Failed or Error phase with a transient
+// reason, such as a failed push to the registry, are retried.
+type BuildRetryPolicy struct {
+	// limit is the maximum number of times a failed build is retried.
+	Limit int32 `json:"limit"`
+
+	// initialDelaySeconds is the number of seconds to wait after a build
+	// failed before it is retried for the first time. The delay doubles with
+	// every following retry.
+	InitialDelaySeconds int64 `json:"initialDelaySeconds,omitempty"`
+
+	// maxDelaySeconds is the upper bound for the number of seconds to wait
+	// before a failed build is retried.
+	// This is optional, if not specified the delay is capped at 24 hours.
+	MaxDelaySeconds int64 `json:"maxDelaySeconds,omitempty"`
+}
+
 // BuildRunPolicy defines the behaviour of how the new builds are executed
 // from the existing build configuration.
 type BuildRunPolicy string
@@ -120,6 +120,10 @@ func ValidateBuildConfig(config *buildapi.BuildConfig) field.ErrorList {
 			"run policy must Parallel, Serial, or SerialLatestOnly"))
 	}
 
+	if config.Spec.RetryPolicy != nil {
+		allErrs = append(allErrs, validateRetryPolicy(config.Spec.RetryPolicy, specPath.Child("retryPolicy"))...)
+	}
+
 	allErrs = append(allErrs, validateCommonSpec(&config.Spec.CommonSpec, specPath)...)
 
 	return allErrs
@@ -173,6 +177,22 @@ const (
 	maxJenkinsfileLengthBytes = 100 * 1000
 )
 
+func validateRetryPolicy(policy *buildapi.BuildRetryPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy.Limit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("limit"), policy.Limit, "limit must be greater than or equal to 0"))
+	}
+	if policy.InitialDelaySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialDelaySeconds"), policy.InitialDelaySeconds, "initialDelaySeconds must be greater than or equal to 0"))
+	}
+	if policy.MaxDelaySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxDelaySeconds"), policy.MaxDelaySeconds, "maxDelaySeconds must be greater than or equal to 0"))
+	} else if policy.MaxDelaySeconds > 0 && policy.MaxDelaySeconds < policy.InitialDelaySeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxDelaySeconds"), policy.MaxDelaySeconds, "maxDelaySeconds must not be less than initialDelaySeconds"))
+	}
+	return allErrs
+}
+
 func hasProxy(source *buildapi.GitBuildSource) bool {
 	return (source.HTTPProxy != nil && len(*source.HTTPProxy) > 0) || (source.HTTPSProxy != nil && len(*source.HTTPSProxy) > 0)
 }
@@ -519,6 +519,81 @@ func TestBuildConfigValidationFailureRequiredName(t *testing.T) {
 	}
 }
 
+func TestBuildConfigRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *buildapi.BuildRetryPolicy
+		expectField string
+	}{
+		{
+			name:   "no retry policy",
+			policy: nil,
+		},
+		{
+			name:   "valid retry policy",
+			policy: &buildapi.BuildRetryPolicy{Limit: 3, InitialDelaySeconds: 30, MaxDelaySeconds: 300},
+		},
+		{
+			name:   "unbounded delay",
+			policy: &buildapi.BuildRetryPolicy{Limit: 3, InitialDelaySeconds: 30},
+		},
+		{
+			name:        "negative limit",
+			policy:      &buildapi.BuildRetryPolicy{Limit: -1},
+			expectField: "spec.retryPolicy.limit",
+		},
+		{
+			name:        "negative initial delay",
+			policy:      &buildapi.BuildRetryPolicy{Limit: 1, InitialDelaySeconds: -1},
+			expectField: "spec.retryPolicy.initialDelaySeconds",
+		},
+		{
+			name:        "max delay below initial delay",
+			policy:      &buildapi.BuildRetryPolicy{Limit: 1, InitialDelaySeconds: 60, MaxDelaySeconds: 30},
+			expectField: "spec.retryPolicy.maxDelaySeconds",
+		},
+	}
+	for _, tc := range tests {
+		buildConfig := &buildapi.BuildConfig{
+			ObjectMeta: kapi.ObjectMeta{Name: "bar", Namespace: "foo"},
+			Spec: buildapi.BuildConfigSpec{
+				RunPolicy:   buildapi.BuildRunPolicySerial,
+				RetryPolicy: tc.policy,
+				CommonSpec: buildapi.CommonSpec{
+					Source: buildapi.BuildSource{
+						Git: &buildapi.GitBuildSource{
+							URI: "http://github.com/my/repository",
+						},
+					},
+					Strategy: buildapi.BuildStrategy{
+						DockerStrategy: &buildapi.DockerBuildStrategy{},
+					},
+					Output: buildapi.BuildOutput{
+						To: &kapi.ObjectReference{
+							Kind: "DockerImage",
+							Name: "repository/data",
+						},
+					},
+				},
+			},
+		}
+		errors := ValidateBuildConfig(buildConfig)
+		if len(tc.expectField) == 0 {
+			if len(errors) != 0 {
+				t.Errorf("%s: unexpected validation errors %v", tc.name, errors)
+			}
+			continue
+		}
+		if len(errors) != 1 {
+			t.Errorf("%s: expected one validation error, got %v", tc.name, errors)
+			continue
+		}
+		if errors[0].Field != tc.expectField {
+			t.Errorf("%s: expected error for field %s, got %s", tc.name, tc.expectField, errors[0].Field)
+		}
+	}
+}
+
 func TestBuildConfigImageChangeTriggers(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -95,6 +95,22 @@ func updateBuildRevision(c client.BuildInterface, build *api.Build, sourceInfo *
 	}
 }
 
+// updateBuildStatusReason records why the build is about to fail, so the
+// build controller can tell transient failures apart from permanent ones.
+func updateBuildStatusReason(c client.BuildInterface, build *api.Build, reason api.StatusReason, message string) {
+	build.Status.Reason = reason
+	build.Status.Message = message
+
+	// Reset ResourceVersion to avoid a conflict with other updates to the build
+	build.ResourceVersion = ""
+
+	glog.V(4).Infof("Setting build status reason to %s", reason)
+	_, err := c.UpdateDetails(build)
+	if err != nil {
+		glog.V(0).Infof("error: An error occurred saving build status reason: %v", err)
+	}
+}
+
 // randomBuildTag generates a random tag used for building images in such a way
 // that the built image can be referred to unambiguously even in the face of
 // concurrent builds with the same name in the same namespace.
@@ -68,6 +68,7 @@ func (d *DockerBuilder) Build() error {
 	}
 	sourceInfo, err := fetchSource(d.dockerClient, buildDir, d.build, d.urlTimeout, os.Stdin, d.gitClient)
 	if err != nil {
+		updateBuildStatusReason(d.client, d.build, api.StatusReasonFetchSourceFailed, "Failed to fetch the input source.")
 		return err
 	}
 	if sourceInfo != nil {
@@ -118,6 +119,7 @@ func (d *DockerBuilder) Build() error {
 		}
 		glog.V(0).Infof("\nPushing image %s ...", pushTag)
 		if err := pushImage(d.dockerClient, pushTag, pushAuthConfig); err != nil {
+			updateBuildStatusReason(d.client, d.build, api.StatusReasonPushImageToRegistryFailed, "Failed to push the image to the registry.")
 			return fmt.Errorf("Failed to push image: %v", err)
 		}
 		glog.V(0).Infof("Push successful")
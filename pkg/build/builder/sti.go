@@ -279,6 +279,7 @@ func (s *S2IBuilder) Build() error {
 				}
 				glog.V(0).Infof("Registry server Password: %s", passwordPresent)
 			}
+			updateBuildStatusReason(s.client, s.build, api.StatusReasonPushImageToRegistryFailed, "Failed to push the image to the registry.")
 			return errors.New(msg)
 		}
 		glog.V(0).Infof("Push successful")
@@ -307,6 +308,7 @@ func (d *downloader) Download(config *s2iapi.Config) (*s2iapi.SourceInfo, error)
 	// fetch source
 	sourceInfo, err := fetchSource(d.s.dockerClient, targetDir, d.s.build, d.timeout, d.in, d.s.gitClient)
 	if err != nil {
+		updateBuildStatusReason(d.s.client, d.s.build, api.StatusReasonFetchSourceFailed, "Failed to fetch the input source.")
 		return nil, err
 	}
 	if sourceInfo != nil {
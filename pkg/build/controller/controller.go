@@ -2,6 +2,8 @@ package controller
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -23,6 +25,8 @@ import (
 type BuildController struct {
 	BuildUpdater      buildclient.BuildUpdater
 	BuildLister       buildclient.BuildLister
+	BuildConfigGetter buildclient.BuildConfigGetter
+	BuildCloner       buildclient.BuildCloner
 	PodManager        podManager
 	BuildStrategy     BuildStrategy
 	ImageStreamClient imageStreamClient
@@ -98,7 +102,7 @@ func (bc *BuildController) HandleBuild(build *buildapi.Build) error {
 		if err := runPolicy.OnComplete(build); err != nil {
 			return err
 		}
-		return nil
+		return bc.retryFailedBuild(build)
 	}
 
 	// A cancelling event was triggered for the build, delete its pod and update build status.
@@ -217,6 +221,111 @@ func (bc *BuildController) nextBuildPhase(build *buildapi.Build) error {
 	return nil
 }
 
+// retryFailedBuild clones a build that failed for a transient reason when the
+// retry policy of its BuildConfig allows another attempt and the backoff delay
+// since the build completed has passed. Builds that are still waiting for
+// their delay are handled again when the build queue is resynced. The retry
+// limit recorded on the build is checked first, so the BuildConfig is only
+// fetched for builds that may be retried.
+func (bc *BuildController) retryFailedBuild(build *buildapi.Build) error {
+	if !buildutil.IsTransientFailure(build) || build.Status.Config == nil {
+		return nil
+	}
+	if _, retried := build.Annotations[buildapi.BuildRetriedByAnnotation]; retried {
+		return nil
+	}
+	attempt := buildutil.RetryAttempt(build)
+	if limit := buildutil.RetryLimit(build); attempt >= limit {
+		if limit > 0 {
+			glog.V(4).Infof("Build %s/%s will not be retried, it reached the retry limit of %d", build.Namespace, build.Name, limit)
+		}
+		return nil
+	}
+
+	config, err := bc.BuildConfigGetter.Get(build.Namespace, build.Status.Config.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return bc.stopRetrying(build)
+		}
+		return fmt.Errorf("unable to get build config for build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	retryPolicy := config.Spec.RetryPolicy
+	if retryPolicy == nil || attempt >= retryPolicy.Limit {
+		// the retry policy changed since the build was created
+		glog.V(4).Infof("Build %s/%s will not be retried, the retry policy of its build config no longer allows it", build.Namespace, build.Name)
+		return bc.stopRetrying(build)
+	}
+	if build.Status.CompletionTimestamp != nil {
+		retryAt := build.Status.CompletionTimestamp.Add(retryDelay(retryPolicy, attempt))
+		if now := time.Now(); now.Before(retryAt) {
+			glog.V(5).Infof("Build %s/%s will be retried in %v", build.Namespace, build.Name, retryAt.Sub(now))
+			return nil
+		}
+	}
+
+	request := &buildapi.BuildRequest{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: build.Name,
+			Annotations: map[string]string{
+				buildapi.BuildRetryAttemptAnnotation: strconv.Itoa(int(attempt + 1)),
+			},
+		},
+		TriggeredBy: []buildapi.BuildTriggerCause{
+			{
+				Message: fmt.Sprintf("Retry %d of %d after build %s failed: %s", attempt+1, retryPolicy.Limit, build.Name, build.Status.Reason),
+			},
+		},
+	}
+	newBuild, err := bc.BuildCloner.Clone(build.Namespace, request)
+	if err != nil {
+		return fmt.Errorf("unable to retry build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	glog.V(4).Infof("Build %s/%s failed with reason %s and was retried as %s", build.Namespace, build.Name, build.Status.Reason, newBuild.Name)
+	bc.Recorder.Eventf(build, kapi.EventTypeNormal, "BuildRetried", "Build failed with reason %s and was retried as %s", build.Status.Reason, newBuild.Name)
+
+	if build.Annotations == nil {
+		build.Annotations = make(map[string]string)
+	}
+	build.Annotations[buildapi.BuildRetriedByAnnotation] = newBuild.Name
+	if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+		return fmt.Errorf("failed to record retry of build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	return nil
+}
+
+// stopRetrying removes the retry limit from a failed build that can no longer be
+// retried, so it is not considered again when the build queue is resynced.
+func (bc *BuildController) stopRetrying(build *buildapi.Build) error {
+	delete(build.Annotations, buildapi.BuildRetryLimitAnnotation)
+	if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+		return fmt.Errorf("failed to record that build %s/%s will not be retried: %v", build.Namespace, build.Name, err)
+	}
+	return nil
+}
+
+// retryDelay returns how long to wait after a build completed before it is
+// retried, given the number of times the original build was already retried.
+// The initial delay doubles with every attempt, up to the maximum delay.
+func retryDelay(policy *buildapi.BuildRetryPolicy, attempt int32) time.Duration {
+	delay := time.Duration(policy.InitialDelaySeconds) * time.Second
+	maxDelay := time.Duration(policy.MaxDelaySeconds) * time.Second
+	for i := int32(0); i < attempt && delay > 0; i++ {
+		if delay > maxRetryDelay/2 {
+			delay = maxRetryDelay
+			break
+		}
+		delay *= 2
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// maxRetryDelay bounds the delay between retries of a build when the retry
+// policy does not set a maximum delay.
+const maxRetryDelay = 24 * time.Hour
+
 // resolveOutputDockerImageReference returns a reference to a Docker image
 // computed from the buid.Spec.Output.To reference.
 func (bc *BuildController) resolveOutputDockerImageReference(build *buildapi.Build) (string, error) {
@@ -311,8 +420,12 @@ func (bc *BuildPodController) HandlePod(pod *kapi.Pod) error {
 	if build.Status.Phase != nextStatus && !buildutil.IsBuildComplete(build) {
 		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
 		build.Status.Phase = nextStatus
-		build.Status.Reason = ""
-		build.Status.Message = ""
+		// Keep the reason the builder reported for a transient failure, it
+		// decides whether the build will be retried.
+		if !buildutil.IsTransientFailure(build) {
+			build.Status.Reason = ""
+			build.Status.Message = ""
+		}
 		if buildutil.IsBuildComplete(build) {
 			now := unversioned.Now()
 			build.Status.CompletionTimestamp = &now
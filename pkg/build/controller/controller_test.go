@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
@@ -965,3 +966,217 @@ func TestHandleBuildPodDeletionBuildUpdateError(t *testing.T) {
 		t.Error("Expected random error, but got none!")
 	}
 }
+
+type fakeBuildConfigGetter struct {
+	config *buildapi.BuildConfig
+	err    error
+	calls  int
+}
+
+func (f *fakeBuildConfigGetter) Get(namespace, name string) (*buildapi.BuildConfig, error) {
+	f.calls++
+	return f.config, f.err
+}
+
+type fakeBuildCloner struct {
+	request *buildapi.BuildRequest
+	err     error
+}
+
+func (f *fakeBuildCloner) Clone(namespace string, request *buildapi.BuildRequest) (*buildapi.Build, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.request = request
+	return &buildapi.Build{ObjectMeta: kapi.ObjectMeta{Name: "data-build-retry", Namespace: namespace}}, nil
+}
+
+func TestRetryFailedBuild(t *testing.T) {
+	longAgo := unversioned.NewTime(time.Now().Add(-time.Hour))
+	justNow := unversioned.Now()
+	retryPolicy := &buildapi.BuildRetryPolicy{Limit: 2, InitialDelaySeconds: 60}
+	tests := []struct {
+		name          string
+		phase         buildapi.BuildPhase
+		reason        buildapi.StatusReason
+		completed     unversioned.Time
+		annotations   map[string]string
+		retryPolicy   *buildapi.BuildRetryPolicy
+		configErr     error
+		expectAttempt string
+		expectGet     bool
+		expectStopped bool
+	}{
+		{
+			name:          "push failure is retried",
+			phase:         buildapi.BuildPhaseFailed,
+			reason:        buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:     longAgo,
+			annotations:   map[string]string{buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy:   retryPolicy,
+			expectAttempt: "1",
+			expectGet:     true,
+		},
+		{
+			name:          "fetch source failure is retried again",
+			phase:         buildapi.BuildPhaseError,
+			reason:        buildapi.StatusReasonFetchSourceFailed,
+			completed:     longAgo,
+			annotations:   map[string]string{buildapi.BuildRetryAttemptAnnotation: "1", buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy:   retryPolicy,
+			expectAttempt: "2",
+			expectGet:     true,
+		},
+		{
+			name:        "retry limit reached",
+			phase:       buildapi.BuildPhaseFailed,
+			reason:      buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:   longAgo,
+			annotations: map[string]string{buildapi.BuildRetryAttemptAnnotation: "2", buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy: retryPolicy,
+		},
+		{
+			name:        "waiting for backoff delay",
+			phase:       buildapi.BuildPhaseFailed,
+			reason:      buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:   justNow,
+			annotations: map[string]string{buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy: retryPolicy,
+			expectGet:   true,
+		},
+		{
+			name:        "already retried",
+			phase:       buildapi.BuildPhaseFailed,
+			reason:      buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:   longAgo,
+			annotations: map[string]string{buildapi.BuildRetriedByAnnotation: "data-build-retry", buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy: retryPolicy,
+		},
+		{
+			name:        "permanent failure",
+			phase:       buildapi.BuildPhaseFailed,
+			completed:   longAgo,
+			annotations: map[string]string{buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy: retryPolicy,
+		},
+		{
+			name:        "no retry limit on the build",
+			phase:       buildapi.BuildPhaseFailed,
+			reason:      buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:   longAgo,
+			retryPolicy: retryPolicy,
+		},
+		{
+			name:          "retry policy removed from the build config",
+			phase:         buildapi.BuildPhaseFailed,
+			reason:        buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:     longAgo,
+			annotations:   map[string]string{buildapi.BuildRetryLimitAnnotation: "2"},
+			expectGet:     true,
+			expectStopped: true,
+		},
+		{
+			name:          "build config deleted",
+			phase:         buildapi.BuildPhaseFailed,
+			reason:        buildapi.StatusReasonPushImageToRegistryFailed,
+			completed:     longAgo,
+			annotations:   map[string]string{buildapi.BuildRetryLimitAnnotation: "2"},
+			retryPolicy:   retryPolicy,
+			configErr:     kerrors.NewNotFound(buildapi.Resource("buildconfigs"), "test-bc"),
+			expectGet:     true,
+			expectStopped: true,
+		},
+	}
+
+	for _, tc := range tests {
+		build := mockBuild(tc.phase, buildapi.BuildOutput{})
+		build.Status.Reason = tc.reason
+		build.Status.CompletionTimestamp = &tc.completed
+		build.Status.Config = &kapi.ObjectReference{Name: "test-bc"}
+		for k, v := range tc.annotations {
+			build.Annotations[k] = v
+		}
+		cloner := &fakeBuildCloner{}
+		var updated *buildapi.Build
+		ctrl := mockBuildController()
+		ctrl.BuildCloner = cloner
+		configGetter := &fakeBuildConfigGetter{
+			config: &buildapi.BuildConfig{Spec: buildapi.BuildConfigSpec{RetryPolicy: tc.retryPolicy}},
+			err:    tc.configErr,
+		}
+		ctrl.BuildConfigGetter = configGetter
+		ctrl.BuildUpdater = &customBuildUpdater{
+			UpdateFunc: func(namespace string, build *buildapi.Build) error {
+				updated = build
+				return nil
+			},
+		}
+
+		if err := ctrl.HandleBuild(build); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got := configGetter.calls > 0; got != tc.expectGet {
+			t.Errorf("%s: expected build config fetched %v, got %v", tc.name, tc.expectGet, got)
+		}
+		if tc.expectStopped {
+			if updated == nil || len(updated.Annotations[buildapi.BuildRetryLimitAnnotation]) > 0 {
+				t.Errorf("%s: expected the retry limit to be removed from the build", tc.name)
+			}
+		}
+		if len(tc.expectAttempt) == 0 {
+			if cloner.request != nil {
+				t.Errorf("%s: build should not have been retried", tc.name)
+			}
+			continue
+		}
+		if cloner.request == nil {
+			t.Errorf("%s: build should have been retried", tc.name)
+			continue
+		}
+		if cloner.request.Name != build.Name {
+			t.Errorf("%s: expected clone of %s, got %s", tc.name, build.Name, cloner.request.Name)
+		}
+		if attempt := cloner.request.Annotations[buildapi.BuildRetryAttemptAnnotation]; attempt != tc.expectAttempt {
+			t.Errorf("%s: expected retry attempt %s, got %s", tc.name, tc.expectAttempt, attempt)
+		}
+		if updated == nil || updated.Annotations[buildapi.BuildRetriedByAnnotation] != "data-build-retry" {
+			t.Errorf("%s: expected failed build to be marked as retried", tc.name)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		policy   buildapi.BuildRetryPolicy
+		attempt  int32
+		expected time.Duration
+	}{
+		{policy: buildapi.BuildRetryPolicy{InitialDelaySeconds: 10}, attempt: 0, expected: 10 * time.Second},
+		{policy: buildapi.BuildRetryPolicy{InitialDelaySeconds: 10}, attempt: 3, expected: 80 * time.Second},
+		{policy: buildapi.BuildRetryPolicy{InitialDelaySeconds: 10, MaxDelaySeconds: 30}, attempt: 3, expected: 30 * time.Second},
+		{policy: buildapi.BuildRetryPolicy{InitialDelaySeconds: 10}, attempt: 100, expected: maxRetryDelay},
+		{policy: buildapi.BuildRetryPolicy{}, attempt: 5, expected: 0},
+	}
+	for i, tc := range tests {
+		if delay := retryDelay(&tc.policy, tc.attempt); delay != tc.expected {
+			t.Errorf("(%d) expected delay %v, got %v", i, tc.expected, delay)
+		}
+	}
+}
+
+func TestHandlePodKeepsTransientFailureReason(t *testing.T) {
+	build := mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{})
+	build.Name = "name"
+	build.Status.Reason = buildapi.StatusReasonPushImageToRegistryFailed
+	ctrl := mockBuildPodController(build)
+	if err := ctrl.HandlePod(mockPod(kapi.PodFailed, 1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if build.Status.Phase != buildapi.BuildPhaseFailed {
+		t.Errorf("expected phase %s, got %s", buildapi.BuildPhaseFailed, build.Status.Phase)
+	}
+	if build.Status.Reason != buildapi.StatusReasonPushImageToRegistryFailed {
+		t.Errorf("expected reason %s, got %s", buildapi.StatusReasonPushImageToRegistryFailed, build.Status.Reason)
+	}
+}
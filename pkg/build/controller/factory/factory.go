@@ -83,6 +83,8 @@ func (factory *BuildControllerFactory) Create() controller.RunnableController {
 	buildController := &buildcontroller.BuildController{
 		BuildUpdater:      factory.BuildUpdater,
 		BuildLister:       factory.BuildLister,
+		BuildConfigGetter: buildclient.NewOSClientBuildConfigClient(factory.OSClient),
+		BuildCloner:       buildclient.NewOSClientBuildClonerClient(factory.OSClient),
 		ImageStreamClient: client,
 		PodManager:        client,
 		RunPolicies:       policy.GetAllRunPolicies(factory.BuildLister, factory.BuildUpdater),
@@ -342,12 +342,20 @@ func (g *BuildGenerator) Clone(ctx kapi.Context, request *buildapi.BuildRequest)
 	newBuild := generateBuildFromBuild(build, buildConfig)
 	glog.V(4).Infof("Build %s/%s has been generated from Build %s/%s", newBuild.Namespace, newBuild.ObjectMeta.Name, build.Namespace, build.ObjectMeta.Name)
 
-	buildTriggerCauses := []buildapi.BuildTriggerCause{}
-	newBuild.Spec.TriggeredBy = append(buildTriggerCauses,
-		buildapi.BuildTriggerCause{
-			Message: "Manually triggered",
-		},
-	)
+	// Add labels and annotations from the buildrequest, without replacing
+	// the ones set by the generator.
+	newBuild.Annotations = policy.MergeMaps(request.Annotations, newBuild.Annotations)
+	newBuild.Labels = policy.MergeMaps(request.Labels, newBuild.Labels)
+
+	if len(request.TriggeredBy) > 0 {
+		newBuild.Spec.TriggeredBy = request.TriggeredBy
+	} else {
+		newBuild.Spec.TriggeredBy = []buildapi.BuildTriggerCause{
+			{
+				Message: "Manually triggered",
+			},
+		}
+	}
 
 	// need to update the BuildConfig because LastVersion changed
 	if buildConfig != nil {
@@ -431,6 +439,7 @@ func (g *BuildGenerator) generateBuildFromConfig(ctx kapi.Context, bc *buildapi.
 	}
 	build.Annotations[buildapi.BuildNumberAnnotation] = strconv.FormatInt(bc.Status.LastVersion, 10)
 	build.Annotations[buildapi.BuildConfigAnnotation] = bcCopy.Name
+	setRetryLimit(build, bc)
 	if build.Labels == nil {
 		build.Labels = make(map[string]string)
 	}
@@ -702,8 +711,12 @@ func generateBuildFromBuild(build *buildapi.Build, buildConfig *buildapi.BuildCo
 		newBuild.Annotations = make(map[string]string)
 	}
 	newBuild.Annotations[buildapi.BuildCloneAnnotation] = build.Name
+	// a clone starts a fresh series of automatic retries.
+	delete(newBuild.Annotations, buildapi.BuildRetryAttemptAnnotation)
+	delete(newBuild.Annotations, buildapi.BuildRetriedByAnnotation)
 	if buildConfig != nil {
 		newBuild.Annotations[buildapi.BuildNumberAnnotation] = strconv.FormatInt(buildConfig.Status.LastVersion, 10)
+		setRetryLimit(newBuild, buildConfig)
 	} else {
 		// builds without a buildconfig don't have build numbers.
 		delete(newBuild.Annotations, buildapi.BuildNumberAnnotation)
@@ -711,6 +724,17 @@ func generateBuildFromBuild(build *buildapi.Build, buildConfig *buildapi.BuildCo
 	return newBuild
 }
 
+// setRetryLimit records the retry limit of the BuildConfig on the build, so the build
+// controller can tell whether a failed build may be retried without fetching the
+// BuildConfig.
+func setRetryLimit(build *buildapi.Build, bc *buildapi.BuildConfig) {
+	if bc.Spec.RetryPolicy == nil || bc.Spec.RetryPolicy.Limit <= 0 {
+		delete(build.Annotations, buildapi.BuildRetryLimitAnnotation)
+		return
+	}
+	build.Annotations[buildapi.BuildRetryLimitAnnotation] = strconv.Itoa(int(bc.Spec.RetryPolicy.Limit))
+}
+
 // getNextBuildNameFromBuild returns name of the next build with random uuid added at the end
 func getNextBuildNameFromBuild(build *buildapi.Build, buildConfig *buildapi.BuildConfig) string {
 	var buildName string
@@ -1680,3 +1680,27 @@ func TestInstantiateBuildTriggerCauseGitHubWebHook(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateBuildFromBuildRetryLimit(t *testing.T) {
+	build := &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: "test-build",
+			Annotations: map[string]string{
+				buildapi.BuildRetryLimitAnnotation:   "3",
+				buildapi.BuildRetryAttemptAnnotation: "1",
+			},
+		},
+	}
+	bc := &buildapi.BuildConfig{Spec: buildapi.BuildConfigSpec{RetryPolicy: &buildapi.BuildRetryPolicy{Limit: 2}}}
+
+	newBuild := generateBuildFromBuild(build, bc)
+	if limit := newBuild.Annotations[buildapi.BuildRetryLimitAnnotation]; limit != "2" {
+		t.Errorf("expected the retry limit of the build config, got %q", limit)
+	}
+
+	bc.Spec.RetryPolicy = nil
+	newBuild = generateBuildFromBuild(build, bc)
+	if limit, ok := newBuild.Annotations[buildapi.BuildRetryLimitAnnotation]; ok {
+		t.Errorf("expected no retry limit, got %q", limit)
+	}
+}
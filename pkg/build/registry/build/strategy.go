@@ -109,25 +109,35 @@ type detailsStrategy struct {
 }
 
 // Prepares a build for update by only allowing an update to build details.
-// For now, this is the Spec.Revision field
+// For now, this is the Spec.Revision field and the Status.Reason and
+// Status.Message fields the builder uses to report why a build failed.
 func (detailsStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newBuild := obj.(*api.Build)
 	oldBuild := old.(*api.Build)
 	revision := newBuild.Spec.Revision
+	reason := newBuild.Status.Reason
+	message := newBuild.Status.Message
 	*newBuild = *oldBuild
-	newBuild.Spec.Revision = revision
+	if revision != nil {
+		newBuild.Spec.Revision = revision
+	}
+	if len(reason) > 0 {
+		newBuild.Status.Reason = reason
+		newBuild.Status.Message = message
+	}
 }
 
-// Validates that an update is valid by ensuring that no Revision exists and that it's not getting updated to blank
+// Validates that an update is valid by ensuring that an existing Revision is not getting
+// replaced and that the update sets either a Revision or a failure Reason
 func (detailsStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) field.ErrorList {
 	newBuild := obj.(*api.Build)
 	oldBuild := old.(*api.Build)
 	errors := field.ErrorList{}
-	if oldBuild.Spec.Revision != nil {
+	if oldBuild.Spec.Revision != nil && !kapi.Semantic.DeepEqual(oldBuild.Spec.Revision, newBuild.Spec.Revision) {
 		// If there was already a revision, then return an error
 		errors = append(errors, field.Duplicate(field.NewPath("status", "revision"), oldBuild.Spec.Revision))
 	}
-	if newBuild.Spec.Revision == nil {
+	if newBuild.Spec.Revision == nil && len(newBuild.Status.Reason) == 0 {
 		errors = append(errors, field.Invalid(field.NewPath("status", "revision"), nil, "cannot set an empty revision in build status"))
 	}
 	return errors
@@ -98,3 +98,74 @@ func TestBuildDecorator(t *testing.T) {
 		t.Errorf("Build duration should be greater than zero")
 	}
 }
+
+func TestDetailsStrategy(t *testing.T) {
+	ctx := kapi.NewDefaultContext()
+	revision := &buildapi.SourceRevision{
+		Git: &buildapi.GitSourceRevision{Commit: "abcdef"},
+	}
+	tests := []struct {
+		name          string
+		old           *buildapi.Build
+		update        *buildapi.Build
+		expectErrors  int
+		expectReason  buildapi.StatusReason
+		expectPhase   buildapi.BuildPhase
+		expectRevised bool
+	}{
+		{
+			name:          "set revision",
+			old:           &buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseRunning}},
+			update:        &buildapi.Build{Spec: buildapi.BuildSpec{CommonSpec: buildapi.CommonSpec{Revision: revision}}},
+			expectPhase:   buildapi.BuildPhaseRunning,
+			expectRevised: true,
+		},
+		{
+			name: "replace revision",
+			old: &buildapi.Build{
+				Spec: buildapi.BuildSpec{CommonSpec: buildapi.CommonSpec{Revision: &buildapi.SourceRevision{Git: &buildapi.GitSourceRevision{Commit: "123456"}}}},
+			},
+			update:       &buildapi.Build{Spec: buildapi.BuildSpec{CommonSpec: buildapi.CommonSpec{Revision: revision}}},
+			expectErrors: 1,
+		},
+		{
+			name: "set reason with existing revision",
+			old: &buildapi.Build{
+				Spec:   buildapi.BuildSpec{CommonSpec: buildapi.CommonSpec{Revision: revision}},
+				Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseRunning},
+			},
+			update: &buildapi.Build{
+				Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseFailed, Reason: buildapi.StatusReasonPushImageToRegistryFailed},
+			},
+			expectReason:  buildapi.StatusReasonPushImageToRegistryFailed,
+			expectPhase:   buildapi.BuildPhaseRunning,
+			expectRevised: true,
+		},
+		{
+			name:         "empty update",
+			old:          &buildapi.Build{},
+			update:       &buildapi.Build{},
+			expectErrors: 1,
+		},
+	}
+	for _, tc := range tests {
+		DetailsStrategy.PrepareForUpdate(tc.update, tc.old)
+		errs := DetailsStrategy.ValidateUpdate(ctx, tc.update, tc.old)
+		if len(errs) != tc.expectErrors {
+			t.Errorf("%s: expected %d errors, got %v", tc.name, tc.expectErrors, errs)
+			continue
+		}
+		if tc.expectErrors > 0 {
+			continue
+		}
+		if tc.update.Status.Reason != tc.expectReason {
+			t.Errorf("%s: expected reason %q, got %q", tc.name, tc.expectReason, tc.update.Status.Reason)
+		}
+		if tc.update.Status.Phase != tc.expectPhase {
+			t.Errorf("%s: expected phase %q, got %q", tc.name, tc.expectPhase, tc.update.Status.Phase)
+		}
+		if revised := tc.update.Spec.Revision == revision; revised != tc.expectRevised {
+			t.Errorf("%s: expected revision to be set: %t", tc.name, tc.expectRevised)
+		}
+	}
+}
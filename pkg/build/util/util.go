@@ -63,6 +63,41 @@ func IsBuildComplete(build *buildapi.Build) bool {
 	return build.Status.Phase != buildapi.BuildPhaseRunning && build.Status.Phase != buildapi.BuildPhasePending && build.Status.Phase != buildapi.BuildPhaseNew
 }
 
+// IsTransientFailure returns true if the provided build ended in the Failed
+// or Error phase for a reason that may go away when the build is run again,
+// such as a failed push to the registry or a timeout fetching the source.
+func IsTransientFailure(build *buildapi.Build) bool {
+	if build.Status.Phase != buildapi.BuildPhaseFailed && build.Status.Phase != buildapi.BuildPhaseError {
+		return false
+	}
+	switch build.Status.Reason {
+	case buildapi.StatusReasonFetchSourceFailed, buildapi.StatusReasonPushImageToRegistryFailed:
+		return true
+	}
+	return false
+}
+
+// RetryAttempt returns the number of times the original build was
+// automatically retried to produce the provided build. Builds that are not
+// retries of a failed build return 0.
+func RetryAttempt(build *buildapi.Build) int32 {
+	attempt, err := strconv.ParseInt(build.Annotations[buildapi.BuildRetryAttemptAnnotation], 10, 32)
+	if err != nil || attempt < 0 {
+		return 0
+	}
+	return int32(attempt)
+}
+
+// RetryLimit returns the retry limit recorded on the provided build when it was
+// created from a BuildConfig with a retry policy, or 0 if the build is not retried.
+func RetryLimit(build *buildapi.Build) int32 {
+	limit, err := strconv.ParseInt(build.Annotations[buildapi.BuildRetryLimitAnnotation], 10, 32)
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return int32(limit)
+}
+
 // IsPaused returns true if the provided BuildConfig is paused and cannot be used to create a new Build
 func IsPaused(bc *buildapi.BuildConfig) bool {
 	return strings.ToLower(bc.Annotations[buildapi.BuildConfigPausedAnnotation]) == "true"
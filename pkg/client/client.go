@@ -22,6 +22,7 @@ type Interface interface {
 	BuildConfigsNamespacer
 	BuildLogsNamespacer
 	ImagesInterfacer
+	ImageSignaturesInterfacer
 	ImageStreamsNamespacer
 	ImageStreamMappingsNamespacer
 	ImageStreamTagsNamespacer
@@ -48,10 +49,13 @@ type Interface interface {
 	SelfSubjectRulesReviewsNamespacer
 	TemplatesNamespacer
 	TemplateConfigsNamespacer
+	TemplateInstancesNamespacer
 	OAuthClientsInterface
 	OAuthClientAuthorizationsInterface
 	OAuthAccessTokensInterface
 	OAuthAuthorizeTokensInterface
+	PersonalAccessTokensInterface
+	UserOAuthAccessTokensInterface
 	PoliciesNamespacer
 	PolicyBindingsNamespacer
 	RolesNamespacer
@@ -83,6 +87,11 @@ func (c *Client) Images() ImageInterface {
 	return newImages(c)
 }
 
+// ImageSignatures provides a REST client for ImageSignatures
+func (c *Client) ImageSignatures() ImageSignatureInterface {
+	return newImageSignatures(c)
+}
+
 // ImageStreamImages provides a REST client for retrieving image secrets in a namespace
 func (c *Client) ImageStreamSecrets(namespace string) ImageStreamSecretInterface {
 	return newImageStreamSecrets(c, namespace)
@@ -178,6 +187,11 @@ func (c *Client) Templates(namespace string) TemplateInterface {
 	return newTemplates(c, namespace)
 }
 
+// TemplateInstances provides a REST client for TemplateInstances
+func (c *Client) TemplateInstances(namespace string) TemplateInstanceInterface {
+	return newTemplateInstances(c, namespace)
+}
+
 // Policies provides a REST client for Policies
 func (c *Client) Policies(namespace string) PolicyInterface {
 	return newPolicies(c, namespace)
@@ -248,6 +262,16 @@ func (c *Client) OAuthAuthorizeTokens() OAuthAuthorizeTokenInterface {
 	return newOAuthAuthorizeTokens(c)
 }
 
+// PersonalAccessTokens provides a REST client for the PersonalAccessTokens of the current user
+func (c *Client) PersonalAccessTokens() PersonalAccessTokenInterface {
+	return newPersonalAccessTokens(c)
+}
+
+// UserOAuthAccessTokens provides a REST client for the OAuthAccessTokens of the current user
+func (c *Client) UserOAuthAccessTokens() UserOAuthAccessTokenInterface {
+	return newUserOAuthAccessTokens(c)
+}
+
 func (c *Client) ClusterPolicies() ClusterPolicyInterface {
 	return newClusterPolicies(c)
 }
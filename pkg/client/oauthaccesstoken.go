@@ -1,6 +1,8 @@
 package client
 
 import (
+	kapi "k8s.io/kubernetes/pkg/api"
+
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 )
 
@@ -13,6 +15,7 @@ type OAuthAccessTokensInterface interface {
 type OAuthAccessTokenInterface interface {
 	Create(token *oauthapi.OAuthAccessToken) (*oauthapi.OAuthAccessToken, error)
 	Get(name string) (*oauthapi.OAuthAccessToken, error)
+	List(opts kapi.ListOptions) (*oauthapi.OAuthAccessTokenList, error)
 	Delete(name string) error
 }
 
@@ -33,6 +36,13 @@ func (c *oauthAccessTokenInterface) Get(name string) (result *oauthapi.OAuthAcce
 	return
 }
 
+// List returns a list of OAuthAccessTokens that match the label and field selectors.
+func (c *oauthAccessTokenInterface) List(opts kapi.ListOptions) (result *oauthapi.OAuthAccessTokenList, err error) {
+	result = &oauthapi.OAuthAccessTokenList{}
+	err = c.r.Get().Resource("oAuthAccessTokens").VersionedParams(&opts, kapi.ParameterCodec).Do().Into(result)
+	return
+}
+
 // Delete removes the OAuthAccessToken on server
 func (c *oauthAccessTokenInterface) Delete(name string) (err error) {
 	err = c.r.Delete().Resource("oAuthAccessTokens").Name(name).Do().Error()
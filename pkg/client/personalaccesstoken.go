@@ -0,0 +1,57 @@
+package client
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// PersonalAccessTokensInterface has methods to work with the PersonalAccessTokens of the current user
+type PersonalAccessTokensInterface interface {
+	PersonalAccessTokens() PersonalAccessTokenInterface
+}
+
+// PersonalAccessTokenInterface exposes methods on PersonalAccessTokens resources.
+type PersonalAccessTokenInterface interface {
+	Create(token *oauthapi.PersonalAccessToken) (*oauthapi.PersonalAccessToken, error)
+	Get(name string) (*oauthapi.PersonalAccessToken, error)
+	List(opts kapi.ListOptions) (*oauthapi.PersonalAccessTokenList, error)
+	Delete(name string) error
+}
+
+type personalAccessTokens struct {
+	r *Client
+}
+
+func newPersonalAccessTokens(c *Client) *personalAccessTokens {
+	return &personalAccessTokens{
+		r: c,
+	}
+}
+
+// Create creates a new personal access token. The returned object holds the token.
+func (c *personalAccessTokens) Create(token *oauthapi.PersonalAccessToken) (result *oauthapi.PersonalAccessToken, err error) {
+	result = &oauthapi.PersonalAccessToken{}
+	err = c.r.Post().Resource("personalAccessTokens").Body(token).Do().Into(result)
+	return
+}
+
+// Get returns information about a particular personal access token and error if one occurs.
+func (c *personalAccessTokens) Get(name string) (result *oauthapi.PersonalAccessToken, err error) {
+	result = &oauthapi.PersonalAccessToken{}
+	err = c.r.Get().Resource("personalAccessTokens").Name(name).Do().Into(result)
+	return
+}
+
+// List returns the personal access tokens of the current user
+func (c *personalAccessTokens) List(opts kapi.ListOptions) (result *oauthapi.PersonalAccessTokenList, err error) {
+	result = &oauthapi.PersonalAccessTokenList{}
+	err = c.r.Get().Resource("personalAccessTokens").VersionedParams(&opts, kapi.ParameterCodec).Do().Into(result)
+	return
+}
+
+// Delete revokes the personal access token
+func (c *personalAccessTokens) Delete(name string) (err error) {
+	err = c.r.Delete().Resource("personalAccessTokens").Name(name).Do().Error()
+	return
+}
@@ -141,6 +141,11 @@ func (c *Fake) Images() client.ImageInterface {
 	return &FakeImages{Fake: c}
 }
 
+// ImageSignatures provides a fake REST client for ImageSignatures
+func (c *Fake) ImageSignatures() client.ImageSignatureInterface {
+	return &FakeImageSignatures{Fake: c}
+}
+
 // ImageStreams provides a fake REST client for ImageStreams
 func (c *Fake) ImageStreamSecrets(namespace string) client.ImageStreamSecretInterface {
 	return &FakeImageStreamSecrets{Fake: c, Namespace: namespace}
@@ -201,6 +206,11 @@ func (c *Fake) Templates(namespace string) client.TemplateInterface {
 	return &FakeTemplates{Fake: c, Namespace: namespace}
 }
 
+// TemplateInstances provides a fake REST client for TemplateInstances
+func (c *Fake) TemplateInstances(namespace string) client.TemplateInstanceInterface {
+	return &FakeTemplateInstances{Fake: c, Namespace: namespace}
+}
+
 // TemplateConfigs provides a fake REST client for TemplateConfigs
 func (c *Fake) TemplateConfigs(namespace string) client.TemplateConfigInterface {
 	return &FakeTemplateConfigs{Fake: c, Namespace: namespace}
@@ -296,6 +306,16 @@ func (c *Fake) OAuthAuthorizeTokens() client.OAuthAuthorizeTokenInterface {
 	return &FakeOAuthAuthorizeTokens{Fake: c}
 }
 
+// PersonalAccessTokens provides a fake REST client for PersonalAccessTokens
+func (c *Fake) PersonalAccessTokens() client.PersonalAccessTokenInterface {
+	return &FakePersonalAccessTokens{Fake: c}
+}
+
+// UserOAuthAccessTokens provides a fake REST client for UserOAuthAccessTokens
+func (c *Fake) UserOAuthAccessTokens() client.UserOAuthAccessTokenInterface {
+	return &FakeUserOAuthAccessTokens{Fake: c}
+}
+
 // LocalSubjectAccessReviews provides a fake REST client for SubjectAccessReviews
 func (c *Fake) LocalSubjectAccessReviews(namespace string) client.LocalSubjectAccessReviewInterface {
 	return &FakeLocalSubjectAccessReviews{Fake: c}
@@ -0,0 +1,46 @@
+package testclient
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// FakePersonalAccessTokens implements PersonalAccessTokenInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the methods you want to test easier.
+type FakePersonalAccessTokens struct {
+	Fake *Fake
+}
+
+func (c *FakePersonalAccessTokens) Create(inObj *oauthapi.PersonalAccessToken) (*oauthapi.PersonalAccessToken, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootCreateAction("personalaccesstokens", inObj), inObj)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.PersonalAccessToken), err
+}
+
+func (c *FakePersonalAccessTokens) Get(name string) (*oauthapi.PersonalAccessToken, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootGetAction("personalaccesstokens", name), &oauthapi.PersonalAccessToken{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.PersonalAccessToken), err
+}
+
+func (c *FakePersonalAccessTokens) List(opts kapi.ListOptions) (*oauthapi.PersonalAccessTokenList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("personalaccesstokens", opts), &oauthapi.PersonalAccessTokenList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.PersonalAccessTokenList), err
+}
+
+func (c *FakePersonalAccessTokens) Delete(name string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("personalaccesstokens", name), &oauthapi.PersonalAccessToken{})
+	return err
+}
@@ -0,0 +1,37 @@
+package testclient
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// FakeUserOAuthAccessTokens implements UserOAuthAccessTokenInterface. Meant to be embedded into a struct to get a
+// default implementation. This makes faking out just the methods you want to test easier.
+type FakeUserOAuthAccessTokens struct {
+	Fake *Fake
+}
+
+func (c *FakeUserOAuthAccessTokens) Get(name string) (*oauthapi.UserOAuthAccessToken, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootGetAction("useroauthaccesstokens", name), &oauthapi.UserOAuthAccessToken{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.UserOAuthAccessToken), err
+}
+
+func (c *FakeUserOAuthAccessTokens) List(opts kapi.ListOptions) (*oauthapi.UserOAuthAccessTokenList, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootListAction("useroauthaccesstokens", opts), &oauthapi.UserOAuthAccessTokenList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*oauthapi.UserOAuthAccessTokenList), err
+}
+
+func (c *FakeUserOAuthAccessTokens) Delete(name string) error {
+	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("useroauthaccesstokens", name), &oauthapi.UserOAuthAccessToken{})
+	return err
+}
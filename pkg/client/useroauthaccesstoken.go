@@ -0,0 +1,50 @@
+package client
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// UserOAuthAccessTokensInterface has methods to work with the OAuthAccessTokens of the current user
+type UserOAuthAccessTokensInterface interface {
+	UserOAuthAccessTokens() UserOAuthAccessTokenInterface
+}
+
+// UserOAuthAccessTokenInterface exposes methods on UserOAuthAccessTokens resources. The tokens are named by their ID,
+// the leading characters of the token.
+type UserOAuthAccessTokenInterface interface {
+	Get(name string) (*oauthapi.UserOAuthAccessToken, error)
+	List(opts kapi.ListOptions) (*oauthapi.UserOAuthAccessTokenList, error)
+	Delete(name string) error
+}
+
+type userOAuthAccessTokens struct {
+	r *Client
+}
+
+func newUserOAuthAccessTokens(c *Client) *userOAuthAccessTokens {
+	return &userOAuthAccessTokens{
+		r: c,
+	}
+}
+
+// Get returns information about a particular access token of the current user and error if one occurs.
+func (c *userOAuthAccessTokens) Get(name string) (result *oauthapi.UserOAuthAccessToken, err error) {
+	result = &oauthapi.UserOAuthAccessToken{}
+	err = c.r.Get().Resource("userOAuthAccessTokens").Name(name).Do().Into(result)
+	return
+}
+
+// List returns the access tokens of the current user
+func (c *userOAuthAccessTokens) List(opts kapi.ListOptions) (result *oauthapi.UserOAuthAccessTokenList, err error) {
+	result = &oauthapi.UserOAuthAccessTokenList{}
+	err = c.r.Get().Resource("userOAuthAccessTokens").VersionedParams(&opts, kapi.ParameterCodec).Do().Into(result)
+	return
+}
+
+// Delete revokes the access token of the current user
+func (c *userOAuthAccessTokens) Delete(name string) (err error) {
+	err = c.r.Delete().Resource("userOAuthAccessTokens").Name(name).Do().Error()
+	return
+}
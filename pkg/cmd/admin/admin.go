@@ -10,12 +10,16 @@ import (
 	"github.com/openshift/origin/pkg/cmd/admin/cert"
 	diagnostics "github.com/openshift/origin/pkg/cmd/admin/diagnostics"
 	"github.com/openshift/origin/pkg/cmd/admin/groups"
+	"github.com/openshift/origin/pkg/cmd/admin/imageusage"
+	"github.com/openshift/origin/pkg/cmd/admin/mirror"
 	"github.com/openshift/origin/pkg/cmd/admin/node"
 	"github.com/openshift/origin/pkg/cmd/admin/policy"
 	"github.com/openshift/origin/pkg/cmd/admin/project"
 	"github.com/openshift/origin/pkg/cmd/admin/prune"
 	"github.com/openshift/origin/pkg/cmd/admin/registry"
 	"github.com/openshift/origin/pkg/cmd/admin/router"
+	"github.com/openshift/origin/pkg/cmd/admin/tokens"
+	"github.com/openshift/origin/pkg/cmd/admin/totp"
 	"github.com/openshift/origin/pkg/cmd/cli/cmd"
 	"github.com/openshift/origin/pkg/cmd/experimental/buildchain"
 	exipfailover "github.com/openshift/origin/pkg/cmd/experimental/ipfailover"
@@ -50,6 +54,8 @@ func NewCommandAdmin(name, fullName string, out io.Writer, errout io.Writer) *co
 				project.NewCmdNewProject(project.NewProjectRecommendedName, fullName+" "+project.NewProjectRecommendedName, f, out),
 				policy.NewCmdPolicy(policy.PolicyRecommendedName, fullName+" "+policy.PolicyRecommendedName, f, out, errout),
 				groups.NewCmdGroups(groups.GroupsRecommendedName, fullName+" "+groups.GroupsRecommendedName, f, out),
+				totp.NewCmdTOTP(totp.TOTPRecommendedName, fullName+" "+totp.TOTPRecommendedName, f, out),
+				tokens.NewCmdTokens(tokens.TokensRecommendedName, fullName+" "+tokens.TokensRecommendedName, f, out),
 			},
 		},
 		{
@@ -67,6 +73,8 @@ func NewCommandAdmin(name, fullName string, out io.Writer, errout io.Writer) *co
 				diagnostics.NewCmdDiagnostics(diagnostics.DiagnosticsRecommendedName, fullName+" "+diagnostics.DiagnosticsRecommendedName, out),
 				node.NewCommandManageNode(f, node.ManageNodeCommandName, fullName+" "+node.ManageNodeCommandName, out, errout),
 				prune.NewCommandPrune(prune.PruneRecommendedName, fullName+" "+prune.PruneRecommendedName, f, out),
+				mirror.NewCmdMirror(mirror.MirrorRecommendedName, fullName+" "+mirror.MirrorRecommendedName, f, out),
+				imageusage.NewCmdImageUsage(imageusage.ImageUsageRecommendedName, fullName+" "+imageusage.ImageUsageRecommendedName, f, out),
 			},
 		},
 		{
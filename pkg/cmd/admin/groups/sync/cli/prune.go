@@ -170,18 +170,31 @@ func (o *PruneOptions) Validate() error {
 // Run creates the GroupSyncer specified and runs it to sync groups
 // the arguments are only here because its the only way to get the printer we need
 func (o *PruneOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
-	bindPassword, err := api.ResolveStringValue(o.Config.BindPassword)
+	pruner, err := o.NewPruner()
 	if err != nil {
 		return err
 	}
+
+	// Now we run the pruner and report any errors
+	pruneErrors := pruner.Prune()
+	return kerrs.NewAggregate(pruneErrors)
+
+}
+
+// NewPruner creates the LDAPGroupPruner specified by the options
+func (o *PruneOptions) NewPruner() (*syncgroups.LDAPGroupPruner, error) {
+	bindPassword, err := api.ResolveStringValue(o.Config.BindPassword)
+	if err != nil {
+		return nil, err
+	}
 	clientConfig, err := ldaputil.NewLDAPClientConfig(o.Config.URL, o.Config.BindDN, bindPassword, o.Config.CA, o.Config.Insecure)
 	if err != nil {
-		return fmt.Errorf("could not determine LDAP client configuration: %v", err)
+		return nil, fmt.Errorf("could not determine LDAP client configuration: %v", err)
 	}
 
 	pruneBuilder, err := buildPruneBuilder(clientConfig, o.Config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// populate schema-independent pruner fields
@@ -191,25 +204,22 @@ func (o *PruneOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
 		DryRun:      !o.Confirm,
 
 		Out: o.Out,
-		Err: os.Stderr,
+		Err: o.Stderr,
 	}
 
 	listerMapper, err := getOpenShiftGroupListerMapper(clientConfig.Host(), o)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	pruner.GroupLister = listerMapper
 	pruner.GroupNameMapper = listerMapper
 
 	pruner.GroupDetector, err = pruneBuilder.GetGroupDetector()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Now we run the pruner and report any errors
-	pruneErrors := pruner.Prune()
-	return kerrs.NewAggregate(pruneErrors)
-
+	return pruner, nil
 }
 
 func buildPruneBuilder(clientConfig ldapclient.Config, pruneConfig *api.LDAPSyncConfig) (PruneBuilder, error) {
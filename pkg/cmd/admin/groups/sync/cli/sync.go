@@ -337,20 +337,46 @@ func (o *SyncOptions) Validate() error {
 // Run creates the GroupSyncer specified and runs it to sync groups
 // the arguments are only here because its the only way to get the printer we need
 func (o *SyncOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
-	bindPassword, err := api.ResolveStringValue(o.Config.BindPassword)
+	syncer, err := o.NewSyncer()
 	if err != nil {
 		return err
 	}
+
+	// Now we run the Syncer and report any errors
+	openshiftGroups, syncErrors := syncer.Sync()
+	if o.Confirm {
+		return kerrs.NewAggregate(syncErrors)
+	}
+
+	list := &kapi.List{}
+	for _, item := range openshiftGroups {
+		list.Items = append(list.Items, item)
+	}
+	mapper, _ := f.Object(false)
+	fn := cmdutil.VersionedPrintObject(f.PrintObject, cmd, mapper, o.Out)
+	if err := fn(list); err != nil {
+		return err
+	}
+
+	return kerrs.NewAggregate(syncErrors)
+}
+
+// NewSyncer creates the LDAPGroupSyncer specified by the options
+func (o *SyncOptions) NewSyncer() (*syncgroups.LDAPGroupSyncer, error) {
+	bindPassword, err := api.ResolveStringValue(o.Config.BindPassword)
+	if err != nil {
+		return nil, err
+	}
 	clientConfig, err := ldaputil.NewLDAPClientConfig(o.Config.URL, o.Config.BindDN, bindPassword, o.Config.CA, o.Config.Insecure)
 	if err != nil {
-		return fmt.Errorf("could not determine LDAP client configuration: %v", err)
+		return nil, fmt.Errorf("could not determine LDAP client configuration: %v", err)
 	}
 
 	errorHandler := o.CreateErrorHandler()
 
 	syncBuilder, err := buildSyncBuilder(clientConfig, o.Config, errorHandler)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// populate schema-independent syncer fields
@@ -360,7 +386,7 @@ func (o *SyncOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
 		DryRun:      !o.Confirm,
 
 		Out: o.Out,
-		Err: os.Stderr,
+		Err: o.Stderr,
 	}
 
 	switch o.Source {
@@ -369,7 +395,7 @@ func (o *SyncOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
 		// pinned by the existing mapping.
 		listerMapper, err := getOpenShiftGroupListerMapper(clientConfig.Host(), o)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		syncer.GroupLister = listerMapper
 		syncer.GroupNameMapper = listerMapper
@@ -377,44 +403,28 @@ func (o *SyncOptions) Run(cmd *cobra.Command, f *clientcmd.Factory) error {
 	case GroupSyncSourceLDAP:
 		syncer.GroupLister, err = getLDAPGroupLister(syncBuilder, o)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		syncer.GroupNameMapper, err = getGroupNameMapper(syncBuilder, o)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 	default:
-		return fmt.Errorf("invalid group source: %v", o.Source)
+		return nil, fmt.Errorf("invalid group source: %v", o.Source)
 	}
 
 	syncer.GroupMemberExtractor, err = syncBuilder.GetGroupMemberExtractor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	syncer.UserNameMapper, err = syncBuilder.GetUserNameMapper()
 	if err != nil {
-		return err
-	}
-
-	// Now we run the Syncer and report any errors
-	openshiftGroups, syncErrors := syncer.Sync()
-	if o.Confirm {
-		return kerrs.NewAggregate(syncErrors)
-	}
-
-	list := &kapi.List{}
-	for _, item := range openshiftGroups {
-		list.Items = append(list.Items, item)
-	}
-	mapper, _ := f.Object(false)
-	fn := cmdutil.VersionedPrintObject(f.PrintObject, cmd, mapper, o.Out)
-	if err := fn(list); err != nil {
-		return err
+		return nil, err
 	}
 
-	return kerrs.NewAggregate(syncErrors)
+	return syncer, nil
 }
 
 func buildSyncBuilder(clientConfig ldapclient.Config, syncConfig *api.LDAPSyncConfig, errorHandler syncerror.Handler) (SyncBuilder, error) {
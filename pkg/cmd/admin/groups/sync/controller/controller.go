@@ -0,0 +1,61 @@
+// Package controller runs the LDAP group sync of `oadm groups sync` and `oadm groups prune` periodically as a master
+// controller.
+package controller
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+
+	kerrs "k8s.io/kubernetes/pkg/util/errors"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	utilwait "k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/openshift/origin/pkg/cmd/admin/groups/sync/cli"
+)
+
+// GroupSyncController periodically syncs OpenShift Groups with LDAP groups, and optionally prunes the OpenShift
+// Groups whose LDAP groups no longer exist. The time of the last successful sync and the error of the last failed
+// sync are recorded as annotations on each synced Group.
+type GroupSyncController struct {
+	// Sync are the options of the group sync
+	Sync *cli.SyncOptions
+	// Prune are the options of the group prune. If nil, groups are not pruned
+	Prune *cli.PruneOptions
+	// Interval is the time between the start of two syncs
+	Interval time.Duration
+}
+
+// Run starts syncing groups in the background
+func (c *GroupSyncController) Run() {
+	go utilwait.Until(c.sync, c.Interval, utilwait.NeverStop)
+}
+
+// sync runs a single sync, followed by a prune if enabled
+func (c *GroupSyncController) sync() {
+	if err := c.syncOnce(); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+func (c *GroupSyncController) syncOnce() error {
+	syncer, err := c.Sync.NewSyncer()
+	if err != nil {
+		return err
+	}
+	syncer.RecordErrors = true
+
+	glog.V(4).Infof("Syncing groups with %s", syncer.Host)
+	syncedGroups, errs := syncer.Sync()
+	glog.V(4).Infof("Synced %d groups with %s", len(syncedGroups), syncer.Host)
+
+	if c.Prune != nil {
+		pruner, err := c.Prune.NewPruner()
+		if err != nil {
+			return kerrs.NewAggregate(append(errs, err))
+		}
+		errs = append(errs, pruner.Prune()...)
+	}
+
+	return kerrs.NewAggregate(errs)
+}
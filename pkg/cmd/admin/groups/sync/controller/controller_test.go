@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	"github.com/openshift/origin/pkg/cmd/admin/groups/sync/cli"
+	"github.com/openshift/origin/pkg/cmd/server/api"
+)
+
+func TestSyncOnceInvalidConfig(t *testing.T) {
+	fakeClient := testclient.NewSimpleFake()
+	controller := &GroupSyncController{
+		Sync: &cli.SyncOptions{
+			Source:         cli.GroupSyncSourceLDAP,
+			Config:         &api.LDAPSyncConfig{URL: "invalid://example.com", RFC2307Config: &api.RFC2307Config{}},
+			Confirm:        true,
+			GroupInterface: fakeClient.Groups(),
+			Out:            ioutil.Discard,
+			Stderr:         ioutil.Discard,
+		},
+	}
+
+	err := controller.syncOnce()
+	if err == nil || !strings.Contains(err.Error(), "LDAP client configuration") {
+		t.Errorf("expected an LDAP client configuration error, got %v", err)
+	}
+	if actions := fakeClient.Actions(); len(actions) != 0 {
+		t.Errorf("unexpected actions %v", actions)
+	}
+}
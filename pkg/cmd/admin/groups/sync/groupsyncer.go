@@ -39,6 +39,8 @@ type LDAPGroupSyncer struct {
 	Host string
 	// DryRun indicates that no changes should be made.
 	DryRun bool
+	// RecordErrors indicates that the errors syncing previously synced Groups should be recorded on the Groups
+	RecordErrors bool
 
 	// Out is used to provide output while the sync job is happening
 	Out io.Writer
@@ -69,6 +71,7 @@ func (s *LDAPGroupSyncer) Sync() ([]*userapi.Group, []error) {
 		if err != nil {
 			fmt.Fprintf(s.Err, "Error determining LDAP group membership for %q: %v.\n", ldapGroupUID, err)
 			errors = append(errors, err)
+			s.recordSyncError(ldapGroupUID, err)
 			continue
 		}
 
@@ -77,6 +80,7 @@ func (s *LDAPGroupSyncer) Sync() ([]*userapi.Group, []error) {
 		if err != nil {
 			fmt.Fprintf(s.Err, "Error determining usernames for LDAP group %q: %v.\n", ldapGroupUID, err)
 			errors = append(errors, err)
+			s.recordSyncError(ldapGroupUID, err)
 			continue
 		}
 		glog.V(1).Infof("Has OpenShift users %v", usernames)
@@ -95,6 +99,7 @@ func (s *LDAPGroupSyncer) Sync() ([]*userapi.Group, []error) {
 			if err := s.updateOpenShiftGroup(openshiftGroup); err != nil {
 				fmt.Fprintf(s.Err, "Error updating OpenShift group %q for LDAP group %q: %v.\n", openshiftGroup.Name, ldapGroupUID, err)
 				errors = append(errors, err)
+				s.recordSyncError(ldapGroupUID, err)
 				continue
 			}
 		}
@@ -173,10 +178,37 @@ func (s *LDAPGroupSyncer) makeOpenShiftGroup(ldapGroupUID string, usernames []st
 	// overwrite Group Users data
 	group.Users = usernames
 	group.Annotations[ldaputil.LDAPSyncTimeAnnotation] = ISO8601(time.Now())
+	delete(group.Annotations, ldaputil.LDAPSyncErrorAnnotation)
 
 	return group, nil
 }
 
+// recordSyncError records the error syncing an LDAP group on the OpenShift Group it was previously synced with, if any
+func (s *LDAPGroupSyncer) recordSyncError(ldapGroupUID string, syncErr error) {
+	if !s.RecordErrors || s.DryRun {
+		return
+	}
+	groupName, err := s.GroupNameMapper.GroupNameFor(ldapGroupUID)
+	if err != nil {
+		return
+	}
+	group, err := s.GroupClient.Get(groupName)
+	if err != nil {
+		if !kapierrors.IsNotFound(err) {
+			fmt.Fprintf(s.Err, "Error recording sync error on OpenShift group %q: %v.\n", groupName, err)
+		}
+		return
+	}
+	// only record errors on the groups synced with this LDAP group
+	if group.Annotations[ldaputil.LDAPURLAnnotation] != s.Host || group.Annotations[ldaputil.LDAPUIDAnnotation] != ldapGroupUID {
+		return
+	}
+	group.Annotations[ldaputil.LDAPSyncErrorAnnotation] = syncErr.Error()
+	if _, err := s.GroupClient.Update(group); err != nil {
+		fmt.Fprintf(s.Err, "Error recording sync error on OpenShift group %q: %v.\n", groupName, err)
+	}
+}
+
 // ISO8601 returns an ISO 6801 formatted string from a time.
 func ISO8601(t time.Time) string {
 	var tz string
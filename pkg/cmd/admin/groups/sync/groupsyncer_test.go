@@ -230,6 +230,29 @@ func TestMissingLDAPGroupUIDMapping(t *testing.T) {
 	checkClientForGroups(tc, newDefaultOpenShiftGroups(testGroupSyncer.Host), t)
 }
 
+// TestRecordSyncError ensures that the error syncing a previously synced group is recorded on the group.
+func TestRecordSyncError(t *testing.T) {
+	testGroupSyncer, tc := newTestSyncer()
+	testGroupSyncer.RecordErrors = true
+	delete(testGroupSyncer.GroupMemberExtractor.(*TestGroupMemberExtractor).MemberMapping, Group1UID)
+	tc.PrependReactor("get", "groups", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		group := newDefaultOpenShiftGroups(testGroupSyncer.Host)[0]
+		if action.(ktestclient.GetAction).GetName() != group.Name {
+			return false, nil, nil
+		}
+		return true, group, nil
+	})
+
+	_, errs := testGroupSyncer.Sync()
+	if len(errs) != 1 {
+		t.Fatalf("unexpected sync error: %v", errs)
+	}
+
+	expectedGroup := newDefaultOpenShiftGroups(testGroupSyncer.Host)[0]
+	expectedGroup.Annotations[ldaputil.LDAPSyncErrorAnnotation] = errs[0].Error()
+	checkClientForGroups(tc, []*userapi.Group{expectedGroup}, t)
+}
+
 func checkClientForGroups(tc *testclient.Fake, expectedGroups []*userapi.Group, t *testing.T) {
 	actualGroups := extractActualGroups(tc)
 
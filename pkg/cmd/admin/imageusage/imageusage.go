@@ -0,0 +1,173 @@
+package imageusage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// ImageUsageRecommendedName is the recommended command name
+	ImageUsageRecommendedName = "image-usage"
+
+	imageUsageLong = `Report how the layers of images use registry storage
+
+Analyses the layers of all images in the cluster and reports, for every project or
+image stream, the size of the layers its images reference. Layers are stored once
+no matter how many images use them, so the size is split into:
+
+* UNIQUE: layers referenced only by this project or image stream, which would be
+  freed if all of its images were deleted
+* SHARED: layers also referenced by other projects or image streams
+
+The summary compares the size the registry stores with the size the images would
+take without sharing layers, and shows the size of layers used only by images no
+image stream references. This is not what 'prune images' would remove: it also keeps
+images referenced by pods, replication controllers, deployment configs and builds,
+and images younger than --keep-younger-than.
+
+Sizes are computed from the layer metadata of images and do not include image
+configurations and manifests.`
+
+	imageUsageExample = `  # Show the layer usage of every project
+  %[1]s
+
+  # Show the layer usage of every image stream
+  %[1]s --by=imagestream`
+)
+
+// ImageUsageOptions holds all the required options for the image usage report
+type ImageUsageOptions struct {
+	Client client.Interface
+	Out    io.Writer
+
+	By string
+}
+
+// NewCmdImageUsage implements the OpenShift cli image-usage command
+func NewCmdImageUsage(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	opts := &ImageUsageOptions{By: "project"}
+
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "Report how the layers of images use registry storage",
+		Long:    imageUsageLong,
+		Example: fmt.Sprintf(imageUsageExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.Complete(f, args, out); err != nil {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "%v", err))
+			}
+
+			if err := opts.Validate(); err != nil {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "%v", err))
+			}
+
+			if err := opts.Run(); err != nil {
+				cmdutil.CheckErr(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.By, "by", opts.By, "Report the usage per 'project' or per 'imagestream'.")
+
+	return cmd
+}
+
+// Complete the options for the image usage report
+func (o *ImageUsageOptions) Complete(f *clientcmd.Factory, args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed to this command")
+	}
+	o.Out = out
+
+	var err error
+	o.Client, _, err = f.Clients()
+	return err
+}
+
+// Validate the options for the image usage report
+func (o *ImageUsageOptions) Validate() error {
+	if o.Client == nil {
+		return errors.New("a client is required")
+	}
+	if o.Out == nil {
+		return errors.New("a writer is required")
+	}
+	if o.groupFunc() == nil {
+		return fmt.Errorf("--by must be 'project' or 'imagestream', not %q", o.By)
+	}
+	return nil
+}
+
+func (o *ImageUsageOptions) groupFunc() GroupFunc {
+	switch o.By {
+	case "project":
+		return ByProject
+	case "imagestream":
+		return ByImageStream
+	default:
+		return nil
+	}
+}
+
+// Run computes and prints the image usage report
+func (o *ImageUsageOptions) Run() error {
+	images, err := o.Client.Images().List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range images.Items {
+		if err := imageapi.ImageWithMetadata(&images.Items[i]); err != nil {
+			return fmt.Errorf("unable to read the metadata of image %s: %v", images.Items[i].Name, err)
+		}
+	}
+	streams, err := o.Client.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	report := NewReport(images.Items, streams.Items, o.groupFunc())
+	return printReport(o.Out, report, o.By)
+}
+
+// printReport prints a table of the usage of every group followed by a summary.
+func printReport(out io.Writer, report *Report, by string) error {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	header := "PROJECT"
+	if by == "imagestream" {
+		header = "IMAGESTREAM"
+	}
+	fmt.Fprintf(w, "%s\tSTREAMS\tIMAGES\tLAYERS\tTOTAL\tUNIQUE\tSHARED\n", header)
+	for _, usage := range report.Groups {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\t%s\n", usage.Name, usage.Streams, usage.Images, usage.Layers,
+			humanSize(usage.Total), humanSize(usage.Unique), humanSize(usage.Shared))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d distinct layers stored in %s", report.Layers, humanSize(report.Stored))
+	if report.Logical > report.Stored {
+		fmt.Fprintf(out, ", sharing layers saves %s of %s", humanSize(report.Logical-report.Stored), humanSize(report.Logical))
+	}
+	fmt.Fprintln(out)
+	if report.Unreferenced > 0 {
+		fmt.Fprintf(out, "%s are used only by images no image stream references\n", humanSize(report.Unreferenced))
+	}
+	return nil
+}
+
+func humanSize(size int64) string {
+	return units.HumanSize(float64(size))
+}
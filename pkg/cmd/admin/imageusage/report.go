@@ -0,0 +1,166 @@
+package imageusage
+
+import (
+	"sort"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// Usage summarizes the layers of the images referenced by a group of image streams, such as the image
+// streams of a project.
+type Usage struct {
+	// Name identifies the group.
+	Name string
+	// Streams is the number of image streams in the group.
+	Streams int
+	// Images is the number of distinct images referenced by the image streams of the group.
+	Images int
+	// Layers is the number of distinct layers of those images.
+	Layers int
+	// Total is the size of the distinct layers of those images.
+	Total int64
+	// Unique is the size of the layers no other group references. It is the storage freed when the
+	// group is deleted and its images are pruned.
+	Unique int64
+	// Shared is the size of the layers other groups reference as well.
+	Shared int64
+}
+
+// Report describes how the layers of all images are used by groups of image streams.
+type Report struct {
+	// Groups holds the usage of every group, the group with the largest unique size first.
+	Groups []Usage
+	// Layers is the number of distinct layers of all images.
+	Layers int
+	// Stored is the size of the distinct layers of all images, which is what the registry stores.
+	Stored int64
+	// Logical is the sum of the sizes of all images, which would be stored without sharing layers.
+	Logical int64
+	// Unreferenced is the size of the layers of images not referenced by any image stream.
+	Unreferenced int64
+}
+
+// GroupFunc returns the name of the group an image stream belongs to.
+type GroupFunc func(stream *imageapi.ImageStream) string
+
+// ByProject groups image streams by their project.
+func ByProject(stream *imageapi.ImageStream) string {
+	return stream.Namespace
+}
+
+// ByImageStream puts every image stream in a group of its own.
+func ByImageStream(stream *imageapi.ImageStream) string {
+	return stream.Namespace + "/" + stream.Name
+}
+
+// groupLayers holds the images and layers referenced by a group.
+type groupLayers struct {
+	streams int
+	images  sets.String
+	layers  sets.String
+}
+
+// NewReport computes the layer usage of the groups of streams returned by groupBy from the layer metadata
+// of images. Images referenced by streams but missing from images are ignored.
+func NewReport(images []imageapi.Image, streams []imageapi.ImageStream, groupBy GroupFunc) *Report {
+	report := &Report{Groups: []Usage{}}
+
+	byName := make(map[string]*imageapi.Image, len(images))
+	sizes := make(map[string]int64)
+	for i := range images {
+		image := &images[i]
+		byName[image.Name] = image
+		for _, layer := range image.DockerImageLayers {
+			sizes[layer.Name] = layer.LayerSize
+			report.Logical += layer.LayerSize
+		}
+	}
+	report.Layers = len(sizes)
+	for _, size := range sizes {
+		report.Stored += size
+	}
+
+	groups := make(map[string]*groupLayers)
+	referenced := sets.NewString()
+	for i := range streams {
+		stream := &streams[i]
+		name := groupBy(stream)
+		group, ok := groups[name]
+		if !ok {
+			group = &groupLayers{images: sets.NewString(), layers: sets.NewString()}
+			groups[name] = group
+		}
+		group.streams++
+		for _, history := range stream.Status.Tags {
+			for _, event := range history.Items {
+				addImage(group, byName, event.Image)
+			}
+		}
+		referenced.Insert(group.layers.List()...)
+	}
+	for name, size := range sizes {
+		if !referenced.Has(name) {
+			report.Unreferenced += size
+		}
+	}
+
+	// count the groups referencing every layer to tell unique from shared layers
+	references := make(map[string]int)
+	for _, group := range groups {
+		for layer := range group.layers {
+			references[layer]++
+		}
+	}
+	for name, group := range groups {
+		usage := Usage{
+			Name:    name,
+			Streams: group.streams,
+			Images:  len(group.images),
+			Layers:  len(group.layers),
+		}
+		for layer := range group.layers {
+			usage.Total += sizes[layer]
+			if references[layer] > 1 {
+				usage.Shared += sizes[layer]
+			} else {
+				usage.Unique += sizes[layer]
+			}
+		}
+		report.Groups = append(report.Groups, usage)
+	}
+	sort.Sort(byUniqueSize(report.Groups))
+	return report
+}
+
+// addImage adds the image of given name and its layers to group. The layers of the images listed by a manifest
+// list are added as well.
+func addImage(group *groupLayers, images map[string]*imageapi.Image, name string) {
+	if group.images.Has(name) {
+		return
+	}
+	image, ok := images[name]
+	if !ok {
+		return
+	}
+	group.images.Insert(name)
+	for _, layer := range image.DockerImageLayers {
+		group.layers.Insert(layer.Name)
+	}
+	for _, manifest := range image.DockerImageManifests {
+		addImage(group, images, manifest.Digest)
+	}
+}
+
+// byUniqueSize sorts usages by decreasing unique size, then by name.
+type byUniqueSize []Usage
+
+func (u byUniqueSize) Len() int      { return len(u) }
+func (u byUniqueSize) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u byUniqueSize) Less(i, j int) bool {
+	if u[i].Unique != u[j].Unique {
+		return u[i].Unique > u[j].Unique
+	}
+	return u[i].Name < u[j].Name
+}
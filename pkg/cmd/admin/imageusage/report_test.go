@@ -0,0 +1,114 @@
+package imageusage
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func image(name string, layers ...imageapi.ImageLayer) imageapi.Image {
+	return imageapi.Image{
+		ObjectMeta:        kapi.ObjectMeta{Name: name},
+		DockerImageLayers: layers,
+	}
+}
+
+func layer(name string, size int64) imageapi.ImageLayer {
+	return imageapi.ImageLayer{Name: name, LayerSize: size}
+}
+
+func stream(namespace, name string, images ...string) imageapi.ImageStream {
+	history := imageapi.TagEventList{}
+	for _, image := range images {
+		history.Items = append(history.Items, imageapi.TagEvent{Image: image})
+	}
+	return imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: namespace, Name: name},
+		Status: imageapi.ImageStreamStatus{
+			Tags: map[string]imageapi.TagEventList{"latest": history},
+		},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	base := layer("base", 100)
+	images := []imageapi.Image{
+		image("app-1", base, layer("app-1", 10)),
+		image("app-2", base, layer("app-2", 20)),
+		image("db", base, layer("db", 50)),
+		image("orphan", layer("orphan", 5)),
+		{
+			ObjectMeta:           kapi.ObjectMeta{Name: "list"},
+			DockerImageManifests: []imageapi.ImageManifest{{Digest: "arm"}},
+		},
+		image("arm", layer("arm", 7)),
+	}
+	streams := []imageapi.ImageStream{
+		stream("dev", "app", "app-2", "app-1"),
+		stream("dev", "db", "db"),
+		stream("prod", "app", "app-1", "missing"),
+		stream("prod", "multi", "list"),
+	}
+
+	testCases := map[string]struct {
+		groupBy  GroupFunc
+		expected []Usage
+	}{
+		"by project": {
+			groupBy: ByProject,
+			expected: []Usage{
+				{Name: "dev", Streams: 2, Images: 3, Layers: 4, Total: 180, Unique: 70, Shared: 110},
+				{Name: "prod", Streams: 2, Images: 3, Layers: 3, Total: 117, Unique: 7, Shared: 110},
+			},
+		},
+		"by image stream": {
+			groupBy: ByImageStream,
+			expected: []Usage{
+				{Name: "dev/db", Streams: 1, Images: 1, Layers: 2, Total: 150, Unique: 50, Shared: 100},
+				{Name: "dev/app", Streams: 1, Images: 2, Layers: 3, Total: 130, Unique: 20, Shared: 110},
+				{Name: "prod/multi", Streams: 1, Images: 2, Layers: 1, Total: 7, Unique: 7},
+				{Name: "prod/app", Streams: 1, Images: 1, Layers: 2, Total: 110, Shared: 110},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		report := NewReport(images, streams, tc.groupBy)
+		if !reflect.DeepEqual(report.Groups, tc.expected) {
+			t.Errorf("%s: unexpected usage:\n%#v\nexpected:\n%#v", name, report.Groups, tc.expected)
+		}
+		if report.Layers != 6 || report.Stored != 192 || report.Logical != 392 || report.Unreferenced != 5 {
+			t.Errorf("%s: unexpected totals: %#v", name, report)
+		}
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	report := &Report{
+		Groups:       []Usage{{Name: "dev", Streams: 1, Images: 2, Layers: 3, Total: 3000, Unique: 1000, Shared: 2000}},
+		Layers:       3,
+		Stored:       3000,
+		Logical:      5000,
+		Unreferenced: 1000,
+	}
+	out := &bytes.Buffer{}
+	if err := printReport(out, report, "project"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"PROJECT",
+		"dev ",
+		"3 kB",
+		"3 distinct layers stored in 3 kB, sharing layers saves 2 kB of 5 kB",
+		"1 kB are used only by images no image stream references",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the output:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/client/restclient"
+	kclientcmd "k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/image/importer"
+	"github.com/openshift/origin/pkg/image/mirror"
+)
+
+const (
+	// MirrorRecommendedName is the recommended command name
+	MirrorRecommendedName = "mirror-imagestreams"
+
+	mirrorLong = `Mirror image streams to another cluster
+
+Copies the images tagged in image streams of the current project, together with their
+layers and signatures, from the integrated registry of this cluster to the integrated
+registry of the cluster described by the --to kubeconfig file. Manifests are copied
+unchanged, so the mirrored images have the same digests and metadata. Signatures are
+copied if the user of the --to kubeconfig file may create image signatures; failing to
+copy them is reported as a warning and does not fail the mirroring.
+
+Image streams are selected by name or by a label selector. By default, the image streams
+are mirrored once. With --interval, the command keeps running and mirrors newly tagged
+images periodically.
+
+Both client configs must use a token, which is used to authenticate to the registries.`
+
+	mirrorExample = `  # Mirror the image streams 'frontend' and 'backend' to the cluster of prod.kubeconfig
+  %[1]s frontend backend --to=prod.kubeconfig
+
+  # Keep mirroring the 'latest' and 'stable' tags of all image streams labeled promote=true
+  # into the project 'shop' of the other cluster every 5 minutes
+  %[1]s -l promote=true --tags=latest,stable --to=prod.kubeconfig --to-namespace=shop --interval=5m`
+)
+
+// MirrorOptions holds all the required options for mirroring image streams
+type MirrorOptions struct {
+	Controller *mirror.Controller
+	Out        io.Writer
+
+	Interval time.Duration
+
+	To            string
+	ToNamespace   string
+	FromRegistry  string
+	ToRegistry    string
+	Insecure      bool
+	Tags          []string
+	LabelSelector string
+}
+
+// NewCmdMirror implements the OpenShift cli mirror-imagestreams command
+func NewCmdMirror(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	opts := &MirrorOptions{}
+
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s [NAME...] --to=KUBECONFIG", name),
+		Short:   "Mirror image streams to another cluster",
+		Long:    mirrorLong,
+		Example: fmt.Sprintf(mirrorExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.Complete(f, args, out); err != nil {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "%v", err))
+			}
+
+			if err := opts.Validate(); err != nil {
+				cmdutil.CheckErr(cmdutil.UsageError(cmd, "%v", err))
+			}
+
+			if err := opts.Run(); err != nil {
+				cmdutil.CheckErr(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.To, "to", opts.To, "Path to the kubeconfig file of the cluster to mirror the image streams to.")
+	cmd.Flags().StringVar(&opts.ToNamespace, "to-namespace", opts.ToNamespace, "The project in the destination cluster to mirror the image streams to. Defaults to the current project.")
+	cmd.Flags().StringVar(&opts.FromRegistry, "from-registry", opts.FromRegistry, "The address of the integrated registry of this cluster, if it is not reachable at the address reported by image streams.")
+	cmd.Flags().StringVar(&opts.ToRegistry, "to-registry", opts.ToRegistry, "The address of the integrated registry of the destination cluster, if it is not reachable at the address reported by image streams.")
+	cmd.Flags().BoolVar(&opts.Insecure, "insecure", opts.Insecure, "If true, connect to the registries over HTTP or without verifying their certificates.")
+	cmd.Flags().StringSliceVar(&opts.Tags, "tags", opts.Tags, "Only mirror these tags. Defaults to all tags.")
+	cmd.Flags().StringVarP(&opts.LabelSelector, "selector", "l", opts.LabelSelector, "Selector (label query) to filter image streams to mirror.")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", opts.Interval, "If set, keep mirroring the image streams with this period instead of exiting after the first run.")
+	cmd.MarkFlagFilename("to")
+
+	return cmd
+}
+
+// Complete the options for mirroring image streams
+func (o *MirrorOptions) Complete(f *clientcmd.Factory, args []string, out io.Writer) error {
+	if len(args) == 0 && len(o.LabelSelector) == 0 {
+		return errors.New("you must specify the names of image streams or a selector")
+	}
+	if len(o.To) == 0 {
+		return errors.New("you must specify a kubeconfig file of the destination cluster with --to")
+	}
+	o.Out = out
+
+	selector, err := labels.Parse(o.LabelSelector)
+	if err != nil {
+		return err
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	if len(o.ToNamespace) == 0 {
+		o.ToNamespace = namespace
+	}
+
+	srcConfig, err := f.OpenShiftClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	source, err := o.endpoint(srcConfig, o.FromRegistry)
+	if err != nil {
+		return err
+	}
+
+	loadingRules := &kclientcmd.ClientConfigLoadingRules{ExplicitPath: o.To}
+	dstConfig, err := kclientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &kclientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load the destination client config: %v", err)
+	}
+	destination, err := o.endpoint(dstConfig, o.ToRegistry)
+	if err != nil {
+		return err
+	}
+
+	o.Controller = &mirror.Controller{
+		Mirrorer: &mirror.ImageStreamMirrorer{
+			Source:      *source,
+			Destination: *destination,
+		},
+		SourceNamespace:      namespace,
+		DestinationNamespace: o.ToNamespace,
+		Names:                sets.NewString(args...),
+		Selector:             selector,
+		Tags:                 sets.NewString(o.Tags...),
+		Out:                  out,
+	}
+	return nil
+}
+
+// endpoint returns a mirroring endpoint authenticating to the master and the registry with the token of config.
+func (o *MirrorOptions) endpoint(config *restclient.Config, registry string) (*mirror.Endpoint, error) {
+	if len(config.BearerToken) == 0 {
+		return nil, fmt.Errorf("the client config for %s must use a token", config.Host)
+	}
+	osClient, err := client.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	insecureTransport, err := restclient.TransportFor(&restclient.Config{Insecure: true})
+	if err != nil {
+		return nil, err
+	}
+	credentials := importer.NewBasicCredentials()
+	// the registry accepts any user name along with a token
+	credentials.Add(&url.URL{}, "unused", config.BearerToken)
+	context := importer.NewContext(http.DefaultTransport, insecureTransport)
+	context.Actions = []string{"pull", "push"}
+
+	return &mirror.Endpoint{
+		Client:    osClient,
+		Retriever: context.WithCredentials(credentials),
+		Registry:  registry,
+		Insecure:  o.Insecure,
+	}, nil
+}
+
+// Validate the options for mirroring image streams
+func (o *MirrorOptions) Validate() error {
+	if o.Controller == nil {
+		return errors.New("a mirroring controller needs to be specified")
+	}
+	if o.Interval < 0 {
+		return errors.New("--interval must not be negative")
+	}
+	return nil
+}
+
+// Run mirrors the image streams once or, if an interval is set, until the process is terminated
+func (o *MirrorOptions) Run() error {
+	if o.Interval == 0 {
+		return o.Controller.RunOnce()
+	}
+	o.Controller.Run(o.Interval, wait.NeverStop)
+	return nil
+}
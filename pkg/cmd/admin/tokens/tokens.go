@@ -0,0 +1,192 @@
+package tokens
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	clitokens "github.com/openshift/origin/pkg/cmd/cli/tokens"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+const TokensRecommendedName = "tokens"
+
+const (
+	tokensLong = `
+Manage the access tokens of users
+
+Every login creates an access token, and users can create personal access tokens for scripts.
+These commands list the tokens of a user and revoke them, for instance when an employee leaves or
+a laptop is lost. Revoking the tokens does not prevent the user from logging in again.`
+
+	listLong = `
+List the access tokens of a user.
+
+The tokens themselves are not shown, only an ID that identifies them, the name of personal access
+tokens, the client that requested the token, and its scopes and expiry.`
+
+	listExample = `  # List the tokens of user1
+  %[1]s user1`
+
+	revokeLong = `
+Revoke access tokens of a user.
+
+Tokens are identified by the ID shown by the list command, or a unique prefix of it, or by the name
+of a personal access token.`
+
+	revokeExample = `  # Revoke all the tokens of user1
+  %[1]s user1 --all
+
+  # Revoke the token of user1 with the ID 'Xk3pQ9aZ2m'
+  %[1]s user1 Xk3pQ9aZ2m`
+)
+
+const (
+	ListRecommendedName   = "list"
+	RevokeRecommendedName = "revoke"
+)
+
+func NewCmdTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	// Parent command to which all subcommands are added.
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: "Manage the access tokens of users",
+		Long:  tokensLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdList(ListRecommendedName, fullName+" "+ListRecommendedName, f, out))
+	cmds.AddCommand(NewCmdRevoke(RevokeRecommendedName, fullName+" "+RevokeRecommendedName, f, out))
+
+	return cmds
+}
+
+type ListOptions struct {
+	TokenClient client.OAuthAccessTokenInterface
+
+	User string
+
+	Out io.Writer
+}
+
+func NewCmdList(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &ListOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " USER",
+		Short:   "List the access tokens of a user",
+		Long:    listLong,
+		Example: fmt.Sprintf(listExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(options.Complete(f, cmd, args))
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	return cmd
+}
+
+func (o *ListOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return kcmdutil.UsageError(cmd, "exactly one user name is required")
+	}
+	o.User = args[0]
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.TokenClient = osClient.OAuthAccessTokens()
+	return nil
+}
+
+func (o *ListOptions) Run() error {
+	tokens, err := listTokens(o.TokenClient, o.User)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Fprintf(o.Out, "User %q has no access tokens.\n", o.User)
+		return nil
+	}
+	return clitokens.PrintTokens(o.Out, tokens)
+}
+
+type RevokeOptions struct {
+	TokenClient client.OAuthAccessTokenInterface
+
+	User  string
+	Names []string
+	All   bool
+
+	Out io.Writer
+}
+
+func NewCmdRevoke(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RevokeOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " USER [ID|NAME ...]",
+		Short:   "Revoke access tokens of a user",
+		Long:    revokeLong,
+		Example: fmt.Sprintf(revokeExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(options.Complete(f, cmd, args))
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.All, "all", options.All, "Revoke all the tokens of the user.")
+
+	return cmd
+}
+
+func (o *RevokeOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return kcmdutil.UsageError(cmd, "a user name is required")
+	}
+	o.User, o.Names = args[0], args[1:]
+	switch {
+	case o.All && len(o.Names) > 0:
+		return kcmdutil.UsageError(cmd, "token IDs can not be given with --all")
+	case !o.All && len(o.Names) == 0:
+		return kcmdutil.UsageError(cmd, "at least one token ID or name is required, or --all")
+	}
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.TokenClient = osClient.OAuthAccessTokens()
+	return nil
+}
+
+func (o *RevokeOptions) Run() error {
+	tokens, err := listTokens(o.TokenClient, o.User)
+	if err != nil {
+		return err
+	}
+	if !o.All {
+		if tokens, err = clitokens.FindTokens(tokens, o.Names); err != nil {
+			return err
+		}
+	}
+	return clitokens.RevokeTokens(o.Out, tokens, o.TokenClient.Delete)
+}
+
+// listTokens returns the access tokens of the user
+func listTokens(c client.OAuthAccessTokenInterface, user string) ([]oauthapi.OAuthAccessToken, error) {
+	list, err := c.List(kapi.ListOptions{FieldSelector: fields.OneTermEqualSelector("userName", user)})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,121 @@
+package totp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	authtotp "github.com/openshift/origin/pkg/auth/totp"
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	EnrollRecommendedName = "enroll"
+	enrollLong            = `
+Enroll a user in multi-factor authentication.
+
+This command generates a new TOTP secret for the user and prints it, along with the otpauth:// URI
+authenticator apps can import. Give both to the user over a secure channel. The secret is stored in
+a secret of the openshift-infra project, which only cluster administrators can read. Once enrolled,
+the user must provide a verification code when logging in with a password identity provider that
+has TOTP enabled. Users must be enrolled under the name they log in with.`
+
+	enrollExample = `  # Enroll user1 in multi-factor authentication
+  %[1]s user1
+
+  # Generate a new secret for user1, who lost the previous one
+  %[1]s user1 --overwrite`
+)
+
+type EnrollOptions struct {
+	UserClient   client.UserInterface
+	SecretClient kclient.SecretsInterface
+
+	User      string
+	Issuer    string
+	Overwrite bool
+
+	Out io.Writer
+}
+
+func NewCmdEnroll(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &EnrollOptions{Issuer: "openshift", Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " USER",
+		Short:   "Enroll a user in multi-factor authentication",
+		Long:    enrollLong,
+		Example: fmt.Sprintf(enrollExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Enroll())
+		},
+	}
+	cmd.Flags().StringVar(&options.Issuer, "issuer", options.Issuer, "The issuer displayed by authenticator apps.")
+	cmd.Flags().BoolVar(&options.Overwrite, "overwrite", options.Overwrite, "If true, replace the secret of a user who is already enrolled.")
+
+	return cmd
+}
+
+func (o *EnrollOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must specify one argument: USER")
+	}
+	o.User = args[0]
+
+	osClient, kClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.UserClient = osClient.Users()
+	o.SecretClient = kClient.Secrets(authtotp.SecretNamespace)
+	return nil
+}
+
+func (o *EnrollOptions) Enroll() error {
+	if _, err := o.UserClient.Get(o.User); err != nil {
+		return err
+	}
+
+	secret, err := authtotp.GenerateSecret()
+	if err != nil {
+		return err
+	}
+	totpSecret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        authtotp.SecretName(o.User),
+			Annotations: map[string]string{authtotp.UserAnnotation: o.User},
+		},
+		Data: map[string][]byte{authtotp.SecretKey: []byte(secret)},
+	}
+	_, err = o.SecretClient.Create(totpSecret)
+	if kerrors.IsAlreadyExists(err) {
+		if !o.Overwrite {
+			return fmt.Errorf("user %q is already enrolled, use --overwrite to generate a new secret", o.User)
+		}
+		var existing *kapi.Secret
+		if existing, err = o.SecretClient.Get(totpSecret.Name); err == nil {
+			existing.Annotations = totpSecret.Annotations
+			existing.Data = totpSecret.Data
+			_, err = o.SecretClient.Update(existing)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Secret: %s\n", secret)
+	fmt.Fprintf(o.Out, "URI: %s\n", authtotp.URI(o.Issuer, o.User, secret))
+	return nil
+}
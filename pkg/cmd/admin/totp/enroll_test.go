@@ -0,0 +1,144 @@
+package totp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	authtotp "github.com/openshift/origin/pkg/auth/totp"
+	"github.com/openshift/origin/pkg/client/testclient"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+// fakeSecrets returns a client storing the secrets it manages in secrets
+func fakeSecrets(secrets map[string]*kapi.Secret) *ktestclient.Fake {
+	fake := ktestclient.NewSimpleFake()
+	fake.PrependReactor("get", "secrets", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if secret, ok := secrets[name]; ok {
+			copied := *secret
+			return true, &copied, nil
+		}
+		return true, nil, kerrors.NewNotFound(kapi.Resource("secrets"), name)
+	})
+	fake.PrependReactor("create", "secrets", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		secret := action.(ktestclient.CreateAction).GetObject().(*kapi.Secret)
+		if _, ok := secrets[secret.Name]; ok {
+			return true, nil, kerrors.NewAlreadyExists(kapi.Resource("secrets"), secret.Name)
+		}
+		secrets[secret.Name] = secret
+		return true, secret, nil
+	})
+	fake.PrependReactor("update", "secrets", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		secret := action.(ktestclient.UpdateAction).GetObject().(*kapi.Secret)
+		secrets[secret.Name] = secret
+		return true, secret, nil
+	})
+	fake.PrependReactor("delete", "secrets", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.DeleteAction).GetName()
+		if _, ok := secrets[name]; !ok {
+			return true, nil, kerrors.NewNotFound(kapi.Resource("secrets"), name)
+		}
+		delete(secrets, name)
+		return true, nil, nil
+	})
+	return fake
+}
+
+// fakeUsers returns a client knowing the named users
+func fakeUsers(names ...string) *testclient.Fake {
+	fake := testclient.NewSimpleFake()
+	fake.PrependReactor("get", "users", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		for _, existing := range names {
+			if name == existing {
+				return true, &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: name}}, nil
+			}
+		}
+		return true, nil, kerrors.NewNotFound(userapi.Resource("users"), name)
+	})
+	return fake
+}
+
+func TestEnroll(t *testing.T) {
+	name := authtotp.SecretName("user1")
+	testCases := map[string]struct {
+		user      string
+		enrolled  bool
+		overwrite bool
+		err       string
+	}{
+		"not enrolled": {
+			user: "user1",
+		},
+		"already enrolled": {
+			user:     "user1",
+			enrolled: true,
+			err:      "already enrolled",
+		},
+		"already enrolled with overwrite": {
+			user:      "user1",
+			enrolled:  true,
+			overwrite: true,
+		},
+		"unknown user": {
+			user: "user2",
+			err:  "not found",
+		},
+	}
+
+	for testName, tc := range testCases {
+		secrets := map[string]*kapi.Secret{}
+		if tc.enrolled {
+			secrets[name] = &kapi.Secret{
+				ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: authtotp.SecretNamespace},
+				Data:       map[string][]byte{authtotp.SecretKey: []byte("OLDSECRET")},
+			}
+		}
+		out := &bytes.Buffer{}
+		o := &EnrollOptions{
+			UserClient:   fakeUsers("user1").Users(),
+			SecretClient: fakeSecrets(secrets).Secrets(authtotp.SecretNamespace),
+			User:         tc.user,
+			Issuer:       "openshift",
+			Overwrite:    tc.overwrite,
+			Out:          out,
+		}
+
+		err := o.Enroll()
+		if len(tc.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: expected an error containing %q, got %v", testName, tc.err, err)
+			}
+			if tc.enrolled && string(secrets[name].Data[authtotp.SecretKey]) != "OLDSECRET" {
+				t.Errorf("%s: expected the secret to be kept", testName)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", testName, err)
+			continue
+		}
+
+		secret, ok := secrets[name]
+		if !ok {
+			t.Errorf("%s: expected the secret to be stored", testName)
+			continue
+		}
+		value := string(secret.Data[authtotp.SecretKey])
+		if len(value) == 0 || value == "OLDSECRET" {
+			t.Errorf("%s: expected a new secret, got %q", testName, value)
+		}
+		if secret.Annotations[authtotp.UserAnnotation] != "user1" {
+			t.Errorf("%s: expected the secret to be annotated with the user, got %v", testName, secret.Annotations)
+		}
+		if !strings.Contains(out.String(), "Secret: "+value+"\n") || !strings.Contains(out.String(), "otpauth://") {
+			t.Errorf("%s: expected the secret and the URI to be printed, got %q", testName, out.String())
+		}
+	}
+}
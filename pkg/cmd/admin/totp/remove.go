@@ -0,0 +1,77 @@
+package totp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/spf13/cobra"
+
+	authtotp "github.com/openshift/origin/pkg/auth/totp"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	RemoveRecommendedName = "remove"
+	removeLong            = `
+Remove the enrollment of a user in multi-factor authentication.
+
+This command deletes the secret holding the TOTP secret of the user. Users who are not enrolled log
+in with their password alone, unless the identity provider requires TOTP.`
+
+	removeExample = `  # Remove the enrollment of user1
+  %[1]s user1`
+)
+
+type RemoveOptions struct {
+	SecretClient kclient.SecretsInterface
+
+	User string
+}
+
+func NewCmdRemove(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RemoveOptions{}
+
+	cmd := &cobra.Command{
+		Use:     name + " USER",
+		Short:   "Remove the enrollment of a user in multi-factor authentication",
+		Long:    removeLong,
+		Example: fmt.Sprintf(removeExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			kcmdutil.CheckErr(options.Remove())
+		},
+	}
+
+	return cmd
+}
+
+func (o *RemoveOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must specify one argument: USER")
+	}
+	o.User = args[0]
+
+	_, kClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.SecretClient = kClient.Secrets(authtotp.SecretNamespace)
+	return nil
+}
+
+// Remove deletes the TOTP secret of the user. Removing the enrollment of a user who is not enrolled is not an error.
+func (o *RemoveOptions) Remove() error {
+	err := o.SecretClient.Delete(authtotp.SecretName(o.User))
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package totp
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	authtotp "github.com/openshift/origin/pkg/auth/totp"
+)
+
+func TestRemove(t *testing.T) {
+	name := authtotp.SecretName("user1")
+	secrets := map[string]*kapi.Secret{
+		name: {ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: authtotp.SecretNamespace}},
+	}
+	o := &RemoveOptions{SecretClient: fakeSecrets(secrets).Secrets(authtotp.SecretNamespace), User: "user1"}
+
+	if err := o.Remove(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := secrets[name]; ok {
+		t.Errorf("expected the secret to be deleted")
+	}
+	// removing the enrollment of a user who is not enrolled succeeds
+	if err := o.Remove(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package totp
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const TOTPRecommendedName = "totp"
+
+const (
+	totpLong = `
+Manage the enrollment of users in multi-factor authentication
+
+Password identity providers can require the users enrolled in time-based one-time passwords (TOTP) to
+provide the code of their authenticator app in addition to their password. The code is entered in the
+login form, or appended to the password when logging in from the command line.`
+)
+
+func NewCmdTOTP(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	// Parent command to which all subcommands are added.
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: "Manage multi-factor authentication of users",
+		Long:  totpLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdEnroll(EnrollRecommendedName, fullName+" "+EnrollRecommendedName, f, out))
+	cmds.AddCommand(NewCmdRemove(RemoveRecommendedName, fullName+" "+RemoveRecommendedName, f, out))
+
+	return cmds
+}
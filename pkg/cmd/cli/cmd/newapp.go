@@ -17,6 +17,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/client/restclient"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	ctl "k8s.io/kubernetes/pkg/kubectl"
@@ -24,6 +25,7 @@ import (
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	"k8s.io/kubernetes/pkg/kubectl/resource"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/wait"
@@ -36,6 +38,7 @@ import (
 	newapp "github.com/openshift/origin/pkg/generate/app"
 	newcmd "github.com/openshift/origin/pkg/generate/app/cmd"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	templateapi "github.com/openshift/origin/pkg/template/api"
 	"github.com/openshift/origin/pkg/util"
 )
 
@@ -58,6 +61,10 @@ configuration, and a service will be connected to the first public port of the a
 components using the various existing flags or let new-app autodetect what kind of components
 you have provided.
 
+The objects created from a template are recorded in a template instance whose name starts
+with the name of the template.
+Deleting the template instance with '%[1]s delete templateinstance NAME' deletes those objects as well.
+
 If you provide source code, a new build will be automatically triggered.
 You can use '%[1]s status' to check the progress.`
 
@@ -198,7 +205,7 @@ func (o *NewAppOptions) Complete(commandName string, f *clientcmd.Factory, c *co
 
 	o.Action.Out, o.Action.ErrOut = o.Out, o.ErrOut
 	o.Action.Bulk.Mapper = clientcmd.ResourceMapper(f)
-	o.Action.Bulk.Op = configcmd.Create
+	o.Action.Bulk.Op = recordObjectUIDs(configcmd.Create)
 	// Retry is used to support previous versions of the API server that will
 	// consider the presence of an unknown trigger type to be an error.
 	o.Action.Bulk.Retry = retryBuildConfig
@@ -525,6 +532,28 @@ func isInvalidTriggerError(err error) bool {
 	return strings.Contains(statusErr.Status().Message, "invalid trigger type")
 }
 
+// recordObjectUIDs wraps op so that the template instances it creates reference the objects created
+// before them by UID, which lets the template instance reaper skip objects that were replaced since.
+func recordObjectUIDs(op configcmd.OpFunc) configcmd.OpFunc {
+	uids := map[kapi.ObjectReference]types.UID{}
+	return func(info *resource.Info, namespace string, obj runtime.Object) (runtime.Object, error) {
+		if templateInstance, ok := obj.(*templateapi.TemplateInstance); ok {
+			for i := range templateInstance.Status.Objects {
+				ref := &templateInstance.Status.Objects[i]
+				ref.UID = uids[kapi.ObjectReference{Kind: ref.Kind, Name: ref.Name}]
+			}
+		}
+		created, err := op(info, namespace, obj)
+		if err != nil {
+			return created, err
+		}
+		if objMeta, err := meta.Accessor(created); err == nil {
+			uids[kapi.ObjectReference{Kind: info.Mapping.GroupVersionKind.Kind, Name: objMeta.GetName()}] = objMeta.GetUID()
+		}
+		return created, nil
+	}
+}
+
 // retryBuildConfig determines if the given error is caused by an invalid trigger
 // error on a BuildConfig. If that is the case, it will remove all triggers with a
 // type that is not in the whitelist for an older server.
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+
+	templateapi "github.com/openshift/origin/pkg/template/api"
+)
+
+func TestRecordObjectUIDs(t *testing.T) {
+	create := func(info *resource.Info, namespace string, obj runtime.Object) (runtime.Object, error) {
+		if service, ok := obj.(*kapi.Service); ok {
+			service.UID = types.UID(service.Name + "-uid")
+		}
+		return obj, nil
+	}
+	info := func(kind string) *resource.Info {
+		return &resource.Info{Mapping: &meta.RESTMapping{GroupVersionKind: unversioned.GroupVersionKind{Kind: kind}}}
+	}
+
+	op := recordObjectUIDs(create)
+	if _, err := op(info("Service"), "ns", &kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "frontend"}}); err != nil {
+		t.Fatal(err)
+	}
+	templateInstance := &templateapi.TemplateInstance{
+		Status: templateapi.TemplateInstanceStatus{
+			Objects: []kapi.ObjectReference{
+				{Kind: "Service", Name: "frontend"},
+				{Kind: "Service", Name: "backend"},
+			},
+		},
+	}
+	if _, err := op(info("TemplateInstance"), "ns", templateInstance); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []kapi.ObjectReference{
+		{Kind: "Service", Name: "frontend", UID: "frontend-uid"},
+		{Kind: "Service", Name: "backend"},
+	}
+	if !reflect.DeepEqual(templateInstance.Status.Objects, expected) {
+		t.Errorf("expected objects %#v, got %#v", expected, templateInstance.Status.Objects)
+	}
+}
@@ -33,7 +33,14 @@ update. Templates have "parameters", which may either be generated on creation o
 as well as metadata describing the template.
 
 The output of the process command is always a list of one or more resources. You may pipe the
-output to the create command over STDIN (using the '-f -' option) or redirect it to a file.`
+output to the create command over STDIN (using the '-f -' option) or redirect it to a file.
+
+Pass --diff to compare the processed resources with the ones in the project instead. Only the
+fields set by the template are compared. Add --apply to create the missing resources and patch
+the changed ones. Parameters without a value are generated again on every run, so the fields of
+existing resources holding generated values keep their current values. The changed resources are
+patched with a strategic merge patch: lists without a merge key, like the arguments of a
+container, are replaced as a whole.`
 
 	processExample = `  # Convert template.json file into resource list and pass to create
   %[1]s process -f template.json | %[1]s create -f -
@@ -51,7 +58,13 @@ output to the create command over STDIN (using the '-f -' option) or redirect it
   %[1]s process openshift//foo
 
   # Convert template.json into resource list
-  cat template.json | %[1]s process -f -`
+  cat template.json | %[1]s process -f -
+
+  # Show what processing a stored template would change in the current project
+  %[1]s process foo --diff
+
+  # Create or update only the resources that differ from the template
+  %[1]s process foo PARM1=VALUE1 --diff --apply`
 )
 
 // NewCmdProcess implements the OpenShift cli process command
@@ -71,6 +84,8 @@ func NewCmdProcess(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.
 	cmd.Flags().StringSliceP("value", "v", nil, "Specify a list of key-value pairs (eg. -v FOO=BAR,BAR=FOO) to set/override parameter values")
 	cmd.Flags().BoolP("parameters", "", false, "Do not process but only print available parameters")
 	cmd.Flags().StringP("labels", "l", "", "Label to set in all resources for this template")
+	cmd.Flags().Bool("diff", false, "If true compare the processed resources with the ones in the project instead of printing them")
+	cmd.Flags().Bool("apply", false, "If true create the missing resources and patch the changed ones. Requires --diff")
 
 	cmd.Flags().StringP("output", "o", "json", "Output format. One of: describe|json|yaml|name|template|templatefile.")
 	cmd.Flags().Bool("raw", false, "If true output the processed template instead of the template's objects. Implied by -o describe")
@@ -129,13 +144,25 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 	}
 
 	if kcmdutil.GetFlagBool(cmd, "parameters") {
-		for _, flag := range []string{"value", "labels", "output", "output-version", "raw", "template"} {
+		for _, flag := range []string{"value", "labels", "output", "output-version", "raw", "template", "diff", "apply"} {
 			if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
 				return kcmdutil.UsageError(cmd, "The --parameters flag does not process the template, can't be used with --%v", flag)
 			}
 		}
 	}
 
+	diff := kcmdutil.GetFlagBool(cmd, "diff")
+	if kcmdutil.GetFlagBool(cmd, "apply") && !diff {
+		return kcmdutil.UsageError(cmd, "The --apply flag requires --diff")
+	}
+	if diff {
+		for _, flag := range []string{"output", "output-version", "raw", "template"} {
+			if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+				return kcmdutil.UsageError(cmd, "The --diff flag does not print the processed resources, can't be used with --%v", flag)
+			}
+		}
+	}
+
 	namespace, explicit, err := f.DefaultNamespace()
 	if err != nil {
 		return err
@@ -248,11 +275,21 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 		return kerrors.NewAggregate(errs)
 	}
 
+	// Reject values of the wrong type before sending the template to the server
+	if errs := template.ValidateParameterValues(obj); len(errs) > 0 {
+		return fmt.Errorf("invalid parameter values for template %q: %v", obj.Name, errs.ToAggregate())
+	}
+
+	generated := generatedParameters(obj)
 	resultObj, err := client.TemplateConfigs(namespace).Create(obj)
 	if err != nil {
 		return fmt.Errorf("error processing the template %q: %v\n", obj.Name, err)
 	}
 
+	if diff {
+		return runProcessDiff(resource.ClientMapperFunc(f.ClientForMapping), mapper, typer, out, namespace, resultObj.Objects, generatedValues(resultObj, generated), kcmdutil.GetFlagBool(cmd, "apply"))
+	}
+
 	if outputFormat == "describe" {
 		if s, err := (&describe.TemplateDescriber{
 			MetadataAccessor: meta.NewAccessor(),
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	promoteLong = `
+Promote an image from one image stream tag to another
+
+The promote command copies the image an image stream tag currently points to into
+another image stream tag, usually in another project, such as when moving an image
+from development to QA and from QA to production. Unlike 'tag', the image is only
+promoted if it passes a number of checks:
+
+* the image has at least one signature
+* the image or its tag is annotated with %[1]s=true
+* no build that will push a newer image to the source tag is still pending or running
+
+Pass --force to promote an image that fails the checks. Every promotion is recorded
+in the %[2]s annotation of the target image stream.
+`
+
+	promoteExample = `  # Promote the image of the tag 'app:latest' in the project 'dev' to 'app:latest' in the project 'qa'.
+  %[1]s promote dev/app:latest qa/app:latest
+
+  # Promote an image that has not been signed yet.
+  %[1]s promote dev/app:latest qa/app:latest --require-signatures=false`
+
+	// maxPromotionHistory is the number of promotions kept in the history of an image stream.
+	maxPromotionHistory = 10
+)
+
+// promotionRecord is an entry of the promotion history of an image stream.
+type promotionRecord struct {
+	// Tag is the tag of the image stream the image was promoted to.
+	Tag string `json:"tag"`
+	// From is the image stream tag the image was promoted from, as <namespace>/<name>:<tag>.
+	From string `json:"from"`
+	// Image is the name of the promoted image.
+	Image string `json:"image"`
+	// User is the user who promoted the image, if known.
+	User string `json:"user,omitempty"`
+	// Time is when the image was promoted.
+	Time unversioned.Time `json:"time"`
+	// Forced is true if the image failed a check and was promoted with --force.
+	Forced bool `json:"forced,omitempty"`
+}
+
+// PromoteOptions contains all the necessary options for the cli promote command.
+type PromoteOptions struct {
+	out      io.Writer
+	osClient client.Interface
+
+	force             bool
+	requireSignatures bool
+	requireTests      bool
+	checkBuilds       bool
+
+	srcNamespace, srcName, srcTag    string
+	destNamespace, destName, destTag string
+}
+
+// NewCmdPromote implements the OpenShift cli promote command.
+func NewCmdPromote(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	opts := &PromoteOptions{
+		requireSignatures: true,
+		requireTests:      true,
+		checkBuilds:       true,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "promote SOURCE DEST",
+		Short:   "Promote an image from one image stream tag to another",
+		Long:    fmt.Sprintf(promoteLong, imageapi.TestsPassedAnnotation, imageapi.PromotionHistoryAnnotation),
+		Example: fmt.Sprintf(promoteExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(opts.Complete(f, cmd, args, out))
+			kcmdutil.CheckErr(opts.Validate())
+			kcmdutil.CheckErr(opts.RunPromote())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.force, "force", opts.force, "Promote the image even if it fails the checks.")
+	cmd.Flags().BoolVar(&opts.requireSignatures, "require-signatures", opts.requireSignatures, "Require the image to have at least one signature.")
+	cmd.Flags().BoolVar(&opts.requireTests, "require-tests", opts.requireTests, fmt.Sprintf("Require the image or its tag to be annotated with %s=true.", imageapi.TestsPassedAnnotation))
+	cmd.Flags().BoolVar(&opts.checkBuilds, "check-builds", opts.checkBuilds, "Require that no build pushing to the source tag is pending or running.")
+
+	return cmd
+}
+
+// Complete completes all the required options for the promote command.
+func (o *PromoteOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string, out io.Writer) error {
+	if len(args) != 2 {
+		return kcmdutil.UsageError(cmd, "you must specify a source and a destination image stream tag")
+	}
+	o.out = out
+
+	var err error
+	o.osClient, _, err = f.Clients()
+	if err != nil {
+		return err
+	}
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	if o.srcNamespace, o.srcName, o.srcTag, err = parseStreamTag(namespace, args[0]); err != nil {
+		return fmt.Errorf("invalid SOURCE: %v", err)
+	}
+	if o.destNamespace, o.destName, o.destTag, err = parseStreamTag(namespace, args[1]); err != nil {
+		return fmt.Errorf("invalid DEST: %v", err)
+	}
+	return nil
+}
+
+// parseStreamTag splits [<namespace>/]<name>:<tag> into its parts.
+func parseStreamTag(defaultNamespace, s string) (string, string, string, error) {
+	namespace, nameAndTag, err := parseStreamName(defaultNamespace, s)
+	if err != nil {
+		return "", "", "", err
+	}
+	name, tag, ok := imageapi.SplitImageStreamTag(nameAndTag)
+	if !ok || !strings.Contains(nameAndTag, ":") {
+		return "", "", "", fmt.Errorf("%q must be of the form [<namespace>/]<stream_name>:<tag>", s)
+	}
+	return namespace, name, tag, nil
+}
+
+// Validate validates all the required options for the promote command.
+func (o PromoteOptions) Validate() error {
+	if o.osClient == nil {
+		return errors.New("a client is required")
+	}
+	if o.out == nil {
+		return errors.New("a writer interface is required")
+	}
+	if o.srcNamespace == o.destNamespace && o.srcName == o.destName && o.srcTag == o.destTag {
+		return errors.New("the source and the destination must be different image stream tags")
+	}
+	return nil
+}
+
+// RunPromote contains all the necessary functionality for the OpenShift cli promote command.
+func (o PromoteOptions) RunPromote() error {
+	source := fmt.Sprintf("%s/%s", o.srcNamespace, imageapi.JoinImageStreamTag(o.srcName, o.srcTag))
+	istag, err := o.osClient.ImageStreamTags(o.srcNamespace).Get(o.srcName, o.srcTag)
+	if err != nil {
+		return err
+	}
+	if len(istag.Image.Name) == 0 {
+		return fmt.Errorf("%s is not currently pointing to an image, cannot promote it", source)
+	}
+
+	failures, err := o.check(istag)
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		if !o.force {
+			return fmt.Errorf("%s cannot be promoted: %s", source, strings.Join(failures, "; "))
+		}
+		for _, failure := range failures {
+			fmt.Fprintf(o.out, "warning: %s\n", failure)
+		}
+	}
+
+	record := promotionRecord{
+		Tag:    o.destTag,
+		From:   source,
+		Image:  istag.Image.Name,
+		Time:   unversioned.Now(),
+		Forced: len(failures) > 0,
+	}
+	if user, err := o.osClient.Users().Get("~"); err == nil {
+		record.User = user.Name
+	} else {
+		glog.V(4).Infof("Unable to determine the current user: %v", err)
+	}
+
+	err = kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		isc := o.osClient.ImageStreams(o.destNamespace)
+		target, err := isc.Get(o.destName)
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+			target = &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{
+					Name: o.destName,
+				},
+			}
+		}
+
+		if target.Spec.Tags == nil {
+			target.Spec.Tags = make(map[string]imageapi.TagReference)
+		}
+		targetRef := target.Spec.Tags[o.destTag]
+		targetRef.From = &kapi.ObjectReference{
+			Kind:      "ImageStreamImage",
+			Namespace: o.srcNamespace,
+			Name:      imageapi.MakeImageStreamImageName(o.srcName, istag.Image.Name),
+		}
+		target.Spec.Tags[o.destTag] = targetRef
+
+		if err := addPromotionRecord(target, record); err != nil {
+			return err
+		}
+
+		if target.CreationTimestamp.IsZero() {
+			_, err = isc.Create(target)
+		} else {
+			_, err = isc.Update(target)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.out, "Promoted %s (%s) to %s/%s.\n", source, istag.Image.Name, o.destNamespace, imageapi.JoinImageStreamTag(o.destName, o.destTag))
+	return nil
+}
+
+// check returns a description of every pre-promotion check the image of istag fails.
+func (o PromoteOptions) check(istag *imageapi.ImageStreamTag) ([]string, error) {
+	failures := []string{}
+	if o.requireSignatures && len(istag.Image.Signatures) == 0 {
+		failures = append(failures, fmt.Sprintf("the image %s is not signed", istag.Image.Name))
+	}
+	if o.requireTests && istag.Annotations[imageapi.TestsPassedAnnotation] != "true" && istag.Image.Annotations[imageapi.TestsPassedAnnotation] != "true" {
+		failures = append(failures, fmt.Sprintf("the image %s is not annotated with %s=true", istag.Image.Name, imageapi.TestsPassedAnnotation))
+	}
+	if o.checkBuilds {
+		builds, err := o.osClient.Builds(o.srcNamespace).List(kapi.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, build := range builds.Items {
+			if isPendingBuildOf(&build, o.srcNamespace, o.srcName, o.srcTag) {
+				failures = append(failures, fmt.Sprintf("the build %s will push a newer image to the tag", build.Name))
+			}
+		}
+	}
+	return failures, nil
+}
+
+// isPendingBuildOf returns true if build has not completed yet and pushes to the given image stream tag.
+func isPendingBuildOf(build *buildapi.Build, namespace, name, tag string) bool {
+	switch build.Status.Phase {
+	case buildapi.BuildPhaseNew, buildapi.BuildPhasePending, buildapi.BuildPhaseRunning:
+	default:
+		return false
+	}
+	to := build.Spec.Output.To
+	if to == nil || to.Kind != "ImageStreamTag" {
+		return false
+	}
+	if len(to.Namespace) > 0 && to.Namespace != namespace {
+		return false
+	}
+	toName, toTag, ok := imageapi.SplitImageStreamTag(to.Name)
+	return ok && toName == name && toTag == tag
+}
+
+// addPromotionRecord adds record to the promotion history annotation of stream, dropping the oldest records
+// once the history is full.
+func addPromotionRecord(stream *imageapi.ImageStream, record promotionRecord) error {
+	history := []promotionRecord{}
+	if value, ok := stream.Annotations[imageapi.PromotionHistoryAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			glog.V(4).Infof("Discarding the invalid promotion history of %s/%s: %v", stream.Namespace, stream.Name, err)
+			history = []promotionRecord{}
+		}
+	}
+	history = append([]promotionRecord{record}, history...)
+	if len(history) > maxPromotionHistory {
+		history = history[:maxPromotionHistory]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	if stream.Annotations == nil {
+		stream.Annotations = make(map[string]string)
+	}
+	stream.Annotations[imageapi.PromotionHistoryAnnotation] = string(data)
+	return nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktc "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	userapi "github.com/openshift/origin/pkg/user/api"
+)
+
+func TestRunPromote(t *testing.T) {
+	testCases := map[string]struct {
+		opts          PromoteOptions
+		signed        bool
+		testsPassed   bool
+		builds        []buildapi.Build
+		expectErr     string
+		expectPromote bool
+		expectForced  bool
+	}{
+		"all checks pass": {
+			opts:          PromoteOptions{requireSignatures: true, requireTests: true, checkBuilds: true},
+			signed:        true,
+			testsPassed:   true,
+			expectPromote: true,
+		},
+		"unsigned image": {
+			opts:        PromoteOptions{requireSignatures: true, requireTests: true, checkBuilds: true},
+			testsPassed: true,
+			expectErr:   "is not signed",
+		},
+		"unsigned image without required signatures": {
+			opts:          PromoteOptions{requireTests: true, checkBuilds: true},
+			testsPassed:   true,
+			expectPromote: true,
+		},
+		"tests not passed": {
+			opts:      PromoteOptions{requireSignatures: true, requireTests: true, checkBuilds: true},
+			signed:    true,
+			expectErr: "is not annotated with " + imageapi.TestsPassedAnnotation,
+		},
+		"pending build": {
+			opts:        PromoteOptions{requireSignatures: true, requireTests: true, checkBuilds: true},
+			signed:      true,
+			testsPassed: true,
+			builds: []buildapi.Build{
+				pushingBuild("app-1", buildapi.BuildPhaseComplete, "app:latest"),
+				pushingBuild("other-1", buildapi.BuildPhaseRunning, "other:latest"),
+				pushingBuild("app-2", buildapi.BuildPhasePending, "app:latest"),
+			},
+			expectErr: "the build app-2 will push a newer image",
+		},
+		"forced": {
+			opts:          PromoteOptions{force: true, requireSignatures: true, requireTests: true, checkBuilds: true},
+			expectPromote: true,
+			expectForced:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		istag := &imageapi.ImageStreamTag{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "dev", Name: "app:latest", Annotations: map[string]string{}},
+			Image:      imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc"}},
+		}
+		if tc.signed {
+			istag.Image.Signatures = []imageapi.ImageSignature{{ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc@1"}}}
+		}
+		if tc.testsPassed {
+			istag.Annotations[imageapi.TestsPassedAnnotation] = "true"
+		}
+		target := &imageapi.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "qa", Name: "app", CreationTimestamp: unversioned.Now()},
+		}
+
+		var updated *imageapi.ImageStream
+		client := &testclient.Fake{}
+		client.AddReactor("get", "imagestreamtags", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, istag, nil
+		})
+		client.AddReactor("list", "builds", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, &buildapi.BuildList{Items: tc.builds}, nil
+		})
+		client.AddReactor("get", "users", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "alice"}}, nil
+		})
+		client.AddReactor("get", "imagestreams", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, target, nil
+		})
+		client.AddReactor("update", "imagestreams", func(action ktc.Action) (bool, runtime.Object, error) {
+			updated = action.(ktc.UpdateAction).GetObject().(*imageapi.ImageStream)
+			return true, updated, nil
+		})
+
+		out := &bytes.Buffer{}
+		opts := tc.opts
+		opts.out = out
+		opts.osClient = client
+		opts.srcNamespace, opts.srcName, opts.srcTag = "dev", "app", "latest"
+		opts.destNamespace, opts.destName, opts.destTag = "qa", "app", "latest"
+
+		err := opts.RunPromote()
+		if len(tc.expectErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("%s: expected error containing %q, got %v", name, tc.expectErr, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if !tc.expectPromote {
+			if updated != nil {
+				t.Errorf("%s: unexpected update of the target stream: %#v", name, updated)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: the target stream was not updated", name)
+			continue
+		}
+
+		from := updated.Spec.Tags["latest"].From
+		if from == nil || from.Kind != "ImageStreamImage" || from.Namespace != "dev" || from.Name != "app@sha256:abc" {
+			t.Errorf("%s: unexpected tag reference: %#v", name, from)
+		}
+		history := []promotionRecord{}
+		if err := json.Unmarshal([]byte(updated.Annotations[imageapi.PromotionHistoryAnnotation]), &history); err != nil {
+			t.Errorf("%s: invalid promotion history: %v", name, err)
+			continue
+		}
+		if len(history) != 1 || history[0].From != "dev/app:latest" || history[0].Image != "sha256:abc" || history[0].User != "alice" || history[0].Forced != tc.expectForced {
+			t.Errorf("%s: unexpected promotion history: %#v", name, history)
+		}
+		if tc.expectForced && !strings.Contains(out.String(), "warning:") {
+			t.Errorf("%s: expected warnings, got %q", name, out.String())
+		}
+	}
+}
+
+func TestAddPromotionRecord(t *testing.T) {
+	stream := &imageapi.ImageStream{}
+	for i := 0; i < maxPromotionHistory+2; i++ {
+		if err := addPromotionRecord(stream, promotionRecord{Tag: "latest", Image: string(rune('a' + i))}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	history := []promotionRecord{}
+	if err := json.Unmarshal([]byte(stream.Annotations[imageapi.PromotionHistoryAnnotation]), &history); err != nil {
+		t.Fatalf("invalid promotion history: %v", err)
+	}
+	if len(history) != maxPromotionHistory {
+		t.Fatalf("expected %d records, got %d", maxPromotionHistory, len(history))
+	}
+	if history[0].Image != "l" || history[maxPromotionHistory-1].Image != "c" {
+		t.Errorf("expected the newest records first, got %#v", history)
+	}
+
+	stream.Annotations[imageapi.PromotionHistoryAnnotation] = "invalid"
+	if err := addPromotionRecord(stream, promotionRecord{Image: "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(stream.Annotations[imageapi.PromotionHistoryAnnotation]), &history); err != nil || len(history) != 1 {
+		t.Errorf("expected an invalid history to be replaced, got %#v", history)
+	}
+}
+
+func pushingBuild(name string, phase buildapi.BuildPhase, to string) buildapi.Build {
+	build := buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "dev", Name: name},
+		Status:     buildapi.BuildStatus{Phase: phase},
+	}
+	build.Spec.Output.To = &kapi.ObjectReference{Kind: "ImageStreamTag", Name: to}
+	return build
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	templateapi "github.com/openshift/origin/pkg/template/api"
+)
+
+// ignoredDiffFields are the fields of the processed objects that are not
+// compared with the live objects.
+var ignoredDiffFields = map[string]bool{
+	"metadata.namespace":       true,
+	"metadata.resourceVersion": true,
+	"status":                   true,
+}
+
+// fieldChange is a field set by a processed template object whose value
+// differs in the live object.
+type fieldChange struct {
+	Path    string
+	Live    interface{}
+	Desired interface{}
+}
+
+// diffTemplateObject returns the fields set in desired that differ in live.
+// Fields only set in live, like the status or the defaults filled in by the
+// server, are not compared.
+func diffTemplateObject(desired, live map[string]interface{}) []fieldChange {
+	return diffFields("", desired, live, nil)
+}
+
+func diffFields(path string, desired, live interface{}, changes []fieldChange) []fieldChange {
+	if ignoredDiffFields[path] {
+		return changes
+	}
+	switch d := desired.(type) {
+	case nil:
+		return changes
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			if live == nil && len(d) == 0 {
+				return changes
+			}
+			return append(changes, fieldChange{Path: path, Live: live, Desired: desired})
+		}
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := key
+			if len(path) > 0 {
+				fieldPath = path + "." + key
+			}
+			changes = diffFields(fieldPath, d[key], l[key], changes)
+		}
+		return changes
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			if live == nil && len(d) == 0 {
+				return changes
+			}
+			return append(changes, fieldChange{Path: path, Live: live, Desired: desired})
+		}
+		for i := range d {
+			changes = diffFields(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], changes)
+		}
+		return changes
+	default:
+		// the server omits empty values
+		if live == nil && reflect.DeepEqual(desired, reflect.Zero(reflect.TypeOf(desired)).Interface()) {
+			return changes
+		}
+		if !reflect.DeepEqual(desired, live) {
+			changes = append(changes, fieldChange{Path: path, Live: live, Desired: desired})
+		}
+		return changes
+	}
+}
+
+// generatedParameters returns the names of the parameters of template whose
+// values are generated when it is processed.
+func generatedParameters(template *templateapi.Template) sets.String {
+	names := sets.NewString()
+	for _, param := range template.Parameters {
+		if len(param.Generate) > 0 && len(param.Value) == 0 {
+			names.Insert(param.Name)
+		}
+	}
+	return names
+}
+
+// generatedValues returns the values of the named parameters of a processed
+// template.
+func generatedValues(template *templateapi.Template, names sets.String) []string {
+	values := []string{}
+	for _, param := range template.Parameters {
+		if names.Has(param.Name) && len(param.Value) > 0 {
+			values = append(values, param.Value)
+		}
+	}
+	return values
+}
+
+// keepGeneratedFields replaces the fields of desired holding one of the
+// generated values with the same fields of live, so that the values generated
+// when the object was created are kept. Fields missing from live are removed,
+// except the elements of lists, which are new elements.
+func keepGeneratedFields(desired, live interface{}, generated []string) (interface{}, bool) {
+	switch d := desired.(type) {
+	case string:
+		for _, value := range generated {
+			if strings.Contains(d, value) {
+				return live, live != nil
+			}
+		}
+		return d, true
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+		for key, value := range d {
+			if kept, ok := keepGeneratedFields(value, l[key], generated); ok {
+				d[key] = kept
+			} else {
+				delete(d, key)
+			}
+		}
+		return d, true
+	case []interface{}:
+		l, _ := live.([]interface{})
+		for i, value := range d {
+			if i >= len(l) {
+				break
+			}
+			if kept, ok := keepGeneratedFields(value, l[i], generated); ok {
+				d[i] = kept
+			}
+		}
+		return d, true
+	default:
+		return desired, true
+	}
+}
+
+// formatDiffValue formats a field value of a fieldChange for display.
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// runProcessDiff compares the processed template objects with the objects
+// present in namespace and prints the changes processing the template would
+// make. When apply is set, missing objects are created and changed objects
+// are patched with the fields set by the template. The fields of existing
+// objects holding one of the generated parameter values keep their values.
+func runProcessDiff(f resource.ClientMapper, mapper meta.RESTMapper, typer runtime.ObjectTyper, out io.Writer, namespace string, objects []runtime.Object, generated []string, apply bool) error {
+	errs := []error{}
+	for _, obj := range objects {
+		desired, err := templateObjectToMap(obj, mapper, typer)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := diffAndApplyObject(f, mapper, out, namespace, desired, generated, apply); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func diffAndApplyObject(f resource.ClientMapper, mapper meta.RESTMapper, out io.Writer, namespace string, desired map[string]interface{}, generated []string, apply bool) error {
+	apiVersion, _ := desired["apiVersion"].(string)
+	kind, _ := desired["kind"].(string)
+	metadata, _ := desired["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if len(kind) == 0 || len(name) == 0 {
+		return fmt.Errorf("unable to compare an object without a kind and a name: %s", formatDiffValue(desired))
+	}
+	gv, err := unversioned.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return err
+	}
+	mapping, err := mapper.RESTMapping(unversioned.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+	if err != nil {
+		return err
+	}
+	client, err := f.ClientForMapping(mapping)
+	if err != nil {
+		return err
+	}
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	delete(metadata, "namespace")
+
+	data, err := client.Get().NamespaceIfScoped(namespace, namespaced).Resource(mapping.Resource).Name(name).Do().Raw()
+	if errors.IsNotFound(err) {
+		if !apply {
+			kcmdutil.PrintSuccess(mapper, false, out, mapping.Resource, name, "to be created")
+			return nil
+		}
+		body, err := json.Marshal(desired)
+		if err != nil {
+			return err
+		}
+		if err := client.Post().NamespaceIfScoped(namespace, namespaced).Resource(mapping.Resource).Body(body).Do().Error(); err != nil {
+			return fmt.Errorf("unable to create %s %q: %v", mapping.Resource, name, err)
+		}
+		kcmdutil.PrintSuccess(mapper, false, out, mapping.Resource, name, "created")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	live := map[string]interface{}{}
+	if err := json.Unmarshal(data, &live); err != nil {
+		return err
+	}
+	keepGeneratedFields(desired, live, generated)
+
+	changes := diffTemplateObject(desired, live)
+	if len(changes) == 0 {
+		kcmdutil.PrintSuccess(mapper, false, out, mapping.Resource, name, "unchanged")
+		return nil
+	}
+	operation := "to be patched:"
+	if apply {
+		delete(desired, "status")
+		patch, err := json.Marshal(desired)
+		if err != nil {
+			return err
+		}
+		if err := client.Patch(kapi.StrategicMergePatchType).NamespaceIfScoped(namespace, namespaced).Resource(mapping.Resource).Name(name).Body(patch).Do().Error(); err != nil {
+			return fmt.Errorf("unable to patch %s %q: %v", mapping.Resource, name, err)
+		}
+		operation = "patched:"
+	}
+	kcmdutil.PrintSuccess(mapper, false, out, mapping.Resource, name, operation)
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s: %s -> %s\n", change.Path, formatDiffValue(change.Live), formatDiffValue(change.Desired))
+	}
+	return nil
+}
+
+// templateObjectToMap returns the serialized form of a processed template
+// object.
+func templateObjectToMap(obj runtime.Object, mapper meta.RESTMapper, typer runtime.ObjectTyper) (map[string]interface{}, error) {
+	var data []byte
+	switch t := obj.(type) {
+	case *runtime.Unstructured:
+		return t.Object, nil
+	case *runtime.Unknown:
+		data = t.Raw
+	default:
+		gvk, err := typer.ObjectKind(obj)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind())
+		if err != nil {
+			return nil, err
+		}
+		data, err = runtime.Encode(kapi.Codecs.LegacyCodec(mapping.GroupVersionKind.GroupVersion()), obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffTemplateObject(t *testing.T) {
+	live := `{
+		"kind": "DeploymentConfig",
+		"apiVersion": "v1",
+		"metadata": {"name": "frontend", "namespace": "test", "resourceVersion": "10", "labels": {"app": "frontend"}},
+		"spec": {
+			"replicas": 1,
+			"template": {"spec": {"containers": [{"name": "web", "image": "web:1", "imagePullPolicy": "Always"}]}}
+		},
+		"status": {"latestVersion": 3}
+	}`
+	tests := map[string]struct {
+		desired  string
+		expected []fieldChange
+	}{
+		"unchanged": {
+			desired: `{"kind": "DeploymentConfig", "apiVersion": "v1", "metadata": {"name": "frontend", "namespace": "", "labels": {"app": "frontend"}},
+				"spec": {"replicas": 1, "template": {"spec": {"containers": [{"name": "web", "image": "web:1"}]}}}, "status": {}}`,
+		},
+		"empty values omitted by the server": {
+			desired: `{"kind": "DeploymentConfig", "metadata": {"name": "frontend", "annotations": {}}, "spec": {"paused": false, "triggers": []}}`,
+		},
+		"changed fields": {
+			desired: `{"kind": "DeploymentConfig", "metadata": {"name": "frontend", "labels": {"app": "frontend", "tier": "web"}},
+				"spec": {"replicas": 2, "template": {"spec": {"containers": [{"name": "web", "image": "web:2"}]}}}}`,
+			expected: []fieldChange{
+				{Path: "metadata.labels.tier", Desired: "web"},
+				{Path: "spec.replicas", Live: float64(1), Desired: float64(2)},
+				{Path: "spec.template.spec.containers[0].image", Live: "web:1", Desired: "web:2"},
+			},
+		},
+		"list length changed": {
+			desired: `{"kind": "DeploymentConfig", "metadata": {"name": "frontend"}, "spec": {"template": {"spec": {"containers": []}}}}`,
+			expected: []fieldChange{
+				{Path: "spec.template.spec.containers", Live: []interface{}{map[string]interface{}{"name": "web", "image": "web:1", "imagePullPolicy": "Always"}}, Desired: []interface{}{}},
+			},
+		},
+	}
+
+	liveObj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(live), &liveObj); err != nil {
+		t.Fatal(err)
+	}
+	for name, test := range tests {
+		desired := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(test.desired), &desired); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		changes := diffTemplateObject(desired, liveObj)
+		if len(changes) == 0 && len(test.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(changes, test.expected) {
+			t.Errorf("%s: expected changes %#v, got %#v", name, test.expected, changes)
+		}
+	}
+}
+
+func TestKeepGeneratedFields(t *testing.T) {
+	live := `{
+		"kind": "DeploymentConfig",
+		"metadata": {"name": "frontend"},
+		"spec": {"template": {"spec": {"containers": [{"name": "web", "args": ["--password=old"], "env": [{"name": "PASSWORD", "value": "old"}]}]}}}
+	}`
+	desired := `{
+		"kind": "DeploymentConfig",
+		"metadata": {"name": "frontend", "annotations": {"token": "generated-token"}},
+		"spec": {"template": {"spec": {"containers": [{"name": "web", "image": "web:2", "args": ["--password=generated-password", "--token=generated-token"], "env": [{"name": "PASSWORD", "value": "generated-password"}]}]}}}
+	}`
+	expected := `{
+		"kind": "DeploymentConfig",
+		"metadata": {"name": "frontend", "annotations": {}},
+		"spec": {"template": {"spec": {"containers": [{"name": "web", "image": "web:2", "args": ["--password=old", "--token=generated-token"], "env": [{"name": "PASSWORD", "value": "old"}]}]}}}
+	}`
+
+	objects := []map[string]interface{}{}
+	for _, data := range []string{live, desired, expected} {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(data), &obj); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, obj)
+	}
+	keepGeneratedFields(objects[1], objects[0], []string{"generated-password", "generated-token"})
+	if !reflect.DeepEqual(objects[1], objects[2]) {
+		t.Errorf("expected %v, got %v", objects[2], objects[1])
+	}
+	if changes := diffTemplateObject(objects[1], objects[0]); len(changes) != 2 {
+		t.Errorf("expected only the image and the new argument to change, got %#v", changes)
+	}
+}
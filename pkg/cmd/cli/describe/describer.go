@@ -48,6 +48,7 @@ func describerMap(c *client.Client, kclient kclient.Interface, host string) map[
 		routeapi.Kind("Route"):                        &RouteDescriber{c, kclient},
 		projectapi.Kind("Project"):                    &ProjectDescriber{c, kclient},
 		templateapi.Kind("Template"):                  &TemplateDescriber{c, meta.NewAccessor(), kapi.Scheme, nil},
+		templateapi.Kind("TemplateInstance"):          &TemplateInstanceDescriber{c},
 		authorizationapi.Kind("Policy"):               &PolicyDescriber{c},
 		authorizationapi.Kind("PolicyBinding"):        &PolicyBindingDescriber{c},
 		authorizationapi.Kind("RoleBinding"):          &RoleBindingDescriber{c},
@@ -57,6 +58,8 @@ func describerMap(c *client.Client, kclient kclient.Interface, host string) map[
 		authorizationapi.Kind("ClusterRoleBinding"):   &ClusterRoleBindingDescriber{c},
 		authorizationapi.Kind("ClusterRole"):          &ClusterRoleDescriber{c},
 		oauthapi.Kind("OAuthAccessToken"):             &OAuthAccessTokenDescriber{c},
+		oauthapi.Kind("PersonalAccessToken"):          &PersonalAccessTokenDescriber{c},
+		oauthapi.Kind("UserOAuthAccessToken"):         &UserOAuthAccessTokenDescriber{c},
 		userapi.Kind("User"):                          &UserDescriber{c},
 		userapi.Kind("Group"):                         &GroupDescriber{c.Groups()},
 		userapi.Kind("UserIdentityMapping"):           &UserIdentityMappingDescriber{c},
@@ -431,6 +434,9 @@ func (d *BuildConfigDescriber) Describe(namespace, name string, settings kctl.De
 		}
 		describeCommonSpec(buildConfig.Spec.CommonSpec, out)
 		formatString(out, "\nBuild Run Policy", string(buildConfig.Spec.RunPolicy))
+		if retryPolicy := buildConfig.Spec.RetryPolicy; retryPolicy != nil {
+			formatString(out, "Build Retry Policy", describeBuildRetryPolicy(retryPolicy))
+		}
 		d.DescribeTriggers(buildConfig, out)
 		if len(buildList.Items) == 0 {
 			return nil
@@ -455,6 +461,17 @@ func (d *BuildConfigDescriber) Describe(namespace, name string, settings kctl.De
 	})
 }
 
+// describeBuildRetryPolicy returns a one line summary of a build retry policy.
+func describeBuildRetryPolicy(policy *buildapi.BuildRetryPolicy) string {
+	desc := fmt.Sprintf("up to %d retries after %v", policy.Limit, time.Duration(policy.InitialDelaySeconds)*time.Second)
+	if policy.MaxDelaySeconds > 0 {
+		desc += fmt.Sprintf(", doubling up to %v", time.Duration(policy.MaxDelaySeconds)*time.Second)
+	} else {
+		desc += ", doubling"
+	}
+	return desc
+}
+
 // OAuthAccessTokenDescriber generates information about an OAuth Acess Token (OAuth)
 type OAuthAccessTokenDescriber struct {
 	client.Interface
@@ -482,6 +499,51 @@ func (d *OAuthAccessTokenDescriber) Describe(namespace, name string, settings kc
 	})
 }
 
+// PersonalAccessTokenDescriber generates information about a personal access token
+type PersonalAccessTokenDescriber struct {
+	client.Interface
+}
+
+func (d *PersonalAccessTokenDescriber) Describe(namespace, name string, settings kctl.DescriberSettings) (string, error) {
+	token, err := d.PersonalAccessTokens().Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	timeExpired := token.CreationTimestamp.Time.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, token.ObjectMeta)
+		formatString(out, "Scopes", token.Scopes)
+		formatString(out, "Expires In", formatToHumanDuration(timeExpired.Sub(time.Now())))
+
+		return nil
+	})
+}
+
+// UserOAuthAccessTokenDescriber generates information about an OAuth access token of the current user
+type UserOAuthAccessTokenDescriber struct {
+	client.Interface
+}
+
+func (d *UserOAuthAccessTokenDescriber) Describe(namespace, name string, settings kctl.DescriberSettings) (string, error) {
+	token, err := d.UserOAuthAccessTokens().Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	timeExpired := token.CreationTimestamp.Time.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, token.ObjectMeta)
+		formatString(out, "Scopes", token.Scopes)
+		formatString(out, "Expires In", formatToHumanDuration(timeExpired.Sub(time.Now())))
+		formatString(out, "Client Name", token.ClientName)
+
+		return nil
+	})
+}
+
 // ImageDescriber generates information about a Image
 type ImageDescriber struct {
 	client.Interface
@@ -534,10 +596,53 @@ func describeImage(image *imageapi.Image, imageName string) (string, error) {
 		formatString(out, "Author", image.DockerImageMetadata.Author)
 		formatString(out, "Arch", image.DockerImageMetadata.Architecture)
 		describeDockerImage(out, image.DockerImageMetadata.Config)
+		describeImageVulnerabilityScan(out, image.VulnerabilityScan)
 		return nil
 	})
 }
 
+func describeImageVulnerabilityScan(out *tabwriter.Writer, scan *imageapi.ImageVulnerabilityScan) {
+	if scan == nil {
+		formatString(out, "Vulnerability Scan", "")
+		return
+	}
+	scanner := scan.Scanner
+	if len(scanner) == 0 {
+		scanner = "<unknown scanner>"
+	}
+	formatString(out, "Vulnerability Scan", fmt.Sprintf("%s, %s ago", scanner, formatRelativeTime(scan.Completed.Time)))
+	if len(scan.Vulnerabilities) == 0 {
+		formatString(out, "Vulnerabilities", "")
+		return
+	}
+
+	vulnerabilities := make([]imageapi.ImageVulnerability, len(scan.Vulnerabilities))
+	copy(vulnerabilities, scan.Vulnerabilities)
+	sort.Sort(vulnerabilitiesBySeverity(vulnerabilities))
+
+	formatString(out, "Vulnerabilities", len(vulnerabilities))
+	fmt.Fprintf(out, "  ID\tSeverity\tPackage\tFixed In\tLink\n")
+	for _, v := range vulnerabilities {
+		pkg := v.Package
+		if len(v.Version) > 0 {
+			pkg = fmt.Sprintf("%s %s", pkg, v.Version)
+		}
+		fmt.Fprintf(out, "  %s\t%s\t%s\t%s\t%s\n", v.ID, v.Severity, toString(pkg), toString(v.FixedVersion), toString(v.Link))
+	}
+}
+
+// vulnerabilitiesBySeverity sorts vulnerabilities from the most to the least severe, and by ID.
+type vulnerabilitiesBySeverity []imageapi.ImageVulnerability
+
+func (v vulnerabilitiesBySeverity) Len() int      { return len(v) }
+func (v vulnerabilitiesBySeverity) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v vulnerabilitiesBySeverity) Less(i, j int) bool {
+	if v[i].Severity != v[j].Severity {
+		return imageapi.VulnerabilitySeverityExceeds(v[i].Severity, v[j].Severity)
+	}
+	return v[i].ID < v[j].ID
+}
+
 func describeDockerImage(out *tabwriter.Writer, image *imageapi.DockerConfig) {
 	if image == nil {
 		return
@@ -636,6 +741,18 @@ func (d *ImageStreamDescriber) Describe(namespace, name string, settings kctl.De
 	return tabbedString(func(out *tabwriter.Writer) error {
 		formatMeta(out, imageStream.ObjectMeta)
 		formatString(out, "Docker Pull Spec", imageStream.Status.DockerImageRepository)
+		if policy := imageStream.Spec.RetentionPolicy; policy != nil {
+			retention := []string{}
+			if policy.MaxTagRevisions > 0 {
+				retention = append(retention, fmt.Sprintf("%d images per tag", policy.MaxTagRevisions))
+			}
+			if policy.MaxTagAgeSeconds > 0 {
+				retention = append(retention, fmt.Sprintf("replaced images for %s", time.Duration(policy.MaxTagAgeSeconds)*time.Second))
+			}
+			if len(retention) > 0 {
+				formatString(out, "Retention", strings.Join(retention, ", "))
+			}
+		}
 		formatImageStreamTags(out, imageStream)
 		return nil
 	})
@@ -953,6 +1070,36 @@ func (d *TemplateDescriber) DescribeTemplate(template *templateapi.Template) (st
 	})
 }
 
+// TemplateInstanceDescriber generates information about a template instance
+type TemplateInstanceDescriber struct {
+	client.Interface
+}
+
+// Describe returns the description of a template instance
+func (d *TemplateInstanceDescriber) Describe(namespace, name string, settings kctl.DescriberSettings) (string, error) {
+	templateInstance, err := d.TemplateInstances(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, templateInstance.ObjectMeta)
+		formatString(out, "Template", templateInstance.Spec.Template.Name)
+		out.Write([]byte("\n"))
+		formatString(out, "Parameters", " ")
+		indent := "    "
+		for _, p := range templateInstance.Spec.Template.Parameters {
+			formatString(out, indent+p.Name, p.Value)
+		}
+		out.Write([]byte("\n"))
+		formatString(out, "Objects", " ")
+		for _, ref := range templateInstance.Status.Objects {
+			fmt.Fprintf(out, "%s%s\t%s\n", indent, ref.Kind, ref.Name)
+		}
+		return nil
+	})
+}
+
 // IdentityDescriber generates information about a user
 type IdentityDescriber struct {
 	client.Interface
@@ -71,6 +71,7 @@ var DescriberCoverageExceptions = []reflect.Type{
 	reflect.TypeOf(&securityapi.PodSecurityPolicySubjectReview{}),
 	reflect.TypeOf(&securityapi.PodSecurityPolicySelfSubjectReview{}),
 	reflect.TypeOf(&securityapi.PodSecurityPolicyReview{}),
+	reflect.TypeOf(&imageapi.ImageSignature{}),
 }
 
 // MissingDescriberCoverageExceptions is the list of types that were missing describer methods when I started
@@ -366,6 +367,58 @@ func TestDescribePostCommitHook(t *testing.T) {
 	}
 }
 
+func TestDescribeImageVulnerabilityScan(t *testing.T) {
+	completed := unversioned.NewTime(time.Now().Add(-2 * time.Hour))
+	tests := []struct {
+		scan *imageapi.ImageVulnerabilityScan
+		want []string
+	}{
+		{
+			want: []string{"Vulnerability Scan: <none>"},
+		},
+		{
+			scan: &imageapi.ImageVulnerabilityScan{Scanner: "clair", Completed: completed},
+			want: []string{"Vulnerability Scan: clair, 2 hours ago", "Vulnerabilities: <none>"},
+		},
+		{
+			scan: &imageapi.ImageVulnerabilityScan{
+				Scanner:   "clair",
+				Completed: completed,
+				Vulnerabilities: []imageapi.ImageVulnerability{
+					{ID: "CVE-2016-0002", Severity: imageapi.VulnerabilitySeverityLow},
+					{ID: "CVE-2016-0001", Severity: imageapi.VulnerabilitySeverityCritical, Package: "openssl", Version: "1.0.1e", FixedVersion: "1.0.1f"},
+				},
+			},
+			want: []string{
+				"Vulnerabilities: 2",
+				"CVE-2016-0001 Critical openssl 1.0.1e 1.0.1f <none>",
+				"CVE-2016-0002 Low <none> <none> <none>",
+			},
+		},
+	}
+	for i, tt := range tests {
+		var b bytes.Buffer
+		out := tabwriter.NewWriter(&b, 0, 8, 1, '\t', 0)
+		describeImageVulnerabilityScan(out, tt.scan)
+		if err := out.Flush(); err != nil {
+			t.Fatalf("%d: flush error: %v", i, err)
+		}
+		lines := []string{}
+		for _, line := range strings.Split(b.String(), "\n") {
+			lines = append(lines, strings.Join(strings.Fields(line), " "))
+		}
+		got := strings.Join(lines, "\n")
+		last := -1
+		for _, want := range tt.want {
+			index := strings.Index(got, want)
+			if index < 0 || index < last {
+				t.Errorf("%d: expected %q in order in output:\n%s", i, want, got)
+			}
+			last = index
+		}
+	}
+}
+
 func TestDescribeBuildSpec(t *testing.T) {
 	tests := []struct {
 		spec buildapi.BuildSpec
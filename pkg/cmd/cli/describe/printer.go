@@ -20,6 +20,7 @@ import (
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/useroauthaccesstoken"
 	projectapi "github.com/openshift/origin/pkg/project/api"
 	quotaapi "github.com/openshift/origin/pkg/quota/api"
 	routeapi "github.com/openshift/origin/pkg/route/api"
@@ -39,6 +40,7 @@ var (
 	routeColumns            = []string{"NAME", "HOST/PORT", "PATH", "SERVICE", "TERMINATION", "LABELS"}
 	deploymentConfigColumns = []string{"NAME", "REVISION", "DESIRED", "CURRENT", "TRIGGERED BY"}
 	templateColumns         = []string{"NAME", "DESCRIPTION", "PARAMETERS", "OBJECTS"}
+	templateInstanceColumns = []string{"NAME", "TEMPLATE", "OBJECTS"}
 	policyColumns           = []string{"NAME", "ROLES", "LAST MODIFIED"}
 	policyBindingColumns    = []string{"NAME", "ROLE BINDINGS", "LAST MODIFIED"}
 	roleBindingColumns      = []string{"NAME", "ROLE", "USERS", "GROUPS", "SERVICE ACCOUNTS", "SUBJECTS"}
@@ -48,6 +50,8 @@ var (
 	oauthClientAuthorizationColumns = []string{"NAME", "USER NAME", "CLIENT NAME", "SCOPES"}
 	oauthAccessTokenColumns         = []string{"NAME", "USER NAME", "CLIENT NAME", "CREATED", "EXPIRES", "REDIRECT URI", "SCOPES"}
 	oauthAuthorizeTokenColumns      = []string{"NAME", "USER NAME", "CLIENT NAME", "CREATED", "EXPIRES", "REDIRECT URI", "SCOPES"}
+	personalAccessTokenColumns      = []string{"NAME", "CREATED", "EXPIRES", "SCOPES"}
+	userOAuthAccessTokenColumns     = []string{"ID", "CLIENT NAME", "CREATED", "EXPIRES", "SCOPES"}
 
 	userColumns                = []string{"NAME", "UID", "FULL NAME", "IDENTITIES"}
 	identityColumns            = []string{"NAME", "IDP NAME", "IDP USER NAME", "USER NAME", "USER UID"}
@@ -87,6 +91,8 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(deploymentConfigColumns, printDeploymentConfigList)
 	p.Handler(templateColumns, printTemplate)
 	p.Handler(templateColumns, printTemplateList)
+	p.Handler(templateInstanceColumns, printTemplateInstance)
+	p.Handler(templateInstanceColumns, printTemplateInstanceList)
 
 	p.Handler(policyColumns, printPolicy)
 	p.Handler(policyColumns, printPolicyList)
@@ -114,6 +120,10 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(oauthAccessTokenColumns, printOAuthAccessTokenList)
 	p.Handler(oauthAuthorizeTokenColumns, printOAuthAuthorizeToken)
 	p.Handler(oauthAuthorizeTokenColumns, printOAuthAuthorizeTokenList)
+	p.Handler(personalAccessTokenColumns, printPersonalAccessToken)
+	p.Handler(personalAccessTokenColumns, printPersonalAccessTokenList)
+	p.Handler(userOAuthAccessTokenColumns, printUserOAuthAccessToken)
+	p.Handler(userOAuthAccessTokenColumns, printUserOAuthAccessTokenList)
 
 	p.Handler(userColumns, printUser)
 	p.Handler(userColumns, printUserList)
@@ -210,6 +220,30 @@ func printTemplateList(list *templateapi.TemplateList, w io.Writer, opts kctl.Pr
 	return nil
 }
 
+func printTemplateInstance(t *templateapi.TemplateInstance, w io.Writer, opts kctl.PrintOptions) error {
+	if opts.WithNamespace {
+		if _, err := fmt.Fprintf(w, "%s\t", t.Namespace); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%d", t.Name, t.Spec.Template.Name, len(t.Status.Objects)); err != nil {
+		return err
+	}
+	if err := appendItemLabels(t.Labels, w, opts.ColumnLabels, opts.ShowLabels); err != nil {
+		return err
+	}
+	return nil
+}
+
+func printTemplateInstanceList(list *templateapi.TemplateInstanceList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, t := range list.Items {
+		if err := printTemplateInstance(&t, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printBuild(build *buildapi.Build, w io.Writer, opts kctl.PrintOptions) error {
 	if opts.WithNamespace {
 		if _, err := fmt.Fprintf(w, "%s\t", build.Namespace); err != nil {
@@ -819,6 +853,38 @@ func printOAuthAuthorizeTokenList(list *oauthapi.OAuthAuthorizeTokenList, w io.W
 	return nil
 }
 
+func printPersonalAccessToken(token *oauthapi.PersonalAccessToken, w io.Writer, opts kctl.PrintOptions) error {
+	created := token.CreationTimestamp
+	expires := created.Add(time.Duration(token.ExpiresIn) * time.Second)
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", token.Name, created, expires, strings.Join(token.Scopes, ","))
+	return err
+}
+
+func printPersonalAccessTokenList(list *oauthapi.PersonalAccessTokenList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, item := range list.Items {
+		if err := printPersonalAccessToken(&item, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printUserOAuthAccessToken(token *oauthapi.UserOAuthAccessToken, w io.Writer, opts kctl.PrintOptions) error {
+	created := token.CreationTimestamp
+	expires := created.Add(time.Duration(token.ExpiresIn) * time.Second)
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", useroauthaccesstoken.TokenID(token.Name), token.ClientName, created, expires, strings.Join(token.Scopes, ","))
+	return err
+}
+
+func printUserOAuthAccessTokenList(list *oauthapi.UserOAuthAccessTokenList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, item := range list.Items {
+		if err := printUserOAuthAccessToken(&item, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printUser(user *userapi.User, w io.Writer, opts kctl.PrintOptions) error {
 	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", user.Name, user.UID, user.FullName, strings.Join(user.Identities, ", "))
 	return err
@@ -0,0 +1,117 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+const (
+	CreateTokenRecommendedName = "create"
+
+	createTokenLong = `
+Create a personal access token.
+
+The token is printed once and can not be retrieved later. It is limited to the given scopes,
+which are required, and expires after the given time. A token created while logged in with a
+scoped token can not have more scopes than the current token.
+
+Scopes can be:
+
+  user:info          read-only access to your user information
+  user:check-access  access to check whether you can perform an action
+  user:list-projects list the projects you can see
+  role:ROLE:PROJECT  the rules of the cluster role ROLE in PROJECT; PROJECT may be * for all
+                     projects, and the scope can be suffixed with :! to allow escalating
+                     resources such as secrets`
+
+	createTokenExample = `  # Create a token for a CI job allowed to edit the project 'myproject', valid for 30 days
+  %[1]s jenkins --scopes=role:edit:myproject --expires-in=720h
+
+  # Create a token able to read your user information, valid for the default 90 days
+  %[1]s whoami --scopes=user:info`
+
+	defaultExpiresIn = 90 * 24 * time.Hour
+)
+
+// CreateTokenOptions holds the options of the create token command
+type CreateTokenOptions struct {
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration
+
+	Client client.PersonalAccessTokenInterface
+	Out    io.Writer
+}
+
+// NewCmdCreateToken implements the create token command
+func NewCmdCreateToken(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	o := &CreateTokenOptions{Out: out, ExpiresIn: defaultExpiresIn}
+	cmd := &cobra.Command{
+		Use:     name + " NAME --scopes=SCOPE",
+		Short:   "Create a personal access token",
+		Long:    createTokenLong,
+		Example: fmt.Sprintf(createTokenExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(o.Complete(f, cmd, args))
+			kcmdutil.CheckErr(o.Validate())
+			kcmdutil.CheckErr(o.Run())
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.Scopes, "scopes", o.Scopes, "The scopes the token is limited to, separated by commas.")
+	cmd.Flags().DurationVar(&o.ExpiresIn, "expires-in", o.ExpiresIn, "The time after which the token expires.")
+	return cmd
+}
+
+func (o *CreateTokenOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return kcmdutil.UsageError(cmd, "exactly one token name is required")
+	}
+	o.Name = args[0]
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.PersonalAccessTokens()
+	return nil
+}
+
+func (o *CreateTokenOptions) Validate() error {
+	if len(o.Scopes) == 0 {
+		return errors.New("at least one scope is required, see --scopes")
+	}
+	if o.ExpiresIn < time.Second {
+		return errors.New("--expires-in must be at least one second")
+	}
+	return nil
+}
+
+func (o *CreateTokenOptions) Run() error {
+	token, err := o.Client.Create(&oauthapi.PersonalAccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: o.Name},
+		Scopes:     o.Scopes,
+		ExpiresIn:  int64(o.ExpiresIn / time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(o.Out, token.Token)
+	if util.IsTerminalWriter(o.Out) {
+		// pretty-print for a TTY
+		fmt.Fprintln(o.Out)
+	}
+	return nil
+}
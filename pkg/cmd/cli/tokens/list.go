@@ -0,0 +1,87 @@
+package tokens
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+const (
+	ListTokensRecommendedName = "list"
+
+	listTokensLong = `
+List your access tokens.
+
+This includes the tokens of your sessions and your personal access tokens. The tokens themselves
+are not shown, only an ID that identifies them, the name of personal access tokens, the client that
+requested the token, and its scopes and expiry. Tokens without scopes have full access.
+
+This command can not be used while logged in with a scoped token.`
+)
+
+// ListTokensOptions holds the options of the list tokens command
+type ListTokensOptions struct {
+	Client client.UserOAuthAccessTokenInterface
+	Out    io.Writer
+}
+
+// NewCmdListTokens implements the list tokens command
+func NewCmdListTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	o := &ListTokensOptions{Out: out}
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: "List your access tokens",
+		Long:  listTokensLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(o.Complete(f, cmd, args))
+			kcmdutil.CheckErr(o.Run())
+		},
+	}
+	return cmd
+}
+
+func (o *ListTokensOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return kcmdutil.UsageError(cmd, "no arguments are allowed")
+	}
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.UserOAuthAccessTokens()
+	return nil
+}
+
+func (o *ListTokensOptions) Run() error {
+	tokens, err := listUserTokens(o.Client)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Fprintln(o.Out, "You have no access tokens.")
+		return nil
+	}
+	return PrintTokens(o.Out, tokens)
+}
+
+// listUserTokens returns the access tokens of the current user
+func listUserTokens(c client.UserOAuthAccessTokenInterface) ([]oauthapi.OAuthAccessToken, error) {
+	list, err := c.List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]oauthapi.OAuthAccessToken, 0, len(list.Items))
+	for _, token := range list.Items {
+		tokens = append(tokens, oauthapi.OAuthAccessToken(token))
+	}
+	return tokens, nil
+}
@@ -0,0 +1,89 @@
+package tokens
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	RevokeTokenRecommendedName = "revoke"
+
+	revokeTokenLong = `
+Revoke access tokens.
+
+Tokens are identified by the ID shown by the list command, or a unique prefix of it, or by the name
+of a personal access token. Revoked tokens can no longer be used to access the API, which ends the
+session they belong to.`
+
+	revokeTokenExample = `  # Revoke the personal access token 'jenkins'
+  %[1]s jenkins
+
+  # Revoke the token with the ID 'Xk3pQ9aZ2m'
+  %[1]s Xk3pQ9aZ2m
+
+  # Revoke all your tokens, including the one in use, ending all your sessions
+  %[1]s --all`
+)
+
+// RevokeTokenOptions holds the options of the revoke token command
+type RevokeTokenOptions struct {
+	Names []string
+	All   bool
+
+	Client client.UserOAuthAccessTokenInterface
+	Out    io.Writer
+}
+
+// NewCmdRevokeToken implements the revoke token command
+func NewCmdRevokeToken(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	o := &RevokeTokenOptions{Out: out}
+	cmd := &cobra.Command{
+		Use:     name + " ID|NAME [ID|NAME ...]",
+		Short:   "Revoke access tokens",
+		Long:    revokeTokenLong,
+		Example: fmt.Sprintf(revokeTokenExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(o.Complete(f, cmd, args))
+			kcmdutil.CheckErr(o.Run())
+		},
+	}
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "Revoke all your tokens.")
+	return cmd
+}
+
+func (o *RevokeTokenOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string) error {
+	switch {
+	case o.All && len(args) > 0:
+		return kcmdutil.UsageError(cmd, "token IDs can not be given with --all")
+	case !o.All && len(args) == 0:
+		return kcmdutil.UsageError(cmd, "at least one token ID or name is required")
+	}
+	o.Names = args
+
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Client = osClient.UserOAuthAccessTokens()
+	return nil
+}
+
+func (o *RevokeTokenOptions) Run() error {
+	tokens, err := listUserTokens(o.Client)
+	if err != nil {
+		return err
+	}
+	if !o.All {
+		if tokens, err = FindTokens(tokens, o.Names); err != nil {
+			return err
+		}
+	}
+	return RevokeTokens(o.Out, tokens, o.Client.Delete)
+}
@@ -0,0 +1,132 @@
+package tokens
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/personalaccesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/useroauthaccesstoken"
+)
+
+const TokensRecommendedName = "tokens"
+
+const (
+	tokensShort = `Manage your access tokens.`
+
+	tokensLong = `
+Manage your access tokens.
+
+Every login, in the web console or from the command line, creates an access token. These tokens
+can be listed, and revoked to end a session on a lost or shared machine.
+
+Personal access tokens are named API tokens you create for yourself, for instance to give a
+continuous integration job access to a project. Each token is limited to the scopes you choose
+and expires after a fixed time, so it can be handed to a script without exposing your session
+token. A token can be revoked at any time.`
+
+	// TokenIDLength is the number of leading characters of a token shown as its ID. The full name of an access
+	// token is the token itself, so it is never printed.
+	TokenIDLength = useroauthaccesstoken.TokenIDLength
+)
+
+func NewCmdTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: tokensShort,
+		Long:  tokensLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdCreateToken(CreateTokenRecommendedName, fullName+" "+CreateTokenRecommendedName, f, out))
+	cmds.AddCommand(NewCmdListTokens(ListTokensRecommendedName, fullName+" "+ListTokensRecommendedName, f, out))
+	cmds.AddCommand(NewCmdRevokeToken(RevokeTokenRecommendedName, fullName+" "+RevokeTokenRecommendedName, f, out))
+
+	return cmds
+}
+
+// TokenID returns the ID an access token is shown and revoked with
+func TokenID(token *oauthapi.OAuthAccessToken) string {
+	return useroauthaccesstoken.TokenID(token.Name)
+}
+
+type byCreationTimestamp []oauthapi.OAuthAccessToken
+
+func (t byCreationTimestamp) Len() int      { return len(t) }
+func (t byCreationTimestamp) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t byCreationTimestamp) Less(i, j int) bool {
+	return t[i].CreationTimestamp.Before(t[j].CreationTimestamp)
+}
+
+// PrintTokens prints the access tokens, oldest first, without the tokens themselves. The name column holds the
+// name of personal access tokens.
+func PrintTokens(out io.Writer, tokens []oauthapi.OAuthAccessToken) error {
+	sort.Sort(byCreationTimestamp(tokens))
+
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCLIENT\tSCOPES\tCREATED\tEXPIRES")
+	for i := range tokens {
+		token := &tokens[i]
+		created := token.CreationTimestamp.Time
+		expires := created.Add(time.Duration(token.ExpiresIn) * time.Second)
+		scopes := strings.Join(token.Scopes, ",")
+		if len(scopes) == 0 {
+			scopes = "<full>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", TokenID(token), token.Labels[personalaccesstoken.NameLabel], token.ClientName, scopes, created.Format(time.RFC3339), expires.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+// FindTokens returns the tokens identified by each argument, either the name of a personal access token or a
+// prefix of a token ID. Each argument must identify exactly one token.
+func FindTokens(tokens []oauthapi.OAuthAccessToken, args []string) ([]oauthapi.OAuthAccessToken, error) {
+	found := []oauthapi.OAuthAccessToken{}
+	for _, arg := range args {
+		matches := []oauthapi.OAuthAccessToken{}
+		for _, token := range tokens {
+			if token.Labels[personalaccesstoken.NameLabel] == arg {
+				matches = append(matches, token)
+			}
+		}
+		if len(matches) == 0 {
+			for _, token := range tokens {
+				if strings.HasPrefix(token.Name, arg) {
+					matches = append(matches, token)
+				}
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no token matches %q", arg)
+		case 1:
+			found = append(found, matches[0])
+		default:
+			return nil, fmt.Errorf("%d tokens match %q, use a longer ID", len(matches), arg)
+		}
+	}
+	return found, nil
+}
+
+// RevokeTokens deletes the tokens and reports each revoked token
+func RevokeTokens(out io.Writer, tokens []oauthapi.OAuthAccessToken, deleteFn func(name string) error) error {
+	for i := range tokens {
+		if err := deleteFn(tokens[i].Name); err != nil {
+			return err
+		}
+		if name := tokens[i].Labels[personalaccesstoken.NameLabel]; len(name) > 0 {
+			fmt.Fprintf(out, "token %s (%s) revoked\n", TokenID(&tokens[i]), name)
+		} else {
+			fmt.Fprintf(out, "token %s revoked\n", TokenID(&tokens[i]))
+		}
+	}
+	return nil
+}
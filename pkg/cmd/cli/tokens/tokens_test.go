@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/personalaccesstoken"
+)
+
+func TestFindTokens(t *testing.T) {
+	tokens := []oauthapi.OAuthAccessToken{
+		{ObjectMeta: kapi.ObjectMeta{Name: "abcdefghijklmnop"}},
+		{ObjectMeta: kapi.ObjectMeta{Name: "abcxyzxyzxyzxyz"}},
+		{ObjectMeta: kapi.ObjectMeta{Name: "qrstuvwxyz0123456", Labels: map[string]string{personalaccesstoken.NameLabel: "jenkins"}}},
+		{ObjectMeta: kapi.ObjectMeta{Name: "jenkins0123456789"}},
+	}
+
+	testCases := map[string]struct {
+		args     []string
+		expected []string
+		err      bool
+	}{
+		"full id": {
+			args:     []string{"abcdefghij"},
+			expected: []string{"abcdefghijklmnop"},
+		},
+		"unique prefix": {
+			args:     []string{"abcx", "q"},
+			expected: []string{"abcxyzxyzxyzxyz", "qrstuvwxyz0123456"},
+		},
+		"personal access token names take precedence": {
+			args:     []string{"jenkins"},
+			expected: []string{"qrstuvwxyz0123456"},
+		},
+		"ambiguous prefix": {
+			args: []string{"abc"},
+			err:  true,
+		},
+		"no match": {
+			args: []string{"abcdefghij", "missing"},
+			err:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		found, err := FindTokens(tokens, tc.args)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", name, found)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(found) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, found)
+			continue
+		}
+		for i := range found {
+			if found[i].Name != tc.expected[i] {
+				t.Errorf("%s: expected %s, got %s", name, tc.expected[i], found[i].Name)
+			}
+		}
+	}
+}
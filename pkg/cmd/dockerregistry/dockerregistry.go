@@ -56,6 +56,8 @@ func Execute(configFile io.Reader) {
 	// with uuid generation under low entropy.
 	uuid.Loggerf = context.GetLogger(ctx).Warnf
 
+	server.ConfigureReadOnly(ctx, config)
+
 	app := handlers.NewApp(ctx, config)
 
 	// TODO add https scheme
@@ -83,6 +85,39 @@ func Execute(configFile io.Reader) {
 		pruneAccessRecords,
 	)
 
+	app.RegisterRoute(
+		// POST /admin/garbagecollect
+		adminRouter.Path("/garbagecollect").Methods("POST"),
+		// handler
+		server.GarbageCollectDispatcher,
+		// repo name not required in url
+		handlers.NameNotRequired,
+		// custom access records
+		pruneAccessRecords,
+	)
+
+	app.RegisterRoute(
+		// GET|PUT /admin/readonly
+		adminRouter.Path("/readonly").Methods("GET", "PUT"),
+		// handler
+		server.ReadOnlyDispatcher,
+		// repo name not required in url
+		handlers.NameNotRequired,
+		// custom access records
+		pruneAccessRecords,
+	)
+
+	app.RegisterRoute(
+		// GET|PUT /extensions/v2/<name>/signatures/<digest>
+		app.NewRoute().Path("/extensions/v2/{name:"+reference.NameRegexp.String()+"}/signatures/{digest:"+reference.DigestRegexp.String()+"}").Methods("GET", "PUT"),
+		// handler
+		server.SignatureDispatcher,
+		// repository name is required to check access to the image
+		handlers.NameRequired,
+		// access records for pull and push are derived from the method
+		handlers.NoCustomAccessRecords,
+	)
+
 	app.RegisterHealthChecks()
 	handler := alive("/", app)
 	// TODO: temporarily keep for backwards compatibility; remove in the future
@@ -200,6 +200,9 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 			case (*GoogleIdentityProvider):
 				refs = append(refs, GetStringSourceFileReferences(&provider.ClientSecret)...)
 
+			case (*SAMLIdentityProvider):
+				refs = append(refs, &provider.SigningCertificate)
+
 			case (*GitHubIdentityProvider):
 				refs = append(refs, GetStringSourceFileReferences(&provider.ClientSecret)...)
 
@@ -213,6 +216,15 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 		}
 	}
 
+	if config.AuditConfig.File != nil {
+		refs = append(refs, &config.AuditConfig.File.Path)
+	}
+	if config.AuditConfig.Webhook != nil {
+		refs = append(refs, &config.AuditConfig.Webhook.CA)
+		refs = append(refs, &config.AuditConfig.Webhook.ClientCert.CertFile)
+		refs = append(refs, &config.AuditConfig.Webhook.ClientCert.KeyFile)
+	}
+
 	if config.AssetConfig != nil {
 		refs = append(refs, &config.AssetConfig.ServingInfo.ServerCert.CertFile)
 		refs = append(refs, &config.AssetConfig.ServingInfo.ServerCert.KeyFile)
@@ -255,6 +267,9 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 		refs = append(refs, &config.ControllerConfig.ServiceServingCert.Signer.CertFile)
 		refs = append(refs, &config.ControllerConfig.ServiceServingCert.Signer.KeyFile)
 	}
+	if config.ControllerConfig.GroupSync != nil {
+		refs = append(refs, &config.ControllerConfig.GroupSync.SyncConfigFile)
+	}
 
 	return refs
 }
@@ -267,6 +282,19 @@ func ResolveNodeConfigPaths(config *NodeConfig, base string) error {
 	return cmdutil.ResolvePaths(GetNodeFileReferences(config), base)
 }
 
+func ResolveLDAPSyncConfigPaths(config *LDAPSyncConfig, base string) error {
+	return cmdutil.ResolvePaths(GetLDAPSyncConfigFileReferences(config), base)
+}
+
+func GetLDAPSyncConfigFileReferences(config *LDAPSyncConfig) []*string {
+	refs := []*string{}
+
+	refs = append(refs, &config.CA)
+	refs = append(refs, GetStringSourceFileReferences(&config.BindPassword)...)
+
+	return refs
+}
+
 func GetNodeFileReferences(config *NodeConfig) []*string {
 	refs := []*string{}
 
@@ -495,7 +523,8 @@ func IsIdentityProviderType(provider runtime.Object) bool {
 		(*OpenIDIdentityProvider),
 		(*GitHubIdentityProvider),
 		(*GitLabIdentityProvider),
-		(*GoogleIdentityProvider):
+		(*GoogleIdentityProvider),
+		(*SAMLIdentityProvider):
 
 		return true
 	}
@@ -503,6 +532,22 @@ func IsIdentityProviderType(provider runtime.Object) bool {
 	return false
 }
 
+// GetOpenIDGroupPrefix returns the prefix of the names of the groups managed by an OpenID identity provider
+func GetOpenIDGroupPrefix(identityProvider IdentityProvider, provider *OpenIDIdentityProvider) string {
+	if len(provider.GroupPrefix) > 0 {
+		return provider.GroupPrefix
+	}
+	return identityProvider.Name + "-"
+}
+
+// GetSAMLGroupPrefix returns the prefix of the names of the groups managed by a SAML identity provider
+func GetSAMLGroupPrefix(identityProvider IdentityProvider, provider *SAMLIdentityProvider) string {
+	if len(provider.GroupPrefix) > 0 {
+		return provider.GroupPrefix
+	}
+	return identityProvider.Name + "-"
+}
+
 func IsOAuthIdentityProvider(provider IdentityProvider) bool {
 	switch provider.Provider.(type) {
 	case
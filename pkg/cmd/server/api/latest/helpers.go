@@ -45,6 +45,27 @@ func ReadAndResolveMasterConfig(filename string) (*configapi.MasterConfig, error
 	return masterConfig, nil
 }
 
+func ReadLDAPSyncConfig(filename string) (*configapi.LDAPSyncConfig, error) {
+	config := &configapi.LDAPSyncConfig{}
+	if err := ReadYAMLFileInto(filename, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func ReadAndResolveLDAPSyncConfig(filename string) (*configapi.LDAPSyncConfig, error) {
+	syncConfig, err := ReadLDAPSyncConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := configapi.ResolveLDAPSyncConfigPaths(syncConfig, path.Dir(filename)); err != nil {
+		return nil, err
+	}
+
+	return syncConfig, nil
+}
+
 func ReadNodeConfig(filename string) (*configapi.NodeConfig, error) {
 	config := &configapi.NodeConfig{}
 	if err := ReadYAMLFileInto(filename, config); err != nil {
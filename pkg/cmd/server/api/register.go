@@ -51,6 +51,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 		&GitLabIdentityProvider{},
 		&GoogleIdentityProvider{},
 		&OpenIDIdentityProvider{},
+		&SAMLIdentityProvider{},
 
 		&LDAPSyncConfig{},
 
@@ -62,6 +63,7 @@ func (obj *DefaultAdmissionConfig) GetObjectKind() unversioned.ObjectKind { retu
 
 func (obj *LDAPSyncConfig) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
 
+func (obj *SAMLIdentityProvider) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
 func (obj *OpenIDIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GoogleIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GitLabIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
@@ -310,6 +310,69 @@ type AuditConfig struct {
 	// If this flag is set, audit log will be printed in the logs.
 	// The logs contains, method, user and a requested URL.
 	Enabled bool
+
+	// Policy selects the requests recorded as structured audit events, and the detail recorded for them. The first
+	// rule matching a request sets its level, and requests matching no rule are not recorded. If empty, all requests
+	// are recorded at the Metadata level. Structured events are only written if File or Webhook is set.
+	Policy []AuditPolicyRule
+	// MaxBodyBytes is the maximum size of the request and response bodies recorded in structured audit events.
+	// Longer bodies are truncated. If 0, bodies of up to 64KiB are recorded.
+	MaxBodyBytes int64
+	// File writes structured audit events to a file, one JSON object per line
+	File *AuditFileConfig
+	// Webhook sends structured audit events to a remote server, as JSON arrays of events POSTed to its URL
+	Webhook *AuditWebhookConfig
+}
+
+// AuditPolicyRule sets the level of detail recorded for the requests it matches. An empty list matches any value.
+type AuditPolicyRule struct {
+	// Level is the detail recorded for the matching requests: None, Metadata, Request or RequestResponse. Metadata
+	// records who made the request, what it was made against and the response code, Request adds the request body and
+	// RequestResponse the response body. The bodies of secrets, users, OAuth clients and OAuth tokens, which hold
+	// credentials, and of build configs and templates being processed or instantiated, which may hold webhook secrets
+	// and parameter values, are never recorded. The names of OAuth tokens, which are the tokens, are not recorded either.
+	Level string
+	// Users are the names of the users the rule applies to
+	Users []string
+	// Groups are the groups the rule applies to. A request matches if its user is in any of the groups.
+	Groups []string
+	// Verbs are the verbs the rule applies to, such as get, list, create, update or delete
+	Verbs []string
+	// Resources are the resources the rule applies to, such as rolebindings. Requests for subresources match their
+	// resource.
+	Resources []string
+	// Namespaces are the namespaces the rule applies to
+	Namespaces []string
+}
+
+const (
+	// AuditLevelNone does not record the requests
+	AuditLevelNone = "None"
+	// AuditLevelMetadata records who made the requests, what they were made against and the response codes
+	AuditLevelMetadata = "Metadata"
+	// AuditLevelRequest records the metadata and the request bodies
+	AuditLevelRequest = "Request"
+	// AuditLevelRequestResponse records the metadata, the request bodies and the response bodies
+	AuditLevelRequestResponse = "RequestResponse"
+)
+
+var ValidAuditLevels = sets.NewString(AuditLevelNone, AuditLevelMetadata, AuditLevelRequest, AuditLevelRequestResponse)
+
+// AuditFileConfig holds the configuration of the audit log file
+type AuditFileConfig struct {
+	// Path is the file structured audit events are appended to
+	Path string
+	// MaxSizeMegabytes is the size at which the file is rotated. If 0, the file is not rotated.
+	MaxSizeMegabytes int
+	// MaxBackups is the number of rotated files kept, named after Path with a .1, .2, ... suffix, from the most recent
+	// to the oldest. If 0, rotated files are removed.
+	MaxBackups int
+}
+
+// AuditWebhookConfig holds the configuration of the server structured audit events are sent to
+type AuditWebhookConfig struct {
+	// RemoteConnectionInfo contains the URL events are POSTed to, and how to connect to it
+	RemoteConnectionInfo
 }
 
 // JenkinsPipelineConfig holds configuration for the Jenkins pipeline strategy
@@ -343,6 +406,9 @@ type ImagePolicyConfig struct {
 	// MaxScheduledImageImportsPerMinute is the maximum number of image streams that will be imported in the background per minute.
 	// The default value is 60. Set to -1 for unlimited.
 	MaxScheduledImageImportsPerMinute int
+	// EnableImageRetention starts the controller enforcing the retention policy of image streams. It deletes the
+	// images removed from the history of image streams that nothing references anymore.
+	EnableImageRetention bool
 }
 
 type ProjectConfig struct {
@@ -618,6 +684,31 @@ type OAuthConfig struct {
 
 	// Templates allow you to customize pages like the login page.
 	Templates *OAuthTemplates
+
+	// LoginThrottling limits repeated failed logins to password identity providers. If nil, failed logins are not
+	// limited.
+	LoginThrottling *LoginThrottlingConfig
+}
+
+// LoginThrottlingConfig limits repeated failed logins to password identity providers. Users are locked out after a
+// number of consecutive failures, and client addresses are throttled after a number of failures within a period. Locked
+// out users and throttled addresses are denied even with a valid password. Each master counts the failed logins it
+// receives, so with several masters the limits apply to each of them.
+type LoginThrottlingConfig struct {
+	// MaxUserFailures is the number of consecutive failed logins after which a user is locked out. 0 disables the
+	// lockout of users.
+	MaxUserFailures int
+	// UserLockoutSeconds is how long a user stays locked out
+	UserLockoutSeconds int32
+	// MaxSourceFailures is the number of failed logins from a client address within SourceWindowSeconds after which
+	// the address is throttled until the period ends. 0 disables the throttling of client addresses.
+	MaxSourceFailures int
+	// SourceWindowSeconds is the period failed logins from a client address are counted over
+	SourceWindowSeconds int32
+	// TrustedProxies is a list of the CIDRs of the proxies in front of the master that are trusted to set the
+	// X-Forwarded-For header. The client address of a request received from a trusted proxy is taken from the header.
+	// If empty, the address the request is received from is the client address.
+	TrustedProxies []string
 }
 
 type OAuthTemplates struct {
@@ -705,6 +796,17 @@ type IdentityProvider struct {
 	MappingMethod string
 	// Provider contains the information about how to set up a specific identity provider
 	Provider runtime.Object
+	// TOTP enables time-based one-time passwords as a second authentication factor. It is only valid for password
+	// identity providers. If nil, no second factor is asked for.
+	TOTP *TOTPConfig
+}
+
+// TOTPConfig holds the configuration of time-based one-time passwords (RFC 6238) as a second authentication factor
+// of a password identity provider. Users enrolled in TOTP append the code of their authenticator app to their password
+// when answering basic auth challenges, or enter it in the login form.
+type TOTPConfig struct {
+	// Required denies the users who are not enrolled in TOTP. If false, enrollment is optional.
+	Required bool
 }
 
 type BasicAuthPasswordIdentityProvider struct {
@@ -868,6 +970,10 @@ type OpenIDIdentityProvider struct {
 
 	// Claims mappings
 	Claims OpenIDClaims
+
+	// GroupPrefix is prepended to the names of the groups from the groups claims. Groups with the prefix are managed
+	// by this identity provider. If unspecified, the name of the identity provider followed by "-" is used
+	GroupPrefix string
 }
 
 type OpenIDURLs struct {
@@ -894,6 +1000,47 @@ type OpenIDClaims struct {
 	// Email is the list of claims whose values should be used as the email address. Optional.
 	// If unspecified, no email is set for the identity
 	Email []string
+	// Groups is the list of claims whose values are the names of the groups of the user. Optional.
+	// A claim value may be a string or a list of strings. If specified, the user is added to and removed from
+	// the groups on login. The groups are created as needed. If none of the claims is present, the groups are left unchanged.
+	Groups []string
+}
+
+type SAMLIdentityProvider struct {
+	unversioned.TypeMeta
+
+	// EntityID is the entity ID of the master as a SAML service provider, the audience of the assertions
+	EntityID string
+	// SSOURL is the single sign-on URL of the identity provider, using the HTTP-Redirect binding
+	SSOURL string
+	// SigningCertificate is the file containing the PEM-encoded certificates the identity provider signs with
+	SigningCertificate string
+
+	// Attributes mappings
+	Attributes SAMLAttributes
+
+	// GroupPrefix is prepended to the names of the groups from the groups attributes. Groups with the prefix are
+	// managed by this identity provider. If unspecified, the name of the identity provider followed by "-" is used
+	GroupPrefix string
+}
+
+type SAMLAttributes struct {
+	// ID is the list of attributes whose values should be used as the user ID.
+	// If unspecified, the name identifier of the assertion subject is used
+	ID []string
+	// PreferredUsername is the list of attributes whose values should be used as the preferred username.
+	// If unspecified, the preferred username is determined from the user ID
+	PreferredUsername []string
+	// Name is the list of attributes whose values should be used as the display name. Optional.
+	// If unspecified, no display name is set for the identity
+	Name []string
+	// Email is the list of attributes whose values should be used as the email address. Optional.
+	// If unspecified, no email is set for the identity
+	Email []string
+	// Groups is the list of attributes whose values are the names of the groups of the user. Optional.
+	// If specified, the user is added to and removed from the groups on login. The groups are created as needed.
+	// If none of the attributes is present, the groups are left unchanged.
+	Groups []string
 }
 
 type GrantConfig struct {
@@ -1199,6 +1346,27 @@ type ControllerConfig struct {
 	// ServiceServingCert holds configuration for service serving cert signer which creates cert/key pairs for
 	// pods fulfilling a service to serve with.
 	ServiceServingCert ServiceServingCert
+
+	// GroupSync holds configuration for the controller periodically syncing OpenShift groups with the groups of an LDAP
+	// server. If this value is nil, groups are not synced by the master.
+	GroupSync *GroupSyncConfig
+}
+
+// GroupSyncConfig holds configuration for the controller periodically syncing OpenShift groups with the groups of
+// an LDAP server, as `oadm groups sync --confirm` and `oadm groups prune --confirm` do.
+type GroupSyncConfig struct {
+	// SyncConfigFile is the file containing the LDAPSyncConfig describing the LDAP server and its schema
+	SyncConfigFile string
+	// SyncIntervalSeconds is the number of seconds between two syncs
+	SyncIntervalSeconds int
+	// Whitelist is the list of the UIDs of the LDAP groups to sync. If empty, all the groups returned by the
+	// LDAP group query of the sync config are synced
+	Whitelist []string
+	// Blacklist is the list of the UIDs of the LDAP groups never synced
+	Blacklist []string
+	// Prune deletes the previously synced OpenShift groups whose LDAP group no longer exists. The whitelist and the
+	// blacklist apply to pruning as well
+	Prune bool
 }
 
 // ServiceServingCert holds configuration for service serving cert signer which creates cert/key pairs for
@@ -164,6 +164,11 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 				obj.MappingMethod = "claim"
 			}
 		},
+		func(obj *GroupSyncConfig) {
+			if obj.SyncIntervalSeconds == 0 {
+				obj.SyncIntervalSeconds = 30 * 60
+			}
+		},
 		func(obj *GrantConfig) {
 			if len(obj.ServiceAccountMethod) == 0 {
 				obj.ServiceAccountMethod = "prompt"
@@ -285,6 +290,9 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 			out.UseAsChallenger = in.UseAsChallenger
 			out.UseAsLogin = in.UseAsLogin
 			out.MappingMethod = in.MappingMethod
+			if in.TOTP != nil {
+				out.TOTP = &TOTPConfig{Required: in.TOTP.Required}
+			}
 			return nil
 		},
 		func(in *IdentityProvider, out *internal.IdentityProvider, s conversion.Scope) error {
@@ -302,6 +310,9 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 			out.UseAsChallenger = in.UseAsChallenger
 			out.UseAsLogin = in.UseAsLogin
 			out.MappingMethod = in.MappingMethod
+			if in.TOTP != nil {
+				out.TOTP = &internal.TOTPConfig{Required: in.TOTP.Required}
+			}
 			return nil
 		},
 		func(in *internal.AdmissionPluginConfig, out *AdmissionPluginConfig, s conversion.Scope) error {
@@ -75,14 +75,51 @@ func (AssetExtensionsConfig) SwaggerDoc() map[string]string {
 }
 
 var map_AuditConfig = map[string]string{
-	"":        "AuditConfig holds configuration for the audit capabilities",
-	"enabled": "If this flag is set, basic audit log will be printed in the logs. The logs contains, method, user and a requested URL.",
+	"":             "AuditConfig holds configuration for the audit capabilities",
+	"enabled":      "If this flag is set, basic audit log will be printed in the logs. The logs contains, method, user and a requested URL.",
+	"policy":       "Policy selects the requests recorded as structured audit events, and the detail recorded for them. The first rule matching a request sets its level, and requests matching no rule are not recorded. If empty, all requests are recorded at the Metadata level. Structured events are only written if File or Webhook is set.",
+	"maxBodyBytes": "MaxBodyBytes is the maximum size of the request and response bodies recorded in structured audit events. Longer bodies are truncated. If 0, bodies of up to 64KiB are recorded.",
+	"file":         "File writes structured audit events to a file, one JSON object per line",
+	"webhook":      "Webhook sends structured audit events to a remote server, as JSON arrays of events POSTed to its URL",
 }
 
 func (AuditConfig) SwaggerDoc() map[string]string {
 	return map_AuditConfig
 }
 
+var map_AuditFileConfig = map[string]string{
+	"":                 "AuditFileConfig holds the configuration of the audit log file",
+	"path":             "Path is the file structured audit events are appended to",
+	"maxSizeMegabytes": "MaxSizeMegabytes is the size at which the file is rotated. If 0, the file is not rotated.",
+	"maxBackups":       "MaxBackups is the number of rotated files kept, named after Path with a .1, .2, ... suffix, from the most recent to the oldest. If 0, rotated files are removed.",
+}
+
+func (AuditFileConfig) SwaggerDoc() map[string]string {
+	return map_AuditFileConfig
+}
+
+var map_AuditPolicyRule = map[string]string{
+	"":           "AuditPolicyRule sets the level of detail recorded for the requests it matches. An empty list matches any value.",
+	"level":      "Level is the detail recorded for the matching requests: None, Metadata, Request or RequestResponse. Metadata records who made the request, what it was made against and the response code, Request adds the request body and RequestResponse the response body. The bodies of secrets, users, OAuth clients and OAuth tokens, which hold credentials, and of build configs and templates being processed or instantiated, which may hold webhook secrets and parameter values, are never recorded. The names of OAuth tokens, which are the tokens, are not recorded either.",
+	"users":      "Users are the names of the users the rule applies to",
+	"groups":     "Groups are the groups the rule applies to. A request matches if its user is in any of the groups.",
+	"verbs":      "Verbs are the verbs the rule applies to, such as get, list, create, update or delete",
+	"resources":  "Resources are the resources the rule applies to, such as rolebindings. Requests for subresources match their resource.",
+	"namespaces": "Namespaces are the namespaces the rule applies to",
+}
+
+func (AuditPolicyRule) SwaggerDoc() map[string]string {
+	return map_AuditPolicyRule
+}
+
+var map_AuditWebhookConfig = map[string]string{
+	"": "AuditWebhookConfig holds the configuration of the server structured audit events are sent to",
+}
+
+func (AuditWebhookConfig) SwaggerDoc() map[string]string {
+	return map_AuditWebhookConfig
+}
+
 var map_AugmentedActiveDirectoryConfig = map[string]string{
 	"":                          "AugmentedActiveDirectoryConfig holds the necessary configuration options to define how an LDAP group sync interacts with an LDAP server using the augmented Active Directory schema",
 	"usersQuery":                "AllUsersQuery holds the template for an LDAP query that returns user entries.",
@@ -118,6 +155,7 @@ func (CertInfo) SwaggerDoc() map[string]string {
 var map_ControllerConfig = map[string]string{
 	"":                   "ControllerConfig holds configuration values for controllers",
 	"serviceServingCert": "ServiceServingCert holds configuration for service serving cert signer which creates cert/key pairs for pods fulfilling a service to serve with.",
+	"groupSync":          "GroupSync holds configuration for the controller periodically syncing OpenShift groups with the groups of an LDAP server. If this value is nil, groups are not synced by the master.",
 }
 
 func (ControllerConfig) SwaggerDoc() map[string]string {
@@ -240,6 +278,19 @@ func (GrantConfig) SwaggerDoc() map[string]string {
 	return map_GrantConfig
 }
 
+var map_GroupSyncConfig = map[string]string{
+	"":                    "GroupSyncConfig holds configuration for the controller periodically syncing OpenShift groups with the groups of an LDAP server, as `oadm groups sync --confirm` and `oadm groups prune --confirm` do.",
+	"syncConfigFile":      "SyncConfigFile is the file containing the LDAPSyncConfig describing the LDAP server and its schema",
+	"syncIntervalSeconds": "SyncIntervalSeconds is the number of seconds between two syncs. Defaults to 1800 (30 minutes)",
+	"whitelist":           "Whitelist is the list of the UIDs of the LDAP groups to sync. If empty, all the groups returned by the LDAP group query of the sync config are synced",
+	"blacklist":           "Blacklist is the list of the UIDs of the LDAP groups never synced",
+	"prune":               "Prune deletes the previously synced OpenShift groups whose LDAP group no longer exists. The whitelist and the blacklist apply to pruning as well",
+}
+
+func (GroupSyncConfig) SwaggerDoc() map[string]string {
+	return map_GroupSyncConfig
+}
+
 var map_HTPasswdPasswordIdentityProvider = map[string]string{
 	"":     "HTPasswdPasswordIdentityProvider provides identities for users authenticating using htpasswd credentials",
 	"file": "File is a reference to your htpasswd file",
@@ -266,6 +317,7 @@ var map_IdentityProvider = map[string]string{
 	"login":         "UseAsLogin indicates whether to use this identity provider for unauthenticated browsers to login against",
 	"mappingMethod": "MappingMethod determines how identities from this provider are mapped to users",
 	"provider":      "Provider contains the information about how to set up a specific identity provider",
+	"totp":          "TOTP enables time-based one-time passwords as a second authentication factor. It is only valid for password identity providers. If nil, no second factor is asked for.",
 }
 
 func (IdentityProvider) SwaggerDoc() map[string]string {
@@ -288,6 +340,7 @@ var map_ImagePolicyConfig = map[string]string{
 	"disableScheduledImport":                     "DisableScheduledImport allows scheduled background import of images to be disabled.",
 	"scheduledImageImportMinimumIntervalSeconds": "ScheduledImageImportMinimumIntervalSeconds is the minimum number of seconds that can elapse between when image streams scheduled for background import are checked against the upstream repository. The default value is 15 minutes.",
 	"maxScheduledImageImportsPerMinute":          "MaxScheduledImageImportsPerMinute is the maximum number of scheduled image streams that will be imported in the background per minute. The default value is 60. Set to -1 for unlimited.",
+	"enableImageRetention":                       "EnableImageRetention starts the controller enforcing the retention policy of image streams. It deletes the images removed from the history of image streams that nothing references anymore.",
 }
 
 func (ImagePolicyConfig) SwaggerDoc() map[string]string {
@@ -413,6 +466,19 @@ func (LocalQuota) SwaggerDoc() map[string]string {
 	return map_LocalQuota
 }
 
+var map_LoginThrottlingConfig = map[string]string{
+	"":                    "LoginThrottlingConfig limits repeated failed logins to password identity providers. Users are locked out after a number of consecutive failures, and client addresses are throttled after a number of failures within a period. Locked out users and throttled addresses are denied even with a valid password. Each master counts the failed logins it receives, so with several masters the limits apply to each of them.",
+	"maxUserFailures":     "MaxUserFailures is the number of consecutive failed logins after which a user is locked out. 0 disables the lockout of users.",
+	"userLockoutSeconds":  "UserLockoutSeconds is how long a user stays locked out",
+	"maxSourceFailures":   "MaxSourceFailures is the number of failed logins from a client address within SourceWindowSeconds after which the address is throttled until the period ends. 0 disables the throttling of client addresses.",
+	"sourceWindowSeconds": "SourceWindowSeconds is the period failed logins from a client address are counted over",
+	"trustedProxies":      "TrustedProxies is a list of the CIDRs of the proxies in front of the master that are trusted to set the X-Forwarded-For header. The client address of a request received from a trusted proxy is taken from the header. If empty, the address the request is received from is the client address.",
+}
+
+func (LoginThrottlingConfig) SwaggerDoc() map[string]string {
+	return map_LoginThrottlingConfig
+}
+
 var map_MasterClients = map[string]string{
 	"": "MasterClients holds references to `.kubeconfig` files that qualify master clients for OpenShift and Kubernetes",
 	"openshiftLoopbackKubeConfig":  "OpenShiftLoopbackKubeConfig is a .kubeconfig filename for system components to loopback to this master",
@@ -560,6 +626,7 @@ var map_OAuthConfig = map[string]string{
 	"sessionConfig":               "SessionConfig hold information about configuring sessions.",
 	"tokenConfig":                 "TokenConfig contains options for authorization and access tokens",
 	"templates":                   "Templates allow you to customize pages like the login page.",
+	"loginThrottling":             "LoginThrottling limits repeated failed logins to password identity providers. If nil, failed logins are not limited.",
 }
 
 func (OAuthConfig) SwaggerDoc() map[string]string {
@@ -583,6 +650,7 @@ var map_OpenIDClaims = map[string]string{
 	"preferredUsername": "PreferredUsername is the list of claims whose values should be used as the preferred username. If unspecified, the preferred username is determined from the value of the id claim",
 	"name":              "Name is the list of claims whose values should be used as the display name. Optional. If unspecified, no display name is set for the identity",
 	"email":             "Email is the list of claims whose values should be used as the email address. Optional. If unspecified, no email is set for the identity",
+	"groups":            "Groups is the list of claims whose values are the names of the groups of the user. Optional. A claim value may be a string or a list of strings. If specified, the user is added to and removed from the groups on login. The groups are created as needed. If none of the claims is present, the groups are left unchanged.",
 }
 
 func (OpenIDClaims) SwaggerDoc() map[string]string {
@@ -596,8 +664,9 @@ var map_OpenIDIdentityProvider = map[string]string{
 	"clientSecret":             "ClientSecret is the oauth client secret",
 	"extraScopes":              "ExtraScopes are any scopes to request in addition to the standard \"openid\" scope.",
 	"extraAuthorizeParameters": "ExtraAuthorizeParameters are any custom parameters to add to the authorize request.",
-	"urls":   "URLs to use to authenticate",
-	"claims": "Claims mappings",
+	"urls":                     "URLs to use to authenticate",
+	"claims":                   "Claims mappings",
+	"groupPrefix":              "GroupPrefix is prepended to the names of the groups from the groups claims. Groups with the prefix are managed by this identity provider. If unspecified, the name of the identity provider followed by \"-\" is used",
 }
 
 func (OpenIDIdentityProvider) SwaggerDoc() map[string]string {
@@ -701,6 +770,32 @@ func (RoutingConfig) SwaggerDoc() map[string]string {
 	return map_RoutingConfig
 }
 
+var map_SAMLAttributes = map[string]string{
+	"":                  "SAMLAttributes contains a list of SAML attributes to use when authenticating with a SAML identity provider",
+	"id":                "ID is the list of attributes whose values should be used as the user ID. If unspecified, the name identifier of the assertion subject is used",
+	"preferredUsername": "PreferredUsername is the list of attributes whose values should be used as the preferred username. If unspecified, the preferred username is determined from the user ID",
+	"name":              "Name is the list of attributes whose values should be used as the display name. Optional. If unspecified, no display name is set for the identity",
+	"email":             "Email is the list of attributes whose values should be used as the email address. Optional. If unspecified, no email is set for the identity",
+	"groups":            "Groups is the list of attributes whose values are the names of the groups of the user. Optional. If specified, the user is added to and removed from the groups on login. The groups are created as needed. If none of the attributes is present, the groups are left unchanged.",
+}
+
+func (SAMLAttributes) SwaggerDoc() map[string]string {
+	return map_SAMLAttributes
+}
+
+var map_SAMLIdentityProvider = map[string]string{
+	"":                   "SAMLIdentityProvider provides identities for users authenticating using a SAML 2.0 identity provider",
+	"entityID":           "EntityID is the entity ID of the master as a SAML service provider, the audience of the assertions",
+	"ssoURL":             "SSOURL is the single sign-on URL of the identity provider, using the HTTP-Redirect binding",
+	"signingCertificate": "SigningCertificate is the file containing the PEM-encoded certificates the identity provider signs with",
+	"attributes":         "Attributes mappings",
+	"groupPrefix":        "GroupPrefix is prepended to the names of the groups from the groups attributes. Groups with the prefix are managed by this identity provider. If unspecified, the name of the identity provider followed by \"-\" is used",
+}
+
+func (SAMLIdentityProvider) SwaggerDoc() map[string]string {
+	return map_SAMLIdentityProvider
+}
+
 var map_SecurityAllocator = map[string]string{
 	"":                    "SecurityAllocator controls the automatic allocation of UIDs and MCS labels to a project. If nil, allocation is disabled.",
 	"uidAllocatorRange":   "UIDAllocatorRange defines the total set of Unix user IDs (UIDs) that will be allocated to projects automatically, and the size of the block each namespace gets. For example, 1000-1999/10 will allocate ten UIDs per namespace, and will be able to allocate up to 100 blocks before running out of space. The default is to allocate from 1 billion to 2 billion in 10k blocks (which is the expected size of the ranges Docker images will use once user namespaces are started).",
@@ -796,6 +891,15 @@ func (StringSourceSpec) SwaggerDoc() map[string]string {
 	return map_StringSourceSpec
 }
 
+var map_TOTPConfig = map[string]string{
+	"":         "TOTPConfig holds the configuration of time-based one-time passwords (RFC 6238) as a second authentication factor of a password identity provider. Users enrolled in TOTP append the code of their authenticator app to their password when answering basic auth challenges, or enter it in the login form.",
+	"required": "Required denies the users who are not enrolled in TOTP. If false, enrollment is optional.",
+}
+
+func (TOTPConfig) SwaggerDoc() map[string]string {
+	return map_TOTPConfig
+}
+
 var map_TokenConfig = map[string]string{
 	"": "TokenConfig holds the necessary configuration options for authorization and access tokens",
 	"authorizeTokenMaxAgeSeconds": "AuthorizeTokenMaxAgeSeconds defines the maximum age of authorize tokens",
@@ -104,7 +104,25 @@ assetConfig:
     requestTimeoutSeconds: 0
 auditConfig:
   enabled: false
+  file:
+    maxBackups: 0
+    maxSizeMegabytes: 0
+    path: ""
+  maxBodyBytes: 0
+  policy:
+  - groups: null
+    level: ""
+    namespaces: null
+    resources: null
+    users: null
+    verbs: null
+  webhook:
+    ca: ""
+    certFile: ""
+    keyFile: ""
+    url: ""
 controllerConfig:
+  groupSync: null
   serviceServingCert:
     signer: null
 controllerLeaseTTL: 0
@@ -148,6 +166,7 @@ imageConfig:
   latest: false
 imagePolicyConfig:
   disableScheduledImport: false
+  enableImageRetention: false
   maxImagesBulkImportedPerRepository: 0
   maxScheduledImageImportsPerMinute: 0
   scheduledImageImportMinimumIntervalSeconds: 0
@@ -216,6 +235,7 @@ oauthConfig:
       keyFile: ""
       kind: BasicAuthPasswordIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -223,6 +243,7 @@ oauthConfig:
     provider:
       apiVersion: v1
       kind: AllowAllPasswordIdentityProvider
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -230,6 +251,7 @@ oauthConfig:
     provider:
       apiVersion: v1
       kind: DenyAllPasswordIdentityProvider
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -238,6 +260,7 @@ oauthConfig:
       apiVersion: v1
       file: ""
       kind: HTPasswdPasswordIdentityProvider
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -255,6 +278,7 @@ oauthConfig:
       insecure: false
       kind: LDAPPasswordIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -276,6 +300,7 @@ oauthConfig:
       insecure: false
       kind: LDAPPasswordIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -291,6 +316,7 @@ oauthConfig:
       loginURL: ""
       nameHeaders: null
       preferredUsernameHeaders: null
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -303,6 +329,7 @@ oauthConfig:
       keyFile: ""
       kind: KeystonePasswordIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -313,6 +340,7 @@ oauthConfig:
       clientSecret: ""
       kind: GitHubIdentityProvider
       organizations: null
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -327,6 +355,7 @@ oauthConfig:
         value: ""
       kind: GitHubIdentityProvider
       organizations: null
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -338,6 +367,7 @@ oauthConfig:
       clientSecret: ""
       kind: GitLabIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -353,6 +383,7 @@ oauthConfig:
         value: ""
       kind: GitLabIdentityProvider
       url: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -363,6 +394,7 @@ oauthConfig:
       clientSecret: ""
       hostedDomain: ""
       kind: GoogleIdentityProvider
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -377,6 +409,7 @@ oauthConfig:
         value: ""
       hostedDomain: ""
       kind: GoogleIdentityProvider
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -386,6 +419,7 @@ oauthConfig:
       ca: ""
       claims:
         email: null
+        groups: null
         id: null
         name: null
         preferredUsername: null
@@ -393,11 +427,13 @@ oauthConfig:
       clientSecret: ""
       extraAuthorizeParameters: null
       extraScopes: null
+      groupPrefix: ""
       kind: OpenIDIdentityProvider
       urls:
         authorize: ""
         token: ""
         userInfo: ""
+    totp: null
   - challenge: false
     login: false
     mappingMethod: ""
@@ -407,6 +443,7 @@ oauthConfig:
       ca: ""
       claims:
         email: null
+        groups: null
         id: null
         name: null
         preferredUsername: null
@@ -418,11 +455,37 @@ oauthConfig:
         value: ""
       extraAuthorizeParameters: null
       extraScopes: null
+      groupPrefix: ""
       kind: OpenIDIdentityProvider
       urls:
         authorize: ""
         token: ""
         userInfo: ""
+    totp: null
+  - challenge: false
+    login: false
+    mappingMethod: ""
+    name: ""
+    provider:
+      apiVersion: v1
+      attributes:
+        email: null
+        groups: null
+        id: null
+        name: null
+        preferredUsername: null
+      entityID: ""
+      groupPrefix: ""
+      kind: SAMLIdentityProvider
+      signingCertificate: ""
+      ssoURL: ""
+    totp: null
+  loginThrottling:
+    maxSourceFailures: 0
+    maxUserFailures: 0
+    sourceWindowSeconds: 0
+    trustedProxies: null
+    userLockoutSeconds: 0
   masterCA: null
   masterPublicURL: ""
   masterURL: ""
@@ -645,6 +708,11 @@ func TestMasterConfig(t *testing.T) {
 			},
 		},
 		EtcdConfig: &internal.EtcdConfig{},
+		AuditConfig: internal.AuditConfig{
+			Policy:  []internal.AuditPolicyRule{{}},
+			File:    &internal.AuditFileConfig{},
+			Webhook: &internal.AuditWebhookConfig{},
+		},
 		OAuthConfig: &internal.OAuthConfig{
 			IdentityProviders: []internal.IdentityProvider{
 				{Provider: &internal.BasicAuthPasswordIdentityProvider{}},
@@ -663,9 +731,11 @@ func TestMasterConfig(t *testing.T) {
 				{Provider: &internal.GoogleIdentityProvider{ClientSecret: internal.StringSource{StringSourceSpec: internal.StringSourceSpec{File: "filename"}}}},
 				{Provider: &internal.OpenIDIdentityProvider{}},
 				{Provider: &internal.OpenIDIdentityProvider{ClientSecret: internal.StringSource{StringSourceSpec: internal.StringSourceSpec{File: "filename"}}}},
+				{Provider: &internal.SAMLIdentityProvider{}},
 			},
-			SessionConfig: &internal.SessionConfig{},
-			Templates:     &internal.OAuthTemplates{},
+			SessionConfig:   &internal.SessionConfig{},
+			Templates:       &internal.OAuthTemplates{},
+			LoginThrottling: &internal.LoginThrottlingConfig{},
 		},
 		AssetConfig: &internal.AssetConfig{
 			Extensions: []internal.AssetExtensionsConfig{{}},
@@ -19,6 +19,7 @@ import (
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/cmd/server/api"
+	"github.com/openshift/origin/pkg/cmd/server/api/latest"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	"github.com/openshift/origin/pkg/security/mcs"
 	"github.com/openshift/origin/pkg/security/uid"
@@ -185,6 +186,49 @@ func ValidateMasterConfig(config *api.MasterConfig, fldPath *field.Path) Validat
 
 	validationResults.Append(ValidateControllerConfig(config.ControllerConfig, fldPath.Child("controllerConfig")))
 
+	validationResults.Append(ValidateAuditConfig(config.AuditConfig, fldPath.Child("auditConfig")))
+
+	return validationResults
+}
+
+func ValidateAuditConfig(config api.AuditConfig, fldPath *field.Path) ValidationResults {
+	validationResults := ValidationResults{}
+
+	for i, rule := range config.Policy {
+		if !api.ValidAuditLevels.Has(rule.Level) {
+			validationResults.AddErrors(field.NotSupported(fldPath.Child("policy").Index(i).Child("level"), rule.Level, api.ValidAuditLevels.List()))
+		}
+	}
+
+	if config.MaxBodyBytes < 0 {
+		validationResults.AddErrors(field.Invalid(fldPath.Child("maxBodyBytes"), config.MaxBodyBytes, "must be greater than or equal to 0"))
+	}
+
+	if config.File != nil {
+		filePath := fldPath.Child("file")
+		if len(config.File.Path) == 0 {
+			validationResults.AddErrors(field.Required(filePath.Child("path"), ""))
+		}
+		if config.File.MaxSizeMegabytes < 0 {
+			validationResults.AddErrors(field.Invalid(filePath.Child("maxSizeMegabytes"), config.File.MaxSizeMegabytes, "must be greater than or equal to 0"))
+		}
+		if config.File.MaxBackups < 0 {
+			validationResults.AddErrors(field.Invalid(filePath.Child("maxBackups"), config.File.MaxBackups, "must be greater than or equal to 0"))
+		}
+	}
+
+	if config.Webhook != nil {
+		validationResults.AddErrors(ValidateRemoteConnectionInfo(config.Webhook.RemoteConnectionInfo, fldPath.Child("webhook"))...)
+	}
+
+	hasSink := config.File != nil || config.Webhook != nil
+	if !config.Enabled && hasSink {
+		validationResults.AddWarnings(field.Invalid(fldPath.Child("enabled"), config.Enabled, "no audit events will be written to the file or webhook unless auditing is enabled"))
+	}
+	if len(config.Policy) > 0 && !hasSink {
+		validationResults.AddWarnings(field.Invalid(fldPath.Child("policy"), "<not displayed>", "the policy is only used for the audit events written to a file or webhook"))
+	}
+
 	return validationResults
 }
 
@@ -194,6 +238,34 @@ func ValidateControllerConfig(config api.ControllerConfig, fldPath *field.Path)
 	if config.ServiceServingCert.Signer != nil {
 		validationResults.AddErrors(ValidateCertInfo(*config.ServiceServingCert.Signer, true, fldPath.Child("serviceServingCert.signer"))...)
 	}
+	if config.GroupSync != nil {
+		validationResults.Append(ValidateGroupSyncConfig(config.GroupSync, fldPath.Child("groupSync")))
+	}
+
+	return validationResults
+}
+
+func ValidateGroupSyncConfig(config *api.GroupSyncConfig, fldPath *field.Path) ValidationResults {
+	validationResults := ValidationResults{}
+
+	syncConfigFilePath := fldPath.Child("syncConfigFile")
+	if fileErrs := ValidateFile(config.SyncConfigFile, syncConfigFilePath); len(fileErrs) > 0 {
+		validationResults.AddErrors(fileErrs...)
+	} else if syncConfig, err := latest.ReadAndResolveLDAPSyncConfig(config.SyncConfigFile); err != nil {
+		validationResults.AddErrors(field.Invalid(syncConfigFilePath, config.SyncConfigFile, fmt.Sprintf("error reading file: %v", err)))
+	} else {
+		syncResults := ValidateLDAPSyncConfig(syncConfig)
+		for _, err := range syncResults.Errors {
+			validationResults.AddErrors(field.Invalid(syncConfigFilePath, config.SyncConfigFile, err.Error()))
+		}
+		for _, err := range syncResults.Warnings {
+			validationResults.AddWarnings(field.Invalid(syncConfigFilePath, config.SyncConfigFile, err.Error()))
+		}
+	}
+
+	if config.SyncIntervalSeconds <= 0 {
+		validationResults.AddErrors(field.Invalid(fldPath.Child("syncIntervalSeconds"), config.SyncIntervalSeconds, "must be greater than 0"))
+	}
 
 	return validationResults
 }
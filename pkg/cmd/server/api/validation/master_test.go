@@ -248,3 +248,69 @@ func TestValidateAdmissionPluginConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAuditConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config         configapi.AuditConfig
+		expectErrors   int
+		expectWarnings int
+	}{
+		"disabled": {},
+		"file with policy": {
+			config: configapi.AuditConfig{
+				Enabled: true,
+				Policy: []configapi.AuditPolicyRule{
+					{Level: configapi.AuditLevelRequestResponse, Resources: []string{"rolebindings"}},
+					{Level: configapi.AuditLevelNone},
+				},
+				File: &configapi.AuditFileConfig{Path: "/var/log/audit.log", MaxSizeMegabytes: 100, MaxBackups: 5},
+			},
+		},
+		"invalid level": {
+			config: configapi.AuditConfig{
+				Enabled: true,
+				Policy:  []configapi.AuditPolicyRule{{Level: "Everything"}},
+				File:    &configapi.AuditFileConfig{Path: "/var/log/audit.log"},
+			},
+			expectErrors: 1,
+		},
+		"invalid file": {
+			config: configapi.AuditConfig{
+				Enabled:      true,
+				MaxBodyBytes: -1,
+				File:         &configapi.AuditFileConfig{MaxSizeMegabytes: -1, MaxBackups: -1},
+			},
+			expectErrors: 4,
+		},
+		"webhook without url": {
+			config: configapi.AuditConfig{
+				Enabled: true,
+				Webhook: &configapi.AuditWebhookConfig{},
+			},
+			expectErrors: 1,
+		},
+		"file when disabled": {
+			config: configapi.AuditConfig{
+				File: &configapi.AuditFileConfig{Path: "/var/log/audit.log"},
+			},
+			expectWarnings: 1,
+		},
+		"policy without sink": {
+			config: configapi.AuditConfig{
+				Enabled: true,
+				Policy:  []configapi.AuditPolicyRule{{Level: configapi.AuditLevelMetadata}},
+			},
+			expectWarnings: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		results := ValidateAuditConfig(tc.config, field.NewPath("auditConfig"))
+		if len(results.Errors) != tc.expectErrors {
+			t.Errorf("%s: expected %d errors, got %v", name, tc.expectErrors, results.Errors)
+		}
+		if len(results.Warnings) != tc.expectWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.expectWarnings, results.Warnings)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package validation
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	"github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/api/latest"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/user/api/validation"
 )
 
@@ -48,6 +50,10 @@ func ValidateOAuthConfig(config *api.OAuthConfig, fldPath *field.Path) Validatio
 
 	validationResults.AddErrors(validateGrantConfig(config.GrantConfig, fldPath.Child("grantConfig"))...)
 
+	if config.LoginThrottling != nil {
+		validationResults.AddErrors(validateLoginThrottlingConfig(config.LoginThrottling, fldPath.Child("loginThrottling"))...)
+	}
+
 	providerNames := sets.NewString()
 	redirectingIdentityProviders := []string{}
 
@@ -159,6 +165,10 @@ func ValidateIdentityProvider(identityProvider api.IdentityProvider, fldPath *fi
 		validationResults.AddErrors(field.NotSupported(fldPath.Child("mappingMethod"), identityProvider.MappingMethod, validMappingMethods.List()))
 	}
 
+	if identityProvider.TOTP != nil && !api.IsPasswordAuthenticator(identityProvider) {
+		validationResults.AddErrors(field.Invalid(fldPath.Child("totp"), identityProvider.TOTP, "only password identity providers support TOTP"))
+	}
+
 	providerPath := fldPath.Child("provider")
 	if !api.IsIdentityProviderType(identityProvider.Provider) {
 		validationResults.AddErrors(field.Invalid(fldPath.Child("provider"), identityProvider.Provider, fmt.Sprintf("%v is invalid in this context", identityProvider.Provider)))
@@ -191,6 +201,9 @@ func ValidateIdentityProvider(identityProvider api.IdentityProvider, fldPath *fi
 		case (*api.OpenIDIdentityProvider):
 			validationResults.AddErrors(ValidateOpenIDIdentityProvider(provider, identityProvider, fldPath)...)
 
+		case (*api.SAMLIdentityProvider):
+			validationResults.AddErrors(ValidateSAMLIdentityProvider(provider, identityProvider, fldPath)...)
+
 		}
 	}
 
@@ -380,6 +393,45 @@ func ValidateOpenIDIdentityProvider(provider *api.OpenIDIdentityProvider, identi
 		allErrs = append(allErrs, ValidateFile(provider.CA, providerPath.Child("ca"))...)
 	}
 
+	if len(provider.Claims.Groups) != 0 {
+		// The prefix must be usable in a group name
+		if ok, msg := validation.ValidateGroupName(api.GetOpenIDGroupPrefix(identityProvider, provider), true); !ok {
+			allErrs = append(allErrs, field.Invalid(providerPath.Child("groupPrefix"), provider.GroupPrefix, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func ValidateSAMLIdentityProvider(provider *api.SAMLIdentityProvider, identityProvider api.IdentityProvider, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	providerPath := fieldPath.Child("provider")
+
+	if len(provider.EntityID) == 0 {
+		allErrs = append(allErrs, field.Required(providerPath.Child("entityID"), ""))
+	}
+
+	_, urlErrs := ValidateSecureURL(provider.SSOURL, providerPath.Child("ssoURL"))
+	allErrs = append(allErrs, urlErrs...)
+
+	signingCertificatePath := providerPath.Child("signingCertificate")
+	if fileErrs := ValidateFile(provider.SigningCertificate, signingCertificatePath); len(fileErrs) > 0 {
+		allErrs = append(allErrs, fileErrs...)
+	} else if _, err := cmdutil.CertificatesFromFile(provider.SigningCertificate); err != nil {
+		allErrs = append(allErrs, field.Invalid(signingCertificatePath, provider.SigningCertificate, err.Error()))
+	}
+
+	if identityProvider.UseAsChallenger {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("challenge"), identityProvider.UseAsChallenger, "A SAML identity provider cannot be used for challenges"))
+	}
+
+	if len(provider.Attributes.Groups) != 0 {
+		// The prefix must be usable in a group name
+		if ok, msg := validation.ValidateGroupName(api.GetSAMLGroupPrefix(identityProvider, provider), true); !ok {
+			allErrs = append(allErrs, field.Invalid(providerPath.Child("groupPrefix"), provider.GroupPrefix, msg))
+		}
+	}
+
 	return allErrs
 }
 
@@ -396,6 +448,30 @@ func validateGrantConfig(config api.GrantConfig, fldPath *field.Path) field.Erro
 	return allErrs
 }
 
+func validateLoginThrottlingConfig(config *api.LoginThrottlingConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.MaxUserFailures < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUserFailures"), config.MaxUserFailures, "must be 0 (disabled) or greater"))
+	} else if config.MaxUserFailures > 0 && config.UserLockoutSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("userLockoutSeconds"), config.UserLockoutSeconds, "must be greater than 0 when maxUserFailures is set"))
+	}
+
+	if config.MaxSourceFailures < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSourceFailures"), config.MaxSourceFailures, "must be 0 (disabled) or greater"))
+	} else if config.MaxSourceFailures > 0 && config.SourceWindowSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sourceWindowSeconds"), config.SourceWindowSeconds, "must be greater than 0 when maxSourceFailures is set"))
+	}
+
+	for i, cidr := range config.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("trustedProxies").Index(i), cidr, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func validateSessionConfig(config *api.SessionConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
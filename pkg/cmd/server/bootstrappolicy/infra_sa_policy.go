@@ -46,6 +46,9 @@ const (
 	InfraGCControllerServiceAccountName = "gc-controller"
 	GCControllerRoleName                = "system:gc-controller"
 
+	InfraImageRetentionControllerServiceAccountName = "image-retention-controller"
+	ImageRetentionControllerRoleName                = "system:image-retention-controller"
+
 	InfraServiceLoadBalancerControllerServiceAccountName = "service-load-balancer-controller"
 	ServiceLoadBalancerControllerRoleName                = "system:service-load-balancer-controller"
 
@@ -116,6 +119,16 @@ func init() {
 					Verbs:     sets.NewString("update"),
 					Resources: sets.NewString("builds"),
 				},
+				// BuildController.BuildConfigGetter (OSClientBuildConfigClient)
+				{
+					Verbs:     sets.NewString("get"),
+					Resources: sets.NewString("buildconfigs"),
+				},
+				// BuildController.BuildCloner (OSClientBuildClonerClient)
+				{
+					Verbs:     sets.NewString("create"),
+					Resources: sets.NewString("builds/clone"),
+				},
 				// Create permission on virtual build type resources allows builds of those types to be updated
 				{
 					Verbs:     sets.NewString("create"),
@@ -572,6 +585,47 @@ func init() {
 		panic(err)
 	}
 
+	err = InfraSAs.addServiceAccount(
+		InfraImageRetentionControllerServiceAccountName,
+		authorizationapi.ClusterRole{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: ImageRetentionControllerRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				// RetentionControllerFactory.Create
+				// RetentionController.prunableImages
+				{
+					Verbs:     sets.NewString("list", "watch"),
+					Resources: sets.NewString("imagestreams"),
+				},
+				// RetentionController.Handle
+				{
+					Verbs:     sets.NewString("update"),
+					Resources: sets.NewString("imagestreams/status"),
+				},
+				// RetentionController.prunableImages
+				{
+					Verbs:     sets.NewString("list"),
+					Resources: sets.NewString("images", "buildconfigs", "builds", "deploymentconfigs"),
+				},
+				{
+					APIGroups: []string{kapi.GroupName},
+					Verbs:     sets.NewString("list"),
+					Resources: sets.NewString("pods", "replicationcontrollers"),
+				},
+				// RetentionController.Sync deletes images and runs the garbage collector of the registry, which
+				// requires the permission to delete images
+				{
+					Verbs:     sets.NewString("delete"),
+					Resources: sets.NewString("images"),
+				},
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	err = InfraSAs.addServiceAccount(
 		InfraServiceLoadBalancerControllerServiceAccountName,
 		authorizationapi.ClusterRole{
@@ -60,6 +60,8 @@ var AdmissionPlugins = []string{
 	"LimitRanger",
 	"ServiceAccount",
 	"SecurityContextConstraint",
+	"ImageSignaturePolicy",
+	"ImageVulnerabilityPolicy",
 	"BuildDefaults",
 	"BuildOverrides",
 	"AlwaysPullImages",
@@ -3,29 +3,50 @@ package origin
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/pborman/uuid"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	utilnet "k8s.io/kubernetes/pkg/util/net"
+	utilwait "k8s.io/kubernetes/pkg/util/wait"
 
+	"github.com/openshift/origin/pkg/audit"
 	authenticationapi "github.com/openshift/origin/pkg/auth/api"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 )
 
 // auditResponseWriter implements http.ResponseWriter interface.
 type auditResponseWriter struct {
 	http.ResponseWriter
 	id string
+
+	// code is the response code, recorded in structured audit events
+	code int
+	// body records the response body, if the structured audit event includes it
+	body io.Writer
 }
 
 func (a *auditResponseWriter) WriteHeader(code int) {
 	glog.Infof("AUDIT: id=%q response=\"%d\"", a.id, code)
+	a.code = code
 	a.ResponseWriter.WriteHeader(code)
 }
 
+func (a *auditResponseWriter) Write(data []byte) (int, error) {
+	if a.code == 0 {
+		a.code = http.StatusOK
+	}
+	if a.body != nil {
+		a.body.Write(data)
+	}
+	return a.ResponseWriter.Write(data)
+}
+
 var _ http.ResponseWriter = &auditResponseWriter{}
 
 // fancyResponseWriterDelegator implements http.CloseNotifier, http.Flusher and
@@ -62,11 +83,23 @@ var _ http.Hijacker = &fancyResponseWriterDelegator{}
 //    - namespace of the request or <none>
 //    - uri is the full URI as requested
 // 2. the response line containing the unique id from 1 and response code
+// If a file or webhook is configured, the requests selected by the audit policy
+// are also recorded as structured events, written once the response is complete.
 func (c *MasterConfig) auditHandler(handler http.Handler) http.Handler {
 	if !c.Options.AuditConfig.Enabled {
 		return handler
 	}
 
+	sinks, err := c.auditSinks()
+	if err != nil {
+		glog.Fatalf("Unable to set up the audit event sinks: %v", err)
+	}
+	policy := audit.NewPolicy(c.Options.AuditConfig.Policy)
+	maxBodyBytes := c.Options.AuditConfig.MaxBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = audit.DefaultMaxBodyBytes
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		ctx, _ := c.RequestContextMapper.Get(req)
 		user, _ := kapi.UserFrom(ctx)
@@ -93,14 +126,114 @@ func (c *MasterConfig) auditHandler(handler http.Handler) http.Handler {
 		}
 		id := uuid.NewRandom().String()
 
+		event := &audit.Event{
+			ID:                 id,
+			Timestamp:          time.Now(),
+			SourceIP:           utilnet.GetClientIP(req).String(),
+			Method:             req.Method,
+			URI:                req.URL.String(),
+			User:               user.GetName(),
+			Groups:             user.GetGroups(),
+			ImpersonatedUser:   req.Header.Get(authenticationapi.ImpersonateUserHeader),
+			ImpersonatedGroups: requestedGroups,
+			Namespace:          kapi.NamespaceValue(ctx),
+		}
+		if attributes, err := c.AuthorizationAttributeBuilder.GetAttributes(req); err != nil {
+			glog.Errorf("Unable to determine the attributes of audited request %s: %v", id, err)
+		} else {
+			event.Verb = attributes.GetVerb()
+			event.APIGroup = attributes.GetAPIGroup()
+			event.Resource = attributes.GetResource()
+			// the names of tokens are the tokens themselves, so they are not recorded
+			event.Name, event.URI = audit.Redact(event.Resource, attributes.GetResourceName(), event.URI)
+		}
+
 		glog.Infof("AUDIT: id=%q ip=%q method=%q user=%q as=%q asgroups=%q namespace=%q uri=%q",
-			id, utilnet.GetClientIP(req), req.Method, user.GetName(), asuser, asgroups, namespace, req.URL)
-		handler.ServeHTTP(constructResponseWriter(w, id), req)
+			id, event.SourceIP, req.Method, user.GetName(), asuser, asgroups, namespace, event.URI)
+
+		if len(sinks) == 0 {
+			handler.ServeHTTP(constructResponseWriter(w, id, nil), req)
+			return
+		}
+		event.Level = policy.Level(user, event.Verb, event.Namespace, event.Resource)
+		if event.Level == audit.LevelNone {
+			handler.ServeHTTP(constructResponseWriter(w, id, nil), req)
+			return
+		}
+
+		var requestBody, responseBody *audit.Body
+		if audit.LevelMetadata.Less(event.Level) && req.Body != nil {
+			requestBody = audit.NewBody(maxBodyBytes)
+			req.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(req.Body, requestBody), req.Body}
+		}
+		var responseBodyWriter io.Writer
+		if audit.LevelRequest.Less(event.Level) {
+			responseBody = audit.NewBody(maxBodyBytes)
+			responseBodyWriter = responseBody
+		}
+
+		responseWriter := constructResponseWriter(w, id, responseBodyWriter)
+		handler.ServeHTTP(responseWriter, req)
+
+		event.ResponseCode = responseCode(responseWriter)
+		if requestBody != nil {
+			event.RequestBody, event.RequestBodyTruncated = requestBody.Encode()
+		}
+		if responseBody != nil {
+			event.ResponseBody, event.ResponseBodyTruncated = responseBody.Encode()
+		}
+		for _, sink := range sinks {
+			if err := sink.Write(event); err != nil {
+				glog.Errorf("Unable to write audit event %s: %v", id, err)
+			}
+		}
 	})
 }
 
-func constructResponseWriter(responseWriter http.ResponseWriter, id string) http.ResponseWriter {
-	delegate := &auditResponseWriter{ResponseWriter: responseWriter, id: id}
+// auditSinks returns the sinks structured audit events are written to
+func (c *MasterConfig) auditSinks() ([]audit.Sink, error) {
+	config := c.Options.AuditConfig
+	sinks := []audit.Sink{}
+	if config.File != nil {
+		sink, err := audit.NewFileSink(config.File.Path, int64(config.File.MaxSizeMegabytes)*1024*1024, config.File.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if config.Webhook != nil {
+		transport, err := cmdutil.TransportFor(config.Webhook.CA, config.Webhook.ClientCert.CertFile, config.Webhook.ClientCert.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		sink := audit.NewWebhookSink(config.Webhook.URL, transport)
+		go sink.Run(utilwait.NeverStop)
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// responseCode returns the response code written to the response writer
+func responseCode(w http.ResponseWriter) int {
+	var code int
+	switch w := w.(type) {
+	case *auditResponseWriter:
+		code = w.code
+	case *fancyResponseWriterDelegator:
+		code = w.code
+	}
+	if code == 0 {
+		// nothing was written, which net/http answers with a 200
+		code = http.StatusOK
+	}
+	return code
+}
+
+func constructResponseWriter(responseWriter http.ResponseWriter, id string, body io.Writer) http.ResponseWriter {
+	delegate := &auditResponseWriter{ResponseWriter: responseWriter, id: id, body: body}
 	// check if the ResponseWriter we're wrapping is the fancy one we need
 	// or if the basic is sufficient
 	_, cn := responseWriter.(http.CloseNotifier)
@@ -25,7 +25,7 @@ func (*fancyResponseWriter) Flush() {}
 func (*fancyResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return nil, nil, nil }
 
 func TestConstructResponseWriter(t *testing.T) {
-	actual := constructResponseWriter(&simpleResponseWriter{}, "")
+	actual := constructResponseWriter(&simpleResponseWriter{}, "", nil)
 	switch v := actual.(type) {
 	case *auditResponseWriter:
 		break
@@ -33,7 +33,7 @@ func TestConstructResponseWriter(t *testing.T) {
 		t.Errorf("Expected auditResponseWriter, got %v", reflect.TypeOf(v))
 	}
 
-	actual = constructResponseWriter(&fancyResponseWriter{}, "")
+	actual = constructResponseWriter(&fancyResponseWriter{}, "", nil)
 	switch v := actual.(type) {
 	case *fancyResponseWriterDelegator:
 		break
@@ -23,6 +23,7 @@ import (
 	knet "k8s.io/kubernetes/pkg/util/net"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"github.com/openshift/origin/pkg/auth/authenticator/challenger/passwordchallenger"
 	"github.com/openshift/origin/pkg/auth/authenticator/challenger/placeholderchallenger"
@@ -32,6 +33,8 @@ import (
 	"github.com/openshift/origin/pkg/auth/authenticator/password/htpasswd"
 	"github.com/openshift/origin/pkg/auth/authenticator/password/keystonepassword"
 	"github.com/openshift/origin/pkg/auth/authenticator/password/ldappassword"
+	"github.com/openshift/origin/pkg/auth/authenticator/password/lockoutpassword"
+	"github.com/openshift/origin/pkg/auth/authenticator/password/totppassword"
 	"github.com/openshift/origin/pkg/auth/authenticator/redirector"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/basicauthrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/headerrequest"
@@ -45,12 +48,14 @@ import (
 	"github.com/openshift/origin/pkg/auth/oauth/external/openid"
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/oauth/registry"
+	"github.com/openshift/origin/pkg/auth/saml"
 	"github.com/openshift/origin/pkg/auth/server/csrf"
 	"github.com/openshift/origin/pkg/auth/server/errorpage"
 	"github.com/openshift/origin/pkg/auth/server/grant"
 	"github.com/openshift/origin/pkg/auth/server/login"
 	"github.com/openshift/origin/pkg/auth/server/selectprovider"
 	"github.com/openshift/origin/pkg/auth/server/tokenrequest"
+	"github.com/openshift/origin/pkg/auth/totp"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
@@ -63,6 +68,7 @@ import (
 	clientetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclient/etcd"
 	clientauthregistry "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization"
 	clientauthetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization/etcd"
+	"github.com/openshift/origin/pkg/oauth/registry/personalaccesstoken"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver/registrystorage"
 	saoauth "github.com/openshift/origin/pkg/serviceaccounts/oauthclient"
@@ -317,6 +323,18 @@ func CreateOrUpdateDefaultOAuthClients(masterPublicAddr string, assetPublicAddre
 		}
 	}
 
+	{
+		// personal access tokens are issued directly by the API, so the client has no redirects
+		personalAccessTokenClient := oauthapi.OAuthClient{
+			ObjectMeta:            kapi.ObjectMeta{Name: personalaccesstoken.ClientName},
+			Secret:                uuid.New(),
+			RespondWithChallenges: false,
+		}
+		if err := ensureOAuthClient(personalAccessTokenClient, clientRegistry, false); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -416,6 +434,7 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 		if err != nil {
 			return nil, err
 		}
+		identityMapper = c.getGroupsIdentityMapper(identityProvider, identityMapper)
 
 		// TODO: refactor handler building per type
 		if configapi.IsPasswordAuthenticator(identityProvider) {
@@ -502,6 +521,42 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 				// For now, all password challenges share a single basic challenger, since they'll all respond to any basic credentials
 				challengers["basic-challenge"] = passwordchallenger.NewBasicAuthChallenger("openshift")
 			}
+		} else if samlProvider, isSAML := identityProvider.Provider.(*configapi.SAMLIdentityProvider); isSAML {
+			certs, err := cmdutil.CertificatesFromFile(samlProvider.SigningCertificate)
+			if err != nil {
+				return nil, err
+			}
+			samlConfig := saml.Config{
+				EntityID:     samlProvider.EntityID,
+				SSOURL:       samlProvider.SSOURL,
+				Certificates: certs,
+
+				IDAttributes:                samlProvider.Attributes.ID,
+				PreferredUsernameAttributes: samlProvider.Attributes.PreferredUsername,
+				NameAttributes:              samlProvider.Attributes.Name,
+				EmailAttributes:             samlProvider.Attributes.Email,
+				GroupsAttributes:            samlProvider.Attributes.Groups,
+			}
+
+			// Default state builder, combining CSRF and return URL handling, sent as the RelayState
+			state := external.CSRFRedirectingState(c.getCSRF())
+
+			if c.SessionAuth == nil {
+				return nil, errors.New("SessionAuth is required for SAML-based login")
+			}
+			samlSuccessHandler := handlers.AuthenticationSuccessHandlers{c.SessionAuth, state}
+			samlErrorHandler := handlers.AuthenticationErrorHandlers{errorHandler, state}
+
+			callbackPath := path.Join(OpenShiftOAuthCallbackPrefix, identityProvider.Name)
+			samlRedirector, samlHandler, err := saml.NewSAMLRedirector(identityProvider.Name, samlConfig, state, c.Options.MasterPublicURL+callbackPath, samlSuccessHandler, samlErrorHandler, identityMapper)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected error: %v", err)
+			}
+
+			mux.Handle(callbackPath, samlHandler)
+			if identityProvider.UseAsLogin {
+				redirectors.Add(identityProvider.Name, samlRedirector)
+			}
 		} else if requestHeaderProvider, isRequestHeader := identityProvider.Provider.(*configapi.RequestHeaderIdentityProvider); isRequestHeader {
 			// We might be redirecting to an external site, we need to fully resolve the request URL to the public master
 			baseRequestURL, err := url.Parse(c.Options.MasterPublicURL + OpenShiftOAuthAPIPrefix + osinserver.AuthorizePath)
@@ -595,6 +650,7 @@ func (c *AuthConfig) getOAuthProvider(identityProvider configapi.IdentityProvide
 			PreferredUsernameClaims: provider.Claims.PreferredUsername,
 			EmailClaims:             provider.Claims.Email,
 			NameClaims:              provider.Claims.Name,
+			GroupsClaims:            provider.Claims.Groups,
 		}
 
 		return openid.NewProvider(identityProvider.Name, transport, config)
@@ -605,7 +661,38 @@ func (c *AuthConfig) getOAuthProvider(identityProvider configapi.IdentityProvide
 
 }
 
+// getGroupsIdentityMapper wraps identityMapper to synchronize the group membership of the users of identity providers
+// configured to return groups
+func (c *AuthConfig) getGroupsIdentityMapper(identityProvider configapi.IdentityProvider, identityMapper authapi.UserIdentityMapper) authapi.UserIdentityMapper {
+	switch provider := identityProvider.Provider.(type) {
+	case (*configapi.OpenIDIdentityProvider):
+		if len(provider.Claims.Groups) > 0 {
+			return identitymapper.NewGroupsIdentityMapper(identityMapper, c.GroupRegistry, configapi.GetOpenIDGroupPrefix(identityProvider, provider))
+		}
+	case (*configapi.SAMLIdentityProvider):
+		if len(provider.Attributes.Groups) > 0 {
+			return identitymapper.NewGroupsIdentityMapper(identityMapper, c.GroupRegistry, configapi.GetSAMLGroupPrefix(identityProvider, provider))
+		}
+	}
+	return identityMapper
+}
+
 func (c *AuthConfig) getPasswordAuthenticator(identityProvider configapi.IdentityProvider) (authenticator.Password, error) {
+	passwordAuth, err := c.getProviderPasswordAuthenticator(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+	if identityProvider.TOTP != nil {
+		passwordAuth = totppassword.New(passwordAuth, c.KubeClient.Secrets(totp.SecretNamespace), identityProvider.TOTP.Required, c.TOTPUsedCodes)
+	}
+	if c.LoginAttempts != nil {
+		// failed verification codes count as failed logins
+		passwordAuth = lockoutpassword.New(identityProvider.Name, passwordAuth, c.LoginAttempts, c.LoginEventRecorder, loginEventObject)
+	}
+	return passwordAuth, nil
+}
+
+func (c *AuthConfig) getProviderPasswordAuthenticator(identityProvider configapi.IdentityProvider) (authenticator.Password, error) {
 	identityMapper, err := identitymapper.NewIdentityUserMapper(c.IdentityRegistry, c.UserRegistry, identitymapper.MappingMethodType(identityProvider.MappingMethod))
 	if err != nil {
 		return nil, err
@@ -696,6 +783,7 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 		if err != nil {
 			return nil, err
 		}
+		identityMapper = c.getGroupsIdentityMapper(identityProvider, identityMapper)
 
 		if configapi.IsPasswordAuthenticator(identityProvider) {
 			passwordAuthenticator, err := c.getPasswordAuthenticator(identityProvider)
@@ -760,6 +848,11 @@ type callbackPasswordAuthenticator struct {
 	handlers.AuthenticationSuccessHandler
 }
 
+// AuthenticatePasswordFromSource passes the client address on to the password authenticator
+func (c *callbackPasswordAuthenticator) AuthenticatePasswordFromSource(source, username, password string) (kuser.Info, bool, error) {
+	return authenticator.AuthenticatePasswordFromSource(c.Password, source, username, password)
+}
+
 // redirectSuccessHandler redirects to the then param on successful authentication
 type redirectSuccessHandler struct{}
 
@@ -3,18 +3,27 @@ package origin
 import (
 	"crypto/md5"
 	"fmt"
+	"net"
 	"net/url"
+	"time"
 
 	"github.com/pborman/uuid"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/storage"
 
+	"github.com/openshift/origin/pkg/auth/authenticator/password/lockoutpassword"
+	"github.com/openshift/origin/pkg/auth/authenticator/password/totppassword"
 	"github.com/openshift/origin/pkg/auth/server/session"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	"github.com/openshift/origin/pkg/cmd/server/etcd"
+	groupregistry "github.com/openshift/origin/pkg/user/registry/group"
+	groupetcd "github.com/openshift/origin/pkg/user/registry/group/etcd"
 	identityregistry "github.com/openshift/origin/pkg/user/registry/identity"
 	identityetcd "github.com/openshift/origin/pkg/user/registry/identity/etcd"
 	userregistry "github.com/openshift/origin/pkg/user/registry/user"
@@ -41,12 +50,29 @@ type AuthConfig struct {
 
 	UserRegistry     userregistry.Registry
 	IdentityRegistry identityregistry.Registry
+	// GroupRegistry is used to synchronize the groups asserted by identity providers
+	GroupRegistry groupregistry.Registry
+	// TOTPUsedCodes is shared by the password identity providers requiring TOTP, so a code can not be used twice
+	TOTPUsedCodes *totppassword.UsedCodes
+	// LoginAttempts is shared by the password identity providers to limit failed logins. It is nil if failed logins are
+	// not limited.
+	LoginAttempts *lockoutpassword.Attempts
+	// LoginEventRecorder records the lockouts of users and the throttling of client addresses on loginEventObject
+	LoginEventRecorder record.EventRecorder
 
 	SessionAuth *session.Authenticator
 
 	HandlerWrapper handlerWrapper
 }
 
+// loginEventObject is the object the lockouts of users and the throttling of client addresses are recorded on. Login
+// names do not necessarily belong to a user, so the events are recorded on the infrastructure namespace.
+var loginEventObject = &kapi.ObjectReference{
+	Kind:      "Namespace",
+	Name:      bootstrappolicy.DefaultOpenShiftInfraNamespace,
+	Namespace: bootstrappolicy.DefaultOpenShiftInfraNamespace,
+}
+
 func BuildAuthConfig(masterConfig *MasterConfig) (*AuthConfig, error) {
 	options := masterConfig.Options
 	kubeClient := masterConfig.KubeClient()
@@ -102,6 +128,33 @@ func BuildAuthConfig(masterConfig *MasterConfig) (*AuthConfig, error) {
 	}
 	identityRegistry := identityregistry.NewRegistry(identityStorage)
 
+	groupStorage, err := groupetcd.NewREST(masterConfig.RESTOptionsGetter)
+	if err != nil {
+		return nil, err
+	}
+	groupRegistry := groupregistry.NewRegistry(groupStorage)
+
+	var loginAttempts *lockoutpassword.Attempts
+	var loginEventRecorder record.EventRecorder
+	if throttling := options.OAuthConfig.LoginThrottling; throttling != nil {
+		trustedProxies := []*net.IPNet{}
+		for _, cidr := range throttling.TrustedProxies {
+			_, proxy, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			trustedProxies = append(trustedProxies, proxy)
+		}
+		loginAttempts = lockoutpassword.NewAttempts(
+			throttling.MaxUserFailures, time.Duration(throttling.UserLockoutSeconds)*time.Second,
+			throttling.MaxSourceFailures, time.Duration(throttling.SourceWindowSeconds)*time.Second,
+			trustedProxies,
+		)
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(kubeClient.Events(""))
+		loginEventRecorder = eventBroadcaster.NewRecorder(kapi.EventSource{Component: "oauth-server"})
+	}
+
 	ret := &AuthConfig{
 		Options: *options.OAuthConfig,
 
@@ -113,6 +166,11 @@ func BuildAuthConfig(masterConfig *MasterConfig) (*AuthConfig, error) {
 
 		IdentityRegistry: identityRegistry,
 		UserRegistry:     userRegistry,
+		GroupRegistry:    groupRegistry,
+		TOTPUsedCodes:    totppassword.NewUsedCodes(),
+
+		LoginAttempts:      loginAttempts,
+		LoginEventRecorder: loginEventRecorder,
 
 		SessionAuth: sessionAuth,
 
@@ -58,17 +58,21 @@ import (
 	"github.com/openshift/origin/pkg/image/registry/image"
 	imageetcd "github.com/openshift/origin/pkg/image/registry/image/etcd"
 	"github.com/openshift/origin/pkg/image/registry/imagesecret"
+	"github.com/openshift/origin/pkg/image/registry/imagesignature"
 	"github.com/openshift/origin/pkg/image/registry/imagestream"
 	imagestreametcd "github.com/openshift/origin/pkg/image/registry/imagestream/etcd"
 	"github.com/openshift/origin/pkg/image/registry/imagestreamimage"
 	"github.com/openshift/origin/pkg/image/registry/imagestreamimport"
 	"github.com/openshift/origin/pkg/image/registry/imagestreammapping"
 	"github.com/openshift/origin/pkg/image/registry/imagestreamtag"
+	accesstokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
 	authorizetokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthauthorizetoken/etcd"
 	clientregistry "github.com/openshift/origin/pkg/oauth/registry/oauthclient"
 	clientetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclient/etcd"
 	clientauthetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization/etcd"
+	"github.com/openshift/origin/pkg/oauth/registry/personalaccesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/useroauthaccesstoken"
 	projectproxy "github.com/openshift/origin/pkg/project/registry/project/proxy"
 	projectrequeststorage "github.com/openshift/origin/pkg/project/registry/projectrequest/delegated"
 	routeallocationcontroller "github.com/openshift/origin/pkg/route/controller/allocation"
@@ -80,6 +84,7 @@ import (
 	saoauth "github.com/openshift/origin/pkg/serviceaccounts/oauthclient"
 	templateregistry "github.com/openshift/origin/pkg/template/registry"
 	templateetcd "github.com/openshift/origin/pkg/template/registry/etcd"
+	templateinstanceetcd "github.com/openshift/origin/pkg/template/registry/templateinstance/etcd"
 	groupetcd "github.com/openshift/origin/pkg/user/registry/group/etcd"
 	identityregistry "github.com/openshift/origin/pkg/user/registry/identity"
 	identityetcd "github.com/openshift/origin/pkg/user/registry/identity/etcd"
@@ -487,6 +492,7 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 	imageStreamImportStorage := imagestreamimport.NewREST(importerFn, imageStreamRegistry, internalImageStreamStorage, imageStorage, c.ImageStreamImportSecretClient(), importTransport, insecureImportTransport, importerDockerClientFn)
 	imageStreamImageStorage := imagestreamimage.NewREST(imageRegistry, imageStreamRegistry)
 	imageStreamImageRegistry := imagestreamimage.NewRegistry(imageStreamImageStorage)
+	imageSignatureStorage := imagesignature.NewREST(imageRegistry)
 
 	buildGenerator := &buildgenerator.BuildGenerator{
 		Client: buildgenerator.Client{
@@ -545,14 +551,25 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 	checkStorageErr(err)
 	accessTokenStorage, err := accesstokenetcd.NewREST(c.RESTOptionsGetter, combinedOAuthClientGetter)
 	checkStorageErr(err)
+	personalAccessTokenNames, err := personalaccesstoken.NewNames(c.RESTOptionsGetter)
+	checkStorageErr(err)
+	personalAccessTokenMaxAge := int64(0)
+	if c.Options.OAuthConfig != nil {
+		personalAccessTokenMaxAge = int64(c.Options.OAuthConfig.TokenConfig.AccessTokenMaxAgeSeconds)
+	}
+	personalAccessTokenStorage := personalaccesstoken.NewREST(accesstokenregistry.NewRegistry(accessTokenStorage), personalAccessTokenNames, userRegistry, personalAccessTokenMaxAge)
+	userAccessTokenStorage := useroauthaccesstoken.NewREST(accesstokenregistry.NewRegistry(accessTokenStorage))
 	clientAuthorizationStorage, err := clientauthetcd.NewREST(c.RESTOptionsGetter, combinedOAuthClientGetter)
 	checkStorageErr(err)
 
 	templateStorage, err := templateetcd.NewREST(c.RESTOptionsGetter)
 	checkStorageErr(err)
+	templateInstanceStorage, err := templateinstanceetcd.NewREST(c.RESTOptionsGetter)
+	checkStorageErr(err)
 
 	storage := map[string]rest.Storage{
 		"images":               imageStorage,
+		"imageSignatures":      imageSignatureStorage,
 		"imageStreams/secrets": imageStreamSecretsStorage,
 		"imageStreams":         imageStreamStorage,
 		"imageStreams/status":  imageStreamStatusStorage,
@@ -573,6 +590,7 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 
 		"processedTemplates": templateregistry.NewREST(),
 		"templates":          templateStorage,
+		"templateInstances":  templateInstanceStorage,
 
 		"routes":        routeStorage,
 		"routes/status": routeStatusStorage,
@@ -593,6 +611,8 @@ func (c *MasterConfig) GetRestStorage() map[string]rest.Storage {
 		"oAuthAccessTokens":         accessTokenStorage,
 		"oAuthClients":              clientStorage,
 		"oAuthClientAuthorizations": clientAuthorizationStorage,
+		"personalAccessTokens":      personalAccessTokenStorage,
+		"userOAuthAccessTokens":     userAccessTokenStorage,
 
 		"resourceAccessReviews":      resourceAccessReviewStorage,
 		"subjectAccessReviews":       subjectAccessReviewStorage,
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net/http"
 	"path"
 	"time"
 
@@ -636,6 +637,35 @@ func (c *MasterConfig) ImageImportControllerClient() *osclient.Client {
 	return c.PrivilegedLoopbackOpenShiftClient
 }
 
+// ImageRetentionControllerClients returns the image retention controller client objects. The registry client
+// authenticates to the integrated registry with the token of the service account of the controller.
+func (c *MasterConfig) ImageRetentionControllerClients() (*osclient.Client, *kclient.Client, *http.Client) {
+	config, osClient, kClient, err := c.GetServiceAccountClients(bootstrappolicy.InfraImageRetentionControllerServiceAccountName)
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	// the registry expects the token as the password of a basic authentication
+	registryConfig := restclient.Config{
+		Username: "unused",
+		Password: config.BearerToken,
+		TLSClientConfig: restclient.TLSClientConfig{
+			CAFile: config.CAFile,
+			CAData: config.CAData,
+		},
+	}
+	transport, err := restclient.TransportFor(&registryConfig)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	return osClient, kClient, &http.Client{Transport: transport}
+}
+
+// GroupSyncControllerClient returns the group sync controller client object
+func (c *MasterConfig) GroupSyncControllerClient() *osclient.Client {
+	return c.PrivilegedLoopbackOpenShiftClient
+}
+
 // DeploymentConfigScaleClient returns the client used by the Scale subresource registry
 func (c *MasterConfig) DeploymentConfigScaleClient() *kclient.Client {
 	return c.PrivilegedLoopbackKubernetesClient
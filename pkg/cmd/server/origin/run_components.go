@@ -29,6 +29,9 @@ import (
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	buildcontrollerfactory "github.com/openshift/origin/pkg/build/controller/factory"
 	buildstrategy "github.com/openshift/origin/pkg/build/controller/strategy"
+	groupsynccli "github.com/openshift/origin/pkg/cmd/admin/groups/sync/cli"
+	groupsynccontroller "github.com/openshift/origin/pkg/cmd/admin/groups/sync/controller"
+	configapilatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
 	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
@@ -421,6 +424,64 @@ func (c *MasterConfig) RunImageImportController() {
 	}
 }
 
+// RunImageRetentionController starts the controller enforcing the retention policy of image streams.
+func (c *MasterConfig) RunImageRetentionController() {
+	if !c.Options.ImagePolicyConfig.EnableImageRetention {
+		glog.V(2).Infof("Image retention is disabled - the retention policy of image streams will be ignored")
+		return
+	}
+	osclient, kclient, registryClient := c.ImageRetentionControllerClients()
+	factory := imagecontroller.RetentionControllerFactory{
+		Client:         osclient,
+		KubeClient:     kclient,
+		RegistryClient: registryClient,
+		ResyncInterval: 10 * time.Minute,
+		SyncInterval:   10 * time.Minute,
+	}
+	factory.Create().Run()
+}
+
+// RunGroupSyncController starts the controller periodically syncing groups with an LDAP server.
+func (c *MasterConfig) RunGroupSyncController() {
+	config := c.Options.ControllerConfig.GroupSync
+	if config == nil {
+		glog.V(3).Infof("Group sync is disabled - groups are not synced with LDAP")
+		return
+	}
+	syncConfig, err := configapilatest.ReadAndResolveLDAPSyncConfig(config.SyncConfigFile)
+	if err != nil {
+		glog.Fatalf("Could not read the group sync config: %v", err)
+	}
+
+	osclient := c.GroupSyncControllerClient()
+	controller := &groupsynccontroller.GroupSyncController{
+		Sync: &groupsynccli.SyncOptions{
+			Source:         groupsynccli.GroupSyncSourceLDAP,
+			Config:         syncConfig,
+			Whitelist:      config.Whitelist,
+			Blacklist:      config.Blacklist,
+			Confirm:        true,
+			GroupInterface: osclient.Groups(),
+			Out:            cmdutil.NewGLogWriterV(4),
+			Stderr:         cmdutil.NewGLogWriterV(0),
+		},
+		Interval: time.Duration(config.SyncIntervalSeconds) * time.Second,
+	}
+	if config.Prune {
+		// prune the groups previously synced with the LDAP server, restricted by the same lists as the sync
+		controller.Prune = &groupsynccli.PruneOptions{
+			Config:         syncConfig,
+			Whitelist:      config.Whitelist,
+			Blacklist:      config.Blacklist,
+			Confirm:        true,
+			GroupInterface: osclient.Groups(),
+			Out:            cmdutil.NewGLogWriterV(4),
+			Stderr:         cmdutil.NewGLogWriterV(0),
+		}
+	}
+	controller.Run()
+}
+
 // RunSecurityAllocationController starts the security allocation controller process.
 func (c *MasterConfig) RunSecurityAllocationController() {
 	alloc := c.Options.ProjectConfig.SecurityAllocator
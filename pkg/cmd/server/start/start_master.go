@@ -653,6 +653,8 @@ func startControllers(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) erro
 	oc.RunDeploymentTriggerController()
 	oc.RunDeploymentImageChangeTriggerController()
 	oc.RunImageImportController()
+	oc.RunImageRetentionController()
+	oc.RunGroupSyncController()
 	oc.RunOriginNamespaceController()
 	oc.RunSDNController()
 	oc.RunClusterQuotaMappingController()
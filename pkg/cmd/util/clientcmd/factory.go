@@ -35,6 +35,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubectl/resource"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util/homedir"
 
 	"github.com/openshift/origin/pkg/api/latest"
@@ -54,6 +55,8 @@ import (
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	routegen "github.com/openshift/origin/pkg/route/generator"
+	templateapi "github.com/openshift/origin/pkg/template/api"
+	templatereaper "github.com/openshift/origin/pkg/template/reaper"
 	userapi "github.com/openshift/origin/pkg/user/api"
 	authenticationreaper "github.com/openshift/origin/pkg/user/reaper"
 )
@@ -292,6 +295,8 @@ func NewFactory(clientConfig kclientcmd.ClientConfig) *Factory {
 			), nil
 		case buildapi.Kind("BuildConfig"):
 			return buildreaper.NewBuildConfigReaper(oc), nil
+		case templateapi.Kind("TemplateInstance"):
+			return templatereaper.NewTemplateInstanceReaper(oc, w.objectUID, w.deleteObject), nil
 		}
 		return kReaperFunc(mapping)
 	}
@@ -658,6 +663,58 @@ func podNameForJob(job *batch.Job, kc *kclient.Client, timeout time.Duration, so
 	return pod.Name, nil
 }
 
+// objectUID returns the UID of the object ref points to in namespace.
+func (w *Factory) objectUID(namespace string, ref api.ObjectReference) (types.UID, error) {
+	gv, err := unversioned.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return "", err
+	}
+	mapper, _ := w.Object(false)
+	mapping, err := mapper.RESTMapping(unversioned.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return "", err
+	}
+	c, err := w.ClientForMapping(mapping)
+	if err != nil {
+		return "", err
+	}
+	obj, err := resource.NewHelper(c, mapping).Get(namespace, ref.Name, false)
+	if err != nil {
+		return "", err
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	return objMeta.GetUID(), nil
+}
+
+// deleteObject deletes the object ref points to in namespace. Objects of kinds that have a reaper
+// are stopped with it, so that the objects they own are deleted as well.
+func (w *Factory) deleteObject(namespace string, ref api.ObjectReference) error {
+	gv, err := unversioned.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return err
+	}
+	mapper, _ := w.Object(false)
+	mapping, err := mapper.RESTMapping(unversioned.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return err
+	}
+	reaper, err := w.Reaper(mapping)
+	if err == nil {
+		return reaper.Stop(namespace, ref.Name, kubectl.Timeout, nil)
+	}
+	if !kubectl.IsNoSuchReaperError(err) {
+		return err
+	}
+	c, err := w.ClientForMapping(mapping)
+	if err != nil {
+		return err
+	}
+	return resource.NewHelper(c, mapping).Delete(namespace, ref.Name)
+}
+
 // Clients returns an OpenShift and Kubernetes client.
 func (f *Factory) Clients() (*client.Client, *kclient.Client, error) {
 	kClient, err := f.Client()
@@ -1,8 +1,11 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/context"
@@ -43,6 +46,11 @@ type blobHandler struct {
 func (bh *blobHandler) Delete(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
+	if isReadOnly() {
+		bh.Errors = append(bh.Errors, ErrorCodeReadOnly)
+		return
+	}
+
 	if len(bh.Digest) == 0 {
 		bh.Errors = append(bh.Errors, v2.ErrorCodeBlobUnknown)
 		return
@@ -73,3 +81,64 @@ func (bh *blobHandler) Delete(w http.ResponseWriter, req *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GarbageCollectDispatcher takes the request context and builds the appropriate handler for handling
+// garbage collection requests.
+func GarbageCollectDispatcher(ctx *handlers.Context, r *http.Request) http.Handler {
+	gcHandler := &garbageCollectHandler{
+		Context: ctx,
+	}
+
+	return gorillahandlers.MethodHandler{
+		"POST": http.HandlerFunc(gcHandler.Post),
+	}
+}
+
+// garbageCollectHandler handles http operations on the garbage collector.
+type garbageCollectHandler struct {
+	*handlers.Context
+}
+
+// Post runs the garbage collector and writes its result. Blobs are deleted only if the dryRun query
+// parameter is false. The gracePeriod query parameter overrides the default minimum age of deleted blobs.
+// The registry does not collect garbage on its own: a single job must send this request to one replica.
+func (gh *garbageCollectHandler) Post(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	dryRun := true
+	if value := req.URL.Query().Get("dryRun"); len(value) > 0 {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			gh.Errors = append(gh.Errors, errcode.ErrorCodeUnknown.WithDetail(fmt.Sprintf("invalid dryRun %q: %v", value, err)))
+			return
+		}
+		dryRun = parsed
+	}
+
+	gracePeriod := defaultGCGracePeriod
+	if value := req.URL.Query().Get("gracePeriod"); len(value) > 0 {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			gh.Errors = append(gh.Errors, errcode.ErrorCodeUnknown.WithDetail(fmt.Sprintf("invalid gracePeriod %q", value)))
+			return
+		}
+		gracePeriod = parsed
+	}
+
+	osClient, _, err := DefaultRegistryClient.Clients()
+	if err != nil {
+		gh.Errors = append(gh.Errors, errcode.ErrorCodeUnknown.WithDetail(err.Error()))
+		return
+	}
+
+	result, err := newGarbageCollector(osClient, gracePeriod).collect(gh, dryRun)
+	if err != nil {
+		gh.Errors = append(gh.Errors, errcode.ErrorCodeUnknown.WithDetail(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		context.GetLogger(gh).Errorf("error writing garbage collection result: %v", err)
+	}
+}
@@ -11,7 +11,8 @@ import (
 )
 
 // errorBlobStore wraps a distribution.BlobStore for a particular repo.
-// before delegating, it ensures auth completed and there were no errors relevant to the repo.
+// before delegating, it ensures auth completed and there were no errors relevant to the repo, and it rejects
+// writes and deletes in read-only maintenance mode.
 type errorBlobStore struct {
 	store distribution.BlobStore
 	repo  *repository
@@ -44,6 +45,9 @@ func (r *errorBlobStore) Put(ctx context.Context, mediaType string, p []byte) (d
 	if err := r.repo.checkPendingErrors(ctx); err != nil {
 		return distribution.Descriptor{}, err
 	}
+	if err := checkReadOnly(); err != nil {
+		return distribution.Descriptor{}, err
+	}
 	return r.store.Put(ctx, mediaType, p)
 }
 
@@ -51,6 +55,9 @@ func (r *errorBlobStore) Create(ctx context.Context, options ...distribution.Blo
 	if err := r.repo.checkPendingErrors(ctx); err != nil {
 		return nil, err
 	}
+	if err := checkReadOnly(); err != nil {
+		return nil, err
+	}
 
 	opts, err := effectiveCreateOptions(options)
 	if err != nil {
@@ -70,6 +77,9 @@ func (r *errorBlobStore) Resume(ctx context.Context, id string) (distribution.Bl
 	if err := r.repo.checkPendingErrors(ctx); err != nil {
 		return nil, err
 	}
+	if err := checkReadOnly(); err != nil {
+		return nil, err
+	}
 	return r.store.Resume(ctx, id)
 }
 
@@ -84,6 +94,9 @@ func (r *errorBlobStore) Delete(ctx context.Context, dgst digest.Digest) error {
 	if err := r.repo.checkPendingErrors(ctx); err != nil {
 		return err
 	}
+	if err := checkReadOnly(); err != nil {
+		return err
+	}
 	return r.store.Delete(ctx, dgst)
 }
 
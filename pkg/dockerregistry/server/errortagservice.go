@@ -6,7 +6,8 @@ import (
 )
 
 // errorTagService wraps a distribution.TagService for a particular repo.
-// before delegating, it ensures auth completed and there were no errors relevant to the repo.
+// before delegating, it ensures auth completed and there were no errors relevant to the repo, and it rejects
+// tagging and untagging in read-only maintenance mode.
 type errorTagService struct {
 	tags distribution.TagService
 	repo *repository
@@ -25,6 +26,9 @@ func (t *errorTagService) Tag(ctx context.Context, tag string, desc distribution
 	if err := t.repo.checkPendingErrors(ctx); err != nil {
 		return err
 	}
+	if err := checkReadOnly(); err != nil {
+		return err
+	}
 	return t.tags.Tag(ctx, tag, desc)
 }
 
@@ -32,6 +36,9 @@ func (t *errorTagService) Untag(ctx context.Context, tag string) error {
 	if err := t.repo.checkPendingErrors(ctx); err != nil {
 		return err
 	}
+	if err := checkReadOnly(); err != nil {
+		return err
+	}
 	return t.tags.Untag(ctx, tag)
 }
 
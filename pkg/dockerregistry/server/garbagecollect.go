@@ -0,0 +1,233 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/storage"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	defaultGCGracePeriod = time.Hour
+
+	// blobsRootPath is where the upstream storage keeps blob data. It mirrors the layout of
+	// github.com/docker/distribution/registry/storage/paths.go which does not export it.
+	blobsRootPath = "/docker/registry/v2/blobs"
+)
+
+var (
+	// gcLock serializes garbage collections.
+	gcLock sync.Mutex
+	// sweepLock is held for reading by manifest pushes and for writing while blobs are swept, so that no push
+	// handled by this registry completes between the final mark and the deletion of the blobs it did not find.
+	sweepLock sync.RWMutex
+)
+
+// GarbageCollectionResult summarizes a single run of the garbage collector.
+type GarbageCollectionResult struct {
+	// DryRun is true if no blob was actually deleted.
+	DryRun bool `json:"dryRun"`
+	// BlobsMarked is the number of blobs referenced by images.
+	BlobsMarked int `json:"blobsMarked"`
+	// BlobsSkipped is the number of unreferenced blobs younger than the grace period.
+	BlobsSkipped int `json:"blobsSkipped"`
+	// BlobsDeleted lists digests of unreferenced blobs that were (or would be) deleted.
+	BlobsDeleted []string `json:"blobsDeleted"`
+	// BytesFreed is the total size of deleted blobs.
+	BytesFreed int64 `json:"bytesFreed"`
+}
+
+// garbageCollector deletes blobs from the storage that are not referenced by any image known to the
+// master. Blobs are marked twice: unreferenced blobs found by the first mark are only deleted if the second
+// mark, taken while the manifest pushes handled by this registry are held off, does not reference them
+// either. Pushes handled by other replicas are only held off if they go through the replica collecting, so
+// the garbage collector must be run by a single replica, from an explicit job, rather than periodically by
+// every replica.
+type garbageCollector struct {
+	driver      storagedriver.StorageDriver
+	blobs       distribution.BlobEnumerator
+	statter     distribution.BlobStatter
+	client      client.Interface
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+// newGarbageCollector returns a collector working on the storage and blobs of the running registry.
+func newGarbageCollector(osClient client.Interface, gracePeriod time.Duration) *garbageCollector {
+	return &garbageCollector{
+		driver:      dockerStorageDriver,
+		blobs:       dockerRegistry.Blobs(),
+		statter:     dockerRegistry.BlobStatter(),
+		client:      osClient,
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+	}
+}
+
+// collect runs a mark and sweep. Blobs are enumerated before images are listed so that a blob of an image
+// created in the meantime is never considered unreferenced.
+func (gc *garbageCollector) collect(ctx context.Context, dryRun bool) (*GarbageCollectionResult, error) {
+	gcLock.Lock()
+	defer gcLock.Unlock()
+
+	result := &GarbageCollectionResult{DryRun: dryRun, BlobsDeleted: []string{}}
+
+	candidates := []digest.Digest{}
+	err := gc.blobs.Enumerate(ctx, func(dgst digest.Digest) error {
+		candidates = append(candidates, dgst)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating blobs: %v", err)
+	}
+
+	marked, err := gc.mark(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.BlobsMarked = marked.Len()
+
+	unreferenced := []digest.Digest{}
+	sizes := map[digest.Digest]int64{}
+	for _, dgst := range candidates {
+		if marked.Has(dgst.String()) {
+			continue
+		}
+
+		info, err := gc.driver.Stat(ctx, blobDataPath(dgst))
+		if err != nil {
+			if _, ok := err.(storagedriver.PathNotFoundError); ok {
+				continue
+			}
+			return result, fmt.Errorf("failed to stat blob %s: %v", dgst, err)
+		}
+		if gc.now().Sub(info.ModTime()) < gc.gracePeriod {
+			context.GetLogger(ctx).Debugf("garbage collector: skipping recent blob %s", dgst)
+			result.BlobsSkipped++
+			continue
+		}
+		unreferenced = append(unreferenced, dgst)
+		sizes[dgst] = info.Size()
+	}
+
+	if dryRun {
+		for _, dgst := range unreferenced {
+			result.BlobsDeleted = append(result.BlobsDeleted, dgst.String())
+			result.BytesFreed += sizes[dgst]
+		}
+		return result, nil
+	}
+
+	// images pushed since the first mark may reference unreferenced blobs again
+	sweepLock.Lock()
+	defer sweepLock.Unlock()
+	remarked, err := gc.mark(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vacuum := storage.NewVacuum(ctx, gc.driver)
+	for _, dgst := range unreferenced {
+		if remarked.Has(dgst.String()) {
+			context.GetLogger(ctx).Debugf("garbage collector: skipping blob %s referenced by a new image", dgst)
+			result.BlobsSkipped++
+			continue
+		}
+		if err := vacuum.RemoveBlob(dgst.String()); err != nil {
+			if _, ok := err.(storagedriver.PathNotFoundError); !ok {
+				return result, fmt.Errorf("failed to delete blob %s: %v", dgst, err)
+			}
+		}
+		gc.clearDescriptor(ctx, dgst)
+		result.BlobsDeleted = append(result.BlobsDeleted, dgst.String())
+		result.BytesFreed += sizes[dgst]
+	}
+
+	return result, nil
+}
+
+// clearDescriptor removes the blob from the global blob descriptor cache, if there is one, so that the
+// registry does not claim to have it. Repository scoped caches are not reachable and expire on their own.
+func (gc *garbageCollector) clearDescriptor(ctx context.Context, dgst digest.Digest) {
+	cache, ok := gc.statter.(distribution.BlobDescriptorService)
+	if !ok {
+		return
+	}
+	if err := cache.Clear(ctx, dgst); err != nil && err != distribution.ErrUnsupported && err != distribution.ErrBlobUnknown {
+		context.GetLogger(ctx).Warnf("garbage collector: failed to clear cached descriptor of blob %s: %v", dgst, err)
+	}
+}
+
+// mark returns digests of all the blobs referenced by images. Images referenced by image streams that
+// were created after the images were listed are fetched individually.
+func (gc *garbageCollector) mark(ctx context.Context) (sets.String, error) {
+	marked := sets.NewString()
+	seen := sets.NewString()
+
+	images, err := gc.client.Images().List(kapi.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %v", err)
+	}
+	for i := range images.Items {
+		markImage(marked, &images.Items[i])
+		seen.Insert(images.Items[i].Name)
+	}
+
+	streams, err := gc.client.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image streams: %v", err)
+	}
+	for _, is := range streams.Items {
+		for _, history := range is.Status.Tags {
+			for _, event := range history.Items {
+				if seen.Has(event.Image) {
+					continue
+				}
+				seen.Insert(event.Image)
+
+				image, err := gc.client.Images().Get(event.Image)
+				if err != nil {
+					if kerrors.IsNotFound(err) {
+						continue
+					}
+					return nil, fmt.Errorf("failed to get image %s: %v", event.Image, err)
+				}
+				context.GetLogger(ctx).Debugf("garbage collector: marking image %s of image stream %s/%s", image.Name, is.Namespace, is.Name)
+				markImage(marked, image)
+			}
+		}
+	}
+
+	return marked, nil
+}
+
+// markImage adds the digests of the manifest, layers and config of the image to marked.
+func markImage(marked sets.String, image *imageapi.Image) {
+	marked.Insert(image.Name)
+	for _, layer := range image.DockerImageLayers {
+		marked.Insert(layer.Name)
+	}
+	// with manifest schema 2, the image ID is the digest of the config blob
+	if _, err := digest.ParseDigest(image.DockerImageMetadata.ID); err == nil {
+		marked.Insert(image.DockerImageMetadata.ID)
+	}
+}
+
+// blobDataPath returns the storage path of the data of the blob with given digest.
+func blobDataPath(dgst digest.Digest) string {
+	hex := dgst.Hex()
+	return path.Join(blobsRootPath, string(dgst.Algorithm()), hex[:2], hex, "data")
+}
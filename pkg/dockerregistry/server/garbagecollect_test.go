@@ -0,0 +1,190 @@
+package server
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestGarbageCollect(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+	registry, err := storage.NewRegistry(ctx, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named, err := reference.ParseNamed("test/is")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := registry.Repository(ctx, named)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobs := map[string]string{}
+	for _, name := range []string{"layer", "config", "streamlayer", "orphan"} {
+		desc, err := repo.Blobs(ctx).Put(ctx, "application/octet-stream", []byte(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobs[name] = desc.Digest.String()
+	}
+
+	listedImage := imageapi.Image{
+		ObjectMeta:          kapi.ObjectMeta{Name: "sha256:listed"},
+		DockerImageLayers:   []imageapi.ImageLayer{{Name: blobs["layer"]}},
+		DockerImageMetadata: imageapi.DockerImage{ID: blobs["config"]},
+	}
+	// an image created after images were listed, only found through its image stream
+	newImage := &imageapi.Image{
+		ObjectMeta:        kapi.ObjectMeta{Name: "sha256:new"},
+		DockerImageLayers: []imageapi.ImageLayer{{Name: blobs["streamlayer"]}},
+	}
+
+	client := &testclient.Fake{}
+	client.AddReactor("list", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageList{Items: []imageapi.Image{listedImage}}, nil
+	})
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamList{Items: []imageapi.ImageStream{{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "is"},
+			Status: imageapi.ImageStreamStatus{Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{Image: "sha256:new"}, {Image: "sha256:listed"}, {Image: "sha256:deleted"}}},
+			}},
+		}}}, nil
+	})
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if name == newImage.Name {
+			return true, newImage, nil
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("images"), name)
+	})
+
+	gc := &garbageCollector{
+		driver:      driver,
+		blobs:       registry.Blobs(),
+		statter:     registry.BlobStatter(),
+		client:      client,
+		gracePeriod: time.Hour,
+		now:         time.Now,
+	}
+
+	// all the blobs are younger than the grace period
+	result, err := gc.collect(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.BlobsDeleted) != 0 || result.BlobsSkipped != 1 {
+		t.Fatalf("expected only the orphaned blob to be skipped, got %#v", result)
+	}
+
+	gc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	result, err = gc.collect(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.BlobsDeleted, []string{blobs["orphan"]}) {
+		t.Fatalf("expected only the orphaned blob to be eligible for deletion, got %v", result.BlobsDeleted)
+	}
+	if _, err := registry.BlobStatter().Stat(ctx, digest.Digest(blobs["orphan"])); err != nil {
+		t.Fatalf("dry run deleted a blob: %v", err)
+	}
+
+	result, err = gc.collect(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.BlobsDeleted, []string{blobs["orphan"]}) || result.BytesFreed != int64(len("orphan")) {
+		t.Fatalf("expected the orphaned blob to be deleted, got %#v", result)
+	}
+
+	remaining := []string{}
+	if err := registry.Blobs().Enumerate(ctx, func(dgst digest.Digest) error {
+		remaining = append(remaining, dgst.String())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{blobs["layer"], blobs["config"], blobs["streamlayer"]}
+	sort.Strings(remaining)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Errorf("expected blobs %v to remain, got %v", expected, remaining)
+	}
+}
+
+func TestGarbageCollectImagePushedDuringMark(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+	registry, err := storage.NewRegistry(ctx, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named, err := reference.ParseNamed("test/is")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := registry.Repository(ctx, named)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := repo.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("reused"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the blob is unreferenced when first marked, and reused by an image pushed before the sweep
+	lists := 0
+	client := &testclient.Fake{}
+	client.AddReactor("list", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		lists++
+		if lists == 1 {
+			return true, &imageapi.ImageList{}, nil
+		}
+		return true, &imageapi.ImageList{Items: []imageapi.Image{{
+			ObjectMeta:        kapi.ObjectMeta{Name: "sha256:pushed"},
+			DockerImageLayers: []imageapi.ImageLayer{{Name: desc.Digest.String()}},
+		}}}, nil
+	})
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamList{}, nil
+	})
+
+	gc := &garbageCollector{
+		driver:      driver,
+		blobs:       registry.Blobs(),
+		statter:     registry.BlobStatter(),
+		client:      client,
+		gracePeriod: time.Hour,
+		now:         func() time.Time { return time.Now().Add(2 * time.Hour) },
+	}
+
+	result, err := gc.collect(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.BlobsDeleted) != 0 || result.BlobsSkipped != 1 {
+		t.Fatalf("expected the reused blob to be skipped, got %#v", result)
+	}
+	if _, err := registry.BlobStatter().Stat(ctx, desc.Digest); err != nil {
+		t.Fatalf("the reused blob was deleted: %v", err)
+	}
+}
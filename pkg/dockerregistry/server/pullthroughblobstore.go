@@ -22,6 +22,8 @@ type pullthroughBlobStore struct {
 
 	repo          *repository
 	digestToStore map[string]distribution.BlobStore
+	// mirror, if true, makes the blobs served from remote stores to be written to the local store as well
+	mirror bool
 }
 
 var _ distribution.BlobStore = &pullthroughBlobStore{}
@@ -121,16 +123,84 @@ func (r *pullthroughBlobStore) ServeBlob(ctx context.Context, w http.ResponseWri
 		return err
 	}
 
+	defer remoteReader.Close()
+
 	setResponseHeaders(w, desc.Size, desc.MediaType, dgst)
 
+	// blobs are not mirrored in read-only maintenance mode to keep the storage unchanged
+	var mirror *blobMirror
+	if r.mirror && req.Method != "HEAD" && !isReadOnly() {
+		mirror = r.newBlobMirror(ctx, dgst)
+	}
+
+	var dst io.Writer = w
+	if mirror != nil {
+		dst = io.MultiWriter(w, mirror)
+	}
+
 	context.GetLogger(r.repo.ctx).Infof("Copying %d bytes of type %q for %q", desc.Size, desc.MediaType, dgst.String())
-	if _, err := io.CopyN(w, remoteReader, desc.Size); err != nil {
+	_, err = io.CopyN(dst, remoteReader, desc.Size)
+	if mirror != nil {
+		mirror.finish(desc, err)
+	}
+	if err != nil {
 		context.GetLogger(r.repo.ctx).Errorf("Failed copying content from remote store %q: %v", dgst.String(), err)
 		return err
 	}
 	return nil
 }
 
+// newBlobMirror starts an upload of the blob to the local store. It returns nil if the upload cannot be
+// started, in which case the blob is served without being mirrored.
+func (r *pullthroughBlobStore) newBlobMirror(ctx context.Context, dgst digest.Digest) *blobMirror {
+	bw, err := r.BlobStore.Create(ctx)
+	if err != nil {
+		context.GetLogger(r.repo.ctx).Errorf("Failed to start mirroring of blob %q: %v", dgst.String(), err)
+		return nil
+	}
+	return &blobMirror{ctx: ctx, dgst: dgst, writer: bw}
+}
+
+// blobMirror writes a blob served from a remote store to the local store. A failure to write locally never
+// interrupts serving the client; the mirroring is abandoned instead and retried on the next pull.
+type blobMirror struct {
+	ctx    context.Context
+	dgst   digest.Digest
+	writer distribution.BlobWriter
+	err    error
+}
+
+var _ io.Writer = &blobMirror{}
+
+// Write passes p to the local blob writer unless a previous write failed. It never returns an error.
+func (m *blobMirror) Write(p []byte) (int, error) {
+	if m.err == nil {
+		_, m.err = m.writer.Write(p)
+	}
+	return len(p), nil
+}
+
+// finish commits the mirrored blob if it was copied completely, otherwise it cancels the upload.
+func (m *blobMirror) finish(desc distribution.Descriptor, copyErr error) {
+	err := copyErr
+	if err == nil {
+		err = m.err
+	}
+	if err != nil {
+		context.GetLogger(m.ctx).Errorf("Failed to mirror blob %q: %v", m.dgst.String(), err)
+		if err := m.writer.Cancel(m.ctx); err != nil {
+			context.GetLogger(m.ctx).Errorf("Failed to cancel mirroring of blob %q: %v", m.dgst.String(), err)
+		}
+		return
+	}
+	if _, err := m.writer.Commit(m.ctx, desc); err != nil {
+		context.GetLogger(m.ctx).Errorf("Failed to commit mirrored blob %q: %v", m.dgst.String(), err)
+		m.writer.Cancel(m.ctx)
+		return
+	}
+	context.GetLogger(m.ctx).Infof("Mirrored blob %q of %d bytes to the local storage", m.dgst.String(), desc.Size)
+}
+
 // findCandidateRepository looks in search for a particular blob, referring to previously cached items
 func (r *pullthroughBlobStore) findCandidateRepository(ctx context.Context, search map[string]*imageapi.DockerImageReference, cachedLayers []string, dgst digest.Digest, retriever importer.RepositoryRetriever) (distribution.Descriptor, error) {
 	// no possible remote locations to search, exit early
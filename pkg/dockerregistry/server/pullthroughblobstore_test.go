@@ -0,0 +1,105 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+)
+
+func newTestRepository(t *testing.T, ctx context.Context, name string) distribution.Repository {
+	registry, err := storage.NewRegistry(ctx, inmemory.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	named, err := reference.ParseNamed(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := registry.Repository(ctx, named)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestPullthroughServeBlobMirror(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		mirror         bool
+		method         string
+		expectMirrored bool
+	}{
+		{
+			name:           "mirroring enabled",
+			mirror:         true,
+			method:         "GET",
+			expectMirrored: true,
+		},
+		{
+			name:   "mirroring disabled",
+			method: "GET",
+		},
+		{
+			name:   "head request",
+			mirror: true,
+			method: "HEAD",
+		},
+	} {
+		ctx := context.Background()
+		content := []byte("remote layer")
+
+		remote := newTestRepository(t, ctx, "remote/is")
+		desc, err := remote.Blobs(ctx).Put(ctx, "application/octet-stream", content)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		local := newTestRepository(t, ctx, "test/is")
+
+		bs := &pullthroughBlobStore{
+			BlobStore: local.Blobs(ctx),
+
+			repo:          &repository{ctx: ctx},
+			digestToStore: map[string]distribution.BlobStore{desc.Digest.String(): remote.Blobs(ctx)},
+			mirror:        tc.mirror,
+		}
+
+		req, err := http.NewRequest(tc.method, "http://example.com/v2/test/is/blobs/"+desc.Digest.String(), nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		w := httptest.NewRecorder()
+		if err := bs.ServeBlob(ctx, w, req, desc.Digest); err != nil {
+			t.Fatalf("%s: unexpected error serving blob: %v", tc.name, err)
+		}
+		if tc.method == "GET" && w.Body.String() != string(content) {
+			t.Errorf("%s: unexpected content served: %q", tc.name, w.Body.String())
+		}
+
+		_, err = local.Blobs(ctx).Stat(ctx, desc.Digest)
+		switch {
+		case tc.expectMirrored && err != nil:
+			t.Errorf("%s: expected blob to be mirrored to the local store, got: %v", tc.name, err)
+		case !tc.expectMirrored && err != distribution.ErrBlobUnknown:
+			t.Errorf("%s: expected blob not to be mirrored to the local store, got: %v", tc.name, err)
+		}
+		if !tc.expectMirrored {
+			continue
+		}
+
+		// the local copy is served once the remote store is gone
+		delete(bs.digestToStore, desc.Digest.String())
+		w = httptest.NewRecorder()
+		if err := bs.ServeBlob(ctx, w, req, desc.Digest); err != nil {
+			t.Fatalf("%s: unexpected error serving mirrored blob: %v", tc.name, err)
+		}
+		if w.Body.String() != string(content) {
+			t.Errorf("%s: unexpected mirrored content served: %q", tc.name, w.Body.String())
+		}
+	}
+}
@@ -7,9 +7,13 @@
 // refuse the manifest if the image exceeds whatever quota or limit set. But the check occurs too late (after
 // the layers are written). This addition allows us to refuse the layers and thus keep the storage clean.
 //
+// Layers are also checked against the openshift.io/imagestreams.storage quota of the project. Its usage is
+// computed by the quota controller from the images referenced by the project's image streams.
+//
 // *Note*: Here, we take into account just a single layer, not the image as a whole because the layers are
 // uploaded before the manifest. This leads to a situation where several layers can be written until a big
-// enough layer will be received that exceeds the limit.
+// enough layer will be received that exceeds the limit. Similarly, the storage usage is updated only once an
+// image is tagged into an image stream, so a single push may exceed the storage quota.
 package server
 
 import (
@@ -23,6 +27,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 
 	imageadmission "github.com/openshift/origin/pkg/image/admission"
+	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
 const (
@@ -86,7 +91,8 @@ func newQuotaEnforcingConfig(ctx context.Context, enforceQuota, projectCacheTTL
 
 	context.GetLogger(ctx).Infof("caching project quota objects with TTL %s", ttl.String())
 	return &quotaEnforcingConfig{
-		limitRanges: newProjectObjectListCache(ttl),
+		limitRanges:    newProjectObjectListCache(ttl),
+		resourceQuotas: newProjectObjectListCache(ttl),
 	}
 }
 
@@ -99,6 +105,8 @@ type quotaEnforcingConfig struct {
 	projectCacheDisabled bool
 	// a cache of limit range objects keyed by project name
 	limitRanges projectObjectListStore
+	// a cache of resource quota objects keyed by project name
+	resourceQuotas projectObjectListStore
 }
 
 // quotaRestrictedBlobStore wraps upstream blob store with a guard preventing big layers exceeding image quotas
@@ -163,13 +171,27 @@ func (bw *quotaRestrictedBlobWriter) Commit(ctx context.Context, provisional dis
 	return bw.BlobWriter.Commit(ctx, provisional)
 }
 
-// admitBlobWrite checks whether the blob does not exceed image limit ranges if set. Returns ErrAccessDenied
-// error if the limit is exceeded.
+// admitBlobWrite checks whether the blob does not exceed image limit ranges and the storage quota of the
+// project if set. Returns ErrAccessDenied error if the limit or quota is exceeded.
 func admitBlobWrite(ctx context.Context, repo *repository, size int64) error {
 	if size < 1 {
 		return nil
 	}
 
+	if err := admitBlobWriteLimits(ctx, repo, size); err != nil {
+		return err
+	}
+
+	// TODO(1): admit also against openshift.io/ImageStream quota resource when we have image stream cache in the
+	// registry
+	// TODO(2): admit also against openshift.io/imagestreamimages and openshift.io/imagestreamtags resources once
+	// we have image stream cache in the registry
+
+	return admitBlobWriteStorage(ctx, repo, size)
+}
+
+// admitBlobWriteLimits checks whether the blob does not exceed image limit ranges.
+func admitBlobWriteLimits(ctx context.Context, repo *repository, size int64) error {
 	var (
 		lrs *kapi.LimitRangeList
 		err error
@@ -206,10 +228,49 @@ func admitBlobWrite(ctx context.Context, repo *repository, size int64) error {
 		}
 	}
 
-	// TODO(1): admit also against openshift.io/ImageStream quota resource when we have image stream cache in the
-	// registry
-	// TODO(2): admit also against openshift.io/imagestreamimages and openshift.io/imagestreamtags resources once
-	// we have image stream cache in the registry
+	return nil
+}
+
+// admitBlobWriteStorage checks whether the blob fits into the storage quota of the project.
+func admitBlobWriteStorage(ctx context.Context, repo *repository, size int64) error {
+	var (
+		rqs *kapi.ResourceQuotaList
+		err error
+	)
+
+	if !quotaEnforcing.projectCacheDisabled {
+		obj, exists, _ := quotaEnforcing.resourceQuotas.get(repo.namespace)
+		if exists {
+			rqs = obj.(*kapi.ResourceQuotaList)
+		}
+	}
+	if rqs == nil {
+		context.GetLogger(ctx).Debugf("listing resource quotas in namespace %s", repo.namespace)
+		rqs, err = repo.quotaClient.ResourceQuotas(repo.namespace).List(kapi.ListOptions{})
+		if err != nil {
+			context.GetLogger(ctx).Errorf("failed to list resourcequotas: %v", err)
+			return err
+		}
+		if !quotaEnforcing.projectCacheDisabled {
+			err = quotaEnforcing.resourceQuotas.add(repo.namespace, rqs)
+			if err != nil {
+				context.GetLogger(ctx).Errorf("failed to cache resource quota list: %v", err)
+			}
+		}
+	}
+
+	for _, quota := range rqs.Items {
+		hard, ok := quota.Status.Hard[imageapi.ResourceImageStreamsStorage]
+		if !ok {
+			continue
+		}
+		used := quota.Status.Used[imageapi.ResourceImageStreamsStorage]
+		if used.Value()+size > hard.Value() {
+			context.GetLogger(ctx).Errorf("refusing to write blob of %d bytes exceeding quota %s: %s of %s %s used",
+				size, quota.Name, used.String(), hard.String(), imageapi.ResourceImageStreamsStorage)
+			return distribution.ErrAccessDenied
+		}
+	}
 
 	return nil
 }
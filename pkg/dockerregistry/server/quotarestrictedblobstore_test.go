@@ -0,0 +1,80 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestAdmitBlobWriteStorage(t *testing.T) {
+	storageQuota := func(name, hard, used string) *kapi.ResourceQuota {
+		return &kapi.ResourceQuota{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: name},
+			Status: kapi.ResourceQuotaStatus{
+				Hard: kapi.ResourceList{imageapi.ResourceImageStreamsStorage: resource.MustParse(hard)},
+				Used: kapi.ResourceList{imageapi.ResourceImageStreamsStorage: resource.MustParse(used)},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		objects       []runtime.Object
+		size          int64
+		expectedError error
+	}{
+		{
+			name: "no quota",
+			size: 1024,
+		},
+		{
+			name: "quota without storage",
+			objects: []runtime.Object{&kapi.ResourceQuota{
+				ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "pods"},
+				Status: kapi.ResourceQuotaStatus{
+					Hard: kapi.ResourceList{kapi.ResourcePods: resource.MustParse("1")},
+				},
+			}},
+			size: 1024,
+		},
+		{
+			name:    "blob fits",
+			objects: []runtime.Object{storageQuota("storage", "2Ki", "1Ki")},
+			size:    1024,
+		},
+		{
+			name:          "blob exceeds quota",
+			objects:       []runtime.Object{storageQuota("storage", "2Ki", "1Ki")},
+			size:          1025,
+			expectedError: distribution.ErrAccessDenied,
+		},
+		{
+			name:          "blob exceeds one of the quotas",
+			objects:       []runtime.Object{storageQuota("big", "1Gi", "0"), storageQuota("small", "1Ki", "0")},
+			size:          2048,
+			expectedError: distribution.ErrAccessDenied,
+		},
+	} {
+		quotaEnforcing = &quotaEnforcingConfig{projectCacheDisabled: true}
+		client := ktestclient.NewSimpleFake(tc.objects...)
+		repo := &repository{
+			namespace:   "test",
+			name:        "is",
+			quotaClient: client,
+			limitClient: client,
+		}
+
+		err := admitBlobWrite(context.Background(), repo, tc.size)
+		if err != tc.expectedError {
+			t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectedError, err)
+		}
+	}
+}
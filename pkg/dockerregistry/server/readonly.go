@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+
+	"github.com/docker/distribution/configuration"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/registry/api/errcode"
+	"github.com/docker/distribution/registry/handlers"
+	gorillahandlers "github.com/gorilla/handlers"
+)
+
+// ReadOnlyEnvVar is an environment variable that, if set to "true", starts the registry in read-only
+// maintenance mode. It overrides the readonly option of openshift registry middleware.
+const ReadOnlyEnvVar = "REGISTRY_MIDDLEWARE_REGISTRY_OPENSHIFT_READONLY"
+
+// ErrorCodeReadOnly is returned for requests that would modify the content of the registry while it is in
+// read-only maintenance mode.
+var ErrorCodeReadOnly = errcode.Register(errGroup, errcode.ErrorDescriptor{
+	Value:          "READ_ONLY",
+	Message:        "the registry is in read-only maintenance mode, pushes and deletes are not allowed",
+	HTTPStatusCode: http.StatusServiceUnavailable,
+})
+
+// readOnly is 1 while the registry is in read-only maintenance mode.
+var readOnly int32
+
+// setReadOnly turns the read-only maintenance mode on or off.
+func setReadOnly(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&readOnly, value)
+}
+
+// isReadOnly returns true if the registry is in read-only maintenance mode.
+func isReadOnly() bool {
+	return atomic.LoadInt32(&readOnly) == 1
+}
+
+// checkReadOnly returns ErrorCodeReadOnly if the registry is in read-only maintenance mode. It is called by the
+// repository wrappers once the request is authorized, so that pushes and deletes of repository content are
+// rejected only for clients allowed to make them.
+func checkReadOnly() error {
+	if isReadOnly() {
+		return ErrorCodeReadOnly
+	}
+	return nil
+}
+
+// ConfigureReadOnly sets the initial read-only maintenance mode from the environment or the readonly option of
+// the openshift registry middleware. The mode can be changed later through the admin endpoint.
+func ConfigureReadOnly(ctx context.Context, config *configuration.Configuration) {
+	enabled := false
+	for _, mw := range config.Middleware["registry"] {
+		if mw.Name == "openshift" {
+			enabled = getBoolOption("readonly", false, mw.Options)
+		}
+	}
+	if value := os.Getenv(ReadOnlyEnvVar); len(value) > 0 {
+		enabled = value == "true"
+	}
+	if enabled {
+		context.GetLogger(ctx).Infof("starting in read-only maintenance mode")
+	}
+	setReadOnly(enabled)
+}
+
+// readOnlyStatus is the representation of the read-only mode exchanged with clients.
+type readOnlyStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ReadOnlyDispatcher takes the request context and builds the appropriate handler for querying and toggling
+// the read-only maintenance mode. The mode is kept in memory, so it must be toggled on every replica.
+func ReadOnlyDispatcher(ctx *handlers.Context, r *http.Request) http.Handler {
+	roHandler := &readOnlyHandler{
+		Context: ctx,
+	}
+
+	return gorillahandlers.MethodHandler{
+		"GET": http.HandlerFunc(roHandler.Get),
+		"PUT": http.HandlerFunc(roHandler.Put),
+	}
+}
+
+// readOnlyHandler handles http operations on the read-only maintenance mode.
+type readOnlyHandler struct {
+	*handlers.Context
+}
+
+// Get writes whether the registry is in read-only mode.
+func (rh *readOnlyHandler) Get(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+	rh.writeStatus(w)
+}
+
+// Put turns the read-only mode on or off as requested by the body and writes the resulting mode.
+func (rh *readOnlyHandler) Put(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	var status readOnlyStatus
+	if err := json.NewDecoder(req.Body).Decode(&status); err != nil {
+		rh.Errors = append(rh.Errors, errcode.ErrorCodeUnknown.WithDetail(fmt.Sprintf("invalid read-only status: %v", err)))
+		return
+	}
+	setReadOnly(status.Enabled)
+	context.GetLogger(rh).Infof("read-only maintenance mode enabled: %t", status.Enabled)
+	rh.writeStatus(w)
+}
+
+func (rh *readOnlyHandler) writeStatus(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(readOnlyStatus{Enabled: isReadOnly()}); err != nil {
+		context.GetLogger(rh).Errorf("error writing read-only status: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution/configuration"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/registry/handlers"
+)
+
+func TestConfigureReadOnly(t *testing.T) {
+	defer setReadOnly(false)
+	defer os.Unsetenv(ReadOnlyEnvVar)
+
+	for _, tc := range []struct {
+		name     string
+		env      string
+		options  configuration.Parameters
+		expected bool
+	}{
+		{name: "default", options: configuration.Parameters{}, expected: false},
+		{name: "option", options: configuration.Parameters{"readonly": true}, expected: true},
+		{name: "env enables", env: "true", options: configuration.Parameters{}, expected: true},
+		{name: "env overrides option", env: "false", options: configuration.Parameters{"readonly": true}, expected: false},
+	} {
+		os.Setenv(ReadOnlyEnvVar, tc.env)
+		config := &configuration.Configuration{
+			Middleware: map[string][]configuration.Middleware{
+				"registry": {{Name: "openshift", Options: tc.options}},
+			},
+		}
+		ConfigureReadOnly(context.Background(), config)
+		if isReadOnly() != tc.expected {
+			t.Errorf("[%s] expected read-only %t, got %t", tc.name, tc.expected, isReadOnly())
+		}
+	}
+}
+
+func TestReadOnlyAdminRequests(t *testing.T) {
+	setReadOnly(true)
+	defer setReadOnly(false)
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		handler func(ctx *handlers.Context) http.HandlerFunc
+	}{
+		{
+			name:   "blob deletion",
+			method: "DELETE",
+			handler: func(ctx *handlers.Context) http.HandlerFunc {
+				return (&blobHandler{Context: ctx, Digest: "sha256:abc"}).Delete
+			},
+		},
+		{
+			name:   "signature",
+			method: "PUT",
+			handler: func(ctx *handlers.Context) http.HandlerFunc {
+				return (&signatureHandler{Context: ctx, Digest: "sha256:abc"}).Put
+			},
+		},
+	} {
+		ctx := &handlers.Context{Context: context.Background()}
+		req, err := http.NewRequest(tc.method, "http://registry/", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %v", tc.name, err)
+		}
+		tc.handler(ctx)(httptest.NewRecorder(), req)
+		if len(ctx.Errors) != 1 || ctx.Errors[0] != ErrorCodeReadOnly {
+			t.Errorf("[%s] expected a read-only error, got %v", tc.name, ctx.Errors)
+		}
+	}
+}
+
+func TestReadOnlyToggle(t *testing.T) {
+	defer setReadOnly(false)
+	quotaEnforcing = &quotaEnforcingConfig{enforcementDisabled: true}
+	ctx := WithAuthPerformed(context.Background())
+	r := &repository{
+		Repository: newTestRepository(t, ctx, "ns/is"),
+		ctx:        ctx,
+		namespace:  "ns",
+		name:       "is",
+	}
+
+	toggle := func(enabled bool) {
+		body := fmt.Sprintf(`{"enabled": %t}`, enabled)
+		req, err := http.NewRequest("PUT", "http://registry/admin/readonly", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		ReadOnlyDispatcher(&handlers.Context{Context: ctx}, req).ServeHTTP(w, req)
+		var status readOnlyStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil || status.Enabled != enabled {
+			t.Fatalf("unexpected read-only status %q: %v", w.Body.String(), err)
+		}
+	}
+
+	desc, err := r.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("layer"))
+	if err != nil {
+		t.Fatalf("unexpected error pushing a blob: %v", err)
+	}
+
+	toggle(true)
+	if _, err := r.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("another layer")); err != ErrorCodeReadOnly {
+		t.Errorf("expected a read-only error pushing a blob, got %v", err)
+	}
+	if _, err := r.Blobs(ctx).Create(ctx); err != ErrorCodeReadOnly {
+		t.Errorf("expected a read-only error starting an upload, got %v", err)
+	}
+	if err := r.Blobs(ctx).Delete(ctx, desc.Digest); err != ErrorCodeReadOnly {
+		t.Errorf("expected a read-only error deleting a blob, got %v", err)
+	}
+	if err := r.Delete(ctx, desc.Digest); err != ErrorCodeReadOnly {
+		t.Errorf("expected a read-only error deleting a manifest, got %v", err)
+	}
+	if err := r.Tags(ctx).Untag(ctx, "latest"); err != ErrorCodeReadOnly {
+		t.Errorf("expected a read-only error untagging, got %v", err)
+	}
+	// pulls are served in read-only mode
+	if content, err := r.Blobs(ctx).Get(ctx, desc.Digest); err != nil || string(content) != "layer" {
+		t.Errorf("unexpected result of pulling a blob in read-only mode: %q, %v", content, err)
+	}
+
+	toggle(false)
+	if _, err := r.Blobs(ctx).Put(ctx, "application/octet-stream", []byte("another layer")); err != nil {
+		t.Errorf("unexpected error pushing a blob after leaving read-only mode: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ func init() {
 	middleware.Register("openshift", func(ctx context.Context, registry distribution.Namespace, options map[string]interface{}) (distribution.Namespace, error) {
 		log.Info("OpenShift registry middleware initializing")
 		dockerRegistry = registry
+
 		return dockerRegistry, nil
 	})
 }
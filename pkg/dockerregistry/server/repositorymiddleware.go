@@ -10,6 +10,7 @@ import (
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	regapi "github.com/docker/distribution/registry/api/v2"
@@ -25,6 +26,7 @@ import (
 	"github.com/openshift/origin/pkg/client"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/importer"
+	"github.com/openshift/origin/pkg/image/ocischema"
 	quotautil "github.com/openshift/origin/pkg/quota/util"
 )
 
@@ -46,6 +48,12 @@ const (
 	// AcceptSchema2EnvVar is a boolean environment variable that allows to accept manifest schema v2
 	// on manifest put requests.
 	AcceptSchema2EnvVar = "REGISTRY_MIDDLEWARE_REPOSITORY_OPENSHIFT_ACCEPTSCHEMA2"
+
+	// MirrorPullthroughEnvVar is a boolean environment variable that makes the registry store blobs served
+	// from remote repositories during pullthrough in its local storage, so that subsequent pulls do not
+	// depend on the remote registry. It overrides the mirrorpullthrough option of openshift middleware.
+	// Recognized values are "true" and "false".
+	MirrorPullthroughEnvVar = "REGISTRY_MIDDLEWARE_REPOSITORY_OPENSHIFT_MIRRORPULLTHROUGH"
 )
 
 var (
@@ -116,6 +124,8 @@ type repository struct {
 	// if true, the repository will check remote references in the image stream to support pulling "through"
 	// from a remote repository
 	pullthrough bool
+	// if true, blobs served from remote repositories during pullthrough are stored in the local storage
+	mirrorPullthrough bool
 	// acceptschema2 allows to refuse the manifest schema version 2
 	acceptschema2 bool
 	// cachedLayers remembers a mapping of layer digest to repositories recently seen with that image to avoid
@@ -142,6 +152,13 @@ func newRepositoryWithClient(
 
 	pullthrough := getBoolOption("pullthrough", false, options)
 
+	mirrorPullthrough := false
+	if os.Getenv(MirrorPullthroughEnvVar) != "" {
+		mirrorPullthrough = os.Getenv(MirrorPullthroughEnvVar) == "true"
+	} else {
+		mirrorPullthrough = getBoolOption("mirrorpullthrough", false, options)
+	}
+
 	acceptschema2 := false
 
 	if os.Getenv(AcceptSchema2EnvVar) != "" {
@@ -158,16 +175,17 @@ func newRepositoryWithClient(
 	return &repository{
 		Repository: repo,
 
-		ctx:              ctx,
-		quotaClient:      quotaClient,
-		limitClient:      limitClient,
-		registryOSClient: registryOSClient,
-		registryAddr:     registryAddr,
-		namespace:        nameParts[0],
-		name:             nameParts[1],
-		pullthrough:      pullthrough,
-		acceptschema2:    acceptschema2,
-		cachedLayers:     cachedLayers,
+		ctx:               ctx,
+		quotaClient:       quotaClient,
+		limitClient:       limitClient,
+		registryOSClient:  registryOSClient,
+		registryAddr:      registryAddr,
+		namespace:         nameParts[0],
+		name:              nameParts[1],
+		pullthrough:       pullthrough,
+		mirrorPullthrough: mirrorPullthrough,
+		acceptschema2:     acceptschema2,
+		cachedLayers:      cachedLayers,
 	}, nil
 }
 
@@ -212,6 +230,7 @@ func (r *repository) Blobs(ctx context.Context) distribution.BlobStore {
 
 			repo:          &repo,
 			digestToStore: make(map[string]distribution.BlobStore),
+			mirror:        r.mirrorPullthrough,
 		}
 	}
 
@@ -281,6 +300,13 @@ func (r *repository) Put(ctx context.Context, manifest distribution.Manifest, op
 	if err := r.checkPendingErrors(ctx); err != nil {
 		return "", err
 	}
+	if err := checkReadOnly(); err != nil {
+		return "", err
+	}
+
+	// the garbage collector must not delete the blobs of the manifest while the image is created
+	sweepLock.RLock()
+	defer sweepLock.RUnlock()
 
 	var canonical []byte
 
@@ -293,7 +319,7 @@ func (r *repository) Put(ctx context.Context, manifest distribution.Manifest, op
 	switch manifest.(type) {
 	case *schema1.SignedManifest:
 		canonical = manifest.(*schema1.SignedManifest).Canonical
-	case *schema2.DeserializedManifest:
+	case *schema2.DeserializedManifest, *ocischema.DeserializedManifest, *manifestlist.DeserializedManifestList, *ocischema.DeserializedIndex:
 		canonical = payload
 	default:
 		err = fmt.Errorf("unrecognized manifest type %T", manifest)
@@ -340,6 +366,16 @@ func (r *repository) Put(ctx context.Context, manifest distribution.Manifest, op
 		return "", err
 	}
 
+	// manifests pushed by digest, like the platform specific manifests of a manifest list, are not tagged
+	// into the image stream and become reachable through the manifest list referencing them
+	if len(ism.Tag) == 0 {
+		if _, err := r.registryOSClient.Images().Create(&ism.Image); err != nil && !kerrors.IsAlreadyExists(err) {
+			context.GetLogger(r.ctx).Errorf("error creating Image: %s", err)
+			return "", err
+		}
+		return dgst, nil
+	}
+
 	if err = r.registryOSClient.ImageStreamMappings(r.namespace).Create(&ism); err != nil {
 		// if the error was that the image stream wasn't found, try to auto provision it
 		statusErr, ok := err.(*kerrors.StatusError)
@@ -396,11 +432,16 @@ func (r *repository) Put(ctx context.Context, manifest distribution.Manifest, op
 
 // fillImageWithMetadata fills a given image with metadata.
 func (r *repository) fillImageWithMetadata(manifest distribution.Manifest, image *imageapi.Image) error {
-	if deserializedManifest, ok := manifest.(*schema2.DeserializedManifest); ok {
-		r.deserializedManifestFillImageMetadata(deserializedManifest, image)
-	} else if signedManifest, ok := manifest.(*schema1.SignedManifest); ok {
-		r.signedManifestFillImageMetadata(signedManifest, image)
-	} else {
+	switch t := manifest.(type) {
+	case *schema2.DeserializedManifest:
+		r.deserializedManifestFillImageMetadata(t.Config.Digest, image)
+	case *ocischema.DeserializedManifest:
+		r.deserializedManifestFillImageMetadata(t.Config.Digest, image)
+	case *manifestlist.DeserializedManifestList, *ocischema.DeserializedIndex:
+		r.manifestListFillImageMetadata(image)
+	case *schema1.SignedManifest:
+		r.signedManifestFillImageMetadata(t, image)
+	default:
 		return fmt.Errorf("unrecognized manifest type %T", manifest)
 	}
 
@@ -457,11 +498,11 @@ func (r *repository) signedManifestFillImageMetadata(manifest *schema1.SignedMan
 	return nil
 }
 
-// deserializedManifestFillImageMetadata fills a given image with metadata.
-func (r *repository) deserializedManifestFillImageMetadata(manifest *schema2.DeserializedManifest, image *imageapi.Image) error {
-	configBytes, err := r.Blobs(r.ctx).Get(r.ctx, manifest.Config.Digest)
+// deserializedManifestFillImageMetadata fills a given image of a schema 2 or an OCI manifest with metadata.
+func (r *repository) deserializedManifestFillImageMetadata(config digest.Digest, image *imageapi.Image) error {
+	configBytes, err := r.Blobs(r.ctx).Get(r.ctx, config)
 	if err != nil {
-		context.GetLogger(r.ctx).Errorf("failed to get image config %s: %v", manifest.Config.Digest.String(), err)
+		context.GetLogger(r.ctx).Errorf("failed to get image config %s: %v", config.String(), err)
 		return err
 	}
 	image.DockerImageConfig = string(configBytes)
@@ -473,6 +514,27 @@ func (r *repository) deserializedManifestFillImageMetadata(manifest *schema2.Des
 	return nil
 }
 
+// manifestListFillImageMetadata fills a given image of a manifest list or an OCI index with the referenced
+// manifests and copies the metadata of the image of the default platform, which has to be pushed before the list.
+func (r *repository) manifestListFillImageMetadata(image *imageapi.Image) error {
+	if err := imageapi.ImageWithMetadata(image); err != nil {
+		return err
+	}
+
+	m := imageapi.DefaultImageManifest(image.DockerImageManifests)
+	if m == nil {
+		return nil
+	}
+	platformImage, err := r.registryOSClient.Images().Get(m.Digest)
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("failed to get image %s referenced by manifest list %s: %v", m.Digest, image.Name, err)
+		return err
+	}
+	image.DockerImageMetadata = platformImage.DockerImageMetadata
+	image.DockerImageMetadata.ID = image.Name
+	return nil
+}
+
 // Delete deletes the manifest with digest `dgst`. Note: Image resources
 // in OpenShift are deleted via 'oadm prune images'. This function deletes
 // the content related to the manifest in the registry's storage (signatures).
@@ -480,6 +542,9 @@ func (r *repository) Delete(ctx context.Context, dgst digest.Digest) error {
 	if err := r.checkPendingErrors(ctx); err != nil {
 		return err
 	}
+	if err := checkReadOnly(); err != nil {
+		return err
+	}
 
 	ms, err := r.Repository.Manifests(r.ctx)
 	if err != nil {
@@ -529,9 +594,12 @@ func (r *repository) rememberLayers(manifest distribution.Manifest, cacheName st
 
 // manifestFromImageWithCachedLayers loads the image and then caches any located layers
 func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, cacheName string) (manifest distribution.Manifest, err error) {
-	if image.DockerImageManifestMediaType == schema2.MediaTypeManifest {
+	switch image.DockerImageManifestMediaType {
+	case schema2.MediaTypeManifest:
 		manifest, err = r.deserializedManifestFromImage(image)
-	} else {
+	case ocischema.MediaTypeImageManifest, manifestlist.MediaTypeManifestList, ocischema.MediaTypeImageIndex:
+		manifest, _, err = distribution.UnmarshalManifest(image.DockerImageManifestMediaType, []byte(image.DockerImageManifest))
+	default:
 		manifest, err = r.signedManifestFromImage(image)
 	}
 
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
+
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/ocischema"
+)
+
+func TestPutOCIIndex(t *testing.T) {
+	quotaEnforcing = &quotaEnforcingConfig{enforcementDisabled: true}
+	ctx := WithAuthPerformed(context.Background())
+	repo := newTestRepository(t, ctx, "ns/is")
+
+	config, err := repo.Blobs(ctx).Put(ctx, ocischema.MediaTypeImageConfig, []byte(`{"architecture": "amd64", "os": "linux", "config": {"User": "nobody"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer, err := repo.Blobs(ctx).Put(ctx, ocischema.MediaTypeImageLayer, []byte("layer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ocischema.FromStruct(ocischema.Manifest{
+		Versioned: manifest.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []distribution.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, payload, _ := m.Payload()
+	manifestDigest := digest.FromBytes(payload)
+
+	images := map[string]*imageapi.Image{}
+	var mapping *imageapi.ImageStreamMapping
+	client := &testclient.Fake{}
+	client.AddReactor("create", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		image := action.(ktestclient.CreateAction).GetObject().(*imageapi.Image)
+		images[image.Name] = image
+		return true, image, nil
+	})
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, images[action.(ktestclient.GetAction).GetName()], nil
+	})
+	client.AddReactor("create", "imagestreammappings", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		mapping = action.(ktestclient.CreateAction).GetObject().(*imageapi.ImageStreamMapping)
+		images[mapping.Image.Name] = &mapping.Image
+		return true, mapping, nil
+	})
+	client.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamImage{}, nil
+	})
+
+	r := &repository{
+		Repository:       repo,
+		ctx:              ctx,
+		registryOSClient: client,
+		registryAddr:     "localhost:5000",
+		namespace:        "ns",
+		name:             "is",
+		acceptschema2:    true,
+		cachedLayers:     cachedLayers,
+	}
+
+	// the platform specific manifest is pushed by digest and not tagged
+	if dgst, err := r.Put(ctx, m); err != nil || dgst != manifestDigest {
+		t.Fatalf("unexpected result of pushing the manifest: %s, %v", dgst, err)
+	}
+	if mapping != nil {
+		t.Fatalf("unexpected image stream mapping: %#v", mapping)
+	}
+	image, ok := images[manifestDigest.String()]
+	if !ok {
+		t.Fatalf("image %s was not created", manifestDigest)
+	}
+	if image.DockerImageMetadata.Config == nil || image.DockerImageMetadata.Config.User != "nobody" || len(image.DockerImageLayers) != 1 {
+		t.Errorf("unexpected image metadata: %#v", image)
+	}
+
+	index, err := ocischema.FromDescriptors([]manifestlist.ManifestDescriptor{{
+		Descriptor: distribution.Descriptor{MediaType: ocischema.MediaTypeImageManifest, Digest: manifestDigest, Size: int64(len(payload))},
+		Platform:   manifestlist.PlatformSpec{Architecture: "amd64", OS: "linux"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDigest, err := r.Put(ctx, index, distribution.WithTag("latest"))
+	if err != nil {
+		t.Fatalf("unexpected error pushing the index: %v", err)
+	}
+	if mapping == nil || mapping.Tag != "latest" || mapping.Image.Name != indexDigest.String() {
+		t.Fatalf("unexpected image stream mapping: %#v", mapping)
+	}
+	if len(mapping.Image.DockerImageManifests) != 1 || mapping.Image.DockerImageManifests[0].Digest != manifestDigest.String() {
+		t.Errorf("unexpected manifests: %#v", mapping.Image.DockerImageManifests)
+	}
+	if mapping.Image.DockerImageMetadata.ID != indexDigest.String() || mapping.Image.DockerImageMetadata.Config.User != "nobody" {
+		t.Errorf("metadata of the default platform not copied: %#v", mapping.Image.DockerImageMetadata)
+	}
+
+	served, err := r.Get(ctx, indexDigest)
+	if err != nil {
+		t.Fatalf("unexpected error getting the index: %v", err)
+	}
+	if _, ok := served.(*ocischema.DeserializedIndex); !ok {
+		t.Fatalf("unexpected manifest type %T", served)
+	}
+	mediaType, servedPayload, _ := served.Payload()
+	if _, expected, _ := index.Payload(); mediaType != ocischema.MediaTypeImageIndex || string(servedPayload) != string(expected) {
+		t.Errorf("unexpected payload %s of type %s", string(servedPayload), mediaType)
+	}
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ctxu "github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/api/errcode"
+	"github.com/docker/distribution/registry/api/v2"
+	"github.com/docker/distribution/registry/handlers"
+	gorillahandlers "github.com/gorilla/handlers"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	imagesignature "github.com/openshift/origin/pkg/image/signature"
+)
+
+const errGroup = "openshift"
+
+var (
+	// ErrorCodeSignatureInvalid is returned when an uploaded signature cannot be parsed or does not match
+	// the image.
+	ErrorCodeSignatureInvalid = errcode.Register(errGroup, errcode.ErrorDescriptor{
+		Value:          "SIGNATURE_INVALID",
+		Message:        "invalid image signature",
+		HTTPStatusCode: http.StatusBadRequest,
+	})
+
+	// ErrorCodeSignatureAlreadyExists is returned when a signature with the same name is already stored.
+	ErrorCodeSignatureAlreadyExists = errcode.Register(errGroup, errcode.ErrorDescriptor{
+		Value:          "SIGNATURE_EXISTS",
+		Message:        "image signature already exists",
+		HTTPStatusCode: http.StatusConflict,
+	})
+)
+
+// signatureSchemaVersion is the version of the signature documents exchanged
+// with clients.
+const signatureSchemaVersion = 2
+
+// signature is the representation of a single image signature exchanged with
+// clients.
+type signature struct {
+	Version int    `json:"schemaVersion"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content []byte `json:"content"`
+}
+
+// signatureList is the response to a signature listing.
+type signatureList struct {
+	Signatures []signature `json:"signatures"`
+}
+
+// SignatureDispatcher takes the request context and builds the appropriate
+// handler for handling image signature requests.
+func SignatureDispatcher(ctx *handlers.Context, r *http.Request) http.Handler {
+	reference := ctxu.GetStringValue(ctx, "vars.digest")
+	dgst, _ := digest.ParseDigest(reference)
+
+	signatureHandler := &signatureHandler{
+		Context: ctx,
+		Digest:  dgst,
+	}
+
+	return gorillahandlers.MethodHandler{
+		"GET": http.HandlerFunc(signatureHandler.Get),
+		"PUT": http.HandlerFunc(signatureHandler.Put),
+	}
+}
+
+// signatureHandler handles http operations on image signatures.
+type signatureHandler struct {
+	*handlers.Context
+
+	Digest digest.Digest
+}
+
+// Get returns all signatures of the image that belongs to the repository.
+func (sh *signatureHandler) Get(w http.ResponseWriter, req *http.Request) {
+	image, ok := sh.getImage()
+	if !ok {
+		return
+	}
+
+	list := signatureList{Signatures: []signature{}}
+	for _, s := range image.Signatures {
+		_, name, err := imageapi.SplitImageSignatureName(s.Name)
+		if err != nil {
+			name = s.Name
+		}
+		list.Signatures = append(list.Signatures, signature{
+			Version: signatureSchemaVersion,
+			Name:    name,
+			Type:    s.Type,
+			Content: s.Content,
+		})
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		sh.Errors = append(sh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// Put stores a new signature of the image that belongs to the repository. The
+// signature must be intact and issued for the image, whether its key is
+// trusted is decided when the image is used.
+func (sh *signatureHandler) Put(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	if isReadOnly() {
+		sh.Errors = append(sh.Errors, ErrorCodeReadOnly)
+		return
+	}
+
+	sig := signature{}
+	if err := json.NewDecoder(req.Body).Decode(&sig); err != nil {
+		sh.Errors = append(sh.Errors, ErrorCodeSignatureInvalid.WithDetail(err))
+		return
+	}
+	if len(sig.Type) == 0 {
+		sig.Type = imageapi.ImageSignatureTypeJWSImageV1
+	}
+	if sig.Type != imageapi.ImageSignatureTypeJWSImageV1 {
+		sh.Errors = append(sh.Errors, ErrorCodeSignatureInvalid.WithDetail(fmt.Sprintf("unsupported signature type %q", sig.Type)))
+		return
+	}
+	if len(sig.Name) == 0 {
+		sig.Name = digest.FromBytes(sig.Content).Hex()
+	}
+	if _, _, err := imagesignature.Parse(sig.Content, sh.Digest.String()); err != nil {
+		sh.Errors = append(sh.Errors, ErrorCodeSignatureInvalid.WithDetail(err.Error()))
+		return
+	}
+
+	if _, ok := sh.getImage(); !ok {
+		return
+	}
+
+	osClient, _, err := DefaultRegistryClient.Clients()
+	if err != nil {
+		sh.Errors = append(sh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		return
+	}
+	newSignature := &imageapi.ImageSignature{
+		ObjectMeta: kapi.ObjectMeta{Name: imageapi.MakeImageSignatureName(sh.Digest.String(), sig.Name)},
+		Type:       sig.Type,
+		Content:    sig.Content,
+	}
+	if _, err := osClient.ImageSignatures().Create(newSignature); err != nil {
+		switch {
+		case kerrors.IsAlreadyExists(err):
+			sh.Errors = append(sh.Errors, ErrorCodeSignatureAlreadyExists.WithDetail(sig.Name))
+		case kerrors.IsInvalid(err), kerrors.IsBadRequest(err):
+			sh.Errors = append(sh.Errors, ErrorCodeSignatureInvalid.WithDetail(err.Error()))
+		default:
+			ctxu.GetLogger(sh).Errorf("error storing signature %s of image %s: %v", sig.Name, sh.Digest, err)
+			sh.Errors = append(sh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// getImage retrieves the image of the handled digest and ensures it belongs
+// to the repository. Errors are recorded in the context.
+func (sh *signatureHandler) getImage() (*imageapi.Image, bool) {
+	if len(sh.Digest) == 0 {
+		sh.Errors = append(sh.Errors, v2.ErrorCodeDigestInvalid)
+		return nil, false
+	}
+
+	nameParts := strings.SplitN(sh.Repository.Named().Name(), "/", 2)
+	if len(nameParts) != 2 {
+		sh.Errors = append(sh.Errors, v2.ErrorCodeNameInvalid.WithDetail(sh.Repository.Named().Name()))
+		return nil, false
+	}
+
+	osClient, _, err := DefaultRegistryClient.Clients()
+	if err != nil {
+		sh.Errors = append(sh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		return nil, false
+	}
+	isImage, err := osClient.ImageStreamImages(nameParts[0]).Get(nameParts[1], sh.Digest.String())
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			sh.Errors = append(sh.Errors, v2.ErrorCodeManifestUnknown.WithDetail(sh.Digest))
+		} else {
+			ctxu.GetLogger(sh).Errorf("error getting image %s: %v", sh.Digest, err)
+			sh.Errors = append(sh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		}
+		return nil, false
+	}
+	return &isImage.Image, true
+}
@@ -17,6 +17,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubectl/resource"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	authapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
@@ -28,6 +29,7 @@ import (
 	"github.com/openshift/origin/pkg/generate/dockerfile"
 	"github.com/openshift/origin/pkg/generate/source"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	templateapi "github.com/openshift/origin/pkg/template/api"
 	outil "github.com/openshift/origin/pkg/util"
 )
 
@@ -350,6 +352,7 @@ func (c *AppConfig) buildPipelines(components app.ComponentReferences, environme
 }
 
 // buildTemplates converts a set of resolved, valid references into references to template objects.
+// The objects of every template are followed by a template instance that records them.
 func (c *AppConfig) buildTemplates(components app.ComponentReferences, environment app.Environment) ([]runtime.Object, error) {
 	objects := []runtime.Object{}
 
@@ -363,11 +366,64 @@ func (c *AppConfig) buildTemplates(components app.ComponentReferences, environme
 		}
 		objects = append(objects, result.Objects...)
 
+		templateInstance, err := c.templateInstance(tpl, result)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, templateInstance)
+
 		DescribeGeneratedTemplate(c.Out, ref.Input().String(), result, c.OriginNamespace)
 	}
 	return objects, nil
 }
 
+// templateInstance returns a template instance recording that tpl was processed into result. The
+// instance is named after the template, with a random suffix so that a template can be instantiated
+// more than once in a project. It keeps the parameter values of result, except the values of the
+// parameters the template generates, which may be passwords. The objects of the template are not kept,
+// the instance references the objects created from them instead.
+func (c *AppConfig) templateInstance(tpl, result *templateapi.Template) (*templateapi.TemplateInstance, error) {
+	templateInstance := &templateapi.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{GenerateName: tpl.Name + "-"},
+		Spec:       templateapi.TemplateInstanceSpec{Template: *tpl},
+	}
+	generated := sets.NewString()
+	for _, param := range tpl.Parameters {
+		if len(param.Generate) > 0 {
+			generated.Insert(param.Name)
+		}
+	}
+	templateInstance.Spec.Template.Parameters = nil
+	for _, param := range result.Parameters {
+		if generated.Has(param.Name) {
+			param.Value = ""
+		}
+		templateInstance.Spec.Template.Parameters = append(templateInstance.Spec.Template.Parameters, param)
+	}
+	templateInstance.Spec.Template.Objects = nil
+
+	for _, obj := range result.Objects {
+		gvk, err := c.Typer.ObjectKind(obj)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := c.Mapper.RESTMapping(gvk.GroupKind())
+		if err != nil {
+			return nil, err
+		}
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		templateInstance.Status.Objects = append(templateInstance.Status.Objects, kapi.ObjectReference{
+			Kind:       gvk.Kind,
+			APIVersion: mapping.GroupVersionKind.GroupVersion().String(),
+			Name:       objMeta.GetName(),
+		})
+	}
+	return templateInstance, nil
+}
+
 // fakeSecretAccessor is used during dry runs of installation
 type fakeSecretAccessor struct {
 	token string
@@ -11,11 +11,13 @@ import (
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apimachinery/registered"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	client "github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/generate/app"
 	templateapi "github.com/openshift/origin/pkg/template/api"
 
@@ -205,6 +207,55 @@ func TestBuildTemplates(t *testing.T) {
 	}
 }
 
+func TestTemplateInstance(t *testing.T) {
+	appCfg := AppConfig{Typer: kapi.Scheme, Mapper: registered.RESTMapper()}
+	tpl := &templateapi.Template{
+		ObjectMeta: kapi.ObjectMeta{Name: "app", Namespace: "openshift"},
+		Parameters: []templateapi.Parameter{
+			{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}"},
+			{Name: "REPLICAS", Value: "1"},
+		},
+		Objects: []runtime.Object{&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1"}`)}},
+	}
+	result := &templateapi.Template{
+		ObjectMeta: tpl.ObjectMeta,
+		Parameters: []templateapi.Parameter{
+			{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}", Value: "generated"},
+			{Name: "REPLICAS", Value: "3"},
+		},
+		Objects: []runtime.Object{
+			&kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "frontend"}},
+			&deployapi.DeploymentConfig{ObjectMeta: kapi.ObjectMeta{Name: "frontend"}},
+		},
+	}
+
+	templateInstance, err := appCfg.templateInstance(tpl, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templateInstance.GenerateName != "app-" || templateInstance.Spec.Template.Namespace != "openshift" {
+		t.Errorf("unexpected template instance metadata: %#v", templateInstance.ObjectMeta)
+	}
+	if len(templateInstance.Spec.Template.Objects) != 0 {
+		t.Errorf("expected the objects of the template to be dropped, got %v", templateInstance.Spec.Template.Objects)
+	}
+	// generated values are not recorded
+	expectedParameters := []templateapi.Parameter{
+		{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}"},
+		{Name: "REPLICAS", Value: "3"},
+	}
+	if !reflect.DeepEqual(templateInstance.Spec.Template.Parameters, expectedParameters) {
+		t.Errorf("expected parameters %v, got %v", expectedParameters, templateInstance.Spec.Template.Parameters)
+	}
+	expected := []kapi.ObjectReference{
+		{Kind: "Service", APIVersion: "v1", Name: "frontend"},
+		{Kind: "DeploymentConfig", APIVersion: "v1", Name: "frontend"},
+	}
+	if !reflect.DeepEqual(templateInstance.Status.Objects, expected) {
+		t.Errorf("expected objects %v, got %v", expected, templateInstance.Status.Objects)
+	}
+}
+
 func fakeTemplateSearcher() app.Searcher {
 	client := &client.Fake{}
 	client.AddReactor("list", "templates", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
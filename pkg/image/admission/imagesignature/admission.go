@@ -0,0 +1,77 @@
+package imagesignature
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/libtrust"
+
+	"k8s.io/kubernetes/pkg/admission"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+
+	"github.com/openshift/origin/pkg/image/admission/podimages"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/signature"
+)
+
+// PluginName is the name of the admission plugin that enforces image signatures.
+const PluginName = "ImageSignaturePolicy"
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(client clientset.Interface, config io.Reader) (admission.Interface, error) {
+		return NewImageSignaturePolicy(), nil
+	})
+}
+
+// imageSignaturePolicy rejects pods whose images are not signed by a key
+// trusted by the project of the pod.
+type imageSignaturePolicy struct {
+	*podimages.Policy
+}
+
+// NewImageSignaturePolicy returns an admission plugin that checks image
+// signatures of pods in projects with trusted signing keys.
+func NewImageSignaturePolicy() admission.Interface {
+	return &imageSignaturePolicy{
+		Policy: podimages.NewPolicy(PluginName),
+	}
+}
+
+// Admit verifies that every image of a pod created in, or updated in, a project
+// with trusted signing keys is referenced by digest and has a valid signature
+// from one of those keys.
+func (p *imageSignaturePolicy) Admit(a admission.Attributes) error {
+	pod, namespace, err := p.PodAndNamespace(a)
+	if err != nil || pod == nil {
+		return err
+	}
+	keyBundle, ok := namespace.Annotations[imageapi.TrustedSigningKeysAnnotation]
+	if !ok || len(keyBundle) == 0 {
+		return nil
+	}
+	trustedKeys, err := signature.ParseTrustedKeys([]byte(keyBundle))
+	if err != nil {
+		return p.Forbidden(pod, fmt.Errorf("project %s has invalid trusted signing keys: %v", namespace.Name, err))
+	}
+
+	return p.CheckImages(pod, func(image string) error {
+		return p.verifyImage(image, trustedKeys)
+	})
+}
+
+// verifyImage resolves the image reference and checks its signatures.
+func (p *imageSignaturePolicy) verifyImage(image string, trustedKeys []libtrust.PublicKey) error {
+	ref, obj, err := p.GetImage(image)
+	if err != nil {
+		return err
+	}
+	if len(ref.ID) == 0 {
+		return fmt.Errorf("image %q must be referenced by digest to verify its signature", image)
+	}
+	if obj == nil {
+		return fmt.Errorf("image %q is not known to the server and has no signatures", image)
+	}
+
+	_, err = signature.VerifyImage(obj, trustedKeys)
+	return err
+}
@@ -0,0 +1,174 @@
+package imagesignature
+
+import (
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/docker/libtrust"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/signature"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+)
+
+const (
+	signedDigest   = "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"
+	unsignedDigest = "sha256:4b6e1f3a9d7c0b2f31c0b2f8e6d1a7c4b9e3f2d1c0a9b8e7f6d5c4b3a2918273"
+)
+
+func generateKey(t *testing.T) libtrust.PrivateKey {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	return key
+}
+
+func newPod(images ...string) *kapi.Pod {
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "pod", Namespace: "signed"}}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, kapi.Container{Name: string('a' + rune(i)), Image: image})
+	}
+	return pod
+}
+
+func TestAdmit(t *testing.T) {
+	signer := generateKey(t)
+	block, err := signer.PublicKey().PEMBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := signature.Sign(signer, signedDigest, "registry.example.com/signed/app", "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectStore := projectcache.NewCacheStore(cache.MetaNamespaceKeyFunc)
+	projectStore.Add(&kapi.Namespace{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        "signed",
+			Annotations: map[string]string{imageapi.TrustedSigningKeysAnnotation: string(pem.EncodeToMemory(block))},
+		},
+	})
+	projectStore.Add(&kapi.Namespace{ObjectMeta: kapi.ObjectMeta{Name: "open"}})
+	projectStore.Add(&kapi.Namespace{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        "broken",
+			Annotations: map[string]string{imageapi.TrustedSigningKeysAnnotation: "not a key"},
+		},
+	})
+
+	images := map[string]*imageapi.Image{
+		signedDigest: {
+			ObjectMeta: kapi.ObjectMeta{Name: signedDigest},
+			Signatures: []imageapi.ImageSignature{{
+				ObjectMeta: kapi.ObjectMeta{Name: signedDigest + "@ci"},
+				Type:       imageapi.ImageSignatureTypeJWSImageV1,
+				Content:    content,
+			}},
+		},
+		unsignedDigest: {ObjectMeta: kapi.ObjectMeta{Name: unsignedDigest}},
+	}
+	client := &testclient.Fake{}
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if image, ok := images[name]; ok {
+			return true, image, nil
+		}
+		return true, nil, kapierrors.NewNotFound(imageapi.Resource("images"), name)
+	})
+
+	plugin := NewImageSignaturePolicy().(*imageSignaturePolicy)
+	plugin.SetOpenshiftClient(client)
+	plugin.SetProjectCache(projectcache.NewFake((&ktestclient.Fake{}).Namespaces(), projectStore, ""))
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		namespace     string
+		pod           *kapi.Pod
+		subresource   string
+		expectedError string
+	}{
+		{
+			name:      "signed image",
+			namespace: "signed",
+			pod:       newPod("registry.example.com/signed/app@" + signedDigest),
+		},
+		{
+			name:      "signed image used by multiple containers",
+			namespace: "signed",
+			pod:       newPod("registry.example.com/signed/app@"+signedDigest, "registry.example.com/signed/app@"+signedDigest),
+		},
+		{
+			name:          "unsigned image",
+			namespace:     "signed",
+			pod:           newPod("registry.example.com/signed/app@"+signedDigest, "registry.example.com/signed/other@"+unsignedDigest),
+			expectedError: "has no signature",
+		},
+		{
+			name:          "image referenced by tag",
+			namespace:     "signed",
+			pod:           newPod("registry.example.com/signed/app:latest"),
+			expectedError: "must be referenced by digest",
+		},
+		{
+			name:          "unknown image",
+			namespace:     "signed",
+			pod:           newPod("registry.example.com/signed/app@sha256:0000000000000000000000000000000000000000000000000000000000000000"),
+			expectedError: "not known to the server",
+		},
+		{
+			name:      "project without trusted keys",
+			namespace: "open",
+			pod:       newPod("busybox"),
+		},
+		{
+			name:          "project with invalid trusted keys",
+			namespace:     "broken",
+			pod:           newPod("busybox"),
+			expectedError: "invalid trusted signing keys",
+		},
+		{
+			name:        "subresource",
+			namespace:   "signed",
+			pod:         newPod("busybox"),
+			subresource: "status",
+		},
+	} {
+		attrs := admission.NewAttributesRecord(tc.pod, kapi.Kind("Pod").WithVersion("version"), tc.namespace, tc.pod.Name, kapi.Resource("pods").WithVersion("version"), tc.subresource, admission.Create, nil)
+		err := plugin.Admit(attrs)
+		switch {
+		case len(tc.expectedError) == 0 && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		case len(tc.expectedError) != 0 && (err == nil || !strings.Contains(err.Error(), tc.expectedError)):
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.expectedError, err)
+		}
+	}
+}
+
+func TestHandles(t *testing.T) {
+	plugin := NewImageSignaturePolicy()
+	for op, shouldHandle := range map[admission.Operation]bool{
+		admission.Create:  true,
+		admission.Update:  true,
+		admission.Connect: false,
+		admission.Delete:  false,
+	} {
+		if e, a := shouldHandle, plugin.Handles(op); e != a {
+			t.Errorf("%v: shouldHandle=%t, handles=%t", op, e, a)
+		}
+	}
+}
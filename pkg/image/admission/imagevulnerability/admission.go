@@ -0,0 +1,94 @@
+package imagevulnerability
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/admission"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+
+	"github.com/openshift/origin/pkg/image/admission/podimages"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// PluginName is the name of the admission plugin that enforces image vulnerability thresholds.
+const PluginName = "ImageVulnerabilityPolicy"
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(client clientset.Interface, config io.Reader) (admission.Interface, error) {
+		return NewImageVulnerabilityPolicy(), nil
+	})
+}
+
+// imageVulnerabilityPolicy rejects pods whose images have known vulnerabilities
+// more serious than the project of the pod allows.
+type imageVulnerabilityPolicy struct {
+	*podimages.Policy
+}
+
+// NewImageVulnerabilityPolicy returns an admission plugin that checks the
+// vulnerability scan results of images of pods in projects with a maximum
+// vulnerability severity.
+func NewImageVulnerabilityPolicy() admission.Interface {
+	return &imageVulnerabilityPolicy{
+		Policy: podimages.NewPolicy(PluginName),
+	}
+}
+
+// Admit rejects a pod created in, or updated in, a project with a maximum
+// vulnerability severity if any of its images has a vulnerability above that
+// severity. Images referenced by tag are resolved through their image stream
+// tag, and images that cannot be resolved to an image known to the server are
+// rejected. Images without scan results are rejected only if the project also
+// requires a vulnerability scan.
+func (p *imageVulnerabilityPolicy) Admit(a admission.Attributes) error {
+	pod, namespace, err := p.PodAndNamespace(a)
+	if err != nil || pod == nil {
+		return err
+	}
+	value, ok := namespace.Annotations[imageapi.MaxVulnerabilitySeverityAnnotation]
+	if !ok || len(value) == 0 {
+		return nil
+	}
+	threshold := imageapi.VulnerabilitySeverity(value)
+	if !imageapi.IsKnownVulnerabilitySeverity(threshold) {
+		return p.Forbidden(pod, fmt.Errorf("project %s has an invalid maximum vulnerability severity %q", namespace.Name, value))
+	}
+	requireScan := namespace.Annotations[imageapi.RequireVulnerabilityScanAnnotation] == "true"
+
+	return p.CheckImages(pod, func(image string) error {
+		return p.checkImage(image, threshold, requireScan)
+	})
+}
+
+// checkImage resolves the image reference and compares its scan results with
+// the threshold.
+func (p *imageVulnerabilityPolicy) checkImage(image string, threshold imageapi.VulnerabilitySeverity, requireScan bool) error {
+	_, obj, err := p.GetImage(image)
+	if err != nil {
+		return err
+	}
+
+	if obj == nil {
+		return fmt.Errorf("image %q cannot be resolved to an image known to the server", image)
+	}
+
+	scan := obj.VulnerabilityScan
+	if scan == nil {
+		if requireScan {
+			return fmt.Errorf("image %q has no vulnerability scan results", image)
+		}
+		return nil
+	}
+
+	found := imageapi.VulnerabilitiesAbove(scan, threshold)
+	if len(found) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(found))
+	for _, v := range found {
+		ids = append(ids, fmt.Sprintf("%s (%s)", v.ID, v.Severity))
+	}
+	return fmt.Errorf("image %q has vulnerabilities more severe than %s: %s", image, threshold, strings.Join(ids, ", "))
+}
@@ -0,0 +1,203 @@
+package imagevulnerability
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/cache"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+)
+
+const (
+	cleanDigest      = "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"
+	vulnerableDigest = "sha256:4b6e1f3a9d7c0b2f31c0b2f8e6d1a7c4b9e3f2d1c0a9b8e7f6d5c4b3a2918273"
+	unscannedDigest  = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+	unknownDigest    = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func newPod(namespace string, images ...string) *kapi.Pod {
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "pod", Namespace: namespace}}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, kapi.Container{Name: string('a' + rune(i)), Image: image})
+	}
+	return pod
+}
+
+func newNamespace(name string, annotations map[string]string) *kapi.Namespace {
+	return &kapi.Namespace{ObjectMeta: kapi.ObjectMeta{Name: name, Annotations: annotations}}
+}
+
+func TestAdmit(t *testing.T) {
+	projectStore := projectcache.NewCacheStore(cache.MetaNamespaceKeyFunc)
+	projectStore.Add(newNamespace("medium", map[string]string{
+		imageapi.MaxVulnerabilitySeverityAnnotation: string(imageapi.VulnerabilitySeverityMedium),
+	}))
+	projectStore.Add(newNamespace("strict", map[string]string{
+		imageapi.MaxVulnerabilitySeverityAnnotation: string(imageapi.VulnerabilitySeverityCritical),
+		imageapi.RequireVulnerabilityScanAnnotation: "true",
+	}))
+	projectStore.Add(newNamespace("open", nil))
+	projectStore.Add(newNamespace("broken", map[string]string{
+		imageapi.MaxVulnerabilitySeverityAnnotation: "Scary",
+	}))
+
+	// the scan results stand in for an external scanner
+	images := map[string]*imageapi.Image{
+		cleanDigest: {
+			ObjectMeta: kapi.ObjectMeta{Name: cleanDigest},
+			VulnerabilityScan: &imageapi.ImageVulnerabilityScan{
+				Scanner:   "test",
+				Completed: unversioned.Now(),
+				Vulnerabilities: []imageapi.ImageVulnerability{
+					{ID: "CVE-2016-0001", Severity: imageapi.VulnerabilitySeverityLow},
+				},
+			},
+		},
+		vulnerableDigest: {
+			ObjectMeta: kapi.ObjectMeta{Name: vulnerableDigest},
+			VulnerabilityScan: &imageapi.ImageVulnerabilityScan{
+				Scanner:   "test",
+				Completed: unversioned.Now(),
+				Vulnerabilities: []imageapi.ImageVulnerability{
+					{ID: "CVE-2016-0002", Severity: imageapi.VulnerabilitySeverityMedium},
+					{ID: "CVE-2016-0003", Severity: imageapi.VulnerabilitySeverityHigh},
+				},
+			},
+		},
+		unscannedDigest: {ObjectMeta: kapi.ObjectMeta{Name: unscannedDigest}},
+	}
+	client := &testclient.Fake{}
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if image, ok := images[name]; ok {
+			return true, image, nil
+		}
+		return true, nil, kapierrors.NewNotFound(imageapi.Resource("images"), name)
+	})
+	tags := map[string]string{
+		"medium/app:clean":      cleanDigest,
+		"medium/app:vulnerable": vulnerableDigest,
+		"medium/app:latest":     unscannedDigest,
+		"medium/app:unknown":    unknownDigest,
+	}
+	client.AddReactor("get", "imagestreamtags", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if digest, ok := tags[action.GetNamespace()+"/"+name]; ok {
+			return true, &imageapi.ImageStreamTag{Image: imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: digest}}}, nil
+		}
+		return true, nil, kapierrors.NewNotFound(imageapi.Resource("imagestreamtags"), name)
+	})
+
+	plugin := NewImageVulnerabilityPolicy().(*imageVulnerabilityPolicy)
+	plugin.SetOpenshiftClient(client)
+	plugin.SetProjectCache(projectcache.NewFake((&ktestclient.Fake{}).Namespaces(), projectStore, ""))
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		pod           *kapi.Pod
+		subresource   string
+		expectedError string
+	}{
+		{
+			name: "image below threshold",
+			pod:  newPod("medium", "registry.example.com/app@"+cleanDigest),
+		},
+		{
+			name:          "image above threshold",
+			pod:           newPod("medium", "registry.example.com/app@"+cleanDigest, "registry.example.com/other@"+vulnerableDigest),
+			expectedError: "CVE-2016-0003 (High)",
+		},
+		{
+			name: "unscanned image",
+			pod:  newPod("medium", "registry.example.com/app@"+unscannedDigest),
+		},
+		{
+			name:          "image not known to the server",
+			pod:           newPod("medium", "registry.example.com/app@"+unknownDigest),
+			expectedError: "cannot be resolved",
+		},
+		{
+			name: "image referenced by tag below threshold",
+			pod:  newPod("medium", "registry.example.com/medium/app:clean", "registry.example.com/medium/app"),
+		},
+		{
+			name:          "image referenced by tag above threshold",
+			pod:           newPod("medium", "registry.example.com/medium/app:vulnerable"),
+			expectedError: "CVE-2016-0003 (High)",
+		},
+		{
+			name:          "image referenced by tag pointing to an image not known to the server",
+			pod:           newPod("medium", "registry.example.com/medium/app:unknown"),
+			expectedError: "cannot be resolved",
+		},
+		{
+			name:          "image referenced by tag without an image stream tag",
+			pod:           newPod("medium", "busybox"),
+			expectedError: "cannot be resolved",
+		},
+		{
+			name: "scanned image in a project with a higher threshold",
+			pod:  newPod("strict", "registry.example.com/app@"+vulnerableDigest),
+		},
+		{
+			name:          "unscanned image in a project requiring a scan",
+			pod:           newPod("strict", "registry.example.com/app@"+unscannedDigest),
+			expectedError: "has no vulnerability scan results",
+		},
+		{
+			name:          "image referenced by tag in a project requiring a scan",
+			pod:           newPod("strict", "registry.example.com/medium/app"),
+			expectedError: "has no vulnerability scan results",
+		},
+		{
+			name: "project without threshold",
+			pod:  newPod("open", "registry.example.com/app@"+vulnerableDigest),
+		},
+		{
+			name:          "project with invalid threshold",
+			pod:           newPod("broken", "busybox"),
+			expectedError: "invalid maximum vulnerability severity",
+		},
+		{
+			name:        "subresource",
+			pod:         newPod("medium", "registry.example.com/app@"+vulnerableDigest),
+			subresource: "status",
+		},
+	} {
+		attrs := admission.NewAttributesRecord(tc.pod, kapi.Kind("Pod").WithVersion("version"), tc.pod.Namespace, tc.pod.Name, kapi.Resource("pods").WithVersion("version"), tc.subresource, admission.Create, nil)
+		err := plugin.Admit(attrs)
+		switch {
+		case len(tc.expectedError) == 0 && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		case len(tc.expectedError) != 0 && (err == nil || !strings.Contains(err.Error(), tc.expectedError)):
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.expectedError, err)
+		}
+	}
+}
+
+func TestHandles(t *testing.T) {
+	plugin := NewImageVulnerabilityPolicy()
+	for op, shouldHandle := range map[admission.Operation]bool{
+		admission.Create:  true,
+		admission.Update:  true,
+		admission.Connect: false,
+		admission.Delete:  false,
+	} {
+		if e, a := shouldHandle, plugin.Handles(op); e != a {
+			t.Errorf("%v: shouldHandle=%t, handles=%t", op, e, a)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package podimages holds what the admission plugins checking the images of pods against a policy of their project
+// share: finding the pods and their projects, visiting every image of a pod and resolving image references.
+package podimages
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/project/cache"
+)
+
+// Policy is embedded by the admission plugins checking the images of pods created or updated in a project
+type Policy struct {
+	*admission.Handler
+	name   string
+	client client.Interface
+	cache  *cache.ProjectCache
+}
+
+var _ = oadmission.WantsOpenshiftClient(&Policy{})
+var _ = oadmission.WantsProjectCache(&Policy{})
+var _ = oadmission.Validator(&Policy{})
+
+// NewPolicy returns the Policy of the named admission plugin, handling pod creations and updates
+func NewPolicy(name string) *Policy {
+	return &Policy{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+		name:    name,
+	}
+}
+
+// PodAndNamespace returns the pod of the admission request and its namespace, or a nil pod if the request is not
+// for a pod.
+func (p *Policy) PodAndNamespace(a admission.Attributes) (*kapi.Pod, *kapi.Namespace, error) {
+	resource := a.GetResource().GroupResource()
+	if resource != kapi.Resource("pods") {
+		return nil, nil, nil
+	}
+	if a.GetSubresource() != "" {
+		// only run the checks on pods proper and not subresources
+		return nil, nil, nil
+	}
+
+	pod, ok := a.GetObject().(*kapi.Pod)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	namespace, err := p.cache.GetNamespace(a.GetNamespace())
+	if err != nil {
+		return nil, nil, apierrors.NewForbidden(resource, pod.Name, err)
+	}
+	return pod, namespace, nil
+}
+
+// CheckImages calls check once for each image of the containers of the pod, and rejects the pod with the first error
+// returned. It is the only place the plugins list the containers of a pod, so the containers the pod API adds, such
+// as init containers, only need to be added here.
+func (p *Policy) CheckImages(pod *kapi.Pod, check func(image string) error) error {
+	checked := sets.NewString()
+	for _, container := range pod.Spec.Containers {
+		if checked.Has(container.Image) {
+			continue
+		}
+		if err := check(container.Image); err != nil {
+			return p.Forbidden(pod, fmt.Errorf("container %s: %v", container.Name, err))
+		}
+		checked.Insert(container.Image)
+	}
+	return nil
+}
+
+// Forbidden returns the error rejecting the pod
+func (p *Policy) Forbidden(pod *kapi.Pod, err error) error {
+	return apierrors.NewForbidden(kapi.Resource("pods"), pod.Name, err)
+}
+
+// GetImage parses the image reference and returns the image it references. A reference by tag is resolved through
+// the image stream tag of the same namespace and name. The image is nil if the reference cannot be resolved or the
+// image is not known to the server.
+func (p *Policy) GetImage(image string) (*imageapi.DockerImageReference, *imageapi.Image, error) {
+	ref, err := imageapi.ParseDockerImageReference(image)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse image reference %q: %v", image, err)
+	}
+	id := ref.ID
+	if len(id) == 0 {
+		if id, err = p.resolveTag(ref); err != nil || len(id) == 0 {
+			return &ref, nil, err
+		}
+	}
+	obj, err := p.client.Images().Get(id)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return &ref, nil, nil
+		}
+		return nil, nil, err
+	}
+	return &ref, obj, nil
+}
+
+// resolveTag returns the name of the image the image stream tag matching the reference points to, or an empty
+// name if there is no such image stream tag.
+func (p *Policy) resolveTag(ref imageapi.DockerImageReference) (string, error) {
+	if len(ref.Namespace) == 0 {
+		return "", nil
+	}
+	tag := ref.Tag
+	if len(tag) == 0 {
+		tag = imageapi.DefaultImageTag
+	}
+	ist, err := p.client.ImageStreamTags(ref.Namespace).Get(ref.Name, tag)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return ist.Image.Name, nil
+}
+
+func (p *Policy) SetOpenshiftClient(c client.Interface) {
+	p.client = c
+}
+
+func (p *Policy) SetProjectCache(c *cache.ProjectCache) {
+	p.cache = c
+}
+
+func (p *Policy) Validate() error {
+	if p.client == nil {
+		return fmt.Errorf("%s needs an Openshift client", p.name)
+	}
+	if p.cache == nil {
+		return fmt.Errorf("%s needs a project cache", p.name)
+	}
+	return nil
+}
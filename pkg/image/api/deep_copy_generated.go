@@ -22,6 +22,8 @@ func init() {
 		DeepCopy_api_DockerImageConfig,
 		DeepCopy_api_DockerImageManifest,
 		DeepCopy_api_DockerImageReference,
+		DeepCopy_api_DockerManifestDescriptor,
+		DeepCopy_api_DockerPlatform,
 		DeepCopy_api_DockerV1CompatibilityImage,
 		DeepCopy_api_DockerV1CompatibilityImageSize,
 		DeepCopy_api_Image,
@@ -29,6 +31,7 @@ func init() {
 		DeepCopy_api_ImageImportStatus,
 		DeepCopy_api_ImageLayer,
 		DeepCopy_api_ImageList,
+		DeepCopy_api_ImageManifest,
 		DeepCopy_api_ImageSignature,
 		DeepCopy_api_ImageStream,
 		DeepCopy_api_ImageStreamImage,
@@ -37,10 +40,13 @@ func init() {
 		DeepCopy_api_ImageStreamImportStatus,
 		DeepCopy_api_ImageStreamList,
 		DeepCopy_api_ImageStreamMapping,
+		DeepCopy_api_ImageStreamRetentionPolicy,
 		DeepCopy_api_ImageStreamSpec,
 		DeepCopy_api_ImageStreamStatus,
 		DeepCopy_api_ImageStreamTag,
 		DeepCopy_api_ImageStreamTagList,
+		DeepCopy_api_ImageVulnerability,
+		DeepCopy_api_ImageVulnerabilityScan,
 		DeepCopy_api_RepositoryImportSpec,
 		DeepCopy_api_RepositoryImportStatus,
 		DeepCopy_api_SignatureCondition,
@@ -310,6 +316,17 @@ func DeepCopy_api_DockerImageManifest(in DockerImageManifest, out *DockerImageMa
 	if err := DeepCopy_api_Descriptor(in.Config, &out.Config, c); err != nil {
 		return err
 	}
+	if in.Manifests != nil {
+		in, out := in.Manifests, &out.Manifests
+		*out = make([]DockerManifestDescriptor, len(in))
+		for i := range in {
+			if err := DeepCopy_api_DockerManifestDescriptor(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
 	return nil
 }
 
@@ -322,6 +339,23 @@ func DeepCopy_api_DockerImageReference(in DockerImageReference, out *DockerImage
 	return nil
 }
 
+func DeepCopy_api_DockerManifestDescriptor(in DockerManifestDescriptor, out *DockerManifestDescriptor, c *conversion.Cloner) error {
+	if err := DeepCopy_api_Descriptor(in.Descriptor, &out.Descriptor, c); err != nil {
+		return err
+	}
+	if err := DeepCopy_api_DockerPlatform(in.Platform, &out.Platform, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeepCopy_api_DockerPlatform(in DockerPlatform, out *DockerPlatform, c *conversion.Cloner) error {
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.Variant = in.Variant
+	return nil
+}
+
 func DeepCopy_api_DockerV1CompatibilityImage(in DockerV1CompatibilityImage, out *DockerV1CompatibilityImage, c *conversion.Cloner) error {
 	out.ID = in.ID
 	out.Parent = in.Parent
@@ -404,6 +438,26 @@ func DeepCopy_api_Image(in Image, out *Image, c *conversion.Cloner) error {
 	}
 	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
 	out.DockerImageConfig = in.DockerImageConfig
+	if in.VulnerabilityScan != nil {
+		in, out := in.VulnerabilityScan, &out.VulnerabilityScan
+		*out = new(ImageVulnerabilityScan)
+		if err := DeepCopy_api_ImageVulnerabilityScan(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.VulnerabilityScan = nil
+	}
+	if in.DockerImageManifests != nil {
+		in, out := in.DockerImageManifests, &out.DockerImageManifests
+		*out = make([]ImageManifest, len(in))
+		for i := range in {
+			if err := DeepCopy_api_ImageManifest(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DockerImageManifests = nil
+	}
 	return nil
 }
 
@@ -441,6 +495,17 @@ func DeepCopy_api_ImageImportStatus(in ImageImportStatus, out *ImageImportStatus
 	} else {
 		out.Image = nil
 	}
+	if in.Manifests != nil {
+		in, out := in.Manifests, &out.Manifests
+		*out = make([]Image, len(in))
+		for i := range in {
+			if err := DeepCopy_api_Image(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
 	return nil
 }
 
@@ -472,7 +537,23 @@ func DeepCopy_api_ImageList(in ImageList, out *ImageList, c *conversion.Cloner)
 	return nil
 }
 
+func DeepCopy_api_ImageManifest(in ImageManifest, out *ImageManifest, c *conversion.Cloner) error {
+	out.Digest = in.Digest
+	out.MediaType = in.MediaType
+	out.ManifestSize = in.ManifestSize
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.Variant = in.Variant
+	return nil
+}
+
 func DeepCopy_api_ImageSignature(in ImageSignature, out *ImageSignature, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := api.DeepCopy_api_ObjectMeta(in.ObjectMeta, &out.ObjectMeta, c); err != nil {
+		return err
+	}
 	out.Type = in.Type
 	if in.Content != nil {
 		in, out := in.Content, &out.Content
@@ -671,6 +752,12 @@ func DeepCopy_api_ImageStreamMapping(in ImageStreamMapping, out *ImageStreamMapp
 	return nil
 }
 
+func DeepCopy_api_ImageStreamRetentionPolicy(in ImageStreamRetentionPolicy, out *ImageStreamRetentionPolicy, c *conversion.Cloner) error {
+	out.MaxTagRevisions = in.MaxTagRevisions
+	out.MaxTagAgeSeconds = in.MaxTagAgeSeconds
+	return nil
+}
+
 func DeepCopy_api_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *conversion.Cloner) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	if in.Tags != nil {
@@ -686,6 +773,15 @@ func DeepCopy_api_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *c
 	} else {
 		out.Tags = nil
 	}
+	if in.RetentionPolicy != nil {
+		in, out := in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(ImageStreamRetentionPolicy)
+		if err := DeepCopy_api_ImageStreamRetentionPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
 	return nil
 }
 
@@ -762,6 +858,35 @@ func DeepCopy_api_ImageStreamTagList(in ImageStreamTagList, out *ImageStreamTagL
 	return nil
 }
 
+func DeepCopy_api_ImageVulnerability(in ImageVulnerability, out *ImageVulnerability, c *conversion.Cloner) error {
+	out.ID = in.ID
+	out.Severity = in.Severity
+	out.Package = in.Package
+	out.Version = in.Version
+	out.FixedVersion = in.FixedVersion
+	out.Link = in.Link
+	return nil
+}
+
+func DeepCopy_api_ImageVulnerabilityScan(in ImageVulnerabilityScan, out *ImageVulnerabilityScan, c *conversion.Cloner) error {
+	out.Scanner = in.Scanner
+	if err := unversioned.DeepCopy_unversioned_Time(in.Completed, &out.Completed, c); err != nil {
+		return err
+	}
+	if in.Vulnerabilities != nil {
+		in, out := in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]ImageVulnerability, len(in))
+		for i := range in {
+			if err := DeepCopy_api_ImageVulnerability(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Vulnerabilities = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_RepositoryImportSpec(in RepositoryImportSpec, out *RepositoryImportSpec, c *conversion.Cloner) error {
 	if err := api.DeepCopy_api_ObjectReference(in.From, &out.From, c); err != nil {
 		return err
@@ -884,6 +1009,7 @@ func DeepCopy_api_TagEventList(in TagEventList, out *TagEventList, c *conversion
 func DeepCopy_api_TagImportPolicy(in TagImportPolicy, out *TagImportPolicy, c *conversion.Cloner) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	out.VersionRange = in.VersionRange
 	return nil
 }
 
@@ -85,6 +85,24 @@ type DockerImageManifest struct {
 	// schema2
 	Layers []Descriptor `json:"layers"`
 	Config Descriptor   `json:"config"`
+
+	// manifest list and OCI image index
+	Manifests []DockerManifestDescriptor `json:"manifests"`
+}
+
+// DockerManifestDescriptor references a platform specific manifest from a manifest list or an OCI image index.
+type DockerManifestDescriptor struct {
+	Descriptor `json:",inline"`
+
+	// Platform describes the platform the manifest is for.
+	Platform DockerPlatform `json:"platform"`
+}
+
+// DockerPlatform describes a platform an image runs on.
+type DockerPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
 }
 
 // DockerFSLayer is a container struct for BlobSums defined in an image manifest
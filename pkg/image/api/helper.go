@@ -108,6 +108,76 @@ func MakeImageStreamImageName(name, id string) string {
 	return fmt.Sprintf("%s@%s", name, id)
 }
 
+// MakeImageSignatureName creates a name for an image signature object from an image name and a signature
+// name.
+func MakeImageSignatureName(imageName, signatureName string) string {
+	return fmt.Sprintf("%s@%s", imageName, signatureName)
+}
+
+// SplitImageSignatureName splits the name of an image signature into an image name and a signature name, and
+// returns an error if the name is not in the right form.
+func SplitImageSignatureName(name string) (imageName, signatureName string, err error) {
+	segments := strings.Split(name, "@")
+	switch len(segments) {
+	case 2:
+		imageName = segments[0]
+		signatureName = segments[1]
+		if len(imageName) == 0 || len(signatureName) == 0 {
+			err = fmt.Errorf("image signature name %q must have an image name and a signature name", name)
+		}
+	default:
+		err = fmt.Errorf("expected exactly one @ in the image signature name %q", name)
+	}
+	return
+}
+
+// IndexOfImageSignatureByName returns the index of the signature with the given name in the signatures
+// slice, or -1 if it is not present.
+func IndexOfImageSignatureByName(signatures []ImageSignature, name string) int {
+	for i := range signatures {
+		if signatures[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// vulnerabilitySeverityRanks orders the known vulnerability severities from the least to the most severe.
+var vulnerabilitySeverityRanks = map[VulnerabilitySeverity]int{
+	VulnerabilitySeverityUnknown:    0,
+	VulnerabilitySeverityNegligible: 1,
+	VulnerabilitySeverityLow:        2,
+	VulnerabilitySeverityMedium:     3,
+	VulnerabilitySeverityHigh:       4,
+	VulnerabilitySeverityCritical:   5,
+}
+
+// IsKnownVulnerabilitySeverity returns true if severity is one of the defined vulnerability severities.
+func IsKnownVulnerabilitySeverity(severity VulnerabilitySeverity) bool {
+	_, ok := vulnerabilitySeverityRanks[severity]
+	return ok
+}
+
+// VulnerabilitySeverityExceeds returns true if severity is more serious than threshold. Unrecognized
+// severities are treated as Unknown.
+func VulnerabilitySeverityExceeds(severity, threshold VulnerabilitySeverity) bool {
+	return vulnerabilitySeverityRanks[severity] > vulnerabilitySeverityRanks[threshold]
+}
+
+// VulnerabilitiesAbove returns the vulnerabilities of the scan that are more serious than threshold.
+func VulnerabilitiesAbove(scan *ImageVulnerabilityScan, threshold VulnerabilitySeverity) []ImageVulnerability {
+	if scan == nil {
+		return nil
+	}
+	var found []ImageVulnerability
+	for _, v := range scan.Vulnerabilities {
+		if VulnerabilitySeverityExceeds(v.Severity, threshold) {
+			found = append(found, v)
+		}
+	}
+	return found
+}
+
 func isRegistryName(str string) bool {
 	switch {
 	case strings.Contains(str, ":"),
@@ -434,6 +504,21 @@ func ImageConfigMatchesImage(image *Image, imageConfig []byte) (bool, error) {
 	return v.Verified(), nil
 }
 
+// DefaultImageManifest returns the manifest of a manifest list that is used by clients that do not select a
+// platform, which is the linux/amd64 one, or the first one if there is no such manifest. It returns nil if
+// manifests is empty.
+func DefaultImageManifest(manifests []ImageManifest) *ImageManifest {
+	for i := range manifests {
+		if manifests[i].OS == "linux" && manifests[i].Architecture == "amd64" {
+			return &manifests[i]
+		}
+	}
+	if len(manifests) > 0 {
+		return &manifests[0]
+	}
+	return nil
+}
+
 // ImageWithMetadata returns a copy of image with the DockerImageMetadata filled in
 // from the raw DockerImageManifest data stored in the image.
 func ImageWithMetadata(image *Image) error {
@@ -511,6 +596,23 @@ func ImageWithMetadata(image *Image) error {
 			image.DockerImageMetadata.Size = v1Metadata.Size
 		}
 	case 2:
+		if manifest.Manifests != nil {
+			// manifest lists and OCI indexes have no config or layers of their own, the metadata of the list
+			// image is copied from one of the referenced manifests when it is imported
+			image.DockerImageManifests = make([]ImageManifest, len(manifest.Manifests))
+			for i, m := range manifest.Manifests {
+				image.DockerImageManifests[i] = ImageManifest{
+					Digest:       m.Digest,
+					MediaType:    m.MediaType,
+					ManifestSize: m.Size,
+					Architecture: m.Platform.Architecture,
+					OS:           m.Platform.OS,
+					Variant:      m.Platform.Variant,
+				}
+			}
+			return nil
+		}
+
 		config := DockerImageConfig{}
 		if err := json.Unmarshal([]byte(image.DockerImageConfig), &config); err != nil {
 			return err
@@ -632,6 +632,25 @@ func validImageWithManifestData() Image {
 	}
 }
 
+const manifestListData = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.oci.image.index.v1+json",
+   "manifests": [
+      {
+         "mediaType": "application/vnd.oci.image.manifest.v1+json",
+         "size": 7682,
+         "digest": "sha256:amd64",
+         "platform": {"architecture": "amd64", "os": "linux"}
+      },
+      {
+         "mediaType": "application/vnd.oci.image.manifest.v1+json",
+         "size": 7143,
+         "digest": "sha256:arm",
+         "platform": {"architecture": "arm", "os": "linux", "variant": "v7"}
+      }
+   ]
+}`
+
 func TestImageWithMetadata(t *testing.T) {
 	tests := map[string]struct {
 		image         Image
@@ -741,6 +760,22 @@ func TestImageWithMetadata(t *testing.T) {
 				},
 			},
 		},
+		"manifest list": {
+			image: Image{
+				ObjectMeta:          kapi.ObjectMeta{Name: "sha256:list"},
+				DockerImageManifest: manifestListData,
+				DockerImageMetadata: DockerImage{ID: "sha256:config", Architecture: "amd64"},
+			},
+			expectedImage: Image{
+				ObjectMeta:          kapi.ObjectMeta{Name: "sha256:list"},
+				DockerImageManifest: manifestListData,
+				DockerImageMetadata: DockerImage{ID: "sha256:config", Architecture: "amd64"},
+				DockerImageManifests: []ImageManifest{
+					{Digest: "sha256:amd64", MediaType: "application/vnd.oci.image.manifest.v1+json", ManifestSize: 7682, Architecture: "amd64", OS: "linux"},
+					{Digest: "sha256:arm", MediaType: "application/vnd.oci.image.manifest.v1+json", ManifestSize: 7143, Architecture: "arm", OS: "linux", Variant: "v7"},
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1452,3 +1487,49 @@ func TestTagsChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestVulnerabilitiesAbove(t *testing.T) {
+	scan := &ImageVulnerabilityScan{
+		Vulnerabilities: []ImageVulnerability{
+			{ID: "CVE-2016-0001", Severity: VulnerabilitySeverityLow},
+			{ID: "CVE-2016-0002", Severity: VulnerabilitySeverityHigh},
+			{ID: "CVE-2016-0003", Severity: VulnerabilitySeverityUnknown},
+			{ID: "CVE-2016-0004", Severity: VulnerabilitySeverityCritical},
+		},
+	}
+	tests := map[string]struct {
+		scan      *ImageVulnerabilityScan
+		threshold VulnerabilitySeverity
+		expected  []string
+	}{
+		"no scan": {
+			threshold: VulnerabilitySeverityUnknown,
+		},
+		"critical": {
+			scan:      scan,
+			threshold: VulnerabilitySeverityCritical,
+		},
+		"medium": {
+			scan:      scan,
+			threshold: VulnerabilitySeverityMedium,
+			expected:  []string{"CVE-2016-0002", "CVE-2016-0004"},
+		},
+		"unknown": {
+			scan:      scan,
+			threshold: VulnerabilitySeverityUnknown,
+			expected:  []string{"CVE-2016-0001", "CVE-2016-0002", "CVE-2016-0004"},
+		},
+	}
+	for name, test := range tests {
+		found := []string{}
+		for _, v := range VulnerabilitiesAbove(test.scan, test.threshold) {
+			found = append(found, v.ID)
+		}
+		if len(test.expected) == 0 {
+			test.expected = []string{}
+		}
+		if !reflect.DeepEqual(found, test.expected) {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, found)
+		}
+	}
+}
@@ -30,6 +30,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Image{},
 		&ImageList{},
+		&ImageSignature{},
 		&DockerImage{},
 		&ImageStream{},
 		&ImageStreamList{},
@@ -43,6 +44,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 
 func (obj *Image) GetObjectKind() unversioned.ObjectKind              { return &obj.TypeMeta }
 func (obj *ImageList) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
+func (obj *ImageSignature) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
 func (obj *DockerImage) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *ImageStream) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *ImageStreamList) GetObjectKind() unversioned.ObjectKind    { return &obj.TypeMeta }
@@ -28,6 +28,25 @@ const (
 	// ExcludeImageSecretAnnotation indicates that a secret should not be returned by imagestream/secrets.
 	ExcludeImageSecretAnnotation = "openshift.io/image.excludeSecret"
 
+	// TrustedSigningKeysAnnotation may be set on a project to a bundle of PEM encoded public keys. When set,
+	// pods in the project may only run images with a valid signature created by one of those keys.
+	TrustedSigningKeysAnnotation = "openshift.io/image.trustedSigningKeys"
+
+	// MaxVulnerabilitySeverityAnnotation may be set on a project to a vulnerability severity. When set, pods in
+	// the project may not run images with a known vulnerability of a higher severity, nor images that cannot be
+	// resolved to an image known to the server.
+	MaxVulnerabilitySeverityAnnotation = "openshift.io/image.maxVulnerabilitySeverity"
+	// RequireVulnerabilityScanAnnotation may be set to "true" on a project with a maximum vulnerability
+	// severity to also reject images that have not been scanned.
+	RequireVulnerabilityScanAnnotation = "openshift.io/image.requireVulnerabilityScan"
+
+	// TestsPassedAnnotation may be set to "true" on an image or on a spec tag to record that the image passed the
+	// tests required before it is promoted to another image stream.
+	TestsPassedAnnotation = "openshift.io/image.testsPassed"
+
+	// PromotionHistoryAnnotation holds a JSON list of the most recent promotions into the tags of an image stream.
+	PromotionHistoryAnnotation = "openshift.io/image.promotionHistory"
+
 	// DefaultImageTag is used when an image tag is needed and the configuration does not specify a tag to use.
 	DefaultImageTag = "latest"
 
@@ -42,6 +61,10 @@ const (
 	// of a project.
 	ResourceImageStreamTags kapi.ResourceName = "openshift.io/image-tags"
 
+	// ResourceImageStreamsStorage represents the total size of unique image layers stored in the integrated
+	// registry and referenced by image streams of a project.
+	ResourceImageStreamsStorage kapi.ResourceName = "openshift.io/imagestreams.storage"
+
 	// Limit that applies to images. Used with a max["storage"] LimitRangeItem to set
 	// the maximum size of an image.
 	LimitTypeImage kapi.LimitType = "openshift.io/Image"
@@ -76,6 +99,27 @@ type Image struct {
 	DockerImageManifestMediaType string
 	// DockerImageConfig is a JSON blob that the runtime uses to set up the container. This is a part of manifest schema v2.
 	DockerImageConfig string
+	// VulnerabilityScan holds the results of the most recent vulnerability scan of the image, if any.
+	VulnerabilityScan *ImageVulnerabilityScan
+	// DockerImageManifests holds the platform specific images referenced by the image if it is a manifest list or
+	// an OCI image index. Each of them is stored as an image of the same name as its digest.
+	DockerImageManifests []ImageManifest
+}
+
+// ImageManifest is a reference to a platform specific image from a manifest list or an OCI image index.
+type ImageManifest struct {
+	// Digest is the digest of the manifest of the image.
+	Digest string
+	// MediaType is the media type of the manifest.
+	MediaType string
+	// ManifestSize is the size of the manifest in bytes.
+	ManifestSize int64
+	// Architecture is the CPU architecture the image runs on.
+	Architecture string
+	// OS is the operating system the image runs on.
+	OS string
+	// Variant is the variant of the CPU, if any.
+	Variant string
 }
 
 // ImageLayer represents a single layer of the image. Some images may have multiple layers. Some may have none.
@@ -91,6 +135,9 @@ type ImageLayer struct {
 const (
 	// The supported type of image signature.
 	ImageSignatureTypeAtomicImageV1 string = "AtomicImageV1"
+	// ImageSignatureTypeJWSImageV1 is a JSON Web Signature over an image claim document that can be
+	// verified with public keys trusted by a project.
+	ImageSignatureTypeJWSImageV1 string = "JWSImageV1"
 )
 
 // ImageSignature holds a signature of an image. It allows to verify image identity and possibly other claims
@@ -100,6 +147,10 @@ const (
 // image verification. The others are parsed from signature's content by the server. They serve just an
 // informative purpose.
 type ImageSignature struct {
+	unversioned.TypeMeta
+	// The name has a form of <imageName>@<signatureName>.
+	kapi.ObjectMeta
+
 	// Required: Describes a type of stored blob.
 	Type string
 	// Required: An opaque binary string which is an image's signature.
@@ -181,6 +232,46 @@ type SignatureSubject struct {
 	PublicKeyID string
 }
 
+// ImageVulnerabilityScan holds the results of scanning an image for known vulnerabilities. The results are
+// produced by an external scanner and stored by the server as reported.
+type ImageVulnerabilityScan struct {
+	// Scanner identifies the tool that produced the results (e.g. "clair").
+	Scanner string
+	// Completed is the time the scan finished.
+	Completed unversioned.Time
+	// Vulnerabilities found in the image. An empty list means that the scanner found none.
+	Vulnerabilities []ImageVulnerability
+}
+
+// VulnerabilitySeverity describes how serious a vulnerability is.
+type VulnerabilitySeverity string
+
+// These are the valid severities of a vulnerability, from the least to the most severe.
+const (
+	VulnerabilitySeverityUnknown    VulnerabilitySeverity = "Unknown"
+	VulnerabilitySeverityNegligible VulnerabilitySeverity = "Negligible"
+	VulnerabilitySeverityLow        VulnerabilitySeverity = "Low"
+	VulnerabilitySeverityMedium     VulnerabilitySeverity = "Medium"
+	VulnerabilitySeverityHigh       VulnerabilitySeverity = "High"
+	VulnerabilitySeverityCritical   VulnerabilitySeverity = "Critical"
+)
+
+// ImageVulnerability describes a single vulnerability found in an image.
+type ImageVulnerability struct {
+	// ID of the vulnerability, usually a CVE identifier.
+	ID string
+	// Severity of the vulnerability.
+	Severity VulnerabilitySeverity
+	// Package is the name of the affected package installed in the image.
+	Package string
+	// Version of the affected package.
+	Version string
+	// FixedVersion is the first version of the package that is not affected, if known.
+	FixedVersion string
+	// Link to a description of the vulnerability.
+	Link string
+}
+
 // ImageStreamList is a list of ImageStream objects.
 type ImageStreamList struct {
 	unversioned.TypeMeta
@@ -208,6 +299,20 @@ type ImageStreamSpec struct {
 	DockerImageRepository string
 	// Tags map arbitrary string values to specific image locators
 	Tags map[string]TagReference
+	// RetentionPolicy, if set, limits the number and age of the images kept in the history of each tag
+	RetentionPolicy *ImageStreamRetentionPolicy
+}
+
+// ImageStreamRetentionPolicy limits the history kept in the status of an image stream. The current image of a
+// tag is always kept. Images removed from the history are deleted once nothing references them. The policy is only
+// enforced if image retention is enabled in the master configuration.
+type ImageStreamRetentionPolicy struct {
+	// MaxTagRevisions is the maximum number of images kept in the history of each tag, including the current
+	// one. Zero means no limit.
+	MaxTagRevisions int32
+	// MaxTagAgeSeconds is the maximum number of seconds an image that is no longer the current image of a tag
+	// is kept in its history. Zero means no limit.
+	MaxTagAgeSeconds int64
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to
@@ -240,6 +345,10 @@ type TagImportPolicy struct {
 	Insecure bool
 	// Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported
 	Scheduled bool
+	// VersionRange, if set, makes the tag track the tag of the Docker repository in From that names the highest
+	// semantic version in this range, for example "~1.4" or ">=1.2 <2.0". The range is resolved again on every
+	// import, and the tag named in From is ignored.
+	VersionRange string
 }
 
 // ImageStreamStatus contains information about the state of this image stream.
@@ -431,4 +540,6 @@ type ImageImportStatus struct {
 	Tag    string
 	Status unversioned.Status
 	Image  *Image
+	// Manifests holds the platform specific images of the image, if it is a manifest list or an OCI image index.
+	Manifests []Image
 }
@@ -74,6 +74,26 @@ func Convert_api_Image_To_v1_Image(in *newer.Image, out *Image, s conversion.Sco
 		out.DockerImageSignatures = nil
 	}
 
+	if in.VulnerabilityScan != nil {
+		out.VulnerabilityScan = new(ImageVulnerabilityScan)
+		if err := s.Convert(in.VulnerabilityScan, out.VulnerabilityScan, 0); err != nil {
+			return err
+		}
+	} else {
+		out.VulnerabilityScan = nil
+	}
+
+	if in.DockerImageManifests != nil {
+		out.DockerImageManifests = make([]ImageManifest, len(in.DockerImageManifests))
+		for i := range in.DockerImageManifests {
+			if err := s.Convert(&in.DockerImageManifests[i], &out.DockerImageManifests[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DockerImageManifests = nil
+	}
+
 	return nil
 }
 
@@ -137,11 +157,34 @@ func Convert_v1_Image_To_api_Image(in *Image, out *newer.Image, s conversion.Sco
 		out.DockerImageSignatures = nil
 	}
 
+	if in.VulnerabilityScan != nil {
+		out.VulnerabilityScan = new(newer.ImageVulnerabilityScan)
+		if err := s.Convert(in.VulnerabilityScan, out.VulnerabilityScan, 0); err != nil {
+			return err
+		}
+	} else {
+		out.VulnerabilityScan = nil
+	}
+
+	if in.DockerImageManifests != nil {
+		out.DockerImageManifests = make([]newer.ImageManifest, len(in.DockerImageManifests))
+		for i := range in.DockerImageManifests {
+			if err := s.Convert(&in.DockerImageManifests[i], &out.DockerImageManifests[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DockerImageManifests = nil
+	}
+
 	return nil
 }
 
 func Convert_v1_ImageStreamSpec_To_api_ImageStreamSpec(in *ImageStreamSpec, out *newer.ImageStreamSpec, s conversion.Scope) error {
 	out.DockerImageRepository = in.DockerImageRepository
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
 	out.Tags = make(map[string]newer.TagReference)
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
@@ -157,6 +200,9 @@ func Convert_api_ImageStreamSpec_To_v1_ImageStreamSpec(in *newer.ImageStreamSpec
 			}
 		}
 	}
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
 	out.Tags = make([]TagReference, 0, 0)
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
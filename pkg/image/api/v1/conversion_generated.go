@@ -26,6 +26,8 @@ func init() {
 		Convert_api_ImageLayer_To_v1_ImageLayer,
 		Convert_v1_ImageList_To_api_ImageList,
 		Convert_api_ImageList_To_v1_ImageList,
+		Convert_v1_ImageManifest_To_api_ImageManifest,
+		Convert_api_ImageManifest_To_v1_ImageManifest,
 		Convert_v1_ImageSignature_To_api_ImageSignature,
 		Convert_api_ImageSignature_To_v1_ImageSignature,
 		Convert_v1_ImageStream_To_api_ImageStream,
@@ -42,6 +44,8 @@ func init() {
 		Convert_api_ImageStreamList_To_v1_ImageStreamList,
 		Convert_v1_ImageStreamMapping_To_api_ImageStreamMapping,
 		Convert_api_ImageStreamMapping_To_v1_ImageStreamMapping,
+		Convert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy,
+		Convert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy,
 		Convert_v1_ImageStreamSpec_To_api_ImageStreamSpec,
 		Convert_api_ImageStreamSpec_To_v1_ImageStreamSpec,
 		Convert_v1_ImageStreamStatus_To_api_ImageStreamStatus,
@@ -50,6 +54,10 @@ func init() {
 		Convert_api_ImageStreamTag_To_v1_ImageStreamTag,
 		Convert_v1_ImageStreamTagList_To_api_ImageStreamTagList,
 		Convert_api_ImageStreamTagList_To_v1_ImageStreamTagList,
+		Convert_v1_ImageVulnerability_To_api_ImageVulnerability,
+		Convert_api_ImageVulnerability_To_v1_ImageVulnerability,
+		Convert_v1_ImageVulnerabilityScan_To_api_ImageVulnerabilityScan,
+		Convert_api_ImageVulnerabilityScan_To_v1_ImageVulnerabilityScan,
 		Convert_v1_RepositoryImportSpec_To_api_RepositoryImportSpec,
 		Convert_api_RepositoryImportSpec_To_v1_RepositoryImportSpec,
 		Convert_v1_RepositoryImportStatus_To_api_RepositoryImportStatus,
@@ -170,6 +178,17 @@ func autoConvert_v1_ImageImportStatus_To_api_ImageImportStatus(in *ImageImportSt
 		out.Image = nil
 	}
 	out.Tag = in.Tag
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]image_api.Image, len(*in))
+		for i := range *in {
+			if err := Convert_v1_Image_To_api_Image(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
 	return nil
 }
 
@@ -192,6 +211,17 @@ func autoConvert_api_ImageImportStatus_To_v1_ImageImportStatus(in *image_api.Ima
 	} else {
 		out.Image = nil
 	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]Image, len(*in))
+		for i := range *in {
+			if err := Convert_api_Image_To_v1_Image(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
 	return nil
 }
 
@@ -271,7 +301,42 @@ func Convert_api_ImageList_To_v1_ImageList(in *image_api.ImageList, out *ImageLi
 	return autoConvert_api_ImageList_To_v1_ImageList(in, out, s)
 }
 
+func autoConvert_v1_ImageManifest_To_api_ImageManifest(in *ImageManifest, out *image_api.ImageManifest, s conversion.Scope) error {
+	out.Digest = in.Digest
+	out.MediaType = in.MediaType
+	out.ManifestSize = in.ManifestSize
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.Variant = in.Variant
+	return nil
+}
+
+func Convert_v1_ImageManifest_To_api_ImageManifest(in *ImageManifest, out *image_api.ImageManifest, s conversion.Scope) error {
+	return autoConvert_v1_ImageManifest_To_api_ImageManifest(in, out, s)
+}
+
+func autoConvert_api_ImageManifest_To_v1_ImageManifest(in *image_api.ImageManifest, out *ImageManifest, s conversion.Scope) error {
+	out.Digest = in.Digest
+	out.MediaType = in.MediaType
+	out.ManifestSize = in.ManifestSize
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.Variant = in.Variant
+	return nil
+}
+
+func Convert_api_ImageManifest_To_v1_ImageManifest(in *image_api.ImageManifest, out *ImageManifest, s conversion.Scope) error {
+	return autoConvert_api_ImageManifest_To_v1_ImageManifest(in, out, s)
+}
+
 func autoConvert_v1_ImageSignature_To_api_ImageSignature(in *ImageSignature, out *image_api.ImageSignature, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
 	out.Type = in.Type
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.Content, &out.Content, s); err != nil {
 		return err
@@ -332,6 +397,13 @@ func Convert_v1_ImageSignature_To_api_ImageSignature(in *ImageSignature, out *im
 }
 
 func autoConvert_api_ImageSignature_To_v1_ImageSignature(in *image_api.ImageSignature, out *ImageSignature, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
 	out.Type = in.Type
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.Content, &out.Content, s); err != nil {
 		return err
@@ -708,6 +780,26 @@ func autoConvert_v1_ImageStreamMapping_To_api_ImageStreamMapping(in *ImageStream
 	return nil
 }
 
+func autoConvert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *ImageStreamRetentionPolicy, out *image_api.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	out.MaxTagRevisions = in.MaxTagRevisions
+	out.MaxTagAgeSeconds = in.MaxTagAgeSeconds
+	return nil
+}
+
+func Convert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *ImageStreamRetentionPolicy, out *image_api.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in, out, s)
+}
+
+func autoConvert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in *image_api.ImageStreamRetentionPolicy, out *ImageStreamRetentionPolicy, s conversion.Scope) error {
+	out.MaxTagRevisions = in.MaxTagRevisions
+	out.MaxTagAgeSeconds = in.MaxTagAgeSeconds
+	return nil
+}
+
+func Convert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in *image_api.ImageStreamRetentionPolicy, out *ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in, out, s)
+}
+
 func autoConvert_v1_ImageStreamTag_To_api_ImageStreamTag(in *ImageStreamTag, out *image_api.ImageStreamTag, s conversion.Scope) error {
 	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
 		return err
@@ -836,6 +928,80 @@ func Convert_api_ImageStreamTagList_To_v1_ImageStreamTagList(in *image_api.Image
 	return autoConvert_api_ImageStreamTagList_To_v1_ImageStreamTagList(in, out, s)
 }
 
+func autoConvert_v1_ImageVulnerability_To_api_ImageVulnerability(in *ImageVulnerability, out *image_api.ImageVulnerability, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Severity = image_api.VulnerabilitySeverity(in.Severity)
+	out.Package = in.Package
+	out.Version = in.Version
+	out.FixedVersion = in.FixedVersion
+	out.Link = in.Link
+	return nil
+}
+
+func Convert_v1_ImageVulnerability_To_api_ImageVulnerability(in *ImageVulnerability, out *image_api.ImageVulnerability, s conversion.Scope) error {
+	return autoConvert_v1_ImageVulnerability_To_api_ImageVulnerability(in, out, s)
+}
+
+func autoConvert_api_ImageVulnerability_To_v1_ImageVulnerability(in *image_api.ImageVulnerability, out *ImageVulnerability, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Severity = VulnerabilitySeverity(in.Severity)
+	out.Package = in.Package
+	out.Version = in.Version
+	out.FixedVersion = in.FixedVersion
+	out.Link = in.Link
+	return nil
+}
+
+func Convert_api_ImageVulnerability_To_v1_ImageVulnerability(in *image_api.ImageVulnerability, out *ImageVulnerability, s conversion.Scope) error {
+	return autoConvert_api_ImageVulnerability_To_v1_ImageVulnerability(in, out, s)
+}
+
+func autoConvert_v1_ImageVulnerabilityScan_To_api_ImageVulnerabilityScan(in *ImageVulnerabilityScan, out *image_api.ImageVulnerabilityScan, s conversion.Scope) error {
+	out.Scanner = in.Scanner
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.Completed, &out.Completed, s); err != nil {
+		return err
+	}
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]image_api.ImageVulnerability, len(*in))
+		for i := range *in {
+			if err := Convert_v1_ImageVulnerability_To_api_ImageVulnerability(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Vulnerabilities = nil
+	}
+	return nil
+}
+
+func Convert_v1_ImageVulnerabilityScan_To_api_ImageVulnerabilityScan(in *ImageVulnerabilityScan, out *image_api.ImageVulnerabilityScan, s conversion.Scope) error {
+	return autoConvert_v1_ImageVulnerabilityScan_To_api_ImageVulnerabilityScan(in, out, s)
+}
+
+func autoConvert_api_ImageVulnerabilityScan_To_v1_ImageVulnerabilityScan(in *image_api.ImageVulnerabilityScan, out *ImageVulnerabilityScan, s conversion.Scope) error {
+	out.Scanner = in.Scanner
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.Completed, &out.Completed, s); err != nil {
+		return err
+	}
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]ImageVulnerability, len(*in))
+		for i := range *in {
+			if err := Convert_api_ImageVulnerability_To_v1_ImageVulnerability(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Vulnerabilities = nil
+	}
+	return nil
+}
+
+func Convert_api_ImageVulnerabilityScan_To_v1_ImageVulnerabilityScan(in *image_api.ImageVulnerabilityScan, out *ImageVulnerabilityScan, s conversion.Scope) error {
+	return autoConvert_api_ImageVulnerabilityScan_To_v1_ImageVulnerabilityScan(in, out, s)
+}
+
 func autoConvert_v1_RepositoryImportSpec_To_api_RepositoryImportSpec(in *RepositoryImportSpec, out *image_api.RepositoryImportSpec, s conversion.Scope) error {
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.From, &out.From, 0); err != nil {
@@ -1093,6 +1259,7 @@ func Convert_api_TagEventCondition_To_v1_TagEventCondition(in *image_api.TagEven
 func autoConvert_v1_TagImportPolicy_To_api_TagImportPolicy(in *TagImportPolicy, out *image_api.TagImportPolicy, s conversion.Scope) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	out.VersionRange = in.VersionRange
 	return nil
 }
 
@@ -1103,6 +1270,7 @@ func Convert_v1_TagImportPolicy_To_api_TagImportPolicy(in *TagImportPolicy, out
 func autoConvert_api_TagImportPolicy_To_v1_TagImportPolicy(in *image_api.TagImportPolicy, out *TagImportPolicy, s conversion.Scope) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	out.VersionRange = in.VersionRange
 	return nil
 }
 
@@ -20,6 +20,7 @@ func init() {
 		DeepCopy_v1_ImageImportStatus,
 		DeepCopy_v1_ImageLayer,
 		DeepCopy_v1_ImageList,
+		DeepCopy_v1_ImageManifest,
 		DeepCopy_v1_ImageSignature,
 		DeepCopy_v1_ImageStream,
 		DeepCopy_v1_ImageStreamImage,
@@ -28,10 +29,13 @@ func init() {
 		DeepCopy_v1_ImageStreamImportStatus,
 		DeepCopy_v1_ImageStreamList,
 		DeepCopy_v1_ImageStreamMapping,
+		DeepCopy_v1_ImageStreamRetentionPolicy,
 		DeepCopy_v1_ImageStreamSpec,
 		DeepCopy_v1_ImageStreamStatus,
 		DeepCopy_v1_ImageStreamTag,
 		DeepCopy_v1_ImageStreamTagList,
+		DeepCopy_v1_ImageVulnerability,
+		DeepCopy_v1_ImageVulnerabilityScan,
 		DeepCopy_v1_NamedTagEventList,
 		DeepCopy_v1_RepositoryImportSpec,
 		DeepCopy_v1_RepositoryImportStatus,
@@ -108,6 +112,26 @@ func DeepCopy_v1_Image(in Image, out *Image, c *conversion.Cloner) error {
 	}
 	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
 	out.DockerImageConfig = in.DockerImageConfig
+	if in.VulnerabilityScan != nil {
+		in, out := in.VulnerabilityScan, &out.VulnerabilityScan
+		*out = new(ImageVulnerabilityScan)
+		if err := DeepCopy_v1_ImageVulnerabilityScan(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.VulnerabilityScan = nil
+	}
+	if in.DockerImageManifests != nil {
+		in, out := in.DockerImageManifests, &out.DockerImageManifests
+		*out = make([]ImageManifest, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_ImageManifest(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DockerImageManifests = nil
+	}
 	return nil
 }
 
@@ -145,6 +169,17 @@ func DeepCopy_v1_ImageImportStatus(in ImageImportStatus, out *ImageImportStatus,
 		out.Image = nil
 	}
 	out.Tag = in.Tag
+	if in.Manifests != nil {
+		in, out := in.Manifests, &out.Manifests
+		*out = make([]Image, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_Image(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
 	return nil
 }
 
@@ -176,7 +211,23 @@ func DeepCopy_v1_ImageList(in ImageList, out *ImageList, c *conversion.Cloner) e
 	return nil
 }
 
+func DeepCopy_v1_ImageManifest(in ImageManifest, out *ImageManifest, c *conversion.Cloner) error {
+	out.Digest = in.Digest
+	out.MediaType = in.MediaType
+	out.ManifestSize = in.ManifestSize
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.Variant = in.Variant
+	return nil
+}
+
 func DeepCopy_v1_ImageSignature(in ImageSignature, out *ImageSignature, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := api_v1.DeepCopy_v1_ObjectMeta(in.ObjectMeta, &out.ObjectMeta, c); err != nil {
+		return err
+	}
 	out.Type = in.Type
 	if in.Content != nil {
 		in, out := in.Content, &out.Content
@@ -374,6 +425,12 @@ func DeepCopy_v1_ImageStreamMapping(in ImageStreamMapping, out *ImageStreamMappi
 	return nil
 }
 
+func DeepCopy_v1_ImageStreamRetentionPolicy(in ImageStreamRetentionPolicy, out *ImageStreamRetentionPolicy, c *conversion.Cloner) error {
+	out.MaxTagRevisions = in.MaxTagRevisions
+	out.MaxTagAgeSeconds = in.MaxTagAgeSeconds
+	return nil
+}
+
 func DeepCopy_v1_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *conversion.Cloner) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	if in.Tags != nil {
@@ -387,6 +444,15 @@ func DeepCopy_v1_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *co
 	} else {
 		out.Tags = nil
 	}
+	if in.RetentionPolicy != nil {
+		in, out := in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(ImageStreamRetentionPolicy)
+		if err := DeepCopy_v1_ImageStreamRetentionPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
 	return nil
 }
 
@@ -461,6 +527,35 @@ func DeepCopy_v1_ImageStreamTagList(in ImageStreamTagList, out *ImageStreamTagLi
 	return nil
 }
 
+func DeepCopy_v1_ImageVulnerability(in ImageVulnerability, out *ImageVulnerability, c *conversion.Cloner) error {
+	out.ID = in.ID
+	out.Severity = in.Severity
+	out.Package = in.Package
+	out.Version = in.Version
+	out.FixedVersion = in.FixedVersion
+	out.Link = in.Link
+	return nil
+}
+
+func DeepCopy_v1_ImageVulnerabilityScan(in ImageVulnerabilityScan, out *ImageVulnerabilityScan, c *conversion.Cloner) error {
+	out.Scanner = in.Scanner
+	if err := unversioned.DeepCopy_unversioned_Time(in.Completed, &out.Completed, c); err != nil {
+		return err
+	}
+	if in.Vulnerabilities != nil {
+		in, out := in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]ImageVulnerability, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_ImageVulnerability(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Vulnerabilities = nil
+	}
+	return nil
+}
+
 func DeepCopy_v1_NamedTagEventList(in NamedTagEventList, out *NamedTagEventList, c *conversion.Cloner) error {
 	out.Tag = in.Tag
 	if in.Items != nil {
@@ -584,6 +679,7 @@ func DeepCopy_v1_TagEventCondition(in TagEventCondition, out *TagEventCondition,
 func DeepCopy_v1_TagImportPolicy(in TagImportPolicy, out *TagImportPolicy, c *conversion.Cloner) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	out.VersionRange = in.VersionRange
 	return nil
 }
 
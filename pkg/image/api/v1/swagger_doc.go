@@ -30,6 +30,8 @@ var map_Image = map[string]string{
 	"dockerImageSignatures":        "DockerImageSignatures provides the signatures as opaque blobs. This is a part of manifest schema v1.",
 	"dockerImageManifestMediaType": "DockerImageManifestMediaType specifies the mediaType of manifest. This is a part of manifest schema v2.",
 	"dockerImageConfig":            "DockerImageConfig is a JSON blob that the runtime uses to set up the container. This is a part of manifest schema v2.",
+	"vulnerabilityScan":            "VulnerabilityScan holds the results of the most recent vulnerability scan of the image, if any.",
+	"dockerImageManifests":         "DockerImageManifests holds the platform specific images referenced by the image if it is a manifest list or an OCI image index. Each of them is stored as an image of the same name as its digest.",
 }
 
 func (Image) SwaggerDoc() map[string]string {
@@ -49,10 +51,11 @@ func (ImageImportSpec) SwaggerDoc() map[string]string {
 }
 
 var map_ImageImportStatus = map[string]string{
-	"":       "ImageImportStatus describes the result of an image import.",
-	"status": "Status is the status of the image import, including errors encountered while retrieving the image",
-	"image":  "Image is the metadata of that image, if the image was located",
-	"tag":    "Tag is the tag this image was located under, if any",
+	"":          "ImageImportStatus describes the result of an image import.",
+	"status":    "Status is the status of the image import, including errors encountered while retrieving the image",
+	"image":     "Image is the metadata of that image, if the image was located",
+	"tag":       "Tag is the tag this image was located under, if any",
+	"manifests": "Manifests holds the platform specific images of the image, if it is a manifest list or an OCI image index",
 }
 
 func (ImageImportStatus) SwaggerDoc() map[string]string {
@@ -80,8 +83,23 @@ func (ImageList) SwaggerDoc() map[string]string {
 	return map_ImageList
 }
 
+var map_ImageManifest = map[string]string{
+	"":             "ImageManifest is a reference to a platform specific image from a manifest list or an OCI image index.",
+	"digest":       "Digest is the digest of the manifest of the image.",
+	"mediaType":    "MediaType is the media type of the manifest.",
+	"manifestSize": "ManifestSize is the size of the manifest in bytes.",
+	"architecture": "Architecture is the CPU architecture the image runs on.",
+	"os":           "OS is the operating system the image runs on.",
+	"variant":      "Variant is the variant of the CPU, if any.",
+}
+
+func (ImageManifest) SwaggerDoc() map[string]string {
+	return map_ImageManifest
+}
+
 var map_ImageSignature = map[string]string{
 	"":              "ImageSignature holds a signature of an image. It allows to verify image identity and possibly other claims as long as the signature is trusted. Based on this information it is possible to restrict runnable images to those matching cluster-wide policy. There are two mandatory fields provided by client: Type and Content. They should be parsed by clients doing image verification. The others are parsed from signature's content by the server. They serve just an informative purpose.",
+	"metadata":      "Standard object's metadata. The name has a form of <imageName>@<signatureName>.",
 	"type":          "Required: Describes a type of stored blob.",
 	"content":       "Required: An opaque binary string which is an image's signature.",
 	"conditions":    "Conditions represent the latest available observations of a signature's current state.",
@@ -171,10 +189,21 @@ func (ImageStreamMapping) SwaggerDoc() map[string]string {
 	return map_ImageStreamMapping
 }
 
+var map_ImageStreamRetentionPolicy = map[string]string{
+	"":                 "ImageStreamRetentionPolicy limits the history kept in the status of an image stream. The current image of a tag is always kept. Images removed from the history are deleted once nothing references them. The policy is only enforced if image retention is enabled in the master configuration.",
+	"maxTagRevisions":  "MaxTagRevisions is the maximum number of images kept in the history of each tag, including the current one. Zero means no limit.",
+	"maxTagAgeSeconds": "MaxTagAgeSeconds is the maximum number of seconds an image that is no longer the current image of a tag is kept in its history. Zero means no limit.",
+}
+
+func (ImageStreamRetentionPolicy) SwaggerDoc() map[string]string {
+	return map_ImageStreamRetentionPolicy
+}
+
 var map_ImageStreamSpec = map[string]string{
-	"": "ImageStreamSpec represents options for ImageStreams.",
+	"":                      "ImageStreamSpec represents options for ImageStreams.",
 	"dockerImageRepository": "DockerImageRepository is optional, if specified this stream is backed by a Docker repository on this server",
 	"tags":                  "Tags map arbitrary string values to specific image locators",
+	"retentionPolicy":       "RetentionPolicy, if set, limits the number and age of the images kept in the history of each tag",
 }
 
 func (ImageStreamSpec) SwaggerDoc() map[string]string {
@@ -214,6 +243,31 @@ func (ImageStreamTagList) SwaggerDoc() map[string]string {
 	return map_ImageStreamTagList
 }
 
+var map_ImageVulnerability = map[string]string{
+	"":             "ImageVulnerability describes a single vulnerability found in an image.",
+	"id":           "ID of the vulnerability, usually a CVE identifier.",
+	"severity":     "Severity of the vulnerability, one of Unknown, Negligible, Low, Medium, High or Critical.",
+	"package":      "Package is the name of the affected package installed in the image.",
+	"version":      "Version of the affected package.",
+	"fixedVersion": "FixedVersion is the first version of the package that is not affected, if known.",
+	"link":         "Link to a description of the vulnerability.",
+}
+
+func (ImageVulnerability) SwaggerDoc() map[string]string {
+	return map_ImageVulnerability
+}
+
+var map_ImageVulnerabilityScan = map[string]string{
+	"":                "ImageVulnerabilityScan holds the results of scanning an image for known vulnerabilities. The results are produced by an external scanner and stored by the server as reported.",
+	"scanner":         "Scanner identifies the tool that produced the results (e.g. \"clair\").",
+	"completed":       "Completed is the time the scan finished.",
+	"vulnerabilities": "Vulnerabilities found in the image. An empty list means that the scanner found none.",
+}
+
+func (ImageVulnerabilityScan) SwaggerDoc() map[string]string {
+	return map_ImageVulnerabilityScan
+}
+
 var map_NamedTagEventList = map[string]string{
 	"":           "NamedTagEventList relates a tag to its image history.",
 	"tag":        "Tag is the tag for which the history is recorded",
@@ -315,9 +369,10 @@ func (TagEventCondition) SwaggerDoc() map[string]string {
 }
 
 var map_TagImportPolicy = map[string]string{
-	"":          "TagImportPolicy describes the tag import policy",
-	"insecure":  "Insecure is true if the server may bypass certificate verification or connect directly over HTTP during image import.",
-	"scheduled": "Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported",
+	"":             "TagImportPolicy describes the tag import policy",
+	"insecure":     "Insecure is true if the server may bypass certificate verification or connect directly over HTTP during image import.",
+	"scheduled":    "Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported",
+	"versionRange": "VersionRange, if set, makes the tag track the tag of the Docker repository in From that names the highest semantic version in this range, for example \"~1.4\" or \">=1.2 <2.0\". The range is resolved again on every import, and the tag named in From is ignored.",
 }
 
 func (TagImportPolicy) SwaggerDoc() map[string]string {
@@ -42,6 +42,27 @@ type Image struct {
 	DockerImageManifestMediaType string `json:"dockerImageManifestMediaType,omitempty"`
 	// DockerImageConfig is a JSON blob that the runtime uses to set up the container. This is a part of manifest schema v2.
 	DockerImageConfig string `json:"dockerImageConfig,omitempty"`
+	// VulnerabilityScan holds the results of the most recent vulnerability scan of the image, if any.
+	VulnerabilityScan *ImageVulnerabilityScan `json:"vulnerabilityScan,omitempty"`
+	// DockerImageManifests holds the platform specific images referenced by the image if it is a manifest list or
+	// an OCI image index. Each of them is stored as an image of the same name as its digest.
+	DockerImageManifests []ImageManifest `json:"dockerImageManifests,omitempty"`
+}
+
+// ImageManifest is a reference to a platform specific image from a manifest list or an OCI image index.
+type ImageManifest struct {
+	// Digest is the digest of the manifest of the image.
+	Digest string `json:"digest"`
+	// MediaType is the media type of the manifest.
+	MediaType string `json:"mediaType"`
+	// ManifestSize is the size of the manifest in bytes.
+	ManifestSize int64 `json:"manifestSize"`
+	// Architecture is the CPU architecture the image runs on.
+	Architecture string `json:"architecture"`
+	// OS is the operating system the image runs on.
+	OS string `json:"os"`
+	// Variant is the variant of the CPU, if any.
+	Variant string `json:"variant,omitempty"`
 }
 
 // ImageLayer represents a single layer of the image. Some images may have multiple layers. Some may have none.
@@ -61,6 +82,10 @@ type ImageLayer struct {
 // image verification. The others are parsed from signature's content by the server. They serve just an
 // informative purpose.
 type ImageSignature struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata. The name has a form of <imageName>@<signatureName>.
+	kapi.ObjectMeta `json:"metadata,omitempty"`
+
 	// Required: Describes a type of stored blob.
 	Type string `json:"type"`
 	// Required: An opaque binary string which is an image's signature.
@@ -128,6 +153,36 @@ type SignatureSubject struct {
 	PublicKeyID string `json:"publicKeyID"`
 }
 
+// ImageVulnerabilityScan holds the results of scanning an image for known vulnerabilities. The results are
+// produced by an external scanner and stored by the server as reported.
+type ImageVulnerabilityScan struct {
+	// Scanner identifies the tool that produced the results (e.g. "clair").
+	Scanner string `json:"scanner,omitempty"`
+	// Completed is the time the scan finished.
+	Completed unversioned.Time `json:"completed"`
+	// Vulnerabilities found in the image. An empty list means that the scanner found none.
+	Vulnerabilities []ImageVulnerability `json:"vulnerabilities"`
+}
+
+// VulnerabilitySeverity describes how serious a vulnerability is.
+type VulnerabilitySeverity string
+
+// ImageVulnerability describes a single vulnerability found in an image.
+type ImageVulnerability struct {
+	// ID of the vulnerability, usually a CVE identifier.
+	ID string `json:"id"`
+	// Severity of the vulnerability, one of Unknown, Negligible, Low, Medium, High or Critical.
+	Severity VulnerabilitySeverity `json:"severity"`
+	// Package is the name of the affected package installed in the image.
+	Package string `json:"package,omitempty"`
+	// Version of the affected package.
+	Version string `json:"version,omitempty"`
+	// FixedVersion is the first version of the package that is not affected, if known.
+	FixedVersion string `json:"fixedVersion,omitempty"`
+	// Link to a description of the vulnerability.
+	Link string `json:"link,omitempty"`
+}
+
 // ImageStreamList is a list of ImageStream objects.
 type ImageStreamList struct {
 	unversioned.TypeMeta `json:",inline"`
@@ -158,6 +213,20 @@ type ImageStreamSpec struct {
 	DockerImageRepository string `json:"dockerImageRepository,omitempty"`
 	// Tags map arbitrary string values to specific image locators
 	Tags []TagReference `json:"tags,omitempty"`
+	// RetentionPolicy, if set, limits the number and age of the images kept in the history of each tag
+	RetentionPolicy *ImageStreamRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// ImageStreamRetentionPolicy limits the history kept in the status of an image stream. The current image of a
+// tag is always kept. Images removed from the history are deleted once nothing references them. The policy is only
+// enforced if image retention is enabled in the master configuration.
+type ImageStreamRetentionPolicy struct {
+	// MaxTagRevisions is the maximum number of images kept in the history of each tag, including the current
+	// one. Zero means no limit.
+	MaxTagRevisions int32 `json:"maxTagRevisions,omitempty"`
+	// MaxTagAgeSeconds is the maximum number of seconds an image that is no longer the current image of a tag
+	// is kept in its history. Zero means no limit.
+	MaxTagAgeSeconds int64 `json:"maxTagAgeSeconds,omitempty"`
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to an ImageStreamTag, ImageStreamImage, or DockerImage this tag should track.
@@ -183,6 +252,10 @@ type TagImportPolicy struct {
 	Insecure bool `json:"insecure,omitempty"`
 	// Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported
 	Scheduled bool `json:"scheduled,omitempty"`
+	// VersionRange, if set, makes the tag track the tag of the Docker repository in From that names the highest
+	// semantic version in this range, for example "~1.4" or ">=1.2 <2.0". The range is resolved again on every
+	// import, and the tag named in From is ignored.
+	VersionRange string `json:"versionRange,omitempty"`
 }
 
 // ImageStreamStatus contains information about the state of this image stream.
@@ -388,4 +461,6 @@ type ImageImportStatus struct {
 	Image *Image `json:"image,omitempty"`
 	// Tag is the tag this image was located under, if any
 	Tag string `json:"tag,omitempty"`
+	// Manifests holds the platform specific images of the image, if it is a manifest list or an OCI image index
+	Manifests []Image `json:"manifests,omitempty"`
 }
@@ -89,12 +89,18 @@ func Convert_v1beta3_ImageStreamSpec_To_api_ImageStreamSpec(in *ImageStreamSpec,
 			}
 		}
 	}
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
 	out.Tags = make(map[string]newer.TagReference)
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
 
 func Convert_api_ImageStreamSpec_To_v1beta3_ImageStreamSpec(in *newer.ImageStreamSpec, out *ImageStreamSpec, s conversion.Scope) error {
 	out.DockerImageRepository = in.DockerImageRepository
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
 	out.Tags = make([]TagReference, 0, 0)
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
@@ -150,6 +150,20 @@ type ImageStreamSpec struct {
 	DockerImageRepository string `json:"dockerImageRepository,omitempty"`
 	// Tags map arbitrary string values to specific image locators
 	Tags []TagReference `json:"tags,omitempty"`
+	// RetentionPolicy, if set, limits the number and age of the images kept in the history of each tag
+	RetentionPolicy *ImageStreamRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// ImageStreamRetentionPolicy limits the history kept in the status of an image stream. The current image of a
+// tag is always kept. Images removed from the history are deleted once nothing references them. The policy is only
+// enforced if image retention is enabled in the master configuration.
+type ImageStreamRetentionPolicy struct {
+	// MaxTagRevisions is the maximum number of images kept in the history of each tag, including the current
+	// one. Zero means no limit.
+	MaxTagRevisions int32 `json:"maxTagRevisions,omitempty"`
+	// MaxTagAgeSeconds is the maximum number of seconds an image that is no longer the current image of a tag
+	// is kept in its history. Zero means no limit.
+	MaxTagAgeSeconds int64 `json:"maxTagAgeSeconds,omitempty"`
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to an ImageStreamTag, ImageStreamImage, or DockerImage this tag should track.
@@ -171,6 +185,10 @@ type TagImportPolicy struct {
 	Insecure bool `json:"insecure,omitempty"`
 	// Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported
 	Scheduled bool `json:"scheduled,omitempty"`
+	// VersionRange, if set, makes the tag track the tag of the Docker repository in From that names the highest
+	// semantic version in this range, for example "~1.4" or ">=1.2 <2.0". The range is resolved again on every
+	// import, and the tag named in From is ignored.
+	VersionRange string `json:"versionRange,omitempty"`
 }
 
 // ImageStreamStatus contains information about the state of this image stream.
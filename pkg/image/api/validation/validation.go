@@ -63,6 +63,55 @@ func validateImage(image *api.Image, fldPath *field.Path) field.ErrorList {
 		result = append(result, validateImageSignature(&sig, fldPath.Child("signatures").Index(i))...)
 	}
 
+	if image.VulnerabilityScan != nil {
+		result = append(result, validateImageVulnerabilityScan(image.VulnerabilityScan, fldPath.Child("vulnerabilityScan"))...)
+	}
+
+	return result
+}
+
+func validateImageVulnerabilityScan(scan *api.ImageVulnerabilityScan, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if scan.Completed.IsZero() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("completed"), ""))
+	}
+	for i, v := range scan.Vulnerabilities {
+		vPath := fldPath.Child("vulnerabilities").Index(i)
+		if len(v.ID) == 0 {
+			allErrs = append(allErrs, field.Required(vPath.Child("id"), ""))
+		}
+		if !api.IsKnownVulnerabilitySeverity(v.Severity) {
+			allErrs = append(allErrs, field.NotSupported(vPath.Child("severity"), v.Severity, []string{
+				string(api.VulnerabilitySeverityUnknown),
+				string(api.VulnerabilitySeverityNegligible),
+				string(api.VulnerabilitySeverityLow),
+				string(api.VulnerabilitySeverityMedium),
+				string(api.VulnerabilitySeverityHigh),
+				string(api.VulnerabilitySeverityCritical),
+			}))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateImageSignatureName checks that the name of an image signature has a form of
+// <imageName>@<signatureName>.
+func ValidateImageSignatureName(name string, prefix bool) (bool, string) {
+	if ok, reason := oapi.MinimalNameRequirements(name, prefix); !ok {
+		return ok, reason
+	}
+	if _, _, err := api.SplitImageSignatureName(name); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+// ValidateImageSignature tests required fields for an ImageSignature managed as a standalone object.
+func ValidateImageSignature(signature *api.ImageSignature) field.ErrorList {
+	result := validation.ValidateObjectMeta(&signature.ObjectMeta, false, ValidateImageSignatureName, field.NewPath("metadata"))
+	result = append(result, validateImageSignature(signature, nil)...)
 	return result
 }
 
@@ -145,6 +194,15 @@ func ValidateImageStream(stream *api.ImageStream) field.ErrorList {
 		path := field.NewPath("spec", "tags").Key(tag)
 		result = append(result, ValidateImageStreamTagReference(tagRef, path)...)
 	}
+	if policy := stream.Spec.RetentionPolicy; policy != nil {
+		path := field.NewPath("spec", "retentionPolicy")
+		if policy.MaxTagRevisions < 0 {
+			result = append(result, field.Invalid(path.Child("maxTagRevisions"), policy.MaxTagRevisions, "must be greater than or equal to 0"))
+		}
+		if policy.MaxTagAgeSeconds < 0 {
+			result = append(result, field.Invalid(path.Child("maxTagAgeSeconds"), policy.MaxTagAgeSeconds, "must be greater than or equal to 0"))
+		}
+	}
 	for tag, history := range stream.Status.Tags {
 		for i, tagEvent := range history.Items {
 			if len(tagEvent.DockerImageReference) == 0 {
@@ -168,10 +226,14 @@ func ValidateImageStreamTagReference(tagRef api.TagReference, fldPath *field.Pat
 			if ref, err := api.ParseDockerImageReference(tagRef.From.Name); err == nil && tagRef.ImportPolicy.Scheduled && len(ref.ID) > 0 {
 				errs = append(errs, field.Invalid(fldPath.Child("from", "name"), tagRef.From.Name, "only tags can be scheduled for import"))
 			}
+			errs = append(errs, validateVersionRange(tagRef.From.Name, tagRef.ImportPolicy, fldPath)...)
 		case "ImageStreamImage", "ImageStreamTag":
 			if tagRef.ImportPolicy.Scheduled {
 				errs = append(errs, field.Invalid(fldPath.Child("importPolicy", "scheduled"), tagRef.ImportPolicy.Scheduled, "only tags pointing to Docker repositories may be scheduled for background import"))
 			}
+			if len(tagRef.ImportPolicy.VersionRange) > 0 {
+				errs = append(errs, field.Invalid(fldPath.Child("importPolicy", "versionRange"), tagRef.ImportPolicy.VersionRange, "only tags pointing to Docker repositories may track a version range"))
+			}
 		default:
 			errs = append(errs, field.Required(fldPath.Child("from", "kind"), "valid values are 'DockerImage', 'ImageStreamImage', 'ImageStreamTag'"))
 		}
@@ -259,6 +321,22 @@ func ValidateImageStreamTagUpdate(newIST, oldIST *api.ImageStreamTag) field.Erro
 	return result
 }
 
+// validateVersionRange ensures that the version range of an import policy is valid and that the Docker image
+// it is used with is not referenced by ID.
+func validateVersionRange(from string, importPolicy api.TagImportPolicy, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(importPolicy.VersionRange) == 0 {
+		return errs
+	}
+	if _, err := api.ParseVersionRange(importPolicy.VersionRange); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("importPolicy", "versionRange"), importPolicy.VersionRange, err.Error()))
+	}
+	if ref, err := api.ParseDockerImageReference(from); err == nil && len(ref.ID) > 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("from", "name"), from, "an image ID cannot track a version range"))
+	}
+	return errs
+}
+
 func ValidateImageStreamImport(isi *api.ImageStreamImport) field.ErrorList {
 	specPath := field.NewPath("spec")
 	imagesPath := specPath.Child("images")
@@ -282,6 +360,7 @@ func ValidateImageStreamImport(isi *api.ImageStreamImport) field.ErrorList {
 						errs = append(errs, field.Invalid(imagesPath.Index(i).Child("from", "name"), spec.From.Name, "only tags can be scheduled for import"))
 					}
 				}
+				errs = append(errs, validateVersionRange(spec.From.Name, spec.ImportPolicy, imagesPath.Index(i))...)
 			}
 		default:
 			errs = append(errs, field.Invalid(imagesPath.Index(i).Child("from", "kind"), from.Kind, "only DockerImage is supported"))
@@ -303,6 +382,9 @@ func ValidateImageStreamImport(isi *api.ImageStreamImport) field.ErrorList {
 					}
 				}
 			}
+			if len(spec.ImportPolicy.VersionRange) > 0 {
+				errs = append(errs, field.Invalid(repoPath.Child("importPolicy", "versionRange"), spec.ImportPolicy.VersionRange, "a version range may only be used to import a single tag"))
+			}
 		default:
 			errs = append(errs, field.Invalid(repoPath.Child("from", "kind"), from.Kind, "only DockerImage is supported"))
 		}
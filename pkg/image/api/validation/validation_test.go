@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/diff"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
@@ -49,6 +50,39 @@ func TestValidateImageMissingFields(t *testing.T) {
 			field.ErrorTypeRequired,
 			"dockerImageReference",
 		},
+		"missing vulnerability scan completion time": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				VulnerabilityScan:    &api.ImageVulnerabilityScan{},
+			},
+			field.ErrorTypeRequired,
+			"vulnerabilityScan.completed",
+		},
+		"missing vulnerability ID": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				VulnerabilityScan: &api.ImageVulnerabilityScan{
+					Completed:       unversioned.Now(),
+					Vulnerabilities: []api.ImageVulnerability{{Severity: api.VulnerabilitySeverityLow}},
+				},
+			},
+			field.ErrorTypeRequired,
+			"vulnerabilityScan.vulnerabilities[0].id",
+		},
+		"unsupported vulnerability severity": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				VulnerabilityScan: &api.ImageVulnerabilityScan{
+					Completed:       unversioned.Now(),
+					Vulnerabilities: []api.ImageVulnerability{{ID: "CVE-2016-0001", Severity: "Scary"}},
+				},
+			},
+			field.ErrorTypeNotSupported,
+			"vulnerabilityScan.vulnerabilities[0].severity",
+		},
 	}
 
 	for k, v := range errorCases {
@@ -213,6 +247,55 @@ func TestValidateImageSignature(t *testing.T) {
 
 }
 
+func TestValidateStandaloneImageSignature(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		signatureName string
+		expectedField string
+	}{
+		{
+			name:          "valid",
+			signatureName: "sha256:4c4e1f2f3e3a@signature-1",
+		},
+		{
+			name:          "missing name",
+			signatureName: "",
+			expectedField: "metadata.name",
+		},
+		{
+			name:          "missing signature name",
+			signatureName: "sha256:4c4e1f2f3e3a",
+			expectedField: "metadata.name",
+		},
+		{
+			name:          "missing image name",
+			signatureName: "@signature-1",
+			expectedField: "metadata.name",
+		},
+		{
+			name:          "too many separators",
+			signatureName: "sha256:4c4e1f2f3e3a@signature@1",
+			expectedField: "metadata.name",
+		},
+	} {
+		signature := &api.ImageSignature{
+			ObjectMeta: kapi.ObjectMeta{Name: tc.signatureName},
+			Type:       api.ImageSignatureTypeJWSImageV1,
+			Content:    []byte("blob"),
+		}
+		errs := ValidateImageSignature(signature)
+		if len(tc.expectedField) == 0 {
+			if len(errs) != 0 {
+				t.Errorf("[%s] unexpected errors: %v", tc.name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != tc.expectedField {
+			t.Errorf("[%s] expected a single error for field %s, got: %v", tc.name, tc.expectedField, errs)
+		}
+	}
+}
+
 func TestValidateImageStreamMappingNotOK(t *testing.T) {
 	errorCases := map[string]struct {
 		I api.ImageStreamMapping
@@ -337,6 +420,7 @@ func TestValidateImageStream(t *testing.T) {
 		name                  string
 		dockerImageRepository string
 		specTags              map[string]api.TagReference
+		retentionPolicy       *api.ImageStreamRetentionPolicy
 		statusTags            map[string]api.TagEventList
 		expected              field.ErrorList
 	}{
@@ -476,10 +560,82 @@ func TestValidateImageStream(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "tags").Key("otherimage").Child("importPolicy", "scheduled"), true, "only tags pointing to Docker repositories may be scheduled for background import"),
 			},
 		},
-		"valid": {
+		"invalid version range": {
 			namespace: "namespace",
 			name:      "foo",
 			specTags: map[string]api.TagReference{
+				"tag": {
+					From: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "abc",
+					},
+					ImportPolicy: api.TagImportPolicy{VersionRange: "latest"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tags").Key("tag").Child("importPolicy", "versionRange"), "latest", `invalid version range "latest": "latest" is not a valid version`),
+			},
+		},
+		"image IDs can't track a version range": {
+			namespace: "namespace",
+			name:      "foo",
+			specTags: map[string]api.TagReference{
+				"badid": {
+					From: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "abc@badid",
+					},
+					ImportPolicy: api.TagImportPolicy{VersionRange: "~1.4"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tags").Key("badid").Child("from", "name"), "abc@badid", "an image ID cannot track a version range"),
+			},
+		},
+		"ImageStreamTags can't track a version range": {
+			namespace: "namespace",
+			name:      "foo",
+			specTags: map[string]api.TagReference{
+				"other": {
+					From: &kapi.ObjectReference{
+						Kind: "ImageStreamTag",
+						Name: "other:latest",
+					},
+					ImportPolicy: api.TagImportPolicy{VersionRange: "~1.4"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tags").Key("other").Child("importPolicy", "versionRange"), "~1.4", "only tags pointing to Docker repositories may track a version range"),
+			},
+		},
+		"negative retention": {
+			namespace:       "namespace",
+			name:            "foo",
+			retentionPolicy: &api.ImageStreamRetentionPolicy{MaxTagRevisions: -1},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "retentionPolicy", "maxTagRevisions"), int32(-1), "must be greater than or equal to 0"),
+			},
+		},
+		"negative retention age": {
+			namespace:       "namespace",
+			name:            "foo",
+			retentionPolicy: &api.ImageStreamRetentionPolicy{MaxTagAgeSeconds: -1},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "retentionPolicy", "maxTagAgeSeconds"), int64(-1), "must be greater than or equal to 0"),
+			},
+		},
+		"valid": {
+			namespace:       "namespace",
+			name:            "foo",
+			retentionPolicy: &api.ImageStreamRetentionPolicy{MaxTagRevisions: 5, MaxTagAgeSeconds: 3600},
+			specTags: map[string]api.TagReference{
+				"range": {
+					From: &kapi.ObjectReference{
+						Kind: "DockerImage",
+						Name: "abc",
+					},
+					ImportPolicy: api.TagImportPolicy{Scheduled: true, VersionRange: ">=1.2 <2"},
+				},
 				"tag": {
 					From: &kapi.ObjectReference{
 						Kind: "DockerImage",
@@ -534,7 +690,8 @@ func TestValidateImageStream(t *testing.T) {
 			},
 			Spec: api.ImageStreamSpec{
 				DockerImageRepository: test.dockerImageRepository,
-				Tags: test.specTags,
+				Tags:                  test.specTags,
+				RetentionPolicy:       test.retentionPolicy,
 			},
 			Status: api.ImageStreamStatus{
 				Tags: test.statusTags,
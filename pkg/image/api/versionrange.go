@@ -0,0 +1,235 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// VersionRange is a range of semantic versions. It is a list of alternatives, each of which is a list of
+// comparisons a version has to satisfy.
+type VersionRange [][]versionComparison
+
+// versionComparison compares a version to a fixed version with one of the operators <, <=, =, >= and >.
+type versionComparison struct {
+	op      string
+	version semver.Version
+}
+
+// ParseVersionRange parses a range of semantic versions. Alternatives are separated by "||" and the comparisons
+// of an alternative by white space. A comparison is a version prefixed by one of the operators <, <=, =, >=, >,
+// ~ (patch releases of the version, "~1.4" is ">=1.4.0 <1.5.0") or ^ (releases compatible with the version,
+// "^1.4" is ">=1.4.0 <2.0.0"). The minor and patch numbers of versions may be omitted or replaced by "x" or
+// "*" to match any number, so "1.4", "1.4.x" and "~1.4" describe the same range.
+func ParseVersionRange(s string) (VersionRange, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, fmt.Errorf("the version range must not be empty")
+	}
+	var r VersionRange
+	for _, alternative := range strings.Split(s, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid version range %q: empty alternative", s)
+		}
+		comparisons := []versionComparison{}
+		for i := 0; i < len(fields); i++ {
+			token := fields[i]
+			// allow white space between an operator and its version
+			if strings.TrimLeft(token, "<>=~^") == "" && i+1 < len(fields) {
+				i++
+				token += fields[i]
+			}
+			parsed, err := parseVersionComparison(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range %q: %v", s, err)
+			}
+			comparisons = append(comparisons, parsed...)
+		}
+		r = append(r, comparisons)
+	}
+	return r, nil
+}
+
+// parseVersionComparison turns a single comparison, possibly of a partial version, into the equivalent list
+// of comparisons of complete versions.
+func parseVersionComparison(s string) ([]versionComparison, error) {
+	op := ""
+	for _, prefix := range []string{"<=", ">=", "<", ">", "=", "~", "^"} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			break
+		}
+	}
+	v, specified, err := parsePartialVersion(strings.TrimPrefix(strings.TrimPrefix(s, op), "v"))
+	if err != nil {
+		return nil, err
+	}
+
+	if specified == 3 {
+		switch op {
+		case "", "=":
+			return []versionComparison{{"=", v}}, nil
+		case "~":
+			return []versionComparison{{">=", v}, {"<", semver.Version{Major: v.Major, Minor: v.Minor + 1}}}, nil
+		case "^":
+			return []versionComparison{{">=", v}, caretUpperBound(v)}, nil
+		default:
+			return []versionComparison{{op, v}}, nil
+		}
+	}
+
+	// next is the first version after all the versions matching the partial version
+	var next semver.Version
+	switch specified {
+	case 0:
+		if op == "<" || op == ">" {
+			return nil, fmt.Errorf("%q cannot be used with %s", s, op)
+		}
+		return []versionComparison{}, nil
+	case 1:
+		next = semver.Version{Major: v.Major + 1}
+	case 2:
+		next = semver.Version{Major: v.Major, Minor: v.Minor + 1}
+	}
+	switch op {
+	case "", "=", "~":
+		return []versionComparison{{">=", v}, {"<", next}}, nil
+	case "^":
+		if v.Major > 0 {
+			return []versionComparison{{">=", v}, caretUpperBound(v)}, nil
+		}
+		return []versionComparison{{">=", v}, {"<", next}}, nil
+	case ">":
+		return []versionComparison{{">=", next}}, nil
+	case "<=":
+		return []versionComparison{{"<", next}}, nil
+	default:
+		return []versionComparison{{op, v}}, nil
+	}
+}
+
+// parsePartialVersion parses a version whose minor and patch numbers may be omitted or replaced by a wildcard,
+// and returns the number of specified numbers. Only complete versions may have a pre-release or build suffix.
+func parsePartialVersion(s string) (semver.Version, int, error) {
+	if v, err := semver.Parse(s); err == nil {
+		return v, 3, nil
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return semver.Version{}, 0, fmt.Errorf("%q is not a valid version", s)
+	}
+	numbers := [3]uint64{}
+	specified := 0
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			continue
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil || specified != i {
+			return semver.Version{}, 0, fmt.Errorf("%q is not a valid version", s)
+		}
+		numbers[i] = n
+		specified++
+	}
+	return semver.Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, specified, nil
+}
+
+// caretUpperBound returns the comparison excluding the versions that are incompatible with v, which are those
+// that differ in the first non-zero number.
+func caretUpperBound(v semver.Version) versionComparison {
+	switch {
+	case v.Major > 0:
+		return versionComparison{"<", semver.Version{Major: v.Major + 1}}
+	case v.Minor > 0:
+		return versionComparison{"<", semver.Version{Minor: v.Minor + 1}}
+	default:
+		return versionComparison{"<", semver.Version{Patch: v.Patch + 1}}
+	}
+}
+
+// Contains returns true if v is in the range. Pre-release versions are never contained in the range.
+func (r VersionRange) Contains(v semver.Version) bool {
+	if len(v.Pre) > 0 {
+		return false
+	}
+	for _, comparisons := range r {
+		matches := true
+		for _, c := range comparisons {
+			if !c.matches(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (c versionComparison) matches(v semver.Version) bool {
+	switch cmp := v.Compare(c.version); c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// TagVersion returns the semantic version a tag name represents. Tags may be prefixed by "v" and omit the
+// minor and patch numbers, so "v1.4" represents the version 1.4.0.
+func TagVersion(tag string) (semver.Version, bool) {
+	short := strings.TrimLeft(tag, "v")
+	if v, err := semver.Parse(short); err == nil {
+		return v, true
+	}
+	switch {
+	case reMajorSemantic.MatchString(short):
+		short += ".0.0"
+	case reMinorSemantic.MatchString(short):
+		short += ".0"
+	default:
+		return semver.Version{}, false
+	}
+	v, err := semver.Parse(short)
+	return v, err == nil
+}
+
+// HighestTagInVersionRange returns the tag representing the highest semantic version in versionRange. Of tags
+// representing the same version, the one naming the version completely is preferred, so the tag "1.4.0" is
+// chosen over "1.4". An error is returned if the range is invalid or no tag is in the range.
+func HighestTagInVersionRange(tags []string, versionRange string) (string, error) {
+	r, err := ParseVersionRange(versionRange)
+	if err != nil {
+		return "", err
+	}
+	var highest string
+	var highestVersion semver.Version
+	for _, tag := range tags {
+		v, ok := TagVersion(tag)
+		if !ok || !r.Contains(v) {
+			continue
+		}
+		if len(highest) > 0 {
+			switch cmp := v.Compare(highestVersion); {
+			case cmp < 0:
+				continue
+			case cmp == 0 && strings.Count(tag, ".") <= strings.Count(highest, "."):
+				continue
+			}
+		}
+		highest, highestVersion = tag, v
+	}
+	if len(highest) == 0 {
+		return "", fmt.Errorf("no tag matches the version range %q", versionRange)
+	}
+	return highest, nil
+}
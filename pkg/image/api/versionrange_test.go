@@ -0,0 +1,133 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+)
+
+func TestVersionRangeContains(t *testing.T) {
+	tests := []struct {
+		versionRange string
+		contained    []string
+		notContained []string
+	}{
+		{
+			versionRange: "~1.4",
+			contained:    []string{"1.4.0", "1.4.9"},
+			notContained: []string{"1.3.9", "1.5.0", "2.0.0", "1.4.10-rc.1"},
+		},
+		{
+			versionRange: "~1.4.2",
+			contained:    []string{"1.4.2", "1.4.9"},
+			notContained: []string{"1.4.1", "1.5.0"},
+		},
+		{
+			versionRange: "1.4.x",
+			contained:    []string{"1.4.0", "1.4.9"},
+			notContained: []string{"1.5.0"},
+		},
+		{
+			versionRange: "1",
+			contained:    []string{"1.0.0", "1.9.9"},
+			notContained: []string{"0.9.0", "2.0.0"},
+		},
+		{
+			versionRange: "1.4.2",
+			contained:    []string{"1.4.2"},
+			notContained: []string{"1.4.3"},
+		},
+		{
+			versionRange: "^1.4",
+			contained:    []string{"1.4.0", "1.9.0"},
+			notContained: []string{"1.3.0", "2.0.0"},
+		},
+		{
+			versionRange: "^0.4.2",
+			contained:    []string{"0.4.2", "0.4.9"},
+			notContained: []string{"0.5.0"},
+		},
+		{
+			versionRange: "^0.0.3",
+			contained:    []string{"0.0.3"},
+			notContained: []string{"0.0.4"},
+		},
+		{
+			versionRange: ">=1.2 <1.4",
+			contained:    []string{"1.2.0", "1.3.9"},
+			notContained: []string{"1.1.9", "1.4.0"},
+		},
+		{
+			versionRange: ">= 1.2 <= 1.4",
+			contained:    []string{"1.2.0", "1.4.9"},
+			notContained: []string{"1.5.0"},
+		},
+		{
+			versionRange: ">1.4",
+			contained:    []string{"1.5.0"},
+			notContained: []string{"1.4.9"},
+		},
+		{
+			versionRange: "~1.2 || ~1.4",
+			contained:    []string{"1.2.3", "1.4.3"},
+			notContained: []string{"1.3.0"},
+		},
+		{
+			versionRange: "*",
+			contained:    []string{"0.0.1", "10.0.0"},
+			notContained: []string{"1.0.0-alpha"},
+		},
+	}
+	for _, test := range tests {
+		r, err := ParseVersionRange(test.versionRange)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.versionRange, err)
+			continue
+		}
+		for _, s := range test.contained {
+			if !r.Contains(semver.MustParse(s)) {
+				t.Errorf("%s: expected %s to be in the range", test.versionRange, s)
+			}
+		}
+		for _, s := range test.notContained {
+			if r.Contains(semver.MustParse(s)) {
+				t.Errorf("%s: expected %s not to be in the range", test.versionRange, s)
+			}
+		}
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	for _, s := range []string{"", " ", "latest", "1.2.3.4", "1.x.3", "~", ">1 ||", "<*", "=>1.0"} {
+		if _, err := ParseVersionRange(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestHighestTagInVersionRange(t *testing.T) {
+	tags := []string{"latest", "1.3.9", "1.4", "v1.4.2", "1.4.10", "1.4.11-beta", "1.5.0", "2", "1.6", "1.6.0"}
+	tests := []struct {
+		versionRange string
+		expected     string
+		expectError  bool
+	}{
+		{versionRange: "~1.4", expected: "1.4.10"},
+		{versionRange: "<1.4.3", expected: "v1.4.2"},
+		{versionRange: "~1.6", expected: "1.6.0"},
+		{versionRange: "^1", expected: "1.6.0"},
+		{versionRange: ">=2", expected: "2"},
+		{versionRange: "~3", expectError: true},
+		{versionRange: "foo", expectError: true},
+	}
+	for _, test := range tests {
+		tag, err := HighestTagInVersionRange(tags, test.versionRange)
+		if (err != nil) != test.expectError {
+			t.Errorf("%s: unexpected error: %v", test.versionRange, err)
+			continue
+		}
+		if tag != test.expected {
+			t.Errorf("%s: expected tag %q, got %q", test.versionRange, test.expected, tag)
+		}
+	}
+}
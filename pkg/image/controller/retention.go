@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	kutilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+	utilwait "k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/watch"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/controller"
+	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/prune"
+	oserrors "github.com/openshift/origin/pkg/util/errors"
+)
+
+// RetentionController enforces the retention policy of image streams. It removes the images exceeding the
+// policy from the history of the tags and, once per sync, deletes the removed images that were pushed to the
+// integrated registry and that nothing references anymore, then runs the garbage collector of the registry to
+// delete their blobs and manifests.
+type RetentionController struct {
+	client  client.Interface
+	kClient kclient.Interface
+	now     func() time.Time
+
+	registryClient   *http.Client
+	garbageCollector prune.RegistryGarbageCollector
+
+	lock sync.Mutex
+	// removed holds the images removed from the history of image streams since the last sync
+	removed sets.String
+}
+
+// NewRetentionController returns a controller enforcing the retention policy of image streams. registryClient
+// is used to run the garbage collector of the integrated registry and must be authorized to prune images.
+func NewRetentionController(osClient client.Interface, kClient kclient.Interface, registryClient *http.Client) *RetentionController {
+	return &RetentionController{
+		client:           osClient,
+		kClient:          kClient,
+		now:              time.Now,
+		registryClient:   registryClient,
+		garbageCollector: prune.NewRegistryGarbageCollector(),
+		removed:          sets.NewString(),
+	}
+}
+
+// Handle trims the history of the tags of stream. The removed images are deleted by the next sync if nothing
+// references them.
+func (c *RetentionController) Handle(stream *api.ImageStream) error {
+	policy := stream.Spec.RetentionPolicy
+	if policy == nil || (policy.MaxTagRevisions == 0 && policy.MaxTagAgeSeconds == 0) {
+		return nil
+	}
+
+	obj, err := kapi.Scheme.DeepCopy(stream)
+	if err != nil {
+		return err
+	}
+	trimmed := obj.(*api.ImageStream)
+	removed := trimTagHistory(trimmed, policy, c.now())
+	if len(removed) == 0 {
+		return nil
+	}
+
+	glog.V(4).Infof("Removing %d images from the history of image stream %s/%s", len(removed), stream.Namespace, stream.Name)
+	if _, err := c.client.ImageStreams(stream.Namespace).UpdateStatus(trimmed); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removed = c.removed.Union(removed)
+	return nil
+}
+
+// trimTagHistory removes the images exceeding policy from the history of every tag of stream and returns the
+// names of the images no longer found in any history of the stream. The current image of a tag is always kept.
+func trimTagHistory(stream *api.ImageStream, policy *api.ImageStreamRetentionPolicy, now time.Time) sets.String {
+	removed := sets.NewString()
+	kept := sets.NewString()
+	for tag, history := range stream.Status.Tags {
+		items := []api.TagEvent{}
+		for i, event := range history.Items {
+			switch {
+			case i == 0:
+			case policy.MaxTagRevisions > 0 && i >= int(policy.MaxTagRevisions):
+				removed.Insert(event.Image)
+				continue
+			// an image stopped being the current image of the tag when the next newer one was tagged
+			case policy.MaxTagAgeSeconds > 0 && now.Sub(history.Items[i-1].Created.Time) > time.Duration(policy.MaxTagAgeSeconds)*time.Second:
+				removed.Insert(event.Image)
+				continue
+			}
+			kept.Insert(event.Image)
+			items = append(items, event)
+		}
+		if len(items) < len(history.Items) {
+			history.Items = items
+			stream.Status.Tags[tag] = history
+		}
+	}
+	return removed.Difference(kept)
+}
+
+// Sync deletes the images removed from the history of image streams since the last sync that were pushed to the
+// integrated registry and that are not referenced anymore. References are found like the image pruner does: by
+// image streams, pods, replication controllers, deployment configs, build configs, builds and manifest lists. The
+// objects are listed once per sync, and only if images were removed. Once images are deleted, the garbage
+// collector of every registry they were pushed to is run, so that the blobs and manifests of the images are
+// deleted too. A registry that fails to collect garbage is collected again the next time images are deleted.
+func (c *RetentionController) Sync() error {
+	c.lock.Lock()
+	removed := c.removed
+	c.removed = sets.NewString()
+	c.lock.Unlock()
+	if len(removed) == 0 {
+		return nil
+	}
+
+	prunable, err := c.prunableImages()
+	if err != nil {
+		// retry with the next sync
+		c.lock.Lock()
+		c.removed = c.removed.Union(removed)
+		c.lock.Unlock()
+		return err
+	}
+
+	errs := []error{}
+	registries := sets.NewString()
+	for _, image := range prunable {
+		if !removed.Has(image.Name) {
+			continue
+		}
+		glog.V(4).Infof("Deleting image %s which is no longer referenced", image.Name)
+		if err := c.client.Images().Delete(image.Name); err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		}
+		ref, err := api.ParseDockerImageReference(image.DockerImageReference)
+		if err != nil || len(ref.Registry) == 0 {
+			errs = append(errs, fmt.Errorf("unable to determine the registry of image %s from %q", image.Name, image.DockerImageReference))
+			continue
+		}
+		registries.Insert(ref.Registry)
+	}
+	for _, registry := range registries.List() {
+		if err := c.garbageCollector.CollectGarbage(c.registryClient, registry); err != nil {
+			errs = append(errs, fmt.Errorf("unable to collect garbage in registry %s: %v", registry, err))
+		}
+	}
+	return kutilerrors.NewAggregate(errs)
+}
+
+// prunableImages returns the images pushed to the integrated registry that nothing references. Every revision in
+// the history of a tag is a reference, and images are candidates regardless of their age.
+func (c *RetentionController) prunableImages() ([]*api.Image, error) {
+	images, err := c.client.Images().List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	streams, err := c.client.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.kClient.Pods(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	rcs, err := c.kClient.ReplicationControllers(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bcs, err := c.client.BuildConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	// build configs and builds may be disabled in Atomic
+	if err = oserrors.TolerateNotFoundError(err); err != nil {
+		return nil, err
+	}
+	if bcs == nil {
+		bcs = &buildapi.BuildConfigList{}
+	}
+	builds, err := c.client.Builds(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err = oserrors.TolerateNotFoundError(err); err != nil {
+		return nil, err
+	}
+	if builds == nil {
+		builds = &buildapi.BuildList{}
+	}
+	dcs, err := c.client.DeploymentConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return prune.PrunableImages(prune.ImageRegistryPrunerOptions{
+		KeepTagRevisions: math.MaxInt32,
+		Images:           images,
+		Streams:          streams,
+		Pods:             pods,
+		RCs:              rcs,
+		BCs:              bcs,
+		Builds:           builds,
+		DCs:              dcs,
+	}), nil
+}
+
+// RetentionControllerFactory can create a RetentionController.
+type RetentionControllerFactory struct {
+	Client     client.Interface
+	KubeClient kclient.Interface
+	// RegistryClient is used to run the garbage collector of the integrated registry.
+	RegistryClient *http.Client
+	// ResyncInterval is how often every image stream is checked, so that images exceeding the maximum age
+	// are removed even if the stream does not change.
+	ResyncInterval time.Duration
+	// SyncInterval is how often the removed images that are no longer referenced are deleted.
+	SyncInterval time.Duration
+}
+
+// Create creates a RetentionController.
+func (f *RetentionControllerFactory) Create() controller.RunnableController {
+	lw := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return f.Client.ImageStreams(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return f.Client.ImageStreams(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	q := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(lw, &api.ImageStream{}, q, f.ResyncInterval).Run()
+
+	c := NewRetentionController(f.Client, f.KubeClient, f.RegistryClient)
+
+	retry := &controller.RetryController{
+		Queue: controller.NewQueueWrapper(q),
+		RetryManager: controller.NewQueueRetryManager(
+			controller.NewQueueWrapper(q),
+			cache.MetaNamespaceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				utilruntime.HandleError(err)
+				if kerrors.IsConflict(err) || kerrors.IsNotFound(err) {
+					// the stream changed or is gone, the reflector delivers the current version
+					return false
+				}
+				return retries.Count < 5
+			},
+			flowcontrol.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			return c.Handle(obj.(*api.ImageStream))
+		},
+	}
+	return &retentionRunner{RunnableController: retry, controller: c, interval: f.SyncInterval}
+}
+
+// retentionRunner runs the handling of image streams and the periodic sync of a RetentionController.
+type retentionRunner struct {
+	controller.RunnableController
+	controller *RetentionController
+	interval   time.Duration
+}
+
+// Run starts handling image streams and syncing periodically.
+func (r *retentionRunner) Run() {
+	r.RunnableController.Run()
+	go utilwait.Forever(func() {
+		if err := r.controller.Sync(); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}, r.interval)
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+func tagHistory(now time.Time, ages ...time.Duration) api.TagEventList {
+	history := api.TagEventList{}
+	for i, age := range ages {
+		history.Items = append(history.Items, api.TagEvent{
+			Image:   string(rune('a' + i)),
+			Created: unversioned.NewTime(now.Add(-age)),
+		})
+	}
+	return history
+}
+
+func historyImages(history api.TagEventList) []string {
+	images := []string{}
+	for _, event := range history.Items {
+		images = append(images, event.Image)
+	}
+	return images
+}
+
+func TestTrimTagHistory(t *testing.T) {
+	now := time.Now()
+	testCases := map[string]struct {
+		policy   api.ImageStreamRetentionPolicy
+		history  api.TagEventList
+		expected []string
+		removed  []string
+	}{
+		"max revisions": {
+			policy:   api.ImageStreamRetentionPolicy{MaxTagRevisions: 2},
+			history:  tagHistory(now, 0, time.Hour, 2*time.Hour, 3*time.Hour),
+			expected: []string{"a", "b"},
+			removed:  []string{"c", "d"},
+		},
+		"max age": {
+			policy:   api.ImageStreamRetentionPolicy{MaxTagAgeSeconds: 90 * 60},
+			history:  tagHistory(now, 0, time.Hour, 2*time.Hour, 3*time.Hour),
+			expected: []string{"a", "b", "c"},
+			removed:  []string{"d"},
+		},
+		"current image is kept": {
+			policy:   api.ImageStreamRetentionPolicy{MaxTagRevisions: 1, MaxTagAgeSeconds: 1},
+			history:  tagHistory(now, 24*time.Hour, 48*time.Hour),
+			expected: []string{"a"},
+			removed:  []string{"b"},
+		},
+		"within the policy": {
+			policy:   api.ImageStreamRetentionPolicy{MaxTagRevisions: 5, MaxTagAgeSeconds: 24 * 60 * 60},
+			history:  tagHistory(now, 0, time.Hour),
+			expected: []string{"a", "b"},
+			removed:  []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		stream := &api.ImageStream{
+			Status: api.ImageStreamStatus{
+				Tags: map[string]api.TagEventList{"latest": tc.history},
+			},
+		}
+		removed := trimTagHistory(stream, &tc.policy, now)
+		if images := historyImages(stream.Status.Tags["latest"]); !reflect.DeepEqual(images, tc.expected) {
+			t.Errorf("%s: expected history %v, got %v", name, tc.expected, images)
+		}
+		if !removed.Equal(sets.NewString(tc.removed...)) {
+			t.Errorf("%s: expected removed images %v, got %v", name, tc.removed, removed.List())
+		}
+	}
+}
+
+func TestTrimTagHistoryImageInOtherTag(t *testing.T) {
+	now := time.Now()
+	stream := &api.ImageStream{
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"latest": tagHistory(now, 0, time.Hour),
+				"stable": {Items: []api.TagEvent{{Image: "b", Created: unversioned.NewTime(now)}}},
+			},
+		},
+	}
+	removed := trimTagHistory(stream, &api.ImageStreamRetentionPolicy{MaxTagRevisions: 1}, now)
+	if len(removed) != 0 {
+		t.Errorf("image still referenced by another tag was removed: %v", removed.List())
+	}
+	if images := historyImages(stream.Status.Tags["latest"]); !reflect.DeepEqual(images, []string{"a"}) {
+		t.Errorf("unexpected history %v", images)
+	}
+}
+
+// fakeGarbageCollector records the registries it is asked to collect garbage in.
+type fakeGarbageCollector struct {
+	registries []string
+}
+
+func (gc *fakeGarbageCollector) CollectGarbage(registryClient *http.Client, registryURL string) error {
+	gc.registries = append(gc.registries, registryURL)
+	return nil
+}
+
+func TestRetentionController(t *testing.T) {
+	now := time.Now()
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+		Spec: api.ImageStreamSpec{
+			RetentionPolicy: &api.ImageStreamRetentionPolicy{MaxTagRevisions: 1},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{"latest": tagHistory(now, 0, time.Hour, 2*time.Hour, 3*time.Hour, 4*time.Hour, 5*time.Hour)},
+		},
+	}
+	other := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "other", Name: "app"},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{"latest": {Items: []api.TagEvent{{Image: "b"}}}},
+		},
+	}
+	managedImage := func(name string) api.Image {
+		return api.Image{
+			ObjectMeta:           kapi.ObjectMeta{Name: name, Annotations: map[string]string{api.ManagedByOpenShiftAnnotation: "true"}},
+			DockerImageReference: "registry:5000/ns/app@" + name,
+			DockerImageManifest:  "{}",
+		}
+	}
+	images := &api.ImageList{Items: []api.Image{
+		managedImage("a"), managedImage("b"), managedImage("c"), managedImage("d"), managedImage("e"),
+		{ObjectMeta: kapi.ObjectMeta{Name: "f"}, DockerImageReference: "docker.io/library/app@f"},
+	}}
+
+	var updated *api.ImageStream
+	deleted := []string{}
+	osClient := &testclient.Fake{}
+	osClient.AddReactor("update", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		updated = action.(ktestclient.UpdateAction).GetObject().(*api.ImageStream)
+		return true, updated, nil
+	})
+	osClient.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &api.ImageStreamList{Items: []api.ImageStream{*updated, *other}}, nil
+	})
+	osClient.AddReactor("list", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, images, nil
+	})
+	osClient.AddReactor("delete", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(ktestclient.DeleteAction).GetName())
+		return true, nil, nil
+	})
+	kClient := ktestclient.NewSimpleFake(
+		&kapi.PodList{Items: []kapi.Pod{{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "pod"},
+			Spec:       kapi.PodSpec{Containers: []kapi.Container{{Image: "registry:5000/ns/app@c"}}},
+			Status:     kapi.PodStatus{Phase: kapi.PodRunning},
+		}}},
+		&kapi.ReplicationControllerList{Items: []kapi.ReplicationController{{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "rc"},
+			Spec: kapi.ReplicationControllerSpec{Template: &kapi.PodTemplateSpec{
+				Spec: kapi.PodSpec{Containers: []kapi.Container{{Image: "registry:5000/ns/app@d"}}},
+			}},
+		}}},
+	)
+
+	gc := &fakeGarbageCollector{}
+	c := NewRetentionController(osClient, kClient, nil)
+	c.now = func() time.Time { return now }
+	c.garbageCollector = gc
+	if err := c.Handle(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if updated == nil {
+		t.Fatalf("the status of the stream was not updated")
+	}
+	if images := historyImages(updated.Status.Tags["latest"]); !reflect.DeepEqual(images, []string{"a"}) {
+		t.Errorf("unexpected history %v", images)
+	}
+	if len(stream.Status.Tags["latest"].Items) != 6 {
+		t.Errorf("the cached stream was modified")
+	}
+	if len(deleted) != 0 {
+		t.Errorf("images were deleted before the sync: %v", deleted)
+	}
+	if len(gc.registries) != 0 {
+		t.Errorf("garbage was collected before the sync: %v", gc.registries)
+	}
+
+	if err := c.Sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// b is referenced by another stream, c by a pod, d by a replication controller and f is not managed
+	if !reflect.DeepEqual(deleted, []string{"e"}) {
+		t.Errorf("unexpected deleted images %v", deleted)
+	}
+	// the blobs and manifests of the deleted image are deleted by the registry it was pushed to
+	if !reflect.DeepEqual(gc.registries, []string{"registry:5000"}) {
+		t.Errorf("unexpected registries collecting garbage %v", gc.registries)
+	}
+
+	// nothing is listed when no image was removed since the last sync
+	osClient.ClearActions()
+	if err := c.Sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actions := osClient.Actions(); len(actions) != 0 {
+		t.Errorf("unexpected actions %v", actions)
+	}
+	if len(gc.registries) != 1 {
+		t.Errorf("unexpected garbage collection %v", gc.registries)
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
-	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/distribution/registry/api/errcode"
 	registryclient "github.com/docker/distribution/registry/client"
@@ -52,6 +51,8 @@ type Context struct {
 	Transport         http.RoundTripper
 	InsecureTransport http.RoundTripper
 	Challenges        auth.ChallengeManager
+	// Actions are the repository actions requested from token servers. If empty, only "pull" is requested.
+	Actions []string
 }
 
 func (c Context) WithCredentials(credentials auth.CredentialStore) RepositoryRetriever {
@@ -103,13 +104,17 @@ func (r *repositoryRetriever) Repository(ctx gocontext.Context, registry *url.UR
 		}
 	}
 
+	actions := r.context.Actions
+	if len(actions) == 0 {
+		actions = []string{"pull"}
+	}
 	rt := transport.NewTransport(
 		t,
 		// TODO: slightly smarter authorizer that retries unauthenticated requests
 		// TODO: make multiple attempts if the first credential fails
 		auth.NewAuthorizer(
 			r.context.Challenges,
-			auth.NewTokenHandler(t, r.credentials, repoName, "pull"),
+			auth.NewTokenHandler(t, r.credentials, repoName, actions...),
 			auth.NewBasicHandler(r.credentials),
 		),
 	)
@@ -189,7 +194,8 @@ func schema1ToImage(manifest *schema1.SignedManifest, d digest.Digest) (*api.Ima
 	return image, nil
 }
 
-func schema2ToImage(manifest *schema2.DeserializedManifest, imageConfig []byte, d digest.Digest) (*api.Image, error) {
+// schema2ToImage converts a schema 2 or an OCI image manifest, which share the same structure, into an image.
+func schema2ToImage(manifest distribution.Manifest, imageConfig []byte, d digest.Digest) (*api.Image, error) {
 	mediatype, payload, err := manifest.Payload()
 	if err != nil {
 		return nil, err
@@ -20,6 +20,7 @@ import (
 	"github.com/docker/distribution/registry/api/errcode"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	"github.com/openshift/origin/pkg/dockerregistry"
 	"github.com/openshift/origin/pkg/image/api"
@@ -41,8 +42,9 @@ type mockRepository struct {
 
 	blobs *mockBlobStore
 
-	manifest *schema1.SignedManifest
-	tags     map[string]string
+	manifest  *schema1.SignedManifest
+	manifests map[digest.Digest]distribution.Manifest
+	tags      map[string]string
 }
 
 func (r *mockRepository) Name() string { return "test" }
@@ -59,6 +61,9 @@ func (r *mockRepository) Exists(ctx context.Context, dgst digest.Digest) (bool,
 	return false, r.getErr
 }
 func (r *mockRepository) Get(ctx context.Context, dgst digest.Digest, options ...distribution.ManifestServiceOption) (distribution.Manifest, error) {
+	if m, ok := r.manifests[dgst]; ok {
+		return m, nil
+	}
 	return r.manifest, r.getErr
 }
 func (r *mockRepository) Delete(ctx context.Context, dgst digest.Digest) error {
@@ -75,6 +80,16 @@ type mockBlobStore struct {
 	distribution.BlobStore
 
 	statErr, serveErr, openErr error
+
+	blobs map[digest.Digest][]byte
+}
+
+func (r *mockBlobStore) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	b, ok := r.blobs[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return b, nil
 }
 
 func (r *mockBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
@@ -193,6 +208,62 @@ func TestDockerV1Fallback(t *testing.T) {
 	}
 }
 
+func TestDockerV1FallbackVersionRange(t *testing.T) {
+	var uri *url.URL
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Docker-Endpoints", uri.Host)
+
+		// get all tags
+		if strings.HasSuffix(r.URL.Path, "/tags") {
+			fmt.Fprintln(w, `{"1.3.9":"image1", "1.4.2":"image2", "1.5.0":"image3", "latest":"image3"}`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/tags/1.4.2") {
+			fmt.Fprintln(w, `"image2"`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/images") {
+			fmt.Fprintln(w, `{}`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/image2/json") {
+			fmt.Fprintln(w, `{"ID":"image2"}`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		t.Logf("tried to access %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	client := dockerregistry.NewClient(10*time.Second, false)
+	ctx := gocontext.WithValue(gocontext.Background(), ContextKeyV1RegistryClient, client)
+
+	uri, _ = url.Parse(server.URL)
+	isi := &api.ImageStreamImport{
+		Spec: api.ImageStreamImportSpec{
+			Images: []api.ImageImportSpec{
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: uri.Host + "/test"}, ImportPolicy: api.TagImportPolicy{Insecure: true, VersionRange: "~1.4"}},
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: uri.Host + "/test"}, ImportPolicy: api.TagImportPolicy{Insecure: true, VersionRange: "~2"}},
+			},
+		},
+	}
+
+	retriever := &mockRetriever{err: fmt.Errorf("does not support v2 API")}
+	im := NewImageStreamImporter(retriever, 5, nil)
+	if err := im.Import(ctx, isi); err != nil {
+		t.Fatal(err)
+	}
+	if image := isi.Status.Images[0]; image.Status.Status != unversioned.StatusSuccess || image.Image == nil || image.Tag != "1.4.2" {
+		t.Errorf("unexpected import of a version range: %#v", image)
+	}
+	if status := isi.Status.Images[1].Status; status.Status != unversioned.StatusFailure || status.Reason != unversioned.StatusReasonNotFound {
+		t.Errorf("unexpected status of an unmatched range: %#v", status)
+	}
+}
+
 func TestPing(t *testing.T) {
 	retriever := NewContext(http.DefaultTransport, http.DefaultTransport).WithCredentials(NoCredentials).(*repositoryRetriever)
 
@@ -10,12 +10,14 @@ import (
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/distribution/registry/api/errcode"
 	"github.com/docker/distribution/registry/api/v2"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	kapierrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/flowcontrol"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/openshift/origin/pkg/dockerregistry"
 	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/ocischema"
 )
 
 // Add a dockerregistry.Client to the passed context with this key to support v1 Docker registry importing
@@ -91,6 +94,7 @@ func (i *ImageStreamImporter) Import(ctx gocontext.Context, isi *api.ImageStream
 func importImages(ctx gocontext.Context, retriever RepositoryRetriever, isi *api.ImageStreamImport, cache map[manifestKey]*api.Image, limiter flowcontrol.RateLimiter) {
 	tags := make(map[manifestKey][]int)
 	ids := make(map[manifestKey][]int)
+	ranges := make(map[manifestKey][]int)
 	repositories := make(map[repositoryKey]*importRepository)
 
 	isi.Status.Images = make([]api.ImageImportStatus, len(isi.Spec.Images))
@@ -131,6 +135,16 @@ func importImages(ctx gocontext.Context, retriever RepositoryRetriever, isi *api
 					Image: cache[id],
 				})
 			}
+		} else if versionRange := spec.ImportPolicy.VersionRange; len(versionRange) > 0 {
+			// the tag is resolved from the version range when the repository is imported
+			r := manifestKey{repositoryKey: key}
+			r.value = versionRange
+			ranges[r] = append(ranges[r], i)
+			if len(ranges[r]) == 1 {
+				repo.Tags = append(repo.Tags, importTag{
+					VersionRange: versionRange,
+				})
+			}
 		} else {
 			tag := manifestKey{repositoryKey: key}
 			tag.value = defaultRef.Tag
@@ -153,7 +167,13 @@ func importImages(ctx gocontext.Context, retriever RepositoryRetriever, isi *api
 			if tag.Image != nil {
 				cache[j] = tag.Image
 			}
-			for _, index := range tags[j] {
+			indexes := tags[j]
+			if len(tag.VersionRange) > 0 {
+				r := manifestKey{repositoryKey: key}
+				r.value = tag.VersionRange
+				indexes = ranges[r]
+			}
+			for _, index := range indexes {
 				if tag.Err != nil {
 					setImageImportStatus(isi, index, tag.Name, tag.Err)
 					continue
@@ -165,6 +185,7 @@ func importImages(ctx gocontext.Context, retriever RepositoryRetriever, isi *api
 				copied.DockerImageReference = ref.MostSpecific().Exact()
 				image.Tag = tag.Name
 				image.Image = &copied
+				image.Manifests = platformImages(repo.Ref, tag.Manifests)
 				image.Status.Status = unversioned.StatusSuccess
 			}
 		}
@@ -185,12 +206,28 @@ func importImages(ctx gocontext.Context, retriever RepositoryRetriever, isi *api
 				ref.Tag, ref.ID = "", copied.Name
 				copied.DockerImageReference = ref.MostSpecific().Exact()
 				image.Image = &copied
+				image.Manifests = platformImages(repo.Ref, digest.Manifests)
 				image.Status.Status = unversioned.StatusSuccess
 			}
 		}
 	}
 }
 
+// platformImages returns copies of the images of a manifest list referencing them by digest in the repository
+// of ref.
+func platformImages(ref api.DockerImageReference, images []api.Image) []api.Image {
+	if len(images) == 0 {
+		return nil
+	}
+	copied := make([]api.Image, len(images))
+	for i := range images {
+		copied[i] = images[i]
+		ref.Tag, ref.ID = "", images[i].Name
+		copied[i].DockerImageReference = ref.MostSpecific().Exact()
+	}
+	return copied
+}
+
 // importFromRepository imports the repository named on the ImageStreamImport, if any, importing up to maximumTags, and reporting
 // status on each image that is attempted to be imported. If the repository cannot be found or tags cannot be retrieved, the repository
 // status field is set.
@@ -401,13 +438,13 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 
 		if signedManifest, isSchema1 := manifest.(*schema1.SignedManifest); isSchema1 {
 			importDigest.Image, err = schema1ToImage(signedManifest, d)
-		} else if deserializedManifest, isSchema2 := manifest.(*schema2.DeserializedManifest); isSchema2 {
-			imageConfig, err := b.Get(ctx, deserializedManifest.Config.Digest)
+		} else if configDigest, hasConfig := imageConfigDigest(manifest); hasConfig {
+			imageConfig, err := b.Get(ctx, configDigest)
 			if err != nil {
 				glog.V(5).Infof("unable to access the image config using digest %q for repository %#v: %#v", d, repository, err)
 				if isDockerError(err, v2.ErrorCodeManifestUnknown) {
 					ref := repository.Ref
-					ref.ID = deserializedManifest.Config.Digest.String()
+					ref.ID = configDigest.String()
 					importDigest.Err = kapierrors.NewNotFound(api.Resource("dockerimage"), ref.Exact())
 				} else {
 					importDigest.Err = formatRepositoryError(repository, "", importDigest.Name, err)
@@ -415,7 +452,14 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 				continue
 			}
 
-			importDigest.Image, err = schema2ToImage(deserializedManifest, imageConfig, d)
+			importDigest.Image, err = schema2ToImage(manifest, imageConfig, d)
+		} else if isManifestList(manifest) {
+			importDigest.Image, importDigest.Manifests, err = manifestListToImage(ctx, s, b, manifest, d)
+			if err != nil {
+				glog.V(5).Infof("unable to access the manifests listed by digest %q for repository %#v: %#v", d, repository, err)
+				importDigest.Err = formatRepositoryError(repository, "", importDigest.Name, err)
+				continue
+			}
 		} else {
 			glog.V(5).Infof("unsupported manifest type: %T", manifest)
 			continue
@@ -432,6 +476,33 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 		}
 	}
 
+	// resolve the tags tracking a version range to the tag of the highest version in the range
+	var allTags []string
+	var allTagsErr error
+	for i := range repository.Tags {
+		importTag := &repository.Tags[i]
+		if len(importTag.VersionRange) == 0 || importTag.Err != nil || importTag.Image != nil {
+			continue
+		}
+		if allTags == nil && allTagsErr == nil {
+			limiter.Accept()
+			if allTags, allTagsErr = repo.Tags(ctx).All(ctx); allTagsErr != nil {
+				glog.V(5).Infof("unable to access tags for repository %#v: %#v", repository, allTagsErr)
+			}
+		}
+		if allTagsErr != nil {
+			importTag.Err = formatRepositoryError(repository, "", "", allTagsErr)
+			continue
+		}
+		name, err := api.HighestTagInVersionRange(allTags, importTag.VersionRange)
+		if err != nil {
+			importTag.Err = kapierrors.NewNotFound(api.Resource("dockerimage"), fmt.Sprintf("%s:%s", repository.Ref.Exact(), importTag.VersionRange))
+			continue
+		}
+		glog.V(5).Infof("resolved version range %q of repository %#v to tag %q", importTag.VersionRange, repository, name)
+		importTag.Name = name
+	}
+
 	for i := range repository.Tags {
 		importTag := &repository.Tags[i]
 		if importTag.Err != nil || importTag.Image != nil {
@@ -453,14 +524,21 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 
 		if signedManifest, isSchema1 := manifest.(*schema1.SignedManifest); isSchema1 {
 			importTag.Image, err = schema1ToImage(signedManifest, "")
-		} else if deserializedManifest, isSchema2 := manifest.(*schema2.DeserializedManifest); isSchema2 {
-			imageConfig, err := b.Get(ctx, deserializedManifest.Config.Digest)
+		} else if configDigest, hasConfig := imageConfigDigest(manifest); hasConfig {
+			imageConfig, err := b.Get(ctx, configDigest)
 			if err != nil {
 				glog.V(5).Infof("unable to access image config using digest %q for tag %q for repository %#v: %#v", desc.Digest, importTag.Name, repository, err)
 				importTag.Err = formatRepositoryError(repository, importTag.Name, "", err)
 				continue
 			}
-			importTag.Image, err = schema2ToImage(deserializedManifest, imageConfig, "")
+			importTag.Image, err = schema2ToImage(manifest, imageConfig, "")
+		} else if isManifestList(manifest) {
+			importTag.Image, importTag.Manifests, err = manifestListToImage(ctx, s, b, manifest, "")
+			if err != nil {
+				glog.V(5).Infof("unable to access the manifests listed by digest %q for tag %q for repository %#v: %#v", desc.Digest, importTag.Name, repository, err)
+				importTag.Err = formatRepositoryError(repository, importTag.Name, "", err)
+				continue
+			}
 		} else {
 			glog.V(5).Infof("unsupported manifest type: %T", manifest)
 			continue
@@ -477,6 +555,89 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 	}
 }
 
+// imageConfigDigest returns the digest of the image config referenced by schema 2 and OCI image manifests.
+func imageConfigDigest(manifest distribution.Manifest) (digest.Digest, bool) {
+	switch t := manifest.(type) {
+	case *schema2.DeserializedManifest:
+		return t.Config.Digest, true
+	case *ocischema.DeserializedManifest:
+		return t.Config.Digest, true
+	}
+	return "", false
+}
+
+// isManifestList returns true if manifest is a Docker manifest list or an OCI image index.
+func isManifestList(manifest distribution.Manifest) bool {
+	switch manifest.(type) {
+	case *manifestlist.DeserializedManifestList, *ocischema.DeserializedIndex:
+		return true
+	}
+	return false
+}
+
+// manifestListToImage converts a manifest list or an OCI image index into an image and loads the images of all
+// the platform specific manifests it references. The metadata of the list image is copied from the image of the
+// default platform.
+func manifestListToImage(ctx gocontext.Context, s distribution.ManifestService, b distribution.BlobStore, manifest distribution.Manifest, d digest.Digest) (*api.Image, []api.Image, error) {
+	mediatype, payload, err := manifest.Payload()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(d) == 0 {
+		d = digest.FromBytes(payload)
+	}
+	image := &api.Image{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: d.String(),
+		},
+		DockerImageManifest:          string(payload),
+		DockerImageManifestMediaType: mediatype,
+		DockerImageMetadataVersion:   "1.0",
+	}
+	if err := api.ImageWithMetadata(image); err != nil {
+		return nil, nil, err
+	}
+
+	images := make([]api.Image, 0, len(image.DockerImageManifests))
+	for _, m := range image.DockerImageManifests {
+		dgst, err := digest.ParseDigest(m.Digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		platformManifest, err := s.Get(ctx, dgst)
+		if err != nil {
+			return nil, nil, err
+		}
+		configDigest, hasConfig := imageConfigDigest(platformManifest)
+		if !hasConfig {
+			return nil, nil, fmt.Errorf("manifest %s of type %T cannot be referenced by a manifest list", dgst, platformManifest)
+		}
+		imageConfig, err := b.Get(ctx, configDigest)
+		if err != nil {
+			return nil, nil, err
+		}
+		platformImage, err := schema2ToImage(platformManifest, imageConfig, dgst)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := api.ImageWithMetadata(platformImage); err != nil {
+			return nil, nil, err
+		}
+		images = append(images, *platformImage)
+	}
+
+	if m := api.DefaultImageManifest(image.DockerImageManifests); m != nil {
+		for i := range images {
+			if images[i].Name == m.Digest {
+				image.DockerImageMetadata = images[i].DockerImageMetadata
+				image.DockerImageMetadata.ID = image.Name
+				break
+			}
+		}
+	}
+	return image, images, nil
+}
+
 func importRepositoryFromDockerV1(ctx gocontext.Context, repository *importRepository, limiter flowcontrol.RateLimiter) {
 	value := ctx.Value(ContextKeyV1RegistryClient)
 	if value == nil {
@@ -549,6 +710,39 @@ func importRepositoryFromDockerV1(ctx gocontext.Context, repository *importRepos
 		}
 	}
 
+	// resolve the tags tracking a version range to the tag of the highest version in the range
+	var allTags []string
+	var allTagsErr error
+	for i := range repository.Tags {
+		importTag := &repository.Tags[i]
+		if len(importTag.VersionRange) == 0 || importTag.Err != nil || importTag.Image != nil {
+			continue
+		}
+		if allTags == nil && allTagsErr == nil {
+			limiter.Accept()
+			tagMap, err := conn.ImageTags(repository.Ref.Namespace, repository.Ref.Name)
+			if err != nil {
+				glog.V(5).Infof("unable to access tags for repository %#v: %#v", repository, err)
+				allTagsErr = err
+			}
+			allTags = make([]string, 0, len(tagMap))
+			for tag := range tagMap {
+				allTags = append(allTags, tag)
+			}
+		}
+		if allTagsErr != nil {
+			importTag.Err = allTagsErr
+			continue
+		}
+		name, err := api.HighestTagInVersionRange(allTags, importTag.VersionRange)
+		if err != nil {
+			importTag.Err = kapierrors.NewNotFound(api.Resource("dockerimage"), fmt.Sprintf("%s:%s", repository.Ref.Exact(), importTag.VersionRange))
+			continue
+		}
+		glog.V(5).Infof("resolved version range %q of repository %#v to tag %q", importTag.VersionRange, repository, name)
+		importTag.Name = name
+	}
+
 	for i := range repository.Tags {
 		importTag := &repository.Tags[i]
 		if importTag.Err != nil || importTag.Image != nil {
@@ -570,15 +764,19 @@ func importRepositoryFromDockerV1(ctx gocontext.Context, repository *importRepos
 }
 
 type importTag struct {
-	Name  string
-	Image *api.Image
-	Err   error
+	Name string
+	// VersionRange, if set, is resolved to the Name of the tag to import
+	VersionRange string
+	Image        *api.Image
+	Manifests    []api.Image
+	Err          error
 }
 
 type importDigest struct {
-	Name  string
-	Image *api.Image
-	Err   error
+	Name      string
+	Image     *api.Image
+	Manifests []api.Image
+	Err       error
 }
 
 type importRepository struct {
@@ -7,12 +7,17 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/ocischema"
 )
 
 func TestImportNothing(t *testing.T) {
@@ -195,6 +200,47 @@ func TestImport(t *testing.T) {
 				}
 			},
 		},
+		{
+			retriever: &mockRetriever{
+				repo: &mockRepository{
+					manifest: m,
+					tags: map[string]string{
+						"1.3.9":  "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238",
+						"v1.4.2": "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238",
+						"1.4.10": "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238",
+						"1.5.0":  "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238",
+						"latest": "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238",
+					},
+				},
+			},
+			isi: api.ImageStreamImport{
+				Spec: api.ImageStreamImportSpec{
+					Images: []api.ImageImportSpec{
+						{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test"}, ImportPolicy: api.TagImportPolicy{VersionRange: "~1.4"}},
+						{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test"}, ImportPolicy: api.TagImportPolicy{VersionRange: "<1.4.3"}},
+						{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test"}, ImportPolicy: api.TagImportPolicy{VersionRange: "~2"}},
+					},
+				},
+			},
+			expect: func(isi *api.ImageStreamImport, t *testing.T) {
+				if len(isi.Status.Images) != 3 {
+					t.Fatalf("unexpected number of images: %#v", isi.Status.Images)
+				}
+				expectedTags := []string{"1.4.10", "v1.4.2"}
+				for i, expected := range expectedTags {
+					image := isi.Status.Images[i]
+					if image.Status.Status != unversioned.StatusSuccess || image.Image == nil {
+						t.Errorf("unexpected status %d: %#v", i, image.Status)
+					}
+					if image.Tag != expected {
+						t.Errorf("unexpected tag of status %d (%s != %s)", i, image.Tag, expected)
+					}
+				}
+				if status := isi.Status.Images[2].Status; status.Status != unversioned.StatusFailure || status.Reason != unversioned.StatusReasonNotFound {
+					t.Errorf("unexpected status of an unmatched range: %#v", status)
+				}
+			},
+		},
 	}
 	for i, test := range testCases {
 		im := NewImageStreamImporter(test.retriever, 5, nil)
@@ -207,6 +253,90 @@ func TestImport(t *testing.T) {
 	}
 }
 
+func TestImportIndex(t *testing.T) {
+	blobs := map[digest.Digest][]byte{}
+	manifests := map[digest.Digest]distribution.Manifest{}
+	descriptors := []manifestlist.ManifestDescriptor{}
+	for _, arch := range []string{"arm64", "amd64"} {
+		config := []byte(fmt.Sprintf(`{"architecture": %q, "os": "linux", "config": {"Labels": {"arch": %q}}}`, arch, arch))
+		configDigest := digest.FromBytes(config)
+		blobs[configDigest] = config
+		m, err := ocischema.FromStruct(ocischema.Manifest{
+			Versioned: manifest.Versioned{SchemaVersion: 2},
+			Config:    distribution.Descriptor{MediaType: ocischema.MediaTypeImageConfig, Digest: configDigest, Size: int64(len(config))},
+			Layers: []distribution.Descriptor{
+				{MediaType: ocischema.MediaTypeImageLayer, Digest: digest.FromBytes([]byte(arch)), Size: 100},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, payload, _ := m.Payload()
+		dgst := digest.FromBytes(payload)
+		manifests[dgst] = m
+		descriptors = append(descriptors, manifestlist.ManifestDescriptor{
+			Descriptor: distribution.Descriptor{MediaType: ocischema.MediaTypeImageManifest, Digest: dgst, Size: int64(len(payload))},
+			Platform:   manifestlist.PlatformSpec{Architecture: arch, OS: "linux"},
+		})
+	}
+	index, err := ocischema.FromDescriptors(descriptors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, payload, _ := index.Payload()
+	indexDigest := digest.FromBytes(payload)
+	manifests[indexDigest] = index
+
+	isi := &api.ImageStreamImport{
+		Spec: api.ImageStreamImportSpec{
+			Images: []api.ImageImportSpec{
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test:latest"}},
+			},
+		},
+	}
+	retriever := &mockRetriever{
+		repo: &mockRepository{
+			blobs:     &mockBlobStore{blobs: blobs},
+			manifests: manifests,
+			tags:      map[string]string{"latest": indexDigest.String()},
+		},
+	}
+	if err := NewImageStreamImporter(retriever, 5, nil).Import(nil, isi); err != nil {
+		t.Fatal(err)
+	}
+
+	status := isi.Status.Images[0]
+	if status.Status.Status != unversioned.StatusSuccess {
+		t.Fatalf("unexpected status: %#v", status.Status)
+	}
+	image := status.Image
+	if image.Name != indexDigest.String() || image.DockerImageManifestMediaType != ocischema.MediaTypeImageIndex {
+		t.Errorf("unexpected image %s of type %s", image.Name, image.DockerImageManifestMediaType)
+	}
+	if len(image.DockerImageManifests) != 2 || image.DockerImageManifests[1].Digest != descriptors[1].Digest.String() {
+		t.Errorf("unexpected manifests: %#v", image.DockerImageManifests)
+	}
+	// the metadata of the list is the one of the linux/amd64 image
+	if image.DockerImageMetadata.ID != indexDigest.String() || image.DockerImageMetadata.Config.Labels["arch"] != "amd64" {
+		t.Errorf("unexpected metadata: %#v", image.DockerImageMetadata)
+	}
+
+	if len(status.Manifests) != 2 {
+		t.Fatalf("unexpected platform images: %#v", status.Manifests)
+	}
+	for i, platformImage := range status.Manifests {
+		if platformImage.Name != descriptors[i].Digest.String() || platformImage.DockerImageManifestMediaType != ocischema.MediaTypeImageManifest {
+			t.Errorf("unexpected platform image %s of type %s", platformImage.Name, platformImage.DockerImageManifestMediaType)
+		}
+		if platformImage.DockerImageReference != "test@"+descriptors[i].Digest.String() {
+			t.Errorf("unexpected platform image reference: %s", platformImage.DockerImageReference)
+		}
+		if len(platformImage.DockerImageLayers) != 1 || platformImage.DockerImageMetadata.Architecture != descriptors[i].Platform.Architecture {
+			t.Errorf("unexpected platform image metadata: %#v", platformImage)
+		}
+	}
+}
+
 const etcdManifest = `
 {
    "schemaVersion": 1, 
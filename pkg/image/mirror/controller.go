@@ -0,0 +1,78 @@
+package mirror
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gocontext "golang.org/x/net/context"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+	kutilerrors "k8s.io/kubernetes/pkg/util/errors"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+// Controller periodically mirrors a selection of image streams of a source namespace into a destination
+// namespace.
+type Controller struct {
+	Mirrorer *ImageStreamMirrorer
+
+	// SourceNamespace is the namespace of the mirrored image streams.
+	SourceNamespace string
+	// DestinationNamespace is the namespace the image streams are mirrored to.
+	DestinationNamespace string
+	// Names, if not empty, restricts mirroring to the image streams of these names.
+	Names sets.String
+	// Selector restricts mirroring to the image streams matching it.
+	Selector labels.Selector
+	// Tags, if not empty, restricts mirroring to these tags.
+	Tags sets.String
+
+	// Out, if set, receives a line for every mirrored tag.
+	Out io.Writer
+}
+
+// Run mirrors the image streams every interval until stopCh is closed. Errors are logged and the failed
+// tags are retried in the next round.
+func (c *Controller) Run(interval time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := c.RunOnce(); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}, interval, stopCh)
+}
+
+// RunOnce mirrors all the selected image streams once.
+func (c *Controller) RunOnce() error {
+	streams, err := c.Mirrorer.Source.Client.ImageStreams(c.SourceNamespace).List(kapi.ListOptions{LabelSelector: c.Selector})
+	if err != nil {
+		return fmt.Errorf("unable to list image streams to mirror: %v", err)
+	}
+
+	ctx := gocontext.Background()
+	found := sets.NewString()
+	errs := []error{}
+	for i := range streams.Items {
+		is := &streams.Items[i]
+		if c.Names.Len() > 0 && !c.Names.Has(is.Name) {
+			continue
+		}
+		found.Insert(is.Name)
+
+		result, err := c.Mirrorer.Mirror(ctx, is, c.DestinationNamespace, c.Tags)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if result != nil && c.Out != nil && len(result.Mirrored) > 0 {
+			fmt.Fprintf(c.Out, "%s/%s: mirrored %s\n", is.Namespace, is.Name, strings.Join(result.Mirrored, ", "))
+		}
+	}
+	if missing := c.Names.Difference(found); missing.Len() > 0 {
+		errs = append(errs, fmt.Errorf("image streams not found in namespace %s: %s", c.SourceNamespace, strings.Join(missing.List(), ", ")))
+	}
+	return kutilerrors.NewAggregate(errs)
+}
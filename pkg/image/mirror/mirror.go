@@ -0,0 +1,298 @@
+package mirror
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/golang/glog"
+	gocontext "golang.org/x/net/context"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kutilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/importer"
+	"github.com/openshift/origin/pkg/image/ocischema"
+)
+
+// Endpoint is a cluster and its integrated registry taking part in mirroring.
+type Endpoint struct {
+	// Client talks to the master of the cluster.
+	Client client.Interface
+	// Retriever gives access to repositories of the integrated registry.
+	Retriever importer.RepositoryRetriever
+	// Registry, if set, is the host (and port) used to reach the integrated registry instead of the one
+	// reported by image streams. This is useful if the registry is exposed through a route.
+	Registry string
+	// Insecure allows to contact the registry without TLS verification or over plain HTTP.
+	Insecure bool
+}
+
+// Result describes what happened to the tags of a single image stream.
+type Result struct {
+	// Mirrored lists the tags that were copied to the destination.
+	Mirrored []string
+	// UpToDate lists the tags that already pointed to the same image in the destination.
+	UpToDate []string
+}
+
+// ImageStreamMirrorer copies tagged images of image streams, including their blobs and signatures, from the
+// source integrated registry to the destination one. Manifests are copied verbatim so the digests and the
+// image metadata derived from the manifests are preserved.
+type ImageStreamMirrorer struct {
+	Source      Endpoint
+	Destination Endpoint
+}
+
+// Mirror replicates the latest image of each tag of the source image stream into the image stream of the same
+// name in the destination namespace, which is created if it does not exist. If tags is not empty, only the
+// listed tags are mirrored. Tags whose image is already current in the destination are skipped.
+func (m *ImageStreamMirrorer) Mirror(ctx gocontext.Context, is *imageapi.ImageStream, namespace string, tags sets.String) (*Result, error) {
+	dstStream, err := m.destinationImageStream(is, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	srcRepo, err := m.repository(ctx, &m.Source, is.Status.DockerImageRepository)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access source repository of image stream %s/%s: %v", is.Namespace, is.Name, err)
+	}
+	dstRepo, err := m.repository(ctx, &m.Destination, dstStream.Status.DockerImageRepository)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access destination repository of image stream %s/%s: %v", dstStream.Namespace, dstStream.Name, err)
+	}
+
+	result := &Result{}
+	errs := []error{}
+	for tag, history := range is.Status.Tags {
+		if tags.Len() > 0 && !tags.Has(tag) {
+			continue
+		}
+		if len(history.Items) == 0 {
+			continue
+		}
+		image := history.Items[0].Image
+		if latest := imageapi.LatestTaggedImage(dstStream, tag); latest != nil && latest.Image == image {
+			result.UpToDate = append(result.UpToDate, tag)
+			continue
+		}
+		if err := m.mirrorImage(ctx, is, dstStream, srcRepo, dstRepo, image, tag); err != nil {
+			errs = append(errs, fmt.Errorf("failed to mirror %s/%s:%s: %v", is.Namespace, is.Name, tag, err))
+			continue
+		}
+		result.Mirrored = append(result.Mirrored, tag)
+	}
+	return result, kutilerrors.NewAggregate(errs)
+}
+
+// destinationImageStream returns the image stream the images are mirrored to, creating it if necessary so that
+// the location of its repository in the destination registry is known.
+func (m *ImageStreamMirrorer) destinationImageStream(is *imageapi.ImageStream, namespace string) (*imageapi.ImageStream, error) {
+	dstStream, err := m.Destination.Client.ImageStreams(namespace).Get(is.Name)
+	if err == nil {
+		return dstStream, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	glog.V(4).Infof("Creating image stream %s/%s in the destination", namespace, is.Name)
+	return m.Destination.Client.ImageStreams(namespace).Create(&imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:   is.Name,
+			Labels: is.Labels,
+		},
+	})
+}
+
+// repository returns the registry repository behind dockerImageRepository of an image stream on endpoint e.
+func (m *ImageStreamMirrorer) repository(ctx gocontext.Context, e *Endpoint, dockerImageRepository string) (distribution.Repository, error) {
+	if len(dockerImageRepository) == 0 {
+		return nil, fmt.Errorf("the image stream has no docker image repository, is the integrated registry installed?")
+	}
+	ref, err := imageapi.ParseDockerImageReference(dockerImageRepository)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.Registry) > 0 {
+		ref.Registry = e.Registry
+	}
+	ref = ref.DockerClientDefaults()
+	// insecure registries are retried over plain HTTP by the retriever
+	registry := &url.URL{Scheme: "https", Host: ref.Registry}
+	return e.Retriever.Repository(ctx, registry, ref.RepositoryName(), e.Insecure)
+}
+
+// mirrorImage copies the manifest of the image with the given digest, its blobs and signatures, and tags it in
+// the destination repository. Signatures are read before anything is pushed, and copied on a best effort basis
+// once the manifest is in the destination: failing to copy them does not fail the mirroring of the image. The
+// platform manifests of a manifest list or an OCI index are copied with their blobs before the list.
+func (m *ImageStreamMirrorer) mirrorImage(ctx gocontext.Context, is, dstStream *imageapi.ImageStream, src, dst distribution.Repository, image, tag string) error {
+	dgst, err := digest.ParseDigest(image)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := m.sourceSignatures(is, image)
+	if err != nil {
+		return fmt.Errorf("unable to get the signatures of image %s: %v", image, err)
+	}
+
+	srcManifests, err := src.Manifests(ctx)
+	if err != nil {
+		return err
+	}
+	manifest, err := srcManifests.Get(ctx, dgst)
+	if err != nil {
+		return fmt.Errorf("unable to get manifest %s: %v", dgst, err)
+	}
+
+	dstManifests, err := dst.Manifests(ctx)
+	if err != nil {
+		return err
+	}
+
+	// a manifest list or an OCI index refers to the manifests of its platforms, which the destination must
+	// have before the list is pushed
+	if isManifestList(manifest) {
+		for _, desc := range manifest.References() {
+			platformManifest, err := srcManifests.Get(ctx, desc.Digest)
+			if err != nil {
+				return fmt.Errorf("unable to get manifest %s: %v", desc.Digest, err)
+			}
+			glog.V(4).Infof("Pushing manifest %s of manifest list %s", desc.Digest, dgst)
+			if err := copyManifest(ctx, src, dst, dstManifests, platformManifest, desc.Digest); err != nil {
+				return err
+			}
+		}
+	}
+
+	glog.V(4).Infof("Pushing manifest %s as tag %q", dgst, tag)
+	if err := copyManifest(ctx, src, dst, dstManifests, manifest, dgst, distribution.WithTag(tag)); err != nil {
+		return err
+	}
+
+	if err := m.copySignatures(dstStream, image, signatures); err != nil {
+		glog.Warningf("Unable to copy the signatures of image %s to %s/%s: %v", image, dstStream.Namespace, dstStream.Name, err)
+	}
+	return nil
+}
+
+// copyManifest copies the blobs of manifest from src to dst and pushes it to dstManifests, checking that the
+// destination stores it under dgst.
+func copyManifest(ctx gocontext.Context, src, dst distribution.Repository, dstManifests distribution.ManifestService, manifest distribution.Manifest, dgst digest.Digest, options ...distribution.ManifestServiceOption) error {
+	for _, desc := range blobsOf(manifest) {
+		if err := copyBlob(ctx, src.Blobs(ctx), dst.Blobs(ctx), desc); err != nil {
+			return fmt.Errorf("unable to copy blob %s: %v", desc.Digest, err)
+		}
+	}
+	pushed, err := dstManifests.Put(ctx, manifest, options...)
+	if err != nil {
+		return fmt.Errorf("unable to push manifest %s: %v", dgst, err)
+	}
+	if pushed != dgst {
+		return fmt.Errorf("the destination registry stored the manifest as %s instead of %s", pushed, dgst)
+	}
+	return nil
+}
+
+// isManifestList returns true if manifest is a manifest list or an OCI index, whose references are manifests
+// rather than blobs.
+func isManifestList(manifest distribution.Manifest) bool {
+	switch manifest.(type) {
+	case *manifestlist.DeserializedManifestList, *ocischema.DeserializedIndex:
+		return true
+	}
+	return false
+}
+
+// blobsOf returns the descriptors of all blobs the manifest refers to.
+func blobsOf(manifest distribution.Manifest) []distribution.Descriptor {
+	if isManifestList(manifest) {
+		return nil
+	}
+	blobs := manifest.References()
+	// the config blob of schema 2 manifests is not among the references
+	if m, ok := manifest.(*schema2.DeserializedManifest); ok {
+		blobs = append([]distribution.Descriptor{m.Target()}, blobs...)
+	}
+	return blobs
+}
+
+// sourceSignatures returns the signatures of the image in the source image stream. They are read through the
+// image stream, so only access to its namespace is needed.
+func (m *ImageStreamMirrorer) sourceSignatures(is *imageapi.ImageStream, image string) ([]imageapi.ImageSignature, error) {
+	isi, err := m.Source.Client.ImageStreamImages(is.Namespace).Get(is.Name, image)
+	if err != nil {
+		return nil, err
+	}
+	return isi.Image.Signatures, nil
+}
+
+// copySignatures adds the signatures that the image does not have yet in the destination image stream.
+func (m *ImageStreamMirrorer) copySignatures(dstStream *imageapi.ImageStream, image string, signatures []imageapi.ImageSignature) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	isi, err := m.Destination.Client.ImageStreamImages(dstStream.Namespace).Get(dstStream.Name, image)
+	if err != nil {
+		return err
+	}
+	existing := sets.NewString()
+	for _, signature := range isi.Image.Signatures {
+		existing.Insert(signature.Name)
+	}
+	for _, signature := range signatures {
+		if existing.Has(signature.Name) {
+			continue
+		}
+		_, err := m.Destination.Client.ImageSignatures().Create(&imageapi.ImageSignature{
+			ObjectMeta: kapi.ObjectMeta{Name: signature.Name},
+			Type:       signature.Type,
+			Content:    signature.Content,
+		})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("unable to copy signature %s: %v", signature.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyBlob streams the blob described by desc from src to dst unless dst already has it.
+func copyBlob(ctx gocontext.Context, src, dst distribution.BlobStore, desc distribution.Descriptor) error {
+	if _, err := dst.Stat(ctx, desc.Digest); err == nil {
+		return nil
+	} else if err != distribution.ErrBlobUnknown {
+		return err
+	}
+
+	reader, err := src.Open(ctx, desc.Digest)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := dst.Create(ctx)
+	if err != nil {
+		return err
+	}
+
+	glog.V(4).Infof("Copying blob %s", desc.Digest)
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Cancel(ctx)
+		return err
+	}
+	if _, err := writer.Commit(ctx, desc); err != nil {
+		writer.Cancel(ctx)
+		return err
+	}
+	return nil
+}
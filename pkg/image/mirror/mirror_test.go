@@ -0,0 +1,374 @@
+package mirror
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+
+	gocontext "golang.org/x/net/context"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// fakeRetriever serves repositories of an in-memory registry.
+type fakeRetriever struct {
+	registry distribution.Namespace
+	hosts    []string
+}
+
+func (r *fakeRetriever) Repository(ctx gocontext.Context, registry *url.URL, repoName string, insecure bool) (distribution.Repository, error) {
+	r.hosts = append(r.hosts, registry.Host)
+	named, err := reference.ParseNamed(repoName)
+	if err != nil {
+		return nil, err
+	}
+	return r.registry.Repository(ctx, named)
+}
+
+func newFakeRetriever(t *testing.T) *fakeRetriever {
+	registry, err := storage.NewRegistry(context.Background(), inmemory.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fakeRetriever{registry: registry}
+}
+
+// pushTestImage stores a schema 2 image of a single layer in repo and returns the digest of its manifest.
+func pushTestImage(t *testing.T, retriever *fakeRetriever, repoName string) digest.Digest {
+	ctx := context.Background()
+	named, err := reference.ParseNamed(repoName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := retriever.registry.Repository(ctx, named)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := repo.Blobs(ctx).Put(ctx, schema2.MediaTypeConfig, []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer, err := repo.Blobs(ctx).Put(ctx, schema2.MediaTypeLayer, []byte("layer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: manifest.Versioned{SchemaVersion: 2, MediaType: schema2.MediaTypeManifest},
+		Config:    config,
+		Layers:    []distribution.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := repo.Manifests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dgst, err := ms.Put(ctx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dgst
+}
+
+func TestMirror(t *testing.T) {
+	source := newFakeRetriever(t)
+	destination := newFakeRetriever(t)
+	dgst := pushTestImage(t, source, "src/is")
+
+	stream := &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "src", Name: "is"},
+		Status: imageapi.ImageStreamStatus{
+			DockerImageRepository: "172.30.0.1:5000/src/is",
+			Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{Image: dgst.String()}}},
+				"stable": {Items: []imageapi.TagEvent{{Image: dgst.String()}}},
+				"old":    {Items: []imageapi.TagEvent{{Image: dgst.String()}}},
+			},
+		},
+	}
+
+	signature := imageapi.ImageSignature{
+		ObjectMeta: kapi.ObjectMeta{Name: dgst.String() + "@sig"},
+		Type:       imageapi.ImageSignatureTypeJWSImageV1,
+		Content:    []byte("signature"),
+	}
+	srcClient := &testclient.Fake{}
+	srcClient.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		if ns := action.GetNamespace(); ns != "src" {
+			t.Errorf("unexpected source namespace %q", ns)
+		}
+		return true, &imageapi.ImageStreamImage{
+			Image: imageapi.Image{
+				ObjectMeta: kapi.ObjectMeta{Name: dgst.String()},
+				Signatures: []imageapi.ImageSignature{signature},
+			},
+		}, nil
+	})
+
+	dstClient := &testclient.Fake{}
+	dstClient.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("imagestreams"), "is")
+	})
+	dstClient.AddReactor("create", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		is := action.(ktestclient.CreateAction).GetObject().(*imageapi.ImageStream)
+		is.Namespace = "dst"
+		is.Status.DockerImageRepository = "172.30.0.2:5000/dst/is"
+		is.Status.Tags = map[string]imageapi.TagEventList{
+			"stable": {Items: []imageapi.TagEvent{{Image: dgst.String()}}},
+		}
+		return true, is, nil
+	})
+	dstClient.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		if ns := action.GetNamespace(); ns != "dst" {
+			t.Errorf("unexpected destination namespace %q", ns)
+		}
+		return true, &imageapi.ImageStreamImage{Image: imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: dgst.String()}}}, nil
+	})
+	var createdSignature *imageapi.ImageSignature
+	dstClient.AddReactor("create", "imagesignatures", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		createdSignature = action.(ktestclient.CreateAction).GetObject().(*imageapi.ImageSignature)
+		return true, createdSignature, nil
+	})
+
+	m := &ImageStreamMirrorer{
+		Source:      Endpoint{Client: srcClient, Retriever: source},
+		Destination: Endpoint{Client: dstClient, Retriever: destination, Registry: "registry.example.com"},
+	}
+	result, err := m.Mirror(gocontext.Background(), stream, "dst", sets.NewString("latest", "stable"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.Mirrored, []string{"latest"}) {
+		t.Errorf("unexpected mirrored tags: %v", result.Mirrored)
+	}
+	if !reflect.DeepEqual(result.UpToDate, []string{"stable"}) {
+		t.Errorf("unexpected up to date tags: %v", result.UpToDate)
+	}
+	if !reflect.DeepEqual(source.hosts, []string{"172.30.0.1:5000"}) {
+		t.Errorf("unexpected source registry hosts: %v", source.hosts)
+	}
+	if !reflect.DeepEqual(destination.hosts, []string{"registry.example.com"}) {
+		t.Errorf("unexpected destination registry hosts: %v", destination.hosts)
+	}
+
+	ctx := context.Background()
+	dstRepo, err := destination.Repository(ctx, &url.URL{}, "dst/is", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := dstRepo.Manifests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirrored, err := ms.Get(ctx, dgst)
+	if err != nil {
+		t.Fatalf("manifest %s was not mirrored: %v", dgst, err)
+	}
+	for _, desc := range blobsOf(mirrored) {
+		if _, err := dstRepo.Blobs(ctx).Stat(ctx, desc.Digest); err != nil {
+			t.Errorf("blob %s was not mirrored: %v", desc.Digest, err)
+		}
+	}
+
+	if createdSignature == nil {
+		t.Fatalf("signature was not mirrored")
+	}
+	if createdSignature.Name != signature.Name || createdSignature.Type != signature.Type || string(createdSignature.Content) != string(signature.Content) {
+		t.Errorf("unexpected mirrored signature: %#v", createdSignature)
+	}
+}
+
+func TestMirrorMissingImage(t *testing.T) {
+	stream := &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "src", Name: "is"},
+		Status: imageapi.ImageStreamStatus{
+			DockerImageRepository: "172.30.0.1:5000/src/is",
+			Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{Image: digest.FromBytes([]byte("missing")).String()}}},
+			},
+		},
+	}
+	dstClient := &testclient.Fake{}
+	dstClient.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "dst", Name: "is"},
+			Status:     imageapi.ImageStreamStatus{DockerImageRepository: "172.30.0.2:5000/dst/is"},
+		}, nil
+	})
+
+	m := &ImageStreamMirrorer{
+		Source:      Endpoint{Client: &testclient.Fake{}, Retriever: newFakeRetriever(t)},
+		Destination: Endpoint{Client: dstClient, Retriever: newFakeRetriever(t)},
+	}
+	result, err := m.Mirror(gocontext.Background(), stream, "dst", sets.NewString())
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if len(result.Mirrored) != 0 {
+		t.Errorf("unexpected mirrored tags: %v", result.Mirrored)
+	}
+	for _, action := range dstClient.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("unexpected action: %s", fmt.Sprintf("%#v", action))
+		}
+	}
+}
+
+func TestMirrorSignaturesBestEffort(t *testing.T) {
+	source := newFakeRetriever(t)
+	destination := newFakeRetriever(t)
+	dgst := pushTestImage(t, source, "src/is")
+
+	stream := &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "src", Name: "is"},
+		Status: imageapi.ImageStreamStatus{
+			DockerImageRepository: "172.30.0.1:5000/src/is",
+			Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{Image: dgst.String()}}},
+			},
+		},
+	}
+	srcClient := &testclient.Fake{}
+	srcClient.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamImage{
+			Image: imageapi.Image{
+				ObjectMeta: kapi.ObjectMeta{Name: dgst.String()},
+				Signatures: []imageapi.ImageSignature{{ObjectMeta: kapi.ObjectMeta{Name: dgst.String() + "@sig"}}},
+			},
+		}, nil
+	})
+	dstClient := &testclient.Fake{}
+	dstClient.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "dst", Name: "is"},
+			Status:     imageapi.ImageStreamStatus{DockerImageRepository: "172.30.0.2:5000/dst/is"},
+		}, nil
+	})
+	dstClient.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamImage{Image: imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: dgst.String()}}}, nil
+	})
+	dstClient.AddReactor("create", "imagesignatures", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(imageapi.Resource("imagesignatures"), "", fmt.Errorf("denied"))
+	})
+
+	m := &ImageStreamMirrorer{
+		Source:      Endpoint{Client: srcClient, Retriever: source},
+		Destination: Endpoint{Client: dstClient, Retriever: destination},
+	}
+	result, err := m.Mirror(gocontext.Background(), stream, "dst", sets.NewString())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Mirrored, []string{"latest"}) {
+		t.Errorf("unexpected mirrored tags: %v", result.Mirrored)
+	}
+}
+
+func TestMirrorManifestList(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeRetriever(t)
+	destination := newFakeRetriever(t)
+	platformDigest := pushTestImage(t, source, "src/is")
+
+	srcRepo, err := source.Repository(ctx, &url.URL{}, "src/is", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srcManifests, err := srcRepo.Manifests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	platformManifest, err := srcManifests.Get(ctx, platformDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, payload, err := platformManifest.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := manifestlist.FromDescriptors([]manifestlist.ManifestDescriptor{{
+		Descriptor: distribution.Descriptor{MediaType: mediaType, Size: int64(len(payload)), Digest: platformDigest},
+		Platform:   manifestlist.PlatformSpec{Architecture: "amd64", OS: "linux"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listDigest, err := srcManifests.Put(ctx, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "src", Name: "is"},
+		Status: imageapi.ImageStreamStatus{
+			DockerImageRepository: "172.30.0.1:5000/src/is",
+			Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{Image: listDigest.String()}}},
+			},
+		},
+	}
+	srcClient := &testclient.Fake{}
+	srcClient.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamImage{Image: imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: listDigest.String()}}}, nil
+	})
+	dstClient := &testclient.Fake{}
+	dstClient.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "dst", Name: "is"},
+			Status:     imageapi.ImageStreamStatus{DockerImageRepository: "172.30.0.2:5000/dst/is"},
+		}, nil
+	})
+
+	m := &ImageStreamMirrorer{
+		Source:      Endpoint{Client: srcClient, Retriever: source},
+		Destination: Endpoint{Client: dstClient, Retriever: destination},
+	}
+	result, err := m.Mirror(gocontext.Background(), stream, "dst", sets.NewString())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Mirrored, []string{"latest"}) {
+		t.Errorf("unexpected mirrored tags: %v", result.Mirrored)
+	}
+
+	dstRepo, err := destination.Repository(ctx, &url.URL{}, "dst/is", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := dstRepo.Manifests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ms.Get(ctx, listDigest); err != nil {
+		t.Errorf("manifest list %s was not mirrored: %v", listDigest, err)
+	}
+	mirrored, err := ms.Get(ctx, platformDigest)
+	if err != nil {
+		t.Fatalf("platform manifest %s was not mirrored: %v", platformDigest, err)
+	}
+	for _, desc := range blobsOf(mirrored) {
+		if _, err := dstRepo.Blobs(ctx).Stat(ctx, desc.Digest); err != nil {
+			t.Errorf("blob %s was not mirrored: %v", desc.Digest, err)
+		}
+	}
+}
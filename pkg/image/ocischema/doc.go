@@ -0,0 +1,4 @@
+// Package ocischema registers the OCI image manifest and image index media
+// types with the docker distribution library, so that registry clients and
+// servers can exchange them like Docker schema 2 manifests and manifest lists.
+package ocischema
@@ -0,0 +1,188 @@
+package ocischema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
+)
+
+const (
+	// MediaTypeImageManifest specifies the mediaType of OCI image manifests.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// MediaTypeImageIndex specifies the mediaType of OCI image indexes.
+	MediaTypeImageIndex = "application/vnd.oci.image.index.v1+json"
+
+	// MediaTypeImageConfig specifies the mediaType of the image configuration referenced by OCI manifests.
+	MediaTypeImageConfig = "application/vnd.oci.image.config.v1+json"
+
+	// MediaTypeImageLayer specifies the mediaType of gzip compressed layers referenced by OCI manifests.
+	MediaTypeImageLayer = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+func init() {
+	manifestFunc := func(b []byte) (distribution.Manifest, distribution.Descriptor, error) {
+		m := new(DeserializedManifest)
+		if err := m.UnmarshalJSON(b); err != nil {
+			return nil, distribution.Descriptor{}, err
+		}
+		return m, distribution.Descriptor{Digest: digest.FromBytes(b), Size: int64(len(b)), MediaType: MediaTypeImageManifest}, nil
+	}
+	if err := distribution.RegisterManifestSchema(MediaTypeImageManifest, manifestFunc); err != nil {
+		panic(fmt.Sprintf("Unable to register manifest: %s", err))
+	}
+
+	indexFunc := func(b []byte) (distribution.Manifest, distribution.Descriptor, error) {
+		m := new(DeserializedIndex)
+		if err := m.UnmarshalJSON(b); err != nil {
+			return nil, distribution.Descriptor{}, err
+		}
+		return m, distribution.Descriptor{Digest: digest.FromBytes(b), Size: int64(len(b)), MediaType: MediaTypeImageIndex}, nil
+	}
+	if err := distribution.RegisterManifestSchema(MediaTypeImageIndex, indexFunc); err != nil {
+		panic(fmt.Sprintf("Unable to register manifest: %s", err))
+	}
+}
+
+// Manifest defines an OCI image manifest. Apart from the media types it uses, it has the same structure as a
+// Docker schema 2 manifest.
+type Manifest struct {
+	manifest.Versioned
+
+	// Config references the image configuration as a blob.
+	Config distribution.Descriptor `json:"config"`
+
+	// Layers lists descriptors for the layers referenced by the configuration.
+	Layers []distribution.Descriptor `json:"layers"`
+
+	// Annotations contains arbitrary metadata of the manifest.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// References returns the descriptors of the config and the layers of the manifest.
+func (m Manifest) References() []distribution.Descriptor {
+	return append([]distribution.Descriptor{m.Config}, m.Layers...)
+}
+
+// Target returns the image configuration of the manifest.
+func (m Manifest) Target() distribution.Descriptor {
+	return m.Config
+}
+
+// DeserializedManifest wraps Manifest with a copy of the original JSON.
+type DeserializedManifest struct {
+	Manifest
+
+	// canonical is the canonical byte representation of the Manifest.
+	canonical []byte
+}
+
+// FromStruct returns a DeserializedManifest holding m and its JSON representation.
+func FromStruct(m Manifest) (*DeserializedManifest, error) {
+	deserialized := DeserializedManifest{Manifest: m}
+	var err error
+	deserialized.canonical, err = json.MarshalIndent(&m, "", "   ")
+	return &deserialized, err
+}
+
+// UnmarshalJSON populates a new Manifest struct from JSON data.
+func (m *DeserializedManifest) UnmarshalJSON(b []byte) error {
+	m.canonical = make([]byte, len(b), len(b))
+	copy(m.canonical, b)
+
+	var manifest Manifest
+	if err := json.Unmarshal(m.canonical, &manifest); err != nil {
+		return err
+	}
+	if manifest.SchemaVersion != 2 {
+		return fmt.Errorf("unsupported OCI image manifest schema version %d", manifest.SchemaVersion)
+	}
+	if len(manifest.MediaType) > 0 && manifest.MediaType != MediaTypeImageManifest {
+		return fmt.Errorf("unexpected media type %q of an OCI image manifest", manifest.MediaType)
+	}
+	m.Manifest = manifest
+	return nil
+}
+
+// MarshalJSON returns the contents of canonical.
+func (m *DeserializedManifest) MarshalJSON() ([]byte, error) {
+	if len(m.canonical) > 0 {
+		return m.canonical, nil
+	}
+	return nil, errors.New("JSON representation not initialized in DeserializedManifest")
+}
+
+// Payload returns the raw content of the manifest. The media type is optional in OCI manifests, so the
+// constant one is returned.
+func (m DeserializedManifest) Payload() (string, []byte, error) {
+	return MediaTypeImageManifest, m.canonical, nil
+}
+
+// Index defines an OCI image index, which references the image manifests of various platforms. It has the same
+// structure as a Docker manifest list.
+type Index struct {
+	manifestlist.ManifestList
+
+	// Annotations contains arbitrary metadata of the index.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DeserializedIndex wraps Index with a copy of the original JSON.
+type DeserializedIndex struct {
+	Index
+
+	// canonical is the canonical byte representation of the Index.
+	canonical []byte
+}
+
+// FromDescriptors returns a DeserializedIndex referencing the given manifests and its JSON representation.
+func FromDescriptors(descriptors []manifestlist.ManifestDescriptor) (*DeserializedIndex, error) {
+	index := Index{
+		ManifestList: manifestlist.ManifestList{
+			Versioned: manifest.Versioned{SchemaVersion: 2, MediaType: MediaTypeImageIndex},
+			Manifests: descriptors,
+		},
+	}
+	deserialized := DeserializedIndex{Index: index}
+	var err error
+	deserialized.canonical, err = json.MarshalIndent(&index, "", "   ")
+	return &deserialized, err
+}
+
+// UnmarshalJSON populates a new Index struct from JSON data.
+func (m *DeserializedIndex) UnmarshalJSON(b []byte) error {
+	m.canonical = make([]byte, len(b), len(b))
+	copy(m.canonical, b)
+
+	var index Index
+	if err := json.Unmarshal(m.canonical, &index); err != nil {
+		return err
+	}
+	if index.SchemaVersion != 2 {
+		return fmt.Errorf("unsupported OCI image index schema version %d", index.SchemaVersion)
+	}
+	if len(index.MediaType) > 0 && index.MediaType != MediaTypeImageIndex {
+		return fmt.Errorf("unexpected media type %q of an OCI image index", index.MediaType)
+	}
+	m.Index = index
+	return nil
+}
+
+// MarshalJSON returns the contents of canonical.
+func (m *DeserializedIndex) MarshalJSON() ([]byte, error) {
+	if len(m.canonical) > 0 {
+		return m.canonical, nil
+	}
+	return nil, errors.New("JSON representation not initialized in DeserializedIndex")
+}
+
+// Payload returns the raw content of the index. The media type is optional in OCI indexes, so the constant one
+// is returned.
+func (m DeserializedIndex) Payload() (string, []byte, error) {
+	return MediaTypeImageIndex, m.canonical, nil
+}
@@ -0,0 +1,132 @@
+package ocischema
+
+import (
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
+)
+
+const testManifest = `{
+   "schemaVersion": 2,
+   "config": {
+      "mediaType": "application/vnd.oci.image.config.v1+json",
+      "size": 7023,
+      "digest": "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.oci.image.layer.v1.tar+gzip",
+         "size": 32654,
+         "digest": "sha256:9834876dcfb05cb167a5c24953eba58c4ac89b1adf57f28f2f9d09af107ee8f0"
+      }
+   ],
+   "annotations": {
+      "org.opencontainers.image.version": "1.0"
+   }
+}`
+
+const testIndex = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.oci.image.index.v1+json",
+   "manifests": [
+      {
+         "mediaType": "application/vnd.oci.image.manifest.v1+json",
+         "size": 7143,
+         "digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
+         "platform": {
+            "architecture": "ppc64le",
+            "os": "linux"
+         }
+      },
+      {
+         "mediaType": "application/vnd.oci.image.manifest.v1+json",
+         "size": 7682,
+         "digest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270",
+         "platform": {
+            "architecture": "amd64",
+            "os": "linux"
+         }
+      }
+   ]
+}`
+
+func TestUnmarshalManifest(t *testing.T) {
+	m, desc, err := distribution.UnmarshalManifest(MediaTypeImageManifest, []byte(testManifest))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manifest, ok := m.(*DeserializedManifest)
+	if !ok {
+		t.Fatalf("unexpected manifest type %T", m)
+	}
+	if desc.Digest != digest.FromBytes([]byte(testManifest)) || desc.MediaType != MediaTypeImageManifest {
+		t.Errorf("unexpected descriptor: %#v", desc)
+	}
+	if refs := manifest.References(); len(refs) != 2 || refs[0].Digest != manifest.Config.Digest {
+		t.Errorf("unexpected references: %#v", refs)
+	}
+	if manifest.Annotations["org.opencontainers.image.version"] != "1.0" {
+		t.Errorf("unexpected annotations: %#v", manifest.Annotations)
+	}
+	mediaType, payload, err := manifest.Payload()
+	if err != nil || mediaType != MediaTypeImageManifest || string(payload) != testManifest {
+		t.Errorf("unexpected payload %q of type %q: %v", string(payload), mediaType, err)
+	}
+}
+
+func TestUnmarshalIndex(t *testing.T) {
+	m, _, err := distribution.UnmarshalManifest(MediaTypeImageIndex, []byte(testIndex))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	index, ok := m.(*DeserializedIndex)
+	if !ok {
+		t.Fatalf("unexpected manifest type %T", m)
+	}
+	if len(index.Manifests) != 2 || index.Manifests[1].Platform.Architecture != "amd64" {
+		t.Errorf("unexpected manifests: %#v", index.Manifests)
+	}
+	if refs := index.References(); len(refs) != 2 || refs[0].Digest != index.Manifests[0].Digest {
+		t.Errorf("unexpected references: %#v", refs)
+	}
+	_, payload, err := index.Payload()
+	if err != nil || string(payload) != testIndex {
+		t.Errorf("unexpected payload %q: %v", string(payload), err)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mediaType string
+		content   string
+	}{
+		"manifest of wrong schema version": {MediaTypeImageManifest, `{"schemaVersion": 1}`},
+		"manifest of wrong media type":     {MediaTypeImageManifest, `{"schemaVersion": 2, "mediaType": "application/json"}`},
+		"index of wrong schema version":    {MediaTypeImageIndex, `{"schemaVersion": 3}`},
+		"index of wrong media type":        {MediaTypeImageIndex, `{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.manifest.v1+json"}`},
+	} {
+		if _, _, err := distribution.UnmarshalManifest(tc.mediaType, []byte(tc.content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFromDescriptors(t *testing.T) {
+	index, err := FromDescriptors([]manifestlist.ManifestDescriptor{{
+		Descriptor: distribution.Descriptor{MediaType: MediaTypeImageManifest, Digest: digest.FromBytes([]byte("{}")), Size: 2},
+		Platform:   manifestlist.PlatformSpec{Architecture: "amd64", OS: "linux"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, payload, _ := index.Payload()
+	m, _, err := distribution.UnmarshalManifest(MediaTypeImageIndex, payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refs := m.References(); len(refs) != 1 || refs[0].Digest != digest.FromBytes([]byte("{}")) {
+		t.Errorf("unexpected references: %#v", refs)
+	}
+}
@@ -42,6 +42,12 @@ const (
 	// ReferencedImageLayerEdgeKind defines an edge from an ImageStreamNode or an
 	// ImageNode to an ImageLayerNode.
 	ReferencedImageLayerEdgeKind = "ReferencedImageLayer"
+
+	// ReferencedManifestEdgeKind defines an edge from the ImageNode of a
+	// manifest list to the ImageNode of a platform image it references. The
+	// platform image is only a candidate for pruning if the manifest list is
+	// prunable too.
+	ReferencedManifestEdgeKind = "ReferencedManifest"
 )
 
 // pruneAlgorithm contains the various settings to use when evaluating images
@@ -88,6 +94,14 @@ type ManifestPruner interface {
 	PruneManifest(registryClient *http.Client, registryURL, repo, manifest string) error
 }
 
+// RegistryGarbageCollector knows how to make the Docker registry delete the
+// blobs and manifests that no image references anymore.
+type RegistryGarbageCollector interface {
+	// CollectGarbage uses registryClient to ask the registry at registryURL to
+	// run its garbage collector.
+	CollectGarbage(registryClient *http.Client, registryURL string) error
+}
+
 // ImageRegistryPrunerOptions contains the fields used to initialize a new
 // ImageRegistryPruner.
 type ImageRegistryPrunerOptions struct {
@@ -265,28 +279,47 @@ func NewImageRegistryPruner(options ImageRegistryPrunerOptions) ImageRegistryPru
 	}
 }
 
+// PrunableImages returns the images of options that are candidates for pruning
+// and that none of the objects of options reference, as determined by the
+// algorithm described in NewImageRegistryPruner. The registry is not contacted
+// and nothing is removed.
+func PrunableImages(options ImageRegistryPrunerOptions) []*imageapi.Image {
+	options.DryRun = true
+	p := NewImageRegistryPruner(options).(*imageRegistryPruner)
+	prunableImageNodes, _ := calculatePrunableImages(p.g, getImageNodes(p.g.Nodes()))
+	images := []*imageapi.Image{}
+	for _, imageNode := range prunableImageNodes {
+		images = append(images, imageNode.Image)
+	}
+	return images
+}
+
 // addImagesToGraph adds all images to the graph that belong to one of the
 // registries in the algorithm and are at least as old as the minimum age
 // threshold as specified by the algorithm. It also adds all the images' layers
-// to the graph.
+// to the graph, and edges from manifest lists to their platform images. The
+// platform images of manifest lists that are not added to the graph are not
+// added either, so they are never pruned while the manifest list exists.
 func addImagesToGraph(g graph.Graph, images *imageapi.ImageList, algorithm pruneAlgorithm) {
+	candidates := []*imageapi.Image{}
+	kept := sets.NewString()
 	for i := range images.Items {
 		image := &images.Items[i]
 
 		glog.V(4).Infof("Examining image %q", image.Name)
 
-		if image.Annotations == nil {
-			glog.V(4).Infof("Image %q with DockerImageReference %q belongs to an external registry - skipping", image.Name, image.DockerImageReference)
+		if isPruningCandidate(image, algorithm) {
+			candidates = append(candidates, image)
 			continue
 		}
-		if value, ok := image.Annotations[imageapi.ManagedByOpenShiftAnnotation]; !ok || value != "true" {
-			glog.V(4).Infof("Image %q with DockerImageReference %q belongs to an external registry - skipping", image.Name, image.DockerImageReference)
-			continue
+		for _, m := range image.DockerImageManifests {
+			kept.Insert(m.Digest)
 		}
+	}
 
-		age := unversioned.Now().Sub(image.CreationTimestamp.Time)
-		if age < algorithm.keepYoungerThan {
-			glog.V(4).Infof("Image %q is younger than minimum pruning age, skipping (age=%v)", image.Name, age)
+	for _, image := range candidates {
+		if kept.Has(image.Name) {
+			glog.V(4).Infof("Image %q is referenced by a manifest list that is not eligible for pruning, skipping", image.Name)
 			continue
 		}
 
@@ -305,6 +338,41 @@ func addImagesToGraph(g graph.Graph, images *imageapi.ImageList, algorithm prune
 			g.AddEdge(imageNode, layerNode, ReferencedImageLayerEdgeKind)
 		}
 	}
+
+	for _, image := range candidates {
+		listNode := imagegraph.FindImage(g, image.Name)
+		if listNode == nil {
+			continue
+		}
+		for _, m := range image.DockerImageManifests {
+			platformNode := imagegraph.FindImage(g, m.Digest)
+			if platformNode == nil {
+				continue
+			}
+			glog.V(4).Infof("Adding edge from manifest list %q to platform image %q", image.Name, m.Digest)
+			g.AddEdge(listNode, platformNode, ReferencedManifestEdgeKind)
+		}
+	}
+}
+
+// isPruningCandidate returns true if the image is managed by OpenShift and is
+// at least as old as the minimum age threshold of the algorithm.
+func isPruningCandidate(image *imageapi.Image, algorithm pruneAlgorithm) bool {
+	if image.Annotations == nil {
+		glog.V(4).Infof("Image %q with DockerImageReference %q belongs to an external registry - skipping", image.Name, image.DockerImageReference)
+		return false
+	}
+	if value, ok := image.Annotations[imageapi.ManagedByOpenShiftAnnotation]; !ok || value != "true" {
+		glog.V(4).Infof("Image %q with DockerImageReference %q belongs to an external registry - skipping", image.Name, image.DockerImageReference)
+		return false
+	}
+
+	age := unversioned.Now().Sub(image.CreationTimestamp.Time)
+	if age < algorithm.keepYoungerThan {
+		glog.V(4).Infof("Image %q is younger than minimum pruning age, skipping (age=%v)", image.Name, age)
+		return false
+	}
+	return true
 }
 
 // addImageStreamsToGraph adds all the streams to the graph. The most recent n
@@ -364,12 +432,14 @@ func addImageStreamsToGraph(g graph.Graph, streams *imageapi.ImageStreamList, al
 
 				glog.V(4).Infof("Adding stream->layer references")
 				// add stream -> layer references so we can prune them later
-				for _, s := range g.From(imageNode) {
-					if g.Kind(s) != imagegraph.ImageLayerNodeKind {
-						continue
+				for _, n := range append([]gonum.Node{imageNode}, platformImages(g, imageNode)...) {
+					for _, s := range g.From(n) {
+						if g.Kind(s) != imagegraph.ImageLayerNodeKind {
+							continue
+						}
+						glog.V(4).Infof("Adding reference from stream %q to layer %q", stream.Name, s.(*imagegraph.ImageLayerNode).Layer)
+						g.AddEdge(imageStreamNode, s, ReferencedImageLayerEdgeKind)
 					}
-					glog.V(4).Infof("Adding reference from stream %q to layer %q", stream.Name, s.(*imagegraph.ImageLayerNode).Layer)
-					g.AddEdge(imageStreamNode, s, ReferencedImageLayerEdgeKind)
 				}
 			}
 		}
@@ -554,16 +624,37 @@ func edgeKind(g graph.Graph, from, to gonum.Node, desiredKind string) bool {
 	return kinds.Has(desiredKind)
 }
 
+// platformImages returns the nodes of the platform images referenced by the
+// image node if it is a manifest list.
+func platformImages(g graph.Graph, imageNode gonum.Node) []gonum.Node {
+	ret := []gonum.Node{}
+	for _, n := range g.From(imageNode) {
+		if edgeKind(g, imageNode, n, ReferencedManifestEdgeKind) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
 // imageIsPrunable returns true iff the image node only has weak references
 // from its predecessors to it. A weak reference to an image is a reference
 // from an image stream to an image where the image is not the current image
 // for a tag and the image stream is at least as old as the minimum pruning
-// age.
+// age. A platform image is only referenced weakly by its manifest lists if
+// they are prunable.
 func imageIsPrunable(g graph.Graph, imageNode *imagegraph.ImageNode) bool {
 	onlyWeakReferences := true
 
 	for _, n := range g.To(imageNode) {
 		glog.V(4).Infof("Examining predecessor %#v", n)
+		if edgeKind(g, n, imageNode, ReferencedManifestEdgeKind) {
+			if imageIsPrunable(g, n.(*imagegraph.ImageNode)) {
+				continue
+			}
+			glog.V(4).Infof("Image is referenced by a manifest list that is not prunable")
+			onlyWeakReferences = false
+			break
+		}
 		if !edgeKind(g, n, imageNode, WeakReferencedImageEdgeKind) {
 			glog.V(4).Infof("Strong reference detected")
 			onlyWeakReferences = false
@@ -841,12 +932,7 @@ func pruneManifests(g graph.Graph, registryClient *http.Client, registryURL stri
 	errs := []error{}
 
 	for _, imageNode := range imageNodes {
-		for _, n := range g.To(imageNode) {
-			streamNode, ok := n.(*imagegraph.ImageStreamNode)
-			if !ok {
-				continue
-			}
-
+		for _, streamNode := range imageStreams(g, imageNode) {
 			stream := streamNode.ImageStream
 			repoName := fmt.Sprintf("%s/%s", stream.Namespace, stream.Name)
 
@@ -860,6 +946,28 @@ func pruneManifests(g graph.Graph, registryClient *http.Client, registryURL stri
 	return errs
 }
 
+// imageStreams returns the streams referencing the image node, directly or
+// through the manifest lists referencing it, each stream once.
+func imageStreams(g graph.Graph, imageNode *imagegraph.ImageNode) []*imagegraph.ImageStreamNode {
+	ret := []*imagegraph.ImageStreamNode{}
+	seen := make(graph.NodeSet)
+	predecessors := g.To(imageNode)
+	for _, n := range g.To(imageNode) {
+		if edgeKind(g, n, imageNode, ReferencedManifestEdgeKind) {
+			predecessors = append(predecessors, g.To(n)...)
+		}
+	}
+	for _, n := range predecessors {
+		streamNode, ok := n.(*imagegraph.ImageStreamNode)
+		if !ok || seen.Has(n.ID()) {
+			continue
+		}
+		seen.Add(n.ID())
+		ret = append(ret, streamNode)
+	}
+	return ret
+}
+
 // deletingImagePruner deletes an image from OpenShift.
 type deletingImagePruner struct {
 	images client.ImageInterface
@@ -1008,3 +1116,58 @@ func (p *deletingManifestPruner) PruneManifest(registryClient *http.Client, regi
 	glog.V(4).Infof("Pruning manifest for registry %q, repo %q, manifest %q", registryURL, repoName, manifest)
 	return deleteFromRegistry(registryClient, fmt.Sprintf("%s/v2/%s/manifests/%s", registryURL, repoName, manifest))
 }
+
+// collectingRegistryGarbageCollector runs the garbage collector of the registry.
+type collectingRegistryGarbageCollector struct {
+}
+
+var _ RegistryGarbageCollector = &collectingRegistryGarbageCollector{}
+
+// NewRegistryGarbageCollector creates a new collectingRegistryGarbageCollector.
+func NewRegistryGarbageCollector() RegistryGarbageCollector {
+	return &collectingRegistryGarbageCollector{}
+}
+
+func (c *collectingRegistryGarbageCollector) CollectGarbage(registryClient *http.Client, registryURL string) error {
+	glog.V(4).Infof("Collecting garbage in registry %q", registryURL)
+	return postToRegistry(registryClient, fmt.Sprintf("%s/admin/garbagecollect?dryRun=false", registryURL))
+}
+
+// postToRegistry uses registryClient to send a POST request without a body to
+// the provided url. It attempts an https request first; if that fails, it
+// falls back to http.
+func postToRegistry(registryClient *http.Client, url string) error {
+	postFunc := func(url string) error {
+		resp, err := registryClient.Post(url, "", nil)
+		if err != nil {
+			return fmt.Errorf("error sending request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+			return nil
+		}
+		var response errcode.Errors
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || len(response) == 0 {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		return &response
+	}
+
+	var err error
+	for _, proto := range []string{"https", "http"} {
+		glog.V(4).Infof("Trying %s for %s", proto, url)
+		err = postFunc(fmt.Sprintf("%s://%s", proto, url))
+		if err == nil {
+			return nil
+		}
+
+		if _, ok := err.(*errcode.Errors); ok {
+			// we got a response back from the registry, so return it
+			return err
+		}
+
+		glog.V(4).Infof("Error with %s for %s: %v", proto, url, err)
+	}
+	return err
+}
@@ -90,6 +90,17 @@ func imageWithLayers(id, ref string, layers ...string) imageapi.Image {
 	return image
 }
 
+func manifestList(id, ref string, ageInMinutes int64, platformImages ...string) imageapi.Image {
+	image := imageWithLayers(id, ref)
+	if ageInMinutes >= 0 {
+		image.CreationTimestamp = unversioned.NewTime(unversioned.Now().Add(time.Duration(-1*ageInMinutes) * time.Minute))
+	}
+	for _, platformImage := range platformImages {
+		image.DockerImageManifests = append(image.DockerImageManifests, imageapi.ImageManifest{Digest: platformImage})
+	}
+	return image
+}
+
 func unmanagedImage(id, ref string, hasAnnotations bool, annotation, value string) imageapi.Image {
 	image := imageWithLayers(id, ref)
 	if !hasAnnotations {
@@ -656,6 +667,60 @@ func TestImagePruning(t *testing.T) {
 			expectedDeletions:      []string{},
 			expectedUpdatedStreams: []string{},
 		},
+		"manifest list referenced by stream - don't prune platform images": {
+			images: imageList(
+				manifestList("id", registryURL+"/foo/bar@id", -1, "id2", "id3"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/bar@id3"),
+			),
+			streams: streamList(
+				stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+					),
+				)),
+			),
+			expectedDeletions:      []string{},
+			expectedUpdatedStreams: []string{},
+		},
+		"manifest list referenced by pod - don't prune platform images": {
+			images: imageList(
+				manifestList("id", registryURL+"/foo/bar@id", -1, "id2"),
+				image("id2", registryURL+"/foo/bar@id2"),
+			),
+			pods:              podList(pod("foo", "pod1", kapi.PodRunning, registryURL+"/foo/bar@id")),
+			expectedDeletions: []string{},
+		},
+		"manifest list less than min pruning age - don't prune platform images": {
+			images: imageList(
+				manifestList("id", registryURL+"/foo/bar@id", 5, "id2"),
+				image("id2", registryURL+"/foo/bar@id2"),
+			),
+			expectedDeletions: []string{},
+		},
+		"unreferenced manifest list - prune with platform images": {
+			images: imageList(
+				manifestList("id", registryURL+"/foo/bar@id", -1, "id2"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/bar@id3"),
+			),
+			expectedDeletions: []string{"id", "id2", "id3"},
+		},
+		"platform image referenced by stream - prune manifest list only": {
+			images: imageList(
+				manifestList("id", registryURL+"/foo/bar@id", -1, "id2"),
+				image("id2", registryURL+"/foo/bar@id2"),
+			),
+			streams: streamList(
+				stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+					),
+				)),
+			),
+			expectedDeletions:      []string{"id"},
+			expectedUpdatedStreams: []string{},
+		},
 		"image with bad manifest is pruned ok": {
 			images: imageList(
 				imageWithBadManifest("id", "someregistry/foo/bar@id"),
@@ -847,6 +912,47 @@ func TestRegistryPruning(t *testing.T) {
 			),
 			expectedManifestDeletions: sets.NewString(),
 		},
+		"layers and manifests of platform images pruned with their manifest list": {
+			images: imageList(
+				manifestList("list1", "registry1/foo/bar@list1", -1, "id1"),
+				imageWithLayers("id1", "registry1/foo/bar@id1", "layer1", "layer2"),
+				imageWithLayers("id2", "registry1/foo/bar@id2", "layer2", "layer3"),
+			),
+			streams: streamList(
+				stream("registry1", "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id2", "registry1/foo/bar@id2"),
+						tagEvent("list1", "registry1/foo/bar@list1"),
+					),
+				)),
+			),
+			expectedLayerDeletions: sets.NewString(
+				"registry1|foo/bar|layer1",
+			),
+			expectedBlobDeletions: sets.NewString(
+				"registry1|layer1",
+			),
+			expectedManifestDeletions: sets.NewString(
+				"registry1|foo/bar|list1",
+				"registry1|foo/bar|id1",
+			),
+		},
+		"platform images kept with their manifest list": {
+			images: imageList(
+				manifestList("list1", "registry1/foo/bar@list1", -1, "id1"),
+				imageWithLayers("id1", "registry1/foo/bar@id1", "layer1", "layer2"),
+			),
+			streams: streamList(
+				stream("registry1", "foo", "bar", tags(
+					tag("latest",
+						tagEvent("list1", "registry1/foo/bar@list1"),
+					),
+				)),
+			),
+			expectedLayerDeletions:    sets.NewString(),
+			expectedBlobDeletions:     sets.NewString(),
+			expectedManifestDeletions: sets.NewString(),
+		},
 		"ping error": {
 			images: imageList(
 				imageWithLayers("id1", "registry1/foo/bar@id1", "layer1", "layer2", "layer3", "layer4"),
@@ -64,8 +64,9 @@ func (imageStrategy) Canonicalize(obj runtime.Object) {
 // PrepareForUpdate clears fields that are not allowed to be set by end users on update.
 // It extracts the latest info from the manifest and sets that on the object. It allows a user
 // to update the manifest so that it matches the digest (in case an older server stored a manifest
-// that was malformed, it can always be corrected).
-func (imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
+// that was malformed, it can always be corrected). Signatures may be added or removed, but their
+// details are managed by the server.
+func (s imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newImage := obj.(*api.Image)
 	oldImage := old.(*api.Image)
 
@@ -73,7 +74,7 @@ func (imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newImage.DockerImageMetadata = oldImage.DockerImageMetadata
 	newImage.DockerImageMetadataVersion = oldImage.DockerImageMetadataVersion
 	newImage.DockerImageLayers = oldImage.DockerImageLayers
-	newImage.Signatures = oldImage.Signatures
+	s.clearSignatureDetails(newImage)
 
 	if oldImage.DockerImageSignatures != nil {
 		newImage.DockerImageSignatures = nil
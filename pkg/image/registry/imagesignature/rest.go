@@ -0,0 +1,105 @@
+package imagesignature
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/registry/image"
+)
+
+// REST implements the RESTStorage interface in terms of an image registry. It
+// only supports the Create and Delete methods. Signatures are stored as a part
+// of the image they belong to.
+type REST struct {
+	imageRegistry image.Registry
+}
+
+// NewREST returns a new REST.
+func NewREST(imageRegistry image.Registry) *REST {
+	return &REST{imageRegistry: imageRegistry}
+}
+
+// New returns a new ImageSignature for use with Create.
+func (r *REST) New() runtime.Object {
+	return &api.ImageSignature{}
+}
+
+// Create adds the signature to the image it is named after. A signature with
+// the same name must not exist yet.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	if err := rest.BeforeCreate(Strategy, ctx, obj); err != nil {
+		return nil, err
+	}
+
+	signature := obj.(*api.ImageSignature)
+	imageName, _, err := api.SplitImageSignatureName(signature.Name)
+	if err != nil {
+		return nil, kapierrors.NewBadRequest(err.Error())
+	}
+
+	var created *api.ImageSignature
+	err = kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		image, err := r.imageRegistry.GetImage(ctx, imageName)
+		if err != nil {
+			return err
+		}
+
+		if api.IndexOfImageSignatureByName(image.Signatures, signature.Name) >= 0 {
+			return kapierrors.NewAlreadyExists(api.Resource("imagesignatures"), signature.Name)
+		}
+
+		image.Signatures = append(image.Signatures, *signature)
+		image, err = r.imageRegistry.UpdateImage(ctx, image)
+		if err != nil {
+			return err
+		}
+
+		index := api.IndexOfImageSignatureByName(image.Signatures, signature.Name)
+		if index < 0 {
+			return kapierrors.NewInternalError(fmt.Errorf("signature %q was not stored on image %q", signature.Name, imageName))
+		}
+		created = &image.Signatures[index]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Delete removes the signature from the image it is named after.
+func (r *REST) Delete(ctx kapi.Context, name string) (runtime.Object, error) {
+	imageName, _, err := api.SplitImageSignatureName(name)
+	if err != nil {
+		return nil, kapierrors.NewBadRequest(err.Error())
+	}
+
+	err = kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		image, err := r.imageRegistry.GetImage(ctx, imageName)
+		if err != nil {
+			return err
+		}
+
+		index := api.IndexOfImageSignatureByName(image.Signatures, name)
+		if index < 0 {
+			return kapierrors.NewNotFound(api.Resource("imagesignatures"), name)
+		}
+
+		image.Signatures = append(image.Signatures[:index], image.Signatures[index+1:]...)
+		_, err = r.imageRegistry.UpdateImage(ctx, image)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
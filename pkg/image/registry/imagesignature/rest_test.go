@@ -0,0 +1,116 @@
+package imagesignature
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/watch"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+// fakeImageRegistry keeps a single image in memory.
+type fakeImageRegistry struct {
+	image   *api.Image
+	updates int
+}
+
+func (f *fakeImageRegistry) ListImages(ctx kapi.Context, options *kapi.ListOptions) (*api.ImageList, error) {
+	return &api.ImageList{Items: []api.Image{*f.image}}, nil
+}
+
+func (f *fakeImageRegistry) GetImage(ctx kapi.Context, id string) (*api.Image, error) {
+	if f.image == nil || f.image.Name != id {
+		return nil, kapierrors.NewNotFound(api.Resource("images"), id)
+	}
+	copied := *f.image
+	copied.Signatures = append([]api.ImageSignature(nil), f.image.Signatures...)
+	return &copied, nil
+}
+
+func (f *fakeImageRegistry) CreateImage(ctx kapi.Context, image *api.Image) error {
+	f.image = image
+	return nil
+}
+
+func (f *fakeImageRegistry) UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error) {
+	f.updates++
+	f.image = image
+	return image, nil
+}
+
+func (f *fakeImageRegistry) DeleteImage(ctx kapi.Context, id string) error {
+	f.image = nil
+	return nil
+}
+
+func (f *fakeImageRegistry) WatchImages(ctx kapi.Context, options *kapi.ListOptions) (watch.Interface, error) {
+	return nil, nil
+}
+
+func newSignature(name string) *api.ImageSignature {
+	return &api.ImageSignature{
+		ObjectMeta:    kapi.ObjectMeta{Name: name},
+		Type:          api.ImageSignatureTypeJWSImageV1,
+		Content:       []byte("content"),
+		ImageIdentity: "set by client",
+	}
+}
+
+func TestCreate(t *testing.T) {
+	registry := &fakeImageRegistry{image: &api.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc"}}}
+	storage := NewREST(registry)
+	ctx := kapi.NewContext()
+
+	obj, err := storage.Create(ctx, newSignature("sha256:abc@first"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := obj.(*api.ImageSignature)
+	if created.Name != "sha256:abc@first" {
+		t.Errorf("unexpected name: %s", created.Name)
+	}
+	if len(created.ImageIdentity) != 0 {
+		t.Errorf("expected image identity to be cleared, got %q", created.ImageIdentity)
+	}
+	if len(registry.image.Signatures) != 1 {
+		t.Fatalf("expected the signature to be stored on the image, got %#v", registry.image.Signatures)
+	}
+
+	if _, err := storage.Create(ctx, newSignature("sha256:abc@first")); !kapierrors.IsAlreadyExists(err) {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+	if _, err := storage.Create(ctx, newSignature("sha256:missing@first")); !kapierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := storage.Create(ctx, newSignature("sha256:abc")); !kapierrors.IsInvalid(err) {
+		t.Errorf("expected invalid error, got %v", err)
+	}
+	if registry.updates != 1 {
+		t.Errorf("expected exactly one image update, got %d", registry.updates)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	registry := &fakeImageRegistry{image: &api.Image{
+		ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc"},
+		Signatures: []api.ImageSignature{*newSignature("sha256:abc@first"), *newSignature("sha256:abc@second")},
+	}}
+	storage := NewREST(registry)
+	ctx := kapi.NewContext()
+
+	if _, err := storage.Delete(ctx, "sha256:abc@first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(registry.image.Signatures) != 1 || registry.image.Signatures[0].Name != "sha256:abc@second" {
+		t.Errorf("unexpected signatures: %#v", registry.image.Signatures)
+	}
+	if _, err := storage.Delete(ctx, "sha256:abc@first"); !kapierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := storage.Delete(ctx, "sha256:abc"); !kapierrors.IsBadRequest(err) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package imagesignature
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/api/validation"
+)
+
+// strategy implements behavior for ImageSignatures.
+type strategy struct {
+	runtime.ObjectTyper
+}
+
+// Strategy is the default logic that applies when creating ImageSignature
+// objects via the REST API.
+var Strategy = &strategy{kapi.Scheme}
+
+// NamespaceScoped is false for image signatures.
+func (s *strategy) NamespaceScoped() bool {
+	return false
+}
+
+// GenerateName is not supported, the name of a signature must be provided by the client.
+func (s *strategy) GenerateName(string) string {
+	return ""
+}
+
+func (s *strategy) Canonicalize(runtime.Object) {
+}
+
+// PrepareForCreate clears fields that are set by the server once the signature content is parsed.
+func (s *strategy) PrepareForCreate(obj runtime.Object) {
+	signature := obj.(*api.ImageSignature)
+	signature.Conditions = nil
+	signature.ImageIdentity = ""
+	signature.SignedClaims = nil
+	signature.Created = nil
+	signature.IssuedBy = nil
+	signature.IssuedTo = nil
+}
+
+// Validate validates a new image signature.
+func (s *strategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	signature := obj.(*api.ImageSignature)
+	return validation.ValidateImageSignature(signature)
+}
@@ -6,6 +6,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/registry/image"
@@ -58,12 +59,20 @@ func (r *REST) Get(ctx kapi.Context, id string) (runtime.Object, error) {
 		return nil, errors.NewNotFound(api.Resource("imagestreamimage"), imageID)
 	}
 
+	var imageName string
 	event, err := api.ResolveImageID(repo, imageID)
-	if err != nil {
+	switch {
+	case err == nil:
+		imageName = event.Image
+	case errors.IsNotFound(err):
+		// images of the manifests referenced by a manifest list are not tagged, but can be pulled by digest
+		if imageName = r.findListedImage(ctx, repo, imageID); len(imageName) == 0 {
+			return nil, err
+		}
+	default:
 		return nil, err
 	}
 
-	imageName := event.Image
 	image, err := r.imageRegistry.GetImage(ctx, imageName)
 	if err != nil {
 		return nil, err
@@ -90,3 +99,30 @@ func (r *REST) Get(ctx kapi.Context, id string) (runtime.Object, error) {
 
 	return &isi, nil
 }
+
+// findListedImage returns imageID if it is the full digest of a manifest referenced by a manifest list tagged in
+// the image stream, or an empty string otherwise.
+func (r *REST) findListedImage(ctx kapi.Context, stream *api.ImageStream, imageID string) string {
+	if _, err := digest.ParseDigest(imageID); err != nil {
+		return ""
+	}
+	checked := sets.NewString()
+	for _, history := range stream.Status.Tags {
+		for _, tagging := range history.Items {
+			if checked.Has(tagging.Image) {
+				continue
+			}
+			checked.Insert(tagging.Image)
+			image, err := r.imageRegistry.GetImage(ctx, tagging.Image)
+			if err != nil {
+				continue
+			}
+			for _, m := range image.DockerImageManifests {
+				if m.Digest == imageID {
+					return imageID
+				}
+			}
+		}
+	}
+	return ""
+}
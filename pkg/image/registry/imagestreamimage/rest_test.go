@@ -250,3 +250,56 @@ func TestGet(t *testing.T) {
 		}
 	}
 }
+
+func TestGetListedImage(t *testing.T) {
+	client, server, storage := setup(t)
+	defer server.Terminate(t)
+
+	listDigest := "sha256:90bb15d6bbd6ba6bd82e0feb3ccd5d1fd8ae8a48a2e35fec0ad8f4bed18a7d05"
+	platformDigest := "sha256:6d6b0b9e4f1fdbb9cfb8d4bbc0cd3dcb5e2ae0b66b4d6c0d6d14f25d22b7b3af"
+	repo := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "repo"},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"latest": {Items: []api.TagEvent{{Image: listDigest}}},
+			},
+		},
+	}
+	images := []*api.Image{
+		{
+			ObjectMeta:           kapi.ObjectMeta{Name: listDigest},
+			DockerImageManifests: []api.ImageManifest{{Digest: platformDigest, Architecture: "amd64", OS: "linux"}},
+		},
+		{
+			ObjectMeta:          kapi.ObjectMeta{Name: platformDigest},
+			DockerImageMetadata: api.DockerImage{Architecture: "amd64"},
+		},
+	}
+	objects := map[string]runtime.Object{"/imagestreams/ns/repo": repo}
+	for _, image := range images {
+		objects["/images/"+image.Name] = image
+	}
+	for key, obj := range objects {
+		if _, err := client.Create(context.TODO(), etcdtest.AddPrefix(key), runtime.EncodeOrDie(kapi.Codecs.LegacyCodec(v1.SchemeGroupVersion), obj)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	ctx := kapi.WithNamespace(kapi.NewContext(), "ns")
+	obj, err := storage.Get(ctx, "repo@"+platformDigest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	imageStreamImage := obj.(*api.ImageStreamImage)
+	if e, a := "repo@6d6b0b9", imageStreamImage.Name; e != a {
+		t.Errorf("name: expected %q, got %q", e, a)
+	}
+	if e, a := platformDigest, imageStreamImage.Image.Name; e != a {
+		t.Errorf("image: expected %q, got %q", e, a)
+	}
+
+	// only full digests of listed manifests are resolved
+	if _, err := storage.Get(ctx, "repo@6d6b0b9"); err == nil {
+		t.Errorf("expected an error for a prefix of a listed image")
+	}
+}
@@ -200,6 +200,7 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 			// we've imported a set of tags, ensure spec tag will point to this for later imports
 			from.ID, from.Tag = "", tag
 
+			r.importManifests(ctx, status.Manifests, importedImages, updatedImages)
 			if updated, ok := r.importSuccessful(ctx, image, stream, tag, from.Exact(), nextGeneration, now, spec.ImportPolicy, importedImages, updatedImages); ok {
 				isi.Status.Repository.Images[i].Image = updated
 			}
@@ -222,6 +223,7 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 
 		// record success
 		image := status.Image
+		r.importManifests(ctx, status.Manifests, importedImages, updatedImages)
 		if updated, ok := r.importSuccessful(ctx, image, stream, tag, spec.From.Name, nextGeneration, now, spec.ImportPolicy, importedImages, updatedImages); ok {
 			isi.Status.Images[i].Image = updated
 		}
@@ -391,24 +393,54 @@ func (r *REST) importSuccessful(
 	return nil, false
 }
 
+// importManifests ensures the images of the platform specific manifests referenced by an imported manifest list
+// exist, so that they can be pulled by digest. Like in importSuccessful, a failure to persist an image is recorded
+// in importedImages.
+func (r *REST) importManifests(ctx kapi.Context, images []api.Image, importedImages map[string]error, updatedImages map[string]*api.Image) {
+	for i := range images {
+		image := &images[i]
+		if _, alreadyImported := importedImages[image.Name]; alreadyImported {
+			continue
+		}
+		updated, err := r.images.Create(ctx, image)
+		switch {
+		case kapierrors.IsAlreadyExists(err):
+			updated = image
+			fallthrough
+		case err == nil:
+			updatedImages[image.Name] = updated.(*api.Image)
+			importedImages[image.Name] = nil
+		default:
+			importedImages[image.Name] = err
+		}
+	}
+}
+
 // clearManifests unsets the manifest for each object that does not request it
 func clearManifests(isi *api.ImageStreamImport) {
 	for i := range isi.Status.Images {
 		if !isi.Spec.Images[i].IncludeManifest {
-			if isi.Status.Images[i].Image != nil {
-				isi.Status.Images[i].Image.DockerImageManifest = ""
-			}
+			clearImportStatusManifests(&isi.Status.Images[i])
 		}
 	}
 	if isi.Spec.Repository != nil && !isi.Spec.Repository.IncludeManifest {
 		for i := range isi.Status.Repository.Images {
-			if isi.Status.Repository.Images[i].Image != nil {
-				isi.Status.Repository.Images[i].Image.DockerImageManifest = ""
-			}
+			clearImportStatusManifests(&isi.Status.Repository.Images[i])
 		}
 	}
 }
 
+// clearImportStatusManifests unsets the manifest of the imported image and of the images its manifest list
+// references.
+func clearImportStatusManifests(status *api.ImageImportStatus) {
+	if status.Image != nil {
+		status.Image.DockerImageManifest = ""
+	}
+	for i := range status.Manifests {
+		status.Manifests[i].DockerImageManifest = ""
+	}
+}
+
 func newImportFailedCondition(err error, gen int64, now unversioned.Time) api.TagEventCondition {
 	c := api.TagEventCondition{
 		Type:       api.ImportSuccess,
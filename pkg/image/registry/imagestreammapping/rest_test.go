@@ -587,6 +587,7 @@ type fakeImageRegistry struct {
 	listImages  func(ctx kapi.Context, options *kapi.ListOptions) (*api.ImageList, error)
 	getImage    func(ctx kapi.Context, id string) (*api.Image, error)
 	createImage func(ctx kapi.Context, image *api.Image) error
+	updateImage func(ctx kapi.Context, image *api.Image) (*api.Image, error)
 	deleteImage func(ctx kapi.Context, id string) error
 	watchImages func(ctx kapi.Context, options *kapi.ListOptions) (watch.Interface, error)
 }
@@ -600,6 +601,9 @@ func (f *fakeImageRegistry) GetImage(ctx kapi.Context, id string) (*api.Image, e
 func (f *fakeImageRegistry) CreateImage(ctx kapi.Context, image *api.Image) error {
 	return f.createImage(ctx, image)
 }
+func (f *fakeImageRegistry) UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error) {
+	return f.updateImage(ctx, image)
+}
 func (f *fakeImageRegistry) DeleteImage(ctx kapi.Context, id string) error {
 	return f.deleteImage(ctx, id)
 }
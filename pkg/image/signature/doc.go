@@ -0,0 +1,4 @@
+// Package signature creates and verifies image signatures of the JWSImageV1
+// type. Such a signature is a JSON Web Signature over a claims document that
+// binds a docker image manifest digest to a docker reference.
+package signature
@@ -0,0 +1,161 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/docker/libtrust"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// ClaimsType is the type of the claims document signed by JWSImageV1 signatures.
+const ClaimsType = "atomic container signature"
+
+// Claims is the document signed by a JWSImageV1 signature.
+type Claims struct {
+	Critical CriticalClaims `json:"critical"`
+	Optional OptionalClaims `json:"optional"`
+}
+
+// CriticalClaims must all be understood and checked by the verifier.
+type CriticalClaims struct {
+	Type     string        `json:"type"`
+	Image    ImageClaim    `json:"image"`
+	Identity IdentityClaim `json:"identity"`
+}
+
+// ImageClaim identifies the signed image by its manifest digest.
+type ImageClaim struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// IdentityClaim is the docker reference the signer associated with the image.
+type IdentityClaim struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// OptionalClaims hold informative data about the signature.
+type OptionalClaims struct {
+	Creator   string `json:"creator,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+}
+
+// Sign returns the content of a JWSImageV1 signature of the image with the
+// given manifest digest, created with the provided private key.
+func Sign(key libtrust.PrivateKey, digest, dockerReference, creator string) ([]byte, error) {
+	claims := Claims{
+		Critical: CriticalClaims{
+			Type:     ClaimsType,
+			Image:    ImageClaim{DockerManifestDigest: digest},
+			Identity: IdentityClaim{DockerReference: dockerReference},
+		},
+		Optional: OptionalClaims{
+			Creator:   creator,
+			Timestamp: time.Now().Unix(),
+		},
+	}
+	payload, err := json.MarshalIndent(claims, "", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := libtrust.NewJSONSignature(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := js.Sign(key); err != nil {
+		return nil, err
+	}
+	return js.JWS()
+}
+
+// ParseTrustedKeys reads a bundle of PEM encoded public keys.
+func ParseTrustedKeys(data []byte) ([]libtrust.PublicKey, error) {
+	keys, err := libtrust.UnmarshalPublicKeyPEMBundle(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no public keys found")
+	}
+	return keys, nil
+}
+
+// Parse checks that the content of a JWSImageV1 signature is intact and that
+// it was issued for the image with the given manifest digest. It returns the
+// signed claims and the keys that created the signature. It does not decide
+// whether the signing keys are trusted.
+func Parse(content []byte, digest string) (*Claims, []libtrust.PublicKey, error) {
+	js, err := libtrust.ParseJWS(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse signature: %v", err)
+	}
+	signingKeys, err := js.Verify()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %v", err)
+	}
+
+	payload, err := js.Payload()
+	if err != nil {
+		return nil, nil, err
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse signed claims: %v", err)
+	}
+	if claims.Critical.Type != ClaimsType {
+		return nil, nil, fmt.Errorf("unsupported claims type %q", claims.Critical.Type)
+	}
+	if claims.Critical.Image.DockerManifestDigest != digest {
+		return nil, nil, fmt.Errorf("signature is for image %q, not %q", claims.Critical.Image.DockerManifestDigest, digest)
+	}
+	return claims, signingKeys, nil
+}
+
+// Verify checks that the content of a JWSImageV1 signature was created by one
+// of the trusted keys and that it was issued for the image with the given
+// manifest digest. It returns the signed claims.
+func Verify(content []byte, digest string, trustedKeys []libtrust.PublicKey) (*Claims, error) {
+	claims, signingKeys, err := Parse(content, digest)
+	if err != nil {
+		return nil, err
+	}
+	if !anyKeyTrusted(signingKeys, trustedKeys) {
+		return nil, fmt.Errorf("signature was not created by a trusted key")
+	}
+	return claims, nil
+}
+
+// VerifyImage returns the first signature of the image that is valid and was
+// created by one of the trusted keys, or an error if there is none.
+func VerifyImage(image *imageapi.Image, trustedKeys []libtrust.PublicKey) (*imageapi.ImageSignature, error) {
+	var lastErr error
+	for i := range image.Signatures {
+		signature := &image.Signatures[i]
+		if signature.Type != imageapi.ImageSignatureTypeJWSImageV1 {
+			continue
+		}
+		if _, err := Verify(signature.Content, image.Name, trustedKeys); err != nil {
+			lastErr = err
+			continue
+		}
+		return signature, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("image %s has no valid signature from a trusted key: %v", image.Name, lastErr)
+	}
+	return nil, fmt.Errorf("image %s has no signature of type %s", image.Name, imageapi.ImageSignatureTypeJWSImageV1)
+}
+
+func anyKeyTrusted(keys, trustedKeys []libtrust.PublicKey) bool {
+	for _, key := range keys {
+		for _, trusted := range trustedKeys {
+			if key.KeyID() == trusted.KeyID() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package signature
+
+import (
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/docker/libtrust"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const testDigest = "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"
+
+func generateKey(t *testing.T) libtrust.PrivateKey {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	return key
+}
+
+func encodePublicKey(t *testing.T, key libtrust.PublicKey) []byte {
+	block, err := key.PEMBlock()
+	if err != nil {
+		t.Fatalf("unable to encode key: %v", err)
+	}
+	return pem.EncodeToMemory(block)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	signer := generateKey(t)
+	other := generateKey(t)
+
+	content, err := Sign(signer, testDigest, "registry.example.com/ns/app:v1", "ci")
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+
+	claims, err := Verify(content, testDigest, []libtrust.PublicKey{other.PublicKey(), signer.PublicKey()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Critical.Identity.DockerReference != "registry.example.com/ns/app:v1" || claims.Optional.Creator != "ci" {
+		t.Errorf("unexpected claims: %#v", claims)
+	}
+
+	if _, err := Verify(content, testDigest, []libtrust.PublicKey{other.PublicKey()}); err == nil || !strings.Contains(err.Error(), "trusted key") {
+		t.Errorf("expected untrusted key error, got %v", err)
+	}
+	if _, err := Verify(content, "sha256:other", []libtrust.PublicKey{signer.PublicKey()}); err == nil || !strings.Contains(err.Error(), "signature is for image") {
+		t.Errorf("expected digest mismatch error, got %v", err)
+	}
+	if _, err := Verify([]byte("garbage"), testDigest, []libtrust.PublicKey{signer.PublicKey()}); err == nil {
+		t.Errorf("expected error for malformed signature")
+	}
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	first, second := generateKey(t), generateKey(t)
+	bundle := append(encodePublicKey(t, first.PublicKey()), encodePublicKey(t, second.PublicKey())...)
+
+	keys, err := ParseTrustedKeys(bundle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 || keys[0].KeyID() != first.KeyID() || keys[1].KeyID() != second.KeyID() {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+
+	if _, err := ParseTrustedKeys([]byte("not a key")); err == nil {
+		t.Errorf("expected error for a bundle without keys")
+	}
+}
+
+func TestVerifyImage(t *testing.T) {
+	signer := generateKey(t)
+	other := generateKey(t)
+
+	untrusted, err := Sign(other, testDigest, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trusted, err := Sign(signer, testDigest, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image := &imageapi.Image{
+		ObjectMeta: kapi.ObjectMeta{Name: testDigest},
+		Signatures: []imageapi.ImageSignature{
+			{ObjectMeta: kapi.ObjectMeta{Name: testDigest + "@atomic"}, Type: imageapi.ImageSignatureTypeAtomicImageV1, Content: []byte("gpg")},
+			{ObjectMeta: kapi.ObjectMeta{Name: testDigest + "@untrusted"}, Type: imageapi.ImageSignatureTypeJWSImageV1, Content: untrusted},
+			{ObjectMeta: kapi.ObjectMeta{Name: testDigest + "@trusted"}, Type: imageapi.ImageSignatureTypeJWSImageV1, Content: trusted},
+		},
+	}
+
+	signature, err := VerifyImage(image, []libtrust.PublicKey{signer.PublicKey()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signature.Name != testDigest+"@trusted" {
+		t.Errorf("unexpected signature: %s", signature.Name)
+	}
+
+	image.Signatures = image.Signatures[:2]
+	if _, err := VerifyImage(image, []libtrust.PublicKey{signer.PublicKey()}); err == nil {
+		t.Errorf("expected error for an image without a trusted signature")
+	}
+
+	image.Signatures = nil
+	if _, err := VerifyImage(image, []libtrust.PublicKey{signer.PublicKey()}); err == nil {
+		t.Errorf("expected error for an unsigned image")
+	}
+}
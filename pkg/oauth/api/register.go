@@ -36,9 +36,17 @@ func addKnownTypes(scheme *runtime.Scheme) {
 		&OAuthClientList{},
 		&OAuthClientAuthorization{},
 		&OAuthClientAuthorizationList{},
+		&PersonalAccessToken{},
+		&PersonalAccessTokenList{},
+		&UserOAuthAccessToken{},
+		&UserOAuthAccessTokenList{},
 	)
 }
 
+func (obj *UserOAuthAccessTokenList) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
+func (obj *UserOAuthAccessToken) GetObjectKind() unversioned.ObjectKind         { return &obj.TypeMeta }
+func (obj *PersonalAccessTokenList) GetObjectKind() unversioned.ObjectKind      { return &obj.TypeMeta }
+func (obj *PersonalAccessToken) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
 func (obj *OAuthClientAuthorizationList) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
 func (obj *OAuthClientAuthorization) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
 func (obj *OAuthClientList) GetObjectKind() unversioned.ObjectKind              { return &obj.TypeMeta }
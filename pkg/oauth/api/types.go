@@ -34,6 +34,11 @@ type OAuthAccessToken struct {
 	RefreshToken string
 }
 
+// UserOAuthAccessToken is an OAuthAccessToken of the requesting user. Users list and revoke their own tokens through
+// this type, without access to the tokens of other users. The token is named by its ID, the leading characters of the
+// token, and the authorize and refresh tokens are not set.
+type UserOAuthAccessToken OAuthAccessToken
+
 type OAuthAuthorizeToken struct {
 	unversioned.TypeMeta
 	kapi.ObjectMeta
@@ -123,6 +128,23 @@ type OAuthClientAuthorization struct {
 	Scopes []string
 }
 
+// PersonalAccessToken is a named OAuth access token a user created for themselves, usually for use in scripts and
+// continuous integration. It is stored as an OAuthAccessToken issued to the personal access token client.
+type PersonalAccessToken struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// Scopes is an array of the scopes the token is limited to.
+	Scopes []string
+
+	// ExpiresIn is the seconds from CreationTime before this token expires. It may not exceed the maximum age of access
+	// tokens.
+	ExpiresIn int64
+
+	// Token is the bearer token. It is only returned when the token is created.
+	Token string
+}
+
 type OAuthAccessTokenList struct {
 	unversioned.TypeMeta
 	unversioned.ListMeta
@@ -146,3 +168,15 @@ type OAuthClientAuthorizationList struct {
 	unversioned.ListMeta
 	Items []OAuthClientAuthorization
 }
+
+type PersonalAccessTokenList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []PersonalAccessToken
+}
+
+type UserOAuthAccessTokenList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []UserOAuthAccessToken
+}
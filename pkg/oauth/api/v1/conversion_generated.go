@@ -30,8 +30,16 @@ func init() {
 		Convert_api_OAuthClientAuthorizationList_To_v1_OAuthClientAuthorizationList,
 		Convert_v1_OAuthClientList_To_api_OAuthClientList,
 		Convert_api_OAuthClientList_To_v1_OAuthClientList,
+		Convert_v1_PersonalAccessToken_To_api_PersonalAccessToken,
+		Convert_api_PersonalAccessToken_To_v1_PersonalAccessToken,
+		Convert_v1_PersonalAccessTokenList_To_api_PersonalAccessTokenList,
+		Convert_api_PersonalAccessTokenList_To_v1_PersonalAccessTokenList,
 		Convert_v1_ScopeRestriction_To_api_ScopeRestriction,
 		Convert_api_ScopeRestriction_To_v1_ScopeRestriction,
+		Convert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken,
+		Convert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken,
+		Convert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList,
+		Convert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList,
 	); err != nil {
 		// if one of the conversion functions is malformed, detect it immediately.
 		panic(err)
@@ -532,6 +540,104 @@ func Convert_api_OAuthClientList_To_v1_OAuthClientList(in *oauth_api.OAuthClient
 	return autoConvert_api_OAuthClientList_To_v1_OAuthClientList(in, out, s)
 }
 
+func autoConvert_v1_PersonalAccessToken_To_api_PersonalAccessToken(in *PersonalAccessToken, out *oauth_api.PersonalAccessToken, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Scopes = nil
+	}
+	out.ExpiresIn = in.ExpiresIn
+	out.Token = in.Token
+	return nil
+}
+
+func Convert_v1_PersonalAccessToken_To_api_PersonalAccessToken(in *PersonalAccessToken, out *oauth_api.PersonalAccessToken, s conversion.Scope) error {
+	return autoConvert_v1_PersonalAccessToken_To_api_PersonalAccessToken(in, out, s)
+}
+
+func autoConvert_api_PersonalAccessToken_To_v1_PersonalAccessToken(in *oauth_api.PersonalAccessToken, out *PersonalAccessToken, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Scopes = nil
+	}
+	out.ExpiresIn = in.ExpiresIn
+	out.Token = in.Token
+	return nil
+}
+
+func Convert_api_PersonalAccessToken_To_v1_PersonalAccessToken(in *oauth_api.PersonalAccessToken, out *PersonalAccessToken, s conversion.Scope) error {
+	return autoConvert_api_PersonalAccessToken_To_v1_PersonalAccessToken(in, out, s)
+}
+
+func autoConvert_v1_PersonalAccessTokenList_To_api_PersonalAccessTokenList(in *PersonalAccessTokenList, out *oauth_api.PersonalAccessTokenList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]oauth_api.PersonalAccessToken, len(*in))
+		for i := range *in {
+			if err := Convert_v1_PersonalAccessToken_To_api_PersonalAccessToken(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_PersonalAccessTokenList_To_api_PersonalAccessTokenList(in *PersonalAccessTokenList, out *oauth_api.PersonalAccessTokenList, s conversion.Scope) error {
+	return autoConvert_v1_PersonalAccessTokenList_To_api_PersonalAccessTokenList(in, out, s)
+}
+
+func autoConvert_api_PersonalAccessTokenList_To_v1_PersonalAccessTokenList(in *oauth_api.PersonalAccessTokenList, out *PersonalAccessTokenList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PersonalAccessToken, len(*in))
+		for i := range *in {
+			if err := Convert_api_PersonalAccessToken_To_v1_PersonalAccessToken(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_api_PersonalAccessTokenList_To_v1_PersonalAccessTokenList(in *oauth_api.PersonalAccessTokenList, out *PersonalAccessTokenList, s conversion.Scope) error {
+	return autoConvert_api_PersonalAccessTokenList_To_v1_PersonalAccessTokenList(in, out, s)
+}
+
 func autoConvert_v1_ScopeRestriction_To_api_ScopeRestriction(in *ScopeRestriction, out *oauth_api.ScopeRestriction, s conversion.Scope) error {
 	if in.ExactValues != nil {
 		in, out := &in.ExactValues, &out.ExactValues
@@ -579,3 +685,111 @@ func autoConvert_api_ScopeRestriction_To_v1_ScopeRestriction(in *oauth_api.Scope
 func Convert_api_ScopeRestriction_To_v1_ScopeRestriction(in *oauth_api.ScopeRestriction, out *ScopeRestriction, s conversion.Scope) error {
 	return autoConvert_api_ScopeRestriction_To_v1_ScopeRestriction(in, out, s)
 }
+
+func autoConvert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in *UserOAuthAccessToken, out *oauth_api.UserOAuthAccessToken, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	return nil
+}
+
+func Convert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in *UserOAuthAccessToken, out *oauth_api.UserOAuthAccessToken, s conversion.Scope) error {
+	return autoConvert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in, out, s)
+}
+
+func autoConvert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in *oauth_api.UserOAuthAccessToken, out *UserOAuthAccessToken, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	return nil
+}
+
+func Convert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in *oauth_api.UserOAuthAccessToken, out *UserOAuthAccessToken, s conversion.Scope) error {
+	return autoConvert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in, out, s)
+}
+
+func autoConvert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in *UserOAuthAccessTokenList, out *oauth_api.UserOAuthAccessTokenList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]oauth_api.UserOAuthAccessToken, len(*in))
+		for i := range *in {
+			if err := Convert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in *UserOAuthAccessTokenList, out *oauth_api.UserOAuthAccessTokenList, s conversion.Scope) error {
+	return autoConvert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in, out, s)
+}
+
+func autoConvert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in *oauth_api.UserOAuthAccessTokenList, out *UserOAuthAccessTokenList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UserOAuthAccessToken, len(*in))
+		for i := range *in {
+			if err := Convert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in *oauth_api.UserOAuthAccessTokenList, out *UserOAuthAccessTokenList, s conversion.Scope) error {
+	return autoConvert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in, out, s)
+}
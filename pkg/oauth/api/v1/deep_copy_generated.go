@@ -22,7 +22,11 @@ func init() {
 		DeepCopy_v1_OAuthClientAuthorization,
 		DeepCopy_v1_OAuthClientAuthorizationList,
 		DeepCopy_v1_OAuthClientList,
+		DeepCopy_v1_PersonalAccessToken,
+		DeepCopy_v1_PersonalAccessTokenList,
 		DeepCopy_v1_ScopeRestriction,
+		DeepCopy_v1_UserOAuthAccessToken,
+		DeepCopy_v1_UserOAuthAccessTokenList,
 	); err != nil {
 		// if one of the deep copy functions is malformed, detect it immediately.
 		panic(err)
@@ -236,6 +240,46 @@ func DeepCopy_v1_OAuthClientList(in OAuthClientList, out *OAuthClientList, c *co
 	return nil
 }
 
+func DeepCopy_v1_PersonalAccessToken(in PersonalAccessToken, out *PersonalAccessToken, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := api_v1.DeepCopy_v1_ObjectMeta(in.ObjectMeta, &out.ObjectMeta, c); err != nil {
+		return err
+	}
+	if in.Scopes != nil {
+		in, out := in.Scopes, &out.Scopes
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Scopes = nil
+	}
+	out.ExpiresIn = in.ExpiresIn
+	out.Token = in.Token
+	return nil
+}
+
+func DeepCopy_v1_PersonalAccessTokenList(in PersonalAccessTokenList, out *PersonalAccessTokenList, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := unversioned.DeepCopy_unversioned_ListMeta(in.ListMeta, &out.ListMeta, c); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := in.Items, &out.Items
+		*out = make([]PersonalAccessToken, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_PersonalAccessToken(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
 func DeepCopy_v1_ScopeRestriction(in ScopeRestriction, out *ScopeRestriction, c *conversion.Cloner) error {
 	if in.ExactValues != nil {
 		in, out := in.ExactValues, &out.ExactValues
@@ -255,3 +299,48 @@ func DeepCopy_v1_ScopeRestriction(in ScopeRestriction, out *ScopeRestriction, c
 	}
 	return nil
 }
+
+func DeepCopy_v1_UserOAuthAccessToken(in UserOAuthAccessToken, out *UserOAuthAccessToken, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := api_v1.DeepCopy_v1_ObjectMeta(in.ObjectMeta, &out.ObjectMeta, c); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		in, out := in.Scopes, &out.Scopes
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	return nil
+}
+
+func DeepCopy_v1_UserOAuthAccessTokenList(in UserOAuthAccessTokenList, out *UserOAuthAccessTokenList, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := unversioned.DeepCopy_unversioned_ListMeta(in.ListMeta, &out.ListMeta, c); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := in.Items, &out.Items
+		*out = make([]UserOAuthAccessToken, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_UserOAuthAccessToken(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
@@ -116,6 +116,28 @@ func (OAuthClientList) SwaggerDoc() map[string]string {
 	return map_OAuthClientList
 }
 
+var map_PersonalAccessToken = map[string]string{
+	"":          "PersonalAccessToken is a named OAuth access token a user created for themselves, usually for use in scripts and continuous integration",
+	"metadata":  "Standard object's metadata.",
+	"scopes":    "Scopes is an array of the scopes the token is limited to.",
+	"expiresIn": "ExpiresIn is the seconds from CreationTime before this token expires. It may not exceed the maximum age of access tokens.",
+	"token":     "Token is the bearer token. It is only returned when the token is created.",
+}
+
+func (PersonalAccessToken) SwaggerDoc() map[string]string {
+	return map_PersonalAccessToken
+}
+
+var map_PersonalAccessTokenList = map[string]string{
+	"":         "PersonalAccessTokenList is a collection of personal access tokens",
+	"metadata": "Standard object's metadata.",
+	"items":    "Items is the list of personal access tokens",
+}
+
+func (PersonalAccessTokenList) SwaggerDoc() map[string]string {
+	return map_PersonalAccessTokenList
+}
+
 var map_ScopeRestriction = map[string]string{
 	"":            "ScopeRestriction describe one restriction on scopes.  Exactly one option must be non-nil.",
 	"literals":    "ExactValues means the scope has to match a particular set of strings exactly",
@@ -125,3 +147,13 @@ var map_ScopeRestriction = map[string]string{
 func (ScopeRestriction) SwaggerDoc() map[string]string {
 	return map_ScopeRestriction
 }
+
+var map_UserOAuthAccessTokenList = map[string]string{
+	"":         "UserOAuthAccessTokenList is a collection of the OAuth access tokens of the requesting user",
+	"metadata": "Standard object's metadata.",
+	"items":    "Items is the list of OAuth access tokens",
+}
+
+func (UserOAuthAccessTokenList) SwaggerDoc() map[string]string {
+	return map_UserOAuthAccessTokenList
+}
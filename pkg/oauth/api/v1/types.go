@@ -36,6 +36,10 @@ type OAuthAccessToken struct {
 	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
+// UserOAuthAccessToken is an OAuth access token of the requesting user. The token is named by its ID, the leading
+// characters of the token, and the authorize and refresh tokens are not set.
+type UserOAuthAccessToken OAuthAccessToken
+
 // OAuthAuthorizeToken describes an OAuth authorization token
 type OAuthAuthorizeToken struct {
 	unversioned.TypeMeta `json:",inline"`
@@ -131,6 +135,24 @@ type OAuthClientAuthorization struct {
 	Scopes []string `json:"scopes,omitempty"`
 }
 
+// PersonalAccessToken is a named OAuth access token a user created for themselves, usually for use in scripts and
+// continuous integration
+type PersonalAccessToken struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	kapi.ObjectMeta `json:"metadata,omitempty"`
+
+	// Scopes is an array of the scopes the token is limited to.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ExpiresIn is the seconds from CreationTime before this token expires. It may not exceed the maximum age of access
+	// tokens.
+	ExpiresIn int64 `json:"expiresIn,omitempty"`
+
+	// Token is the bearer token. It is only returned when the token is created.
+	Token string `json:"token,omitempty"`
+}
+
 // OAuthAccessTokenList is a collection of OAuth access tokens
 type OAuthAccessTokenList struct {
 	unversioned.TypeMeta `json:",inline"`
@@ -166,3 +188,21 @@ type OAuthClientAuthorizationList struct {
 	// Items is the list of OAuth client authorizations
 	Items []OAuthClientAuthorization `json:"items"`
 }
+
+// PersonalAccessTokenList is a collection of personal access tokens
+type PersonalAccessTokenList struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	unversioned.ListMeta `json:"metadata,omitempty"`
+	// Items is the list of personal access tokens
+	Items []PersonalAccessToken `json:"items"`
+}
+
+// UserOAuthAccessTokenList is a collection of the OAuth access tokens of the requesting user
+type UserOAuthAccessTokenList struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	unversioned.ListMeta `json:"metadata,omitempty"`
+	// Items is the list of OAuth access tokens
+	Items []UserOAuthAccessToken `json:"items"`
+}
@@ -2,8 +2,10 @@ package validation
 
 import (
 	"fmt"
+	"math"
 	"net/url"
 	"strings"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/serviceaccount"
@@ -74,6 +76,45 @@ func ValidateAccessTokenUpdate(newToken, oldToken *api.OAuthAccessToken) field.E
 	return append(allErrs, validation.ValidateImmutableField(newToken, &copied, field.NewPath(""))...)
 }
 
+func ValidatePersonalAccessTokenName(name string, prefix bool) (bool, string) {
+	if ok, reason := oapi.MinimalNameRequirements(name, prefix); !ok {
+		return ok, reason
+	}
+
+	// the name is stored in a label of the access token
+	return validation.NameIsDNSLabel(name, prefix)
+}
+
+// MaxExpiresInSeconds is the largest expiry in seconds that can be added to the creation time of a token
+const MaxExpiresInSeconds = int64(math.MaxInt64 / int64(time.Second))
+
+// ValidatePersonalAccessToken validates a new personal access token
+func ValidatePersonalAccessToken(token *api.PersonalAccessToken) field.ErrorList {
+	allErrs := validation.ValidateObjectMeta(&token.ObjectMeta, false, ValidatePersonalAccessTokenName, field.NewPath("metadata"))
+	if len(token.Scopes) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("scopes"), ""))
+	}
+	allErrs = append(allErrs, ValidateScopes(token.Scopes, field.NewPath("scopes"))...)
+	switch {
+	case token.ExpiresIn <= 0:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("expiresIn"), token.ExpiresIn, "must be greater than 0"))
+	case token.ExpiresIn > MaxExpiresInSeconds:
+		allErrs = append(allErrs, field.Invalid(field.NewPath("expiresIn"), token.ExpiresIn, fmt.Sprintf("must be at most %d", MaxExpiresInSeconds)))
+	}
+
+	return allErrs
+}
+
+// ValidatePersonalAccessTokenMaxAge validates that a new personal access token does not expire later than
+// maxAgeSeconds, if it is greater than 0
+func ValidatePersonalAccessTokenMaxAge(token *api.PersonalAccessToken, maxAgeSeconds int64) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxAgeSeconds > 0 && token.ExpiresIn > maxAgeSeconds {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("expiresIn"), token.ExpiresIn, fmt.Sprintf("must be at most %d, the maximum age of access tokens", maxAgeSeconds)))
+	}
+	return allErrs
+}
+
 func ValidateAuthorizeToken(authorizeToken *api.OAuthAuthorizeToken) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&authorizeToken.ObjectMeta, false, ValidateTokenName, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateClientNameField(authorizeToken.ClientName, field.NewPath("clientName"))...)
@@ -319,6 +319,101 @@ func TestValidateAccessTokens(t *testing.T) {
 	}
 }
 
+func TestValidatePersonalAccessTokens(t *testing.T) {
+	errs := ValidatePersonalAccessToken(&oapi.PersonalAccessToken{
+		ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+		Scopes:     []string{"user:info"},
+		ExpiresIn:  3600,
+	})
+	if len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	errorCases := map[string]struct {
+		Token oapi.PersonalAccessToken
+		Max   int64
+		T     field.ErrorType
+		F     string
+	}{
+		"zero-length name": {
+			Token: oapi.PersonalAccessToken{
+				Scopes:    []string{"user:info"},
+				ExpiresIn: 3600,
+			},
+			T: field.ErrorTypeRequired,
+			F: "metadata.name",
+		},
+		"invalid name": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "my/token"},
+				Scopes:     []string{"user:info"},
+				ExpiresIn:  3600,
+			},
+			T: field.ErrorTypeInvalid,
+			F: "metadata.name",
+		},
+		"no scopes": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+				ExpiresIn:  3600,
+			},
+			T: field.ErrorTypeRequired,
+			F: "scopes",
+		},
+		"bad scope": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+				Scopes:     []string{"user:dne"},
+				ExpiresIn:  3600,
+			},
+			T: field.ErrorTypeInvalid,
+			F: "scopes[0]",
+		},
+		"no expiry": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+				Scopes:     []string{"user:info"},
+			},
+			T: field.ErrorTypeInvalid,
+			F: "expiresIn",
+		},
+		"expiry beyond the maximum age": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+				Scopes:     []string{"user:info"},
+				ExpiresIn:  86401,
+			},
+			Max: 86400,
+			T:   field.ErrorTypeInvalid,
+			F:   "expiresIn",
+		},
+		"expiry overflowing a duration": {
+			Token: oapi.PersonalAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "jenkins"},
+				Scopes:     []string{"user:info"},
+				ExpiresIn:  MaxExpiresInSeconds + 1,
+			},
+			T: field.ErrorTypeInvalid,
+			F: "expiresIn",
+		},
+	}
+	for k, v := range errorCases {
+		errs := append(ValidatePersonalAccessToken(&v.Token), ValidatePersonalAccessTokenMaxAge(&v.Token, v.Max)...)
+		if len(errs) == 0 {
+			t.Errorf("expected failure %s for %v", k, v.Token)
+			continue
+		}
+		for i := range errs {
+			if errs[i].Type != v.T {
+				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
+			}
+			if errs[i].Field != v.F {
+				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
+			}
+		}
+	}
+}
+
 func TestValidateAuthorizeTokens(t *testing.T) {
 	errs := ValidateAuthorizeToken(&oapi.OAuthAuthorizeToken{
 		ObjectMeta: api.ObjectMeta{Name: "authorizeTokenNameWithMinimumLength"},
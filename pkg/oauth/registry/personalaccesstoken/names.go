@@ -0,0 +1,80 @@
+package personalaccesstoken
+
+import (
+	"path"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	storeerr "k8s.io/kubernetes/pkg/api/errors/storage"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/storage"
+	"k8s.io/kubernetes/pkg/types"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/util/restoptions"
+)
+
+// EtcdPrefix is the key prefix of the reserved personal access token names
+const EtcdPrefix = "/oauth/personalaccesstokens"
+
+// Names reserves the names of the personal access tokens of each user. Reserving a name that is taken fails with an
+// AlreadyExists error, so two tokens of a user can not get the same name.
+type Names interface {
+	// Reserve reserves the name for the user until the token expires
+	Reserve(ctx kapi.Context, userName, name string, expiresIn int64) (*api.PersonalAccessToken, error)
+	// Get returns the reservation of the name for the user
+	Get(ctx kapi.Context, userName, name string) (*api.PersonalAccessToken, error)
+	// Release releases the reservation of the name for the user with the given UID
+	Release(ctx kapi.Context, userName, name string, uid types.UID) error
+}
+
+// names implements Names against etcd
+type names struct {
+	storage storage.Interface
+}
+
+// NewNames returns the names of personal access tokens stored in etcd
+func NewNames(optsGetter restoptions.Getter) (Names, error) {
+	opts, err := optsGetter.GetRESTOptions(api.Resource("personalaccesstokens"))
+	if err != nil {
+		return nil, err
+	}
+	return &names{storage: opts.Storage}, nil
+}
+
+func (n *names) Reserve(ctx kapi.Context, userName, name string, expiresIn int64) (*api.PersonalAccessToken, error) {
+	reservation := &api.PersonalAccessToken{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:              name,
+			UID:               kutil.NewUUID(),
+			CreationTimestamp: unversioned.Now(),
+		},
+		ExpiresIn: expiresIn,
+	}
+	out := &api.PersonalAccessToken{}
+	if err := n.storage.Create(ctx, key(userName, name), reservation, out, uint64(expiresIn)); err != nil {
+		return nil, storeerr.InterpretCreateError(err, api.Resource("personalaccesstokens"), name)
+	}
+	return out, nil
+}
+
+func (n *names) Get(ctx kapi.Context, userName, name string) (*api.PersonalAccessToken, error) {
+	out := &api.PersonalAccessToken{}
+	if err := n.storage.Get(ctx, key(userName, name), out, false); err != nil {
+		return nil, storeerr.InterpretGetError(err, api.Resource("personalaccesstokens"), name)
+	}
+	return out, nil
+}
+
+func (n *names) Release(ctx kapi.Context, userName, name string, uid types.UID) error {
+	out := &api.PersonalAccessToken{}
+	if err := n.storage.Delete(ctx, key(userName, name), out, storage.NewUIDPreconditions(string(uid))); err != nil {
+		return storeerr.InterpretDeleteError(err, api.Resource("personalaccesstokens"), name)
+	}
+	return nil
+}
+
+// key returns the key of the name for the user. User names may not contain slashes.
+func key(userName, name string) string {
+	return path.Join(EtcdPrefix, userName, name)
+}
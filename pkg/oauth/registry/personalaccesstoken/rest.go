@@ -0,0 +1,226 @@
+package personalaccesstoken
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+	"github.com/openshift/origin/pkg/oauth/scope"
+	"github.com/openshift/origin/pkg/oauth/server/osinserver"
+	userregistry "github.com/openshift/origin/pkg/user/registry/user"
+)
+
+const (
+	// ClientName is the name of the OAuth client personal access tokens are issued to
+	ClientName = "openshift-personal-access-token"
+	// NameLabel is the label of an OAuthAccessToken holding the name of the personal access token it backs
+	NameLabel = "oauth.openshift.io/personal-access-token"
+
+	// abandonedReservationAge is the age after which a reserved name without an access token is released. The access
+	// token is created right after the name is reserved, so it is either being created or was revoked directly.
+	abandonedReservationAge = time.Minute
+)
+
+// REST implements the RESTStorage interface for the personal access tokens of the requesting user. Personal access
+// tokens are stored as OAuthAccessTokens issued to the personal access token client, and their names are reserved in
+// Names.
+type REST struct {
+	strategy     strategy
+	accessTokens oauthaccesstoken.Registry
+	names        Names
+	users        userregistry.Registry
+}
+
+// NewREST returns a RESTStorage object for the personal access tokens of the requesting user. If maxAgeSeconds is
+// greater than 0, tokens may not expire later than that.
+func NewREST(accessTokens oauthaccesstoken.Registry, names Names, users userregistry.Registry, maxAgeSeconds int64) *REST {
+	return &REST{strategy: NewStrategy(maxAgeSeconds), accessTokens: accessTokens, names: names, users: users}
+}
+
+var _ rest.Creater = &REST{}
+var _ rest.Lister = &REST{}
+var _ rest.Getter = &REST{}
+var _ rest.Deleter = &REST{}
+
+func (r *REST) New() runtime.Object {
+	return &api.PersonalAccessToken{}
+}
+
+func (r *REST) NewList() runtime.Object {
+	return &api.PersonalAccessTokenList{}
+}
+
+// Create issues a new access token to the requesting user. The token is only returned in the response.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	if err := rest.BeforeCreate(r.strategy, ctx, obj); err != nil {
+		return nil, err
+	}
+	token := obj.(*api.PersonalAccessToken)
+
+	userInfo, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), token.Name, errors.New("unable to determine the requesting user"))
+	}
+	// a scoped token may only be used to create tokens with the same or fewer scopes
+	if scopes := userInfo.GetExtra()[authorizationapi.ScopesKey]; len(scopes) > 0 && !scope.Covers(scopes, token.Scopes) {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), token.Name, fmt.Errorf("the requested scopes %v exceed the scopes %v of the current token", token.Scopes, scopes))
+	}
+	user, err := r.users.GetUser(ctx, userInfo.GetName())
+	if kerrors.IsNotFound(err) {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), token.Name, fmt.Errorf("%q is not a user", userInfo.GetName()))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := r.names.Reserve(ctx, user.Name, token.Name, token.ExpiresIn)
+	if kerrors.IsAlreadyExists(err) && r.releaseAbandoned(ctx, user.Name, token.Name) {
+		reservation, err = r.names.Reserve(ctx, user.Name, token.Name, token.ExpiresIn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret, _, err := osinserver.TokenGen{}.GenerateAccessToken(nil, false)
+	if err != nil {
+		r.release(ctx, user.Name, reservation)
+		return nil, err
+	}
+	accessToken, err := r.accessTokens.CreateAccessToken(ctx, &api.OAuthAccessToken{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:   secret,
+			Labels: map[string]string{NameLabel: token.Name},
+		},
+		ClientName: ClientName,
+		ExpiresIn:  token.ExpiresIn,
+		Scopes:     token.Scopes,
+		UserName:   user.Name,
+		UserUID:    string(user.UID),
+	})
+	if err != nil {
+		r.release(ctx, user.Name, reservation)
+		return nil, err
+	}
+
+	created := personalAccessToken(accessToken)
+	created.Token = accessToken.Name
+	return created, nil
+}
+
+// List returns the personal access tokens of the requesting user
+func (r *REST) List(ctx kapi.Context, options *kapi.ListOptions) (runtime.Object, error) {
+	userInfo, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), "", errors.New("unable to determine the requesting user"))
+	}
+	accessTokens, err := r.list(ctx, userInfo.GetName(), labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	list := &api.PersonalAccessTokenList{}
+	for i := range accessTokens.Items {
+		list.Items = append(list.Items, *personalAccessToken(&accessTokens.Items[i]))
+	}
+	return list, nil
+}
+
+// Get returns the personal access token of the requesting user with the given name. The token itself is not returned.
+func (r *REST) Get(ctx kapi.Context, name string) (runtime.Object, error) {
+	userInfo, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), name, errors.New("unable to determine the requesting user"))
+	}
+	accessToken, err := r.find(ctx, userInfo.GetName(), name)
+	if err != nil {
+		return nil, err
+	}
+	return personalAccessToken(accessToken), nil
+}
+
+// Delete revokes the personal access token of the requesting user with the given name
+func (r *REST) Delete(ctx kapi.Context, name string) (runtime.Object, error) {
+	userInfo, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil, kerrors.NewForbidden(api.Resource("personalaccesstokens"), name, errors.New("unable to determine the requesting user"))
+	}
+	accessToken, err := r.find(ctx, userInfo.GetName(), name)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.accessTokens.DeleteAccessToken(ctx, accessToken.Name); err != nil {
+		return nil, err
+	}
+	if reservation, err := r.names.Get(ctx, userInfo.GetName(), name); err == nil {
+		r.release(ctx, userInfo.GetName(), reservation)
+	} else if !kerrors.IsNotFound(err) {
+		utilruntime.HandleError(fmt.Errorf("unable to release the name of personal access token %q of %q: %v", name, userInfo.GetName(), err))
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
+// releaseAbandoned releases the reserved name of the user if no access token was created for it, and returns true if
+// it was released
+func (r *REST) releaseAbandoned(ctx kapi.Context, userName, name string) bool {
+	reservation, err := r.names.Get(ctx, userName, name)
+	if err != nil || time.Since(reservation.CreationTimestamp.Time) < abandonedReservationAge {
+		return false
+	}
+	if _, err := r.find(ctx, userName, name); !kerrors.IsNotFound(err) {
+		return false
+	}
+	return r.names.Release(ctx, userName, name, reservation.UID) == nil
+}
+
+// release releases the reserved name of the user. A name that could not be released is released when the token would
+// have expired, or when the name is reserved again after abandonedReservationAge.
+func (r *REST) release(ctx kapi.Context, userName string, reservation *api.PersonalAccessToken) {
+	if err := r.names.Release(ctx, userName, reservation.Name, reservation.UID); err != nil && !kerrors.IsNotFound(err) {
+		utilruntime.HandleError(fmt.Errorf("unable to release the name of personal access token %q of %q: %v", reservation.Name, userName, err))
+	}
+}
+
+// list returns the access tokens issued to the personal access token client for the user that match the selector
+func (r *REST) list(ctx kapi.Context, userName string, selector labels.Selector) (*api.OAuthAccessTokenList, error) {
+	return r.accessTokens.ListAccessTokens(ctx, &kapi.ListOptions{
+		LabelSelector: selector,
+		FieldSelector: fields.SelectorFromSet(fields.Set{"clientName": ClientName, "userName": userName}),
+	})
+}
+
+// find returns the access token backing the personal access token of the user with the given name
+func (r *REST) find(ctx kapi.Context, userName, name string) (*api.OAuthAccessToken, error) {
+	accessTokens, err := r.list(ctx, userName, labels.SelectorFromSet(labels.Set{NameLabel: name}))
+	if err != nil {
+		return nil, err
+	}
+	if len(accessTokens.Items) == 0 {
+		return nil, kerrors.NewNotFound(api.Resource("personalaccesstokens"), name)
+	}
+	return &accessTokens.Items[0], nil
+}
+
+// personalAccessToken returns the personal access token backed by the access token, without the token itself
+func personalAccessToken(accessToken *api.OAuthAccessToken) *api.PersonalAccessToken {
+	return &api.PersonalAccessToken{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:              accessToken.Labels[NameLabel],
+			UID:               accessToken.UID,
+			CreationTimestamp: accessToken.CreationTimestamp,
+		},
+		Scopes:    accessToken.Scopes,
+		ExpiresIn: accessToken.ExpiresIn,
+	}
+}
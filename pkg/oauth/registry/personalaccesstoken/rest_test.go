@@ -0,0 +1,262 @@
+package personalaccesstoken
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/types"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/oauth/api"
+	_ "github.com/openshift/origin/pkg/oauth/api/install"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+	userapi "github.com/openshift/origin/pkg/user/api"
+	usertest "github.com/openshift/origin/pkg/user/registry/test"
+)
+
+// testAccessTokenRegistry is an in memory access token registry honoring list selectors
+type testAccessTokenRegistry map[string]*api.OAuthAccessToken
+
+func (r testAccessTokenRegistry) ListAccessTokens(ctx kapi.Context, options *kapi.ListOptions) (*api.OAuthAccessTokenList, error) {
+	matcher := oauthaccesstoken.Matcher(options.LabelSelector, options.FieldSelector)
+	list := &api.OAuthAccessTokenList{}
+	for _, token := range r {
+		if ok, err := matcher.Matches(token); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *token)
+		}
+	}
+	return list, nil
+}
+
+func (r testAccessTokenRegistry) GetAccessToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error) {
+	token, ok := r[name]
+	if !ok {
+		return nil, kerrors.NewNotFound(api.Resource("oauthaccesstokens"), name)
+	}
+	return token, nil
+}
+
+func (r testAccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error) {
+	r[token.Name] = token
+	return token, nil
+}
+
+func (r testAccessTokenRegistry) DeleteAccessToken(ctx kapi.Context, name string) error {
+	if _, ok := r[name]; !ok {
+		return kerrors.NewNotFound(api.Resource("oauthaccesstokens"), name)
+	}
+	delete(r, name)
+	return nil
+}
+
+// testNames is an in memory Names keyed by user and token name
+type testNames map[string]*api.PersonalAccessToken
+
+func (n testNames) Reserve(ctx kapi.Context, userName, name string, expiresIn int64) (*api.PersonalAccessToken, error) {
+	if _, ok := n[path.Join(userName, name)]; ok {
+		return nil, kerrors.NewAlreadyExists(api.Resource("personalaccesstokens"), name)
+	}
+	reservation := &api.PersonalAccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: name, UID: kutil.NewUUID(), CreationTimestamp: unversioned.Now()},
+		ExpiresIn:  expiresIn,
+	}
+	n[path.Join(userName, name)] = reservation
+	return reservation, nil
+}
+
+func (n testNames) Get(ctx kapi.Context, userName, name string) (*api.PersonalAccessToken, error) {
+	reservation, ok := n[path.Join(userName, name)]
+	if !ok {
+		return nil, kerrors.NewNotFound(api.Resource("personalaccesstokens"), name)
+	}
+	return reservation, nil
+}
+
+func (n testNames) Release(ctx kapi.Context, userName, name string, uid types.UID) error {
+	reservation, ok := n[path.Join(userName, name)]
+	if !ok {
+		return kerrors.NewNotFound(api.Resource("personalaccesstokens"), name)
+	}
+	if reservation.UID != uid {
+		return kerrors.NewConflict(api.Resource("personalaccesstokens"), name, nil)
+	}
+	delete(n, path.Join(userName, name))
+	return nil
+}
+
+func newTestREST() (*REST, testAccessTokenRegistry, testNames) {
+	users := usertest.NewUserRegistry()
+	users.Get["bob"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "bob", UID: "bob-uid"}}
+	users.Get["alice"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "alice", UID: "alice-uid"}}
+	accessTokens := testAccessTokenRegistry{}
+	names := testNames{}
+	return NewREST(accessTokens, names, users, 86400), accessTokens, names
+}
+
+func userContext(name string, scopes ...string) kapi.Context {
+	info := &user.DefaultInfo{Name: name}
+	if len(scopes) > 0 {
+		info.Extra = map[string][]string{authorizationapi.ScopesKey: scopes}
+	}
+	return kapi.WithUser(kapi.NewContext(), info)
+}
+
+func TestCreate(t *testing.T) {
+	storage, accessTokens, names := newTestREST()
+
+	obj, err := storage.Create(userContext("bob"), &api.PersonalAccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "jenkins"},
+		Scopes:     []string{"user:info"},
+		ExpiresIn:  3600,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := obj.(*api.PersonalAccessToken)
+	if created.Name != "jenkins" || len(created.Token) == 0 {
+		t.Fatalf("unexpected token: %#v", created)
+	}
+	if len(created.Scopes) != 1 || created.Scopes[0] != "user:info" {
+		t.Errorf("unexpected scopes: %v", created.Scopes)
+	}
+
+	accessToken, ok := accessTokens[created.Token]
+	if !ok {
+		t.Fatalf("expected an access token named %s, got %v", created.Token, accessTokens)
+	}
+	if accessToken.ClientName != ClientName || accessToken.UserName != "bob" || accessToken.UserUID != "bob-uid" || accessToken.ExpiresIn != 3600 || accessToken.Labels[NameLabel] != "jenkins" {
+		t.Errorf("unexpected access token: %#v", accessToken)
+	}
+	if _, ok := names["bob/jenkins"]; !ok {
+		t.Errorf("expected the name to be reserved, got %v", names)
+	}
+
+	if _, err := storage.Create(userContext("bob"), &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}}); !kerrors.IsAlreadyExists(err) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+	// names are per user
+	if _, err := storage.Create(userContext("alice"), &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	testCases := map[string]struct {
+		ctx   kapi.Context
+		token *api.PersonalAccessToken
+		check func(error) bool
+	}{
+		"invalid": {
+			ctx:   userContext("bob"),
+			token: &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, Scopes: []string{"user:info"}},
+			check: kerrors.IsInvalid,
+		},
+		"expiry beyond the maximum age": {
+			ctx:   userContext("bob"),
+			token: &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 86401, Scopes: []string{"user:info"}},
+			check: kerrors.IsInvalid,
+		},
+		"not a user": {
+			ctx:   userContext("system:serviceaccount:ns:sa"),
+			token: &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}},
+			check: kerrors.IsForbidden,
+		},
+		"no user": {
+			ctx:   kapi.NewContext(),
+			token: &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}},
+			check: kerrors.IsForbidden,
+		},
+		"scopes exceeding the current token": {
+			ctx:   userContext("bob", "user:info", "user:list-projects"),
+			token: &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"role:edit:ci"}},
+			check: kerrors.IsForbidden,
+		},
+	}
+	for name, tc := range testCases {
+		storage, _, _ := newTestREST()
+		if _, err := storage.Create(tc.ctx, tc.token); !tc.check(err) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	// scopes covered by the current token are allowed
+	storage, _, _ := newTestREST()
+	if _, err := storage.Create(userContext("bob", "user:info", "user:list-projects"), &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateAbandonedName(t *testing.T) {
+	storage, _, names := newTestREST()
+	token := &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}}
+
+	// a name reserved without an access token is being created
+	names["bob/jenkins"] = &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins", UID: "1", CreationTimestamp: unversioned.Now()}}
+	if _, err := storage.Create(userContext("bob"), token); !kerrors.IsAlreadyExists(err) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+
+	// until it is abandoned
+	names["bob/jenkins"].CreationTimestamp = unversioned.NewTime(time.Now().Add(-2 * abandonedReservationAge))
+	if _, err := storage.Create(userContext("bob"), token); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if names["bob/jenkins"].UID == "1" {
+		t.Errorf("expected the name to be reserved again")
+	}
+}
+
+func TestListGetDelete(t *testing.T) {
+	storage, accessTokens, names := newTestREST()
+	for _, u := range []string{"bob", "alice"} {
+		if _, err := storage.Create(userContext(u), &api.PersonalAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "jenkins"}, ExpiresIn: 3600, Scopes: []string{"user:info"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// tokens of other clients are not personal access tokens
+	accessTokens["session"] = &api.OAuthAccessToken{ObjectMeta: kapi.ObjectMeta{Name: "session"}, ClientName: "openshift-challenging-client", UserName: "bob"}
+
+	obj, err := storage.List(userContext("bob"), &kapi.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := obj.(*api.PersonalAccessTokenList)
+	if len(list.Items) != 1 || list.Items[0].Name != "jenkins" || len(list.Items[0].Token) != 0 {
+		t.Errorf("unexpected list: %#v", list)
+	}
+
+	obj, err = storage.Get(userContext("bob"), "jenkins")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token := obj.(*api.PersonalAccessToken); token.Name != "jenkins" || len(token.Token) != 0 {
+		t.Errorf("unexpected token: %#v", token)
+	}
+	if _, err := storage.Get(userContext("bob"), "missing"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	if _, err := storage.Delete(userContext("bob"), "jenkins"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := storage.Get(userContext("bob"), "jenkins"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, ok := names["bob/jenkins"]; ok {
+		t.Errorf("expected the name to be released")
+	}
+	if _, err := storage.Get(userContext("alice"), "jenkins"); err != nil {
+		t.Errorf("expected the token of alice to remain, got %v", err)
+	}
+	if _, ok := accessTokens["session"]; !ok {
+		t.Errorf("expected the session token to remain")
+	}
+}
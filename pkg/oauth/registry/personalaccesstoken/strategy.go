@@ -0,0 +1,48 @@
+package personalaccesstoken
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/api/validation"
+)
+
+// strategy implements behavior for PersonalAccessTokens
+type strategy struct {
+	runtime.ObjectTyper
+
+	maxAgeSeconds int64
+}
+
+// NewStrategy returns the strategy for personal access tokens, which may not expire later than maxAgeSeconds if it is
+// greater than 0
+func NewStrategy(maxAgeSeconds int64) strategy {
+	return strategy{kapi.Scheme, maxAgeSeconds}
+}
+
+// NamespaceScoped is false for OAuth objects
+func (strategy) NamespaceScoped() bool {
+	return false
+}
+
+func (strategy) GenerateName(base string) string {
+	return base
+}
+
+// PrepareForCreate clears the token, which is generated by the server
+func (strategy) PrepareForCreate(obj runtime.Object) {
+	token := obj.(*api.PersonalAccessToken)
+	token.Token = ""
+}
+
+// Validate validates a new token
+func (s strategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	token := obj.(*api.PersonalAccessToken)
+	return append(validation.ValidatePersonalAccessToken(token), validation.ValidatePersonalAccessTokenMaxAge(token, s.maxAgeSeconds)...)
+}
+
+// Canonicalize normalizes the object after validation.
+func (strategy) Canonicalize(obj runtime.Object) {
+}
@@ -0,0 +1,164 @@
+package useroauthaccesstoken
+
+import (
+	"errors"
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+)
+
+// TokenIDLength is the number of leading characters of an access token that identify it. The name of an access token
+// is the token itself, so the tokens of the requesting user are returned and revoked by their ID instead.
+const TokenIDLength = 10
+
+// TokenID returns the ID of the access token with the given name
+func TokenID(name string) string {
+	if len(name) > TokenIDLength {
+		return name[:TokenIDLength]
+	}
+	return name
+}
+
+// REST implements the RESTStorage interface for the OAuth access tokens of the requesting user. The tokens of other
+// users are never returned, and the tokens of the user are returned without the secrets they hold.
+type REST struct {
+	accessTokens oauthaccesstoken.Registry
+}
+
+// NewREST returns a RESTStorage object for the OAuth access tokens of the requesting user
+func NewREST(accessTokens oauthaccesstoken.Registry) *REST {
+	return &REST{accessTokens: accessTokens}
+}
+
+var _ rest.Lister = &REST{}
+var _ rest.Getter = &REST{}
+var _ rest.Deleter = &REST{}
+
+func (r *REST) New() runtime.Object {
+	return &api.UserOAuthAccessToken{}
+}
+
+func (r *REST) NewList() runtime.Object {
+	return &api.UserOAuthAccessTokenList{}
+}
+
+// List returns the access tokens of the requesting user matching the selectors
+func (r *REST) List(ctx kapi.Context, options *kapi.ListOptions) (runtime.Object, error) {
+	userName, err := requestingUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	label, field := labels.Everything(), fields.Everything()
+	if options != nil && options.LabelSelector != nil {
+		label = options.LabelSelector
+	}
+	if options != nil && options.FieldSelector != nil {
+		field = options.FieldSelector
+	}
+	accessTokens, err := r.list(ctx, userName, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// other field selectors are matched here, so the user name can not be overridden
+	matcher := oauthaccesstoken.Matcher(labels.Everything(), field)
+	list := &api.UserOAuthAccessTokenList{ListMeta: accessTokens.ListMeta}
+	for i := range accessTokens.Items {
+		if ok, err := matcher.Matches(&accessTokens.Items[i]); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *redact(&accessTokens.Items[i]))
+		}
+	}
+	return list, nil
+}
+
+// Get returns the access token of the requesting user with the given ID
+func (r *REST) Get(ctx kapi.Context, id string) (runtime.Object, error) {
+	accessToken, err := r.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return redact(accessToken), nil
+}
+
+// Delete revokes the access token of the requesting user with the given ID
+func (r *REST) Delete(ctx kapi.Context, id string) (runtime.Object, error) {
+	accessToken, err := r.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.accessTokens.DeleteAccessToken(ctx, accessToken.Name); err != nil {
+		return nil, err
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
+// list returns the access tokens of the user that match the label selector
+func (r *REST) list(ctx kapi.Context, userName string, label labels.Selector) (*api.OAuthAccessTokenList, error) {
+	return r.accessTokens.ListAccessTokens(ctx, &kapi.ListOptions{
+		LabelSelector: label,
+		FieldSelector: fields.SelectorFromSet(fields.Set{"userName": userName}),
+	})
+}
+
+// get returns the access token of the requesting user with the given ID. Only the tokens of the user are searched, so
+// the tokens of other users are reported as missing.
+func (r *REST) get(ctx kapi.Context, id string) (*api.OAuthAccessToken, error) {
+	userName, err := requestingUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	accessTokens, err := r.list(ctx, userName, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	matches := []*api.OAuthAccessToken{}
+	for i := range accessTokens.Items {
+		if TokenID(accessTokens.Items[i].Name) == id {
+			matches = append(matches, &accessTokens.Items[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, kerrors.NewNotFound(api.Resource("useroauthaccesstokens"), id)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, kerrors.NewConflict(api.Resource("useroauthaccesstokens"), id, fmt.Errorf("%d tokens have the ID %q", len(matches), id))
+	}
+}
+
+// redact returns the access token with its ID as its name, and without the tokens it was authorized by and can be
+// refreshed with
+func redact(accessToken *api.OAuthAccessToken) *api.UserOAuthAccessToken {
+	token := api.UserOAuthAccessToken(*accessToken)
+	token.Name = TokenID(accessToken.Name)
+	token.AuthorizeToken = ""
+	token.RefreshToken = ""
+	return &token
+}
+
+// requestingUser returns the name of the requesting user. The tokens are only available to unscoped requests, since
+// they would let a scoped token obtain the other, possibly broader, tokens of the user.
+func requestingUser(ctx kapi.Context, name string) (string, error) {
+	userInfo, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return "", kerrors.NewForbidden(api.Resource("useroauthaccesstokens"), name, errors.New("unable to determine the requesting user"))
+	}
+	if len(userInfo.GetExtra()[authorizationapi.ScopesKey]) > 0 {
+		return "", kerrors.NewForbidden(api.Resource("useroauthaccesstokens"), name, errors.New("the tokens of a user can not be accessed with a scoped token"))
+	}
+	return userInfo.GetName(), nil
+}
@@ -0,0 +1,161 @@
+package useroauthaccesstoken
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/fields"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/oauth/api"
+	_ "github.com/openshift/origin/pkg/oauth/api/install"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+)
+
+// testAccessTokenRegistry is an in memory access token registry honoring list selectors
+type testAccessTokenRegistry map[string]*api.OAuthAccessToken
+
+func (r testAccessTokenRegistry) ListAccessTokens(ctx kapi.Context, options *kapi.ListOptions) (*api.OAuthAccessTokenList, error) {
+	matcher := oauthaccesstoken.Matcher(options.LabelSelector, options.FieldSelector)
+	list := &api.OAuthAccessTokenList{}
+	for _, token := range r {
+		if ok, err := matcher.Matches(token); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *token)
+		}
+	}
+	return list, nil
+}
+
+func (r testAccessTokenRegistry) GetAccessToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error) {
+	token, ok := r[name]
+	if !ok {
+		return nil, kerrors.NewNotFound(api.Resource("oauthaccesstokens"), name)
+	}
+	return token, nil
+}
+
+func (r testAccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error) {
+	r[token.Name] = token
+	return token, nil
+}
+
+func (r testAccessTokenRegistry) DeleteAccessToken(ctx kapi.Context, name string) error {
+	if _, ok := r[name]; !ok {
+		return kerrors.NewNotFound(api.Resource("oauthaccesstokens"), name)
+	}
+	delete(r, name)
+	return nil
+}
+
+func newTestREST() (*REST, testAccessTokenRegistry) {
+	accessTokens := testAccessTokenRegistry{
+		"bobsession-secret": {ObjectMeta: kapi.ObjectMeta{Name: "bobsession-secret"}, ClientName: "openshift-browser-client", UserName: "bob", RefreshToken: "refresh-secret"},
+		"bob-cli000-secret": {ObjectMeta: kapi.ObjectMeta{Name: "bob-cli000-secret"}, ClientName: "openshift-challenging-client", UserName: "bob", AuthorizeToken: "authorize-secret"},
+		"alicecli00-secret": {ObjectMeta: kapi.ObjectMeta{Name: "alicecli00-secret"}, ClientName: "openshift-challenging-client", UserName: "alice"},
+	}
+	return NewREST(accessTokens), accessTokens
+}
+
+func userContext(name string, scopes ...string) kapi.Context {
+	info := &user.DefaultInfo{Name: name}
+	if len(scopes) > 0 {
+		info.Extra = map[string][]string{authorizationapi.ScopesKey: scopes}
+	}
+	return kapi.WithUser(kapi.NewContext(), info)
+}
+
+func TestList(t *testing.T) {
+	storage, _ := newTestREST()
+
+	obj, err := storage.List(userContext("bob"), &kapi.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := obj.(*api.UserOAuthAccessTokenList)
+	if len(list.Items) != 2 {
+		t.Fatalf("expected the two tokens of bob, got %#v", list)
+	}
+	for _, token := range list.Items {
+		if len(token.Name) != TokenIDLength || len(token.RefreshToken) != 0 || len(token.AuthorizeToken) != 0 {
+			t.Errorf("expected the token to be redacted: %#v", token)
+		}
+	}
+
+	obj, err = storage.List(userContext("bob"), &kapi.ListOptions{FieldSelector: fields.OneTermEqualSelector("clientName", "openshift-challenging-client")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list := obj.(*api.UserOAuthAccessTokenList); len(list.Items) != 1 || list.Items[0].Name != "bob-cli000" {
+		t.Errorf("unexpected list: %#v", list)
+	}
+
+	// the user name can not be overridden by a field selector
+	obj, err = storage.List(userContext("bob"), &kapi.ListOptions{FieldSelector: fields.OneTermEqualSelector("userName", "alice")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list := obj.(*api.UserOAuthAccessTokenList); len(list.Items) != 0 {
+		t.Errorf("expected no tokens, got %#v", list)
+	}
+}
+
+func TestGetDelete(t *testing.T) {
+	storage, accessTokens := newTestREST()
+
+	obj, err := storage.Get(userContext("bob"), "bob-cli000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token := obj.(*api.UserOAuthAccessToken); token.Name != "bob-cli000" || token.ClientName != "openshift-challenging-client" || len(token.AuthorizeToken) != 0 {
+		t.Errorf("unexpected token: %#v", token)
+	}
+
+	// tokens are only found by their ID
+	if _, err := storage.Get(userContext("bob"), "bob-cli000-secret"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := storage.Delete(userContext("bob"), "bob-cli000-secret"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	// the tokens of other users are not found
+	if _, err := storage.Get(userContext("bob"), "alicecli00"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := storage.Delete(userContext("bob"), "alicecli00"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, ok := accessTokens["alicecli00-secret"]; !ok {
+		t.Errorf("expected the token of alice to remain")
+	}
+
+	if _, err := storage.Delete(userContext("bob"), "bob-cli000"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := accessTokens["bob-cli000-secret"]; ok {
+		t.Errorf("expected the token to be deleted")
+	}
+}
+
+func TestForbidden(t *testing.T) {
+	testCases := map[string]kapi.Context{
+		"no user":      kapi.NewContext(),
+		"scoped token": userContext("bob", "user:info"),
+	}
+	for name, ctx := range testCases {
+		storage, _ := newTestREST()
+		if _, err := storage.List(ctx, &kapi.ListOptions{}); !kerrors.IsForbidden(err) {
+			t.Errorf("%s: expected a forbidden error listing, got %v", name, err)
+		}
+		if _, err := storage.Get(ctx, "bob-cli000"); !kerrors.IsForbidden(err) {
+			t.Errorf("%s: expected a forbidden error getting, got %v", name, err)
+		}
+		if _, err := storage.Delete(ctx, "bob-cli000"); !kerrors.IsForbidden(err) {
+			t.Errorf("%s: expected a forbidden error deleting, got %v", name, err)
+		}
+	}
+}
@@ -1,37 +1,184 @@
 package image
 
 import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+
 	"k8s.io/kubernetes/pkg/admission"
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
 	kquota "k8s.io/kubernetes/pkg/quota"
 	"k8s.io/kubernetes/pkg/quota/generic"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	osclient "github.com/openshift/origin/pkg/client"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
-const imageStreamEvaluatorName = "Evaluator.ImageStream"
+const (
+	imageStreamEvaluatorName = "Evaluator.ImageStream"
+
+	// imageLayersCacheSize is the number of images whose layers are remembered between usage computations.
+	imageLayersCacheSize = 4096
+)
 
 // NewImageStreamEvaluator computes resource usage of ImageStreams. Instantiating this is necessary for
-// resource quota admission controller to properly work on image stream related objects.
-func NewImageStreamEvaluator(isNamespacer osclient.ImageStreamsNamespacer) kquota.Evaluator {
+// resource quota admission controller to properly work on image stream related objects. Besides counting
+// image streams, it computes the storage used by a project in the integrated registry.
+func NewImageStreamEvaluator(isNamespacer osclient.ImageStreamsNamespacer, imageGetter osclient.ImagesInterfacer) kquota.Evaluator {
 	allResources := []kapi.ResourceName{
 		imageapi.ResourceImageStreams,
 	}
+	matchedResources := []kapi.ResourceName{
+		imageapi.ResourceImageStreams,
+		imageapi.ResourceImageStreamsStorage,
+	}
 
-	return &generic.GenericEvaluator{
-		Name:              imageStreamEvaluatorName,
-		InternalGroupKind: imageapi.Kind("ImageStream"),
-		InternalOperationResources: map[admission.Operation][]kapi.ResourceName{
-			admission.Create: allResources,
-		},
-		MatchedResourceNames: allResources,
-		MatchesScopeFunc:     generic.MatchesNoScopeFunc,
-		ConstraintsFunc:      generic.ObjectCountConstraintsFunc(imageapi.ResourceImageStreams),
-		UsageFunc:            generic.ObjectCountUsageFunc(imageapi.ResourceImageStreams),
-		ListFuncByNamespace: func(namespace string, options kapi.ListOptions) (runtime.Object, error) {
-			return isNamespacer.ImageStreams(namespace).List(options)
+	layers, err := lru.New(imageLayersCacheSize)
+	if err != nil {
+		// only happens with a non-positive size
+		panic(err)
+	}
+
+	return &imageStreamEvaluator{
+		GenericEvaluator: &generic.GenericEvaluator{
+			Name:              imageStreamEvaluatorName,
+			InternalGroupKind: imageapi.Kind("ImageStream"),
+			InternalOperationResources: map[admission.Operation][]kapi.ResourceName{
+				admission.Create: allResources,
+			},
+			MatchedResourceNames: matchedResources,
+			MatchesScopeFunc:     generic.MatchesNoScopeFunc,
+			ConstraintsFunc:      generic.ObjectCountConstraintsFunc(imageapi.ResourceImageStreams),
+			UsageFunc:            generic.ObjectCountUsageFunc(imageapi.ResourceImageStreams),
+			ListFuncByNamespace: func(namespace string, options kapi.ListOptions) (runtime.Object, error) {
+				return isNamespacer.ImageStreams(namespace).List(options)
+			},
 		},
+		isNamespacer: isNamespacer,
+		imageGetter:  imageGetter,
+		layers:       layers,
+	}
+}
+
+// imageStreamEvaluator extends the generic evaluator with the computation of storage usage. Storage is
+// not a property of a single image stream, it is only computed for a whole project in UsageStats.
+type imageStreamEvaluator struct {
+	*generic.GenericEvaluator
+
+	isNamespacer osclient.ImageStreamsNamespacer
+	imageGetter  osclient.ImagesInterfacer
+	// layers caches layers of images keyed by image name. Layers of an image never change.
+	layers *lru.Cache
+}
+
+var _ kquota.Evaluator = &imageStreamEvaluator{}
+
+// UsageStats computes the number of image streams in a project and the total size of unique layers of
+// images pushed to the integrated registry and referenced by any of them.
+func (e *imageStreamEvaluator) UsageStats(options kquota.UsageStatsOptions) (kquota.UsageStats, error) {
+	result := kquota.UsageStats{Used: kapi.ResourceList{}}
+
+	list, err := e.isNamespacer.ImageStreams(options.Namespace).List(kapi.ListOptions{})
+	if err != nil {
+		return result, fmt.Errorf("%s: failed to list image streams: %v", e.Name, err)
+	}
+
+	streams := []imageapi.ImageStream{}
+	for i := range list.Items {
+		matchesScopes := true
+		for _, scope := range options.Scopes {
+			if !e.MatchesScope(scope, &list.Items[i]) {
+				matchesScopes = false
+			}
+		}
+		if matchesScopes {
+			streams = append(streams, list.Items[i])
+		}
+	}
+
+	size, err := e.storageUsage(streams)
+	if err != nil {
+		return result, err
+	}
+	result.Used[imageapi.ResourceImageStreams] = *resource.NewQuantity(int64(len(streams)), resource.DecimalSI)
+	result.Used[imageapi.ResourceImageStreamsStorage] = *resource.NewQuantity(size, resource.BinarySI)
+
+	return result, nil
+}
+
+// storageUsage sums the sizes of unique layers of managed images referenced by the status of given image
+// streams, including the layers of the platform specific images of manifest lists. Images that no longer exist are
+// skipped.
+func (e *imageStreamEvaluator) storageUsage(streams []imageapi.ImageStream) (int64, error) {
+	images := sets.NewString()
+	for _, is := range streams {
+		for _, history := range is.Status.Tags {
+			for _, event := range history.Items {
+				images.Insert(event.Image)
+			}
+		}
+	}
+
+	var size int64
+	seenLayers := sets.NewString()
+	for _, name := range images.List() {
+		layers, err := e.imageLayers(name)
+		if err != nil {
+			return 0, err
+		}
+		for _, layer := range layers {
+			if seenLayers.Has(layer.Name) {
+				continue
+			}
+			seenLayers.Insert(layer.Name)
+			size += layer.LayerSize
+		}
+	}
+	return size, nil
+}
+
+// imageLayers returns the layers of the image with given name if it is stored in the integrated registry. The
+// layers of a manifest list or an image index are the layers of the platform specific images it references, which
+// are stored along with it.
+func (e *imageStreamEvaluator) imageLayers(name string) ([]imageapi.ImageLayer, error) {
+	if obj, ok := e.layers.Get(name); ok {
+		return obj.([]imageapi.ImageLayer), nil
+	}
+
+	image, err := e.getImage(name)
+	if err != nil || image == nil {
+		return nil, err
+	}
+
+	var layers []imageapi.ImageLayer
+	if image.Annotations[imageapi.ManagedByOpenShiftAnnotation] == "true" {
+		layers = append(layers, image.DockerImageLayers...)
+		for _, m := range image.DockerImageManifests {
+			platformImage, err := e.getImage(m.Digest)
+			if err != nil {
+				return nil, err
+			}
+			if platformImage != nil {
+				layers = append(layers, platformImage.DockerImageLayers...)
+			}
+		}
+	}
+	e.layers.Add(name, layers)
+	return layers, nil
+}
+
+// getImage returns the image with given name, or nil if it does not exist.
+func (e *imageStreamEvaluator) getImage(name string) (*imageapi.Image, error) {
+	image, err := e.imageGetter.Images().Get(name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to get image %s: %v", e.Name, name, err)
 	}
+	return image, nil
 }
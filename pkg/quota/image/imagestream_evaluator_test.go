@@ -1,10 +1,15 @@
 package image
 
 import (
+	"fmt"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	kquota "k8s.io/kubernetes/pkg/quota"
+	"k8s.io/kubernetes/pkg/runtime"
 
 	"github.com/openshift/origin/pkg/client/testclient"
 	imagetest "github.com/openshift/origin/pkg/image/admission/testutil"
@@ -12,11 +17,33 @@ import (
 )
 
 func TestImageStreamEvaluatorUsageStats(t *testing.T) {
+	images := []imageapi.Image{
+		makeManagedImage("sha256:aaa", imageapi.ImageLayer{Name: "layer1", LayerSize: 100}, imageapi.ImageLayer{Name: "layer2", LayerSize: 200}),
+		makeManagedImage("sha256:bbb", imageapi.ImageLayer{Name: "layer1", LayerSize: 100}, imageapi.ImageLayer{Name: "layer3", LayerSize: 400}),
+		{
+			ObjectMeta:        kapi.ObjectMeta{Name: "sha256:ccc"},
+			DockerImageLayers: []imageapi.ImageLayer{{Name: "external", LayerSize: 1000}},
+		},
+	}
+	// a manifest list of two platform images, one sharing a layer with sha256:aaa
+	manifestList := makeManagedImage("sha256:list")
+	manifestList.DockerImageManifests = []imageapi.ImageManifest{
+		{Digest: "sha256:amd64"},
+		{Digest: "sha256:arm64"},
+		{Digest: "sha256:deleted"},
+	}
+	images = append(images,
+		manifestList,
+		makeManagedImage("sha256:amd64", imageapi.ImageLayer{Name: "layer1", LayerSize: 100}, imageapi.ImageLayer{Name: "amd64", LayerSize: 800}),
+		makeManagedImage("sha256:arm64", imageapi.ImageLayer{Name: "arm64", LayerSize: 1600}),
+	)
+
 	for _, tc := range []struct {
 		name            string
 		iss             []imageapi.ImageStream
 		namespace       string
 		expectedISCount int64
+		expectedStorage int64
 	}{
 		{
 			name:            "no image stream",
@@ -78,11 +105,34 @@ func TestImageStreamEvaluatorUsageStats(t *testing.T) {
 			namespace:       "test",
 			expectedISCount: 1,
 		},
+
+		{
+			name: "unique layers of managed images",
+			iss: []imageapi.ImageStream{
+				makeImageStreamWithImages("test", "is1", "sha256:aaa", "sha256:bbb"),
+				makeImageStreamWithImages("test", "is2", "sha256:bbb", "sha256:ccc", "sha256:deleted"),
+				makeImageStreamWithImages("other", "is3", "sha256:aaa"),
+			},
+			namespace:       "test",
+			expectedISCount: 2,
+			expectedStorage: 700,
+		},
+
+		{
+			name: "layers of the platform images of a manifest list",
+			iss: []imageapi.ImageStream{
+				makeImageStreamWithImages("test", "is1", "sha256:aaa", "sha256:list"),
+			},
+			namespace:       "test",
+			expectedISCount: 1,
+			expectedStorage: 2700,
+		},
 	} {
 		fakeClient := &testclient.Fake{}
 		fakeClient.AddReactor("list", "imagestreams", imagetest.GetFakeImageStreamListHandler(t, tc.iss...))
+		fakeClient.AddReactor("get", "images", getFakeImageGetHandler(images...))
 
-		evaluator := NewImageStreamEvaluator(fakeClient)
+		evaluator := NewImageStreamEvaluator(fakeClient, fakeClient)
 
 		stats, err := evaluator.UsageStats(kquota.UsageStatsOptions{Namespace: tc.namespace})
 		if err != nil {
@@ -91,6 +141,7 @@ func TestImageStreamEvaluatorUsageStats(t *testing.T) {
 		}
 
 		expectedUsage := imagetest.ExpectedResourceListFor(tc.expectedISCount)
+		expectedUsage[imageapi.ResourceImageStreamsStorage] = *resource.NewQuantity(tc.expectedStorage, resource.BinarySI)
 		expectedResources := kquota.ResourceNames(expectedUsage)
 		if len(stats.Used) != len(expectedResources) {
 			t.Errorf("[%s]: got unexpected number of computed resources: %d != %d", tc.name, len(stats.Used), len(expectedResources))
@@ -169,7 +220,7 @@ func TestImageStreamEvaluatorUsage(t *testing.T) {
 		fakeClient := &testclient.Fake{}
 		fakeClient.AddReactor("get", "imagestreams", imagetest.GetFakeImageStreamGetHandler(t, tc.iss...))
 
-		evaluator := NewImageStreamEvaluator(fakeClient)
+		evaluator := NewImageStreamEvaluator(fakeClient, fakeClient)
 
 		usage := evaluator.Usage(newIS)
 		expectedUsage := imagetest.ExpectedResourceListFor(tc.expectedISCount)
@@ -202,3 +253,43 @@ func TestImageStreamEvaluatorUsage(t *testing.T) {
 		}
 	}
 }
+
+func makeManagedImage(name string, layers ...imageapi.ImageLayer) imageapi.Image {
+	return imageapi.Image{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{imageapi.ManagedByOpenShiftAnnotation: "true"},
+		},
+		DockerImageLayers: layers,
+	}
+}
+
+func makeImageStreamWithImages(namespace, name string, images ...string) imageapi.ImageStream {
+	is := imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: imageapi.ImageStreamStatus{
+			Tags: map[string]imageapi.TagEventList{},
+		},
+	}
+	for i, image := range images {
+		is.Status.Tags[fmt.Sprintf("tag%d", i)] = imageapi.TagEventList{
+			Items: []imageapi.TagEvent{{Image: image}},
+		}
+	}
+	return is
+}
+
+func getFakeImageGetHandler(images ...imageapi.Image) ktestclient.ReactionFunc {
+	return func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		for i := range images {
+			if images[i].Name == name {
+				return true, &images[i], nil
+			}
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("images"), name)
+	}
+}
@@ -14,7 +14,7 @@ import (
 // internal registry. It evaluates only image streams and related virtual resources that can cause a creation
 // of new image stream objects.
 func NewImageQuotaRegistry(osClient osclient.Interface) quota.Registry {
-	imageStream := NewImageStreamEvaluator(osClient)
+	imageStream := NewImageStreamEvaluator(osClient, osClient)
 	imageStreamTag := NewImageStreamTagEvaluator(osClient, osClient)
 	imageStreamImport := NewImageStreamImportEvaluator(osClient)
 	return &generic.GenericRegistry{
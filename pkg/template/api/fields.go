@@ -9,3 +9,11 @@ func TemplateToSelectableFields(template *Template) fields.Set {
 		"metadata.name": template.Name,
 	}
 }
+
+// TemplateInstanceToSelectableFields returns a label set that represents the object
+// changes to the returned keys require registering conversions for existing versions using Scheme.AddFieldLabelConversionFunc
+func TemplateInstanceToSelectableFields(templateInstance *TemplateInstance) fields.Set {
+	return fields.Set{
+		"metadata.name": templateInstance.Name,
+	}
+}
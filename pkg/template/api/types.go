@@ -32,6 +32,47 @@ type TemplateList struct {
 	Items []Template
 }
 
+// TemplateInstance records the instantiation of a Template: the template
+// and the parameter values it was processed with, and the objects it
+// created, so that the objects can be deleted as a unit. Instances are not
+// upgraded: processing a newer version of the template does not update the
+// objects or the instance, and the generated parameter values needed to do so
+// are not recorded. To move to a newer version, delete the instance with its
+// objects and instantiate the newer template.
+type TemplateInstance struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// Spec describes the instantiated template.
+	Spec TemplateInstanceSpec
+
+	// Status describes the objects created from the template.
+	Status TemplateInstanceStatus
+}
+
+// TemplateInstanceSpec describes the instantiated template.
+type TemplateInstanceSpec struct {
+	// Template is the template as it was instantiated, without its objects.
+	// Its parameters hold the values the objects were created with, except
+	// the generated values, which are not recorded.
+	Template Template
+}
+
+// TemplateInstanceStatus describes the objects created from the template.
+type TemplateInstanceStatus struct {
+	// Objects references the objects created from the template. The UID of a
+	// reference identifies the created object, so that an object replaced
+	// since is not deleted with the instance.
+	Objects []kapi.ObjectReference
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []TemplateInstance
+}
+
 // Parameter defines a name/value variable that is to be processed during
 // the Template to Config transformation.
 type Parameter struct {
@@ -61,4 +102,55 @@ type Parameter struct {
 
 	// Optional: Indicates the parameter must have a value.  Defaults to false.
 	Required bool
+
+	// Optional: Type of the parameter value. Values of the wrong type are
+	// rejected before the substitution. Defaults to string.
+	Type ParameterType
+
+	// Optional: Pattern is a regular expression the whole value must match.
+	Pattern string
+
+	// Optional: Minimum is the smallest value an int parameter accepts.
+	Minimum *int64
+
+	// Optional: Maximum is the largest value an int parameter accepts.
+	Maximum *int64
+
+	// Optional: AllowedValues lists the values an enum parameter accepts.
+	AllowedValues []string
 }
+
+// ParameterType is the type of the value of a template parameter.
+type ParameterType string
+
+const (
+	// ParameterTypeString accepts any value
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeInt accepts decimal integers
+	ParameterTypeInt ParameterType = "int"
+	// ParameterTypeBool accepts "true" and "false"
+	ParameterTypeBool ParameterType = "bool"
+	// ParameterTypeEnum accepts one of the allowed values of the parameter
+	ParameterTypeEnum ParameterType = "enum"
+	// ParameterTypeBase64 accepts base64 encoded data
+	ParameterTypeBase64 ParameterType = "base64"
+	// ParameterTypeList accepts a comma separated list of values
+	ParameterTypeList ParameterType = "list"
+)
+
+const (
+	// IncludeIfAnnotation on a template object names a bool parameter. The
+	// object is only included when the parameter is "true", or when it is
+	// "false" if the name is prefixed with "!".
+	IncludeIfAnnotation = "template.alpha.openshift.io/if"
+	// ForEachAnnotation on a template object names a list parameter. The
+	// object is repeated once for every value in the list.
+	ForEachAnnotation = "template.alpha.openshift.io/for-each"
+	// ForEachVariableAnnotation on a template object names the variable
+	// holding the current value of the ForEachAnnotation list. Defaults to
+	// DefaultForEachVariable. It must not be the name of a template parameter.
+	ForEachVariableAnnotation = "template.alpha.openshift.io/for-each-variable"
+	// DefaultForEachVariable is the name of the iteration variable when
+	// ForEachVariableAnnotation is not set.
+	DefaultForEachVariable = "ITEM"
+)
@@ -7,6 +7,7 @@ package v1
 import (
 	template_api "github.com/openshift/origin/pkg/template/api"
 	api "k8s.io/kubernetes/pkg/api"
+	api_v1 "k8s.io/kubernetes/pkg/api/v1"
 	conversion "k8s.io/kubernetes/pkg/conversion"
 )
 
@@ -16,6 +17,14 @@ func init() {
 		Convert_api_Parameter_To_v1_Parameter,
 		Convert_v1_Template_To_api_Template,
 		Convert_api_Template_To_v1_Template,
+		Convert_v1_TemplateInstance_To_api_TemplateInstance,
+		Convert_api_TemplateInstance_To_v1_TemplateInstance,
+		Convert_v1_TemplateInstanceList_To_api_TemplateInstanceList,
+		Convert_api_TemplateInstanceList_To_v1_TemplateInstanceList,
+		Convert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec,
+		Convert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec,
+		Convert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus,
+		Convert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus,
 		Convert_v1_TemplateList_To_api_TemplateList,
 		Convert_api_TemplateList_To_v1_TemplateList,
 	); err != nil {
@@ -32,6 +41,29 @@ func autoConvert_v1_Parameter_To_api_Parameter(in *Parameter, out *template_api.
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = template_api.ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.Minimum = nil
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.Maximum = nil
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.AllowedValues = nil
+	}
 	return nil
 }
 
@@ -47,6 +79,29 @@ func autoConvert_api_Parameter_To_v1_Parameter(in *template_api.Parameter, out *
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.Minimum = nil
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.Maximum = nil
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.AllowedValues = nil
+	}
 	return nil
 }
 
@@ -54,6 +109,160 @@ func Convert_api_Parameter_To_v1_Parameter(in *template_api.Parameter, out *Para
 	return autoConvert_api_Parameter_To_v1_Parameter(in, out, s)
 }
 
+func autoConvert_v1_TemplateInstance_To_api_TemplateInstance(in *TemplateInstance, out *template_api.TemplateInstance, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if err := Convert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstance_To_api_TemplateInstance(in *TemplateInstance, out *template_api.TemplateInstance, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstance_To_api_TemplateInstance(in, out, s)
+}
+
+func autoConvert_api_TemplateInstance_To_v1_TemplateInstance(in *template_api.TemplateInstance, out *TemplateInstance, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ObjectMeta, &out.ObjectMeta, 0); err != nil {
+		return err
+	}
+	if err := Convert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstance_To_v1_TemplateInstance(in *template_api.TemplateInstance, out *TemplateInstance, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstance_To_v1_TemplateInstance(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in *TemplateInstanceList, out *template_api.TemplateInstanceList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]template_api.TemplateInstance, len(*in))
+		for i := range *in {
+			if err := Convert_v1_TemplateInstance_To_api_TemplateInstance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in *TemplateInstanceList, out *template_api.TemplateInstanceList, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in *template_api.TemplateInstanceList, out *TemplateInstanceList, s conversion.Scope) error {
+	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemplateInstance, len(*in))
+		for i := range *in {
+			if err := Convert_api_TemplateInstance_To_v1_TemplateInstance(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in *template_api.TemplateInstanceList, out *TemplateInstanceList, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in *TemplateInstanceSpec, out *template_api.TemplateInstanceSpec, s conversion.Scope) error {
+	if err := Convert_v1_Template_To_api_Template(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in *TemplateInstanceSpec, out *template_api.TemplateInstanceSpec, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in *template_api.TemplateInstanceSpec, out *TemplateInstanceSpec, s conversion.Scope) error {
+	if err := Convert_api_Template_To_v1_Template(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in *template_api.TemplateInstanceSpec, out *TemplateInstanceSpec, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in *TemplateInstanceStatus, out *template_api.TemplateInstanceStatus, s conversion.Scope) error {
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]api.ObjectReference, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in *TemplateInstanceStatus, out *template_api.TemplateInstanceStatus, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in *template_api.TemplateInstanceStatus, out *TemplateInstanceStatus, s conversion.Scope) error {
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]api_v1.ObjectReference, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in *template_api.TemplateInstanceStatus, out *TemplateInstanceStatus, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in, out, s)
+}
+
 func autoConvert_v1_TemplateList_To_api_TemplateList(in *TemplateList, out *template_api.TemplateList, s conversion.Scope) error {
 	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
 		return err
@@ -16,6 +16,10 @@ func init() {
 	if err := api.Scheme.AddGeneratedDeepCopyFuncs(
 		DeepCopy_v1_Parameter,
 		DeepCopy_v1_Template,
+		DeepCopy_v1_TemplateInstance,
+		DeepCopy_v1_TemplateInstanceList,
+		DeepCopy_v1_TemplateInstanceSpec,
+		DeepCopy_v1_TemplateInstanceStatus,
 		DeepCopy_v1_TemplateList,
 	); err != nil {
 		// if one of the deep copy functions is malformed, detect it immediately.
@@ -31,6 +35,29 @@ func DeepCopy_v1_Parameter(in Parameter, out *Parameter, c *conversion.Cloner) e
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = in.Type
+	out.Pattern = in.Pattern
+	if in.Minimum != nil {
+		in, out := in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.Minimum = nil
+	}
+	if in.Maximum != nil {
+		in, out := in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.Maximum = nil
+	}
+	if in.AllowedValues != nil {
+		in, out := in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.AllowedValues = nil
+	}
 	return nil
 }
 
@@ -75,6 +102,65 @@ func DeepCopy_v1_Template(in Template, out *Template, c *conversion.Cloner) erro
 	return nil
 }
 
+func DeepCopy_v1_TemplateInstance(in TemplateInstance, out *TemplateInstance, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := api_v1.DeepCopy_v1_ObjectMeta(in.ObjectMeta, &out.ObjectMeta, c); err != nil {
+		return err
+	}
+	if err := DeepCopy_v1_TemplateInstanceSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := DeepCopy_v1_TemplateInstanceStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeepCopy_v1_TemplateInstanceList(in TemplateInstanceList, out *TemplateInstanceList, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
+		return err
+	}
+	if err := unversioned.DeepCopy_unversioned_ListMeta(in.ListMeta, &out.ListMeta, c); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		in, out := in.Items, &out.Items
+		*out = make([]TemplateInstance, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_TemplateInstance(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_TemplateInstanceSpec(in TemplateInstanceSpec, out *TemplateInstanceSpec, c *conversion.Cloner) error {
+	if err := DeepCopy_v1_Template(in.Template, &out.Template, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeepCopy_v1_TemplateInstanceStatus(in TemplateInstanceStatus, out *TemplateInstanceStatus, c *conversion.Cloner) error {
+	if in.Objects != nil {
+		in, out := in.Objects, &out.Objects
+		*out = make([]api_v1.ObjectReference, len(in))
+		for i := range in {
+			if err := api_v1.DeepCopy_v1_ObjectReference(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
 func DeepCopy_v1_TemplateList(in TemplateList, out *TemplateList, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
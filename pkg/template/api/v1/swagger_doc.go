@@ -6,14 +6,19 @@ package v1
 // ==== DO NOT EDIT THIS FILE MANUALLY ====
 
 var map_Parameter = map[string]string{
-	"":            "Parameter defines a name/value variable that is to be processed during the Template to Config transformation.",
-	"name":        "Name must be set and it can be referenced in Template Items using ${PARAMETER_NAME}. Required.",
-	"displayName": "Optional: The name that will show in UI instead of parameter 'Name'",
-	"description": "Description of a parameter. Optional.",
-	"value":       "Value holds the Parameter data. If specified, the generator will be ignored. The value replaces all occurrences of the Parameter ${Name} expression during the Template to Config transformation. Optional.",
-	"generate":    "generate specifies the generator to be used to generate random string from an input value specified by From field. The result string is stored into Value field. If empty, no generator is being used, leaving the result Value untouched. Optional.\n\nThe only supported generator is \"expression\", which accepts a \"from\" value in the form of a simple regular expression containing the range expression \"[a-zA-Z0-9]\", and the length expression \"a{length}\".\n\nExamples:\n\nfrom             | value",
-	"from":        "From is an input value for the generator. Optional.",
-	"required":    "Optional: Indicates the parameter must have a value.  Defaults to false.",
+	"":              "Parameter defines a name/value variable that is to be processed during the Template to Config transformation.",
+	"name":          "Name must be set and it can be referenced in Template Items using ${PARAMETER_NAME}. Required.",
+	"displayName":   "Optional: The name that will show in UI instead of parameter 'Name'",
+	"description":   "Description of a parameter. Optional.",
+	"value":         "Value holds the Parameter data. If specified, the generator will be ignored. The value replaces all occurrences of the Parameter ${Name} expression during the Template to Config transformation. Optional.",
+	"generate":      "generate specifies the generator to be used to generate random string from an input value specified by From field. The result string is stored into Value field. If empty, no generator is being used, leaving the result Value untouched. Optional.\n\nThe only supported generator is \"expression\", which accepts a \"from\" value in the form of a simple regular expression containing the range expression \"[a-zA-Z0-9]\", and the length expression \"a{length}\".\n\nExamples:\n\nfrom             | value",
+	"from":          "From is an input value for the generator. Optional.",
+	"required":      "Optional: Indicates the parameter must have a value.  Defaults to false.",
+	"type":          "Type of the parameter value, one of \"string\", \"int\", \"bool\", \"enum\", \"base64\" or \"list\". Values of the wrong type are rejected before the substitution. Defaults to \"string\". Optional.",
+	"pattern":       "Pattern is a regular expression the whole value must match. Optional.",
+	"minimum":       "Minimum is the smallest value an int parameter accepts. Optional.",
+	"maximum":       "Maximum is the largest value an int parameter accepts. Optional.",
+	"allowedValues": "AllowedValues lists the values an enum parameter accepts. Required for enum parameters.",
 }
 
 func (Parameter) SwaggerDoc() map[string]string {
@@ -32,6 +37,45 @@ func (Template) SwaggerDoc() map[string]string {
 	return map_Template
 }
 
+var map_TemplateInstance = map[string]string{
+	"":         "TemplateInstance records the instantiation of a Template: the template and the parameter values it was processed with, and the objects it created, so that the objects can be deleted as a unit. Instances are not upgraded: processing a newer version of the template does not update the objects or the instance, and the generated parameter values needed to do so are not recorded. To move to a newer version, delete the instance with its objects and instantiate the newer template.",
+	"metadata": "Standard object's metadata.",
+	"spec":     "Spec describes the instantiated template.",
+	"status":   "Status describes the objects created from the template.",
+}
+
+func (TemplateInstance) SwaggerDoc() map[string]string {
+	return map_TemplateInstance
+}
+
+var map_TemplateInstanceList = map[string]string{
+	"":         "TemplateInstanceList is a list of TemplateInstance objects.",
+	"metadata": "Standard object's metadata.",
+	"items":    "Items is a list of template instances",
+}
+
+func (TemplateInstanceList) SwaggerDoc() map[string]string {
+	return map_TemplateInstanceList
+}
+
+var map_TemplateInstanceSpec = map[string]string{
+	"":         "TemplateInstanceSpec describes the instantiated template.",
+	"template": "Template is the template as it was instantiated, without its objects. Its parameters hold the values the objects were created with, except the generated values, which are not recorded.",
+}
+
+func (TemplateInstanceSpec) SwaggerDoc() map[string]string {
+	return map_TemplateInstanceSpec
+}
+
+var map_TemplateInstanceStatus = map[string]string{
+	"":        "TemplateInstanceStatus describes the objects created from the template.",
+	"objects": "Objects references the objects created from the template. The UID of a reference identifies the created object, so that an object replaced since is not deleted with the instance.",
+}
+
+func (TemplateInstanceStatus) SwaggerDoc() map[string]string {
+	return map_TemplateInstanceStatus
+}
+
 var map_TemplateList = map[string]string{
 	"":         "TemplateList is a list of Template objects.",
 	"metadata": "Standard object's metadata.",
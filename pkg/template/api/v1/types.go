@@ -36,6 +36,51 @@ type TemplateList struct {
 	Items []Template `json:"items"`
 }
 
+// TemplateInstance records the instantiation of a Template: the template
+// and the parameter values it was processed with, and the objects it
+// created, so that the objects can be deleted as a unit. Instances are not
+// upgraded: processing a newer version of the template does not update the
+// objects or the instance, and the generated parameter values needed to do so
+// are not recorded. To move to a newer version, delete the instance with its
+// objects and instantiate the newer template.
+type TemplateInstance struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	kapi.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the instantiated template.
+	Spec TemplateInstanceSpec `json:"spec"`
+
+	// Status describes the objects created from the template.
+	Status TemplateInstanceStatus `json:"status,omitempty"`
+}
+
+// TemplateInstanceSpec describes the instantiated template.
+type TemplateInstanceSpec struct {
+	// Template is the template as it was instantiated, without its objects.
+	// Its parameters hold the values the objects were created with, except
+	// the generated values, which are not recorded.
+	Template Template `json:"template"`
+}
+
+// TemplateInstanceStatus describes the objects created from the template.
+type TemplateInstanceStatus struct {
+	// Objects references the objects created from the template. The UID of a
+	// reference identifies the created object, so that an object replaced
+	// since is not deleted with the instance.
+	Objects []kapi.ObjectReference `json:"objects,omitempty"`
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	unversioned.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	// Items is a list of template instances
+	Items []TemplateInstance `json:"items"`
+}
+
 // Parameter defines a name/value variable that is to be processed during
 // the Template to Config transformation.
 type Parameter struct {
@@ -79,4 +124,25 @@ type Parameter struct {
 
 	// Optional: Indicates the parameter must have a value.  Defaults to false.
 	Required bool `json:"required,omitempty"`
+
+	// Type of the parameter value, one of "string", "int", "bool", "enum",
+	// "base64" or "list". Values of the wrong type are rejected before the
+	// substitution. Defaults to "string". Optional.
+	Type ParameterType `json:"type,omitempty"`
+
+	// Pattern is a regular expression the whole value must match. Optional.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Minimum is the smallest value an int parameter accepts. Optional.
+	Minimum *int64 `json:"minimum,omitempty"`
+
+	// Maximum is the largest value an int parameter accepts. Optional.
+	Maximum *int64 `json:"maximum,omitempty"`
+
+	// AllowedValues lists the values an enum parameter accepts. Required
+	// for enum parameters.
+	AllowedValues []string `json:"allowedValues,omitempty"`
 }
+
+// ParameterType is the type of the value of a template parameter.
+type ParameterType string
@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	oapi "github.com/openshift/origin/pkg/api"
@@ -23,6 +24,46 @@ func ValidateParameter(param *api.Parameter, fldPath *field.Path) (allErrs field
 	if !parameterNameExp.MatchString(param.Name) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), param.Name, fmt.Sprintf("does not match %v", parameterNameExp)))
 	}
+	allErrs = append(allErrs, validateParameterConstraints(param, fldPath)...)
+	return
+}
+
+var supportedParameterTypes = sets.NewString(
+	string(api.ParameterTypeString),
+	string(api.ParameterTypeInt),
+	string(api.ParameterTypeBool),
+	string(api.ParameterTypeEnum),
+	string(api.ParameterTypeBase64),
+	string(api.ParameterTypeList),
+)
+
+// validateParameterConstraints tests if the type and the constraints of the Parameter are consistent.
+func validateParameterConstraints(param *api.Parameter, fldPath *field.Path) (allErrs field.ErrorList) {
+	if len(param.Type) > 0 && !supportedParameterTypes.Has(string(param.Type)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), param.Type, supportedParameterTypes.List()))
+	}
+	if len(param.Pattern) > 0 {
+		if _, err := regexp.Compile(param.Pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pattern"), param.Pattern, err.Error()))
+		}
+	}
+	if param.Type != api.ParameterTypeInt {
+		if param.Minimum != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minimum"), *param.Minimum, "may only be set for int parameters"))
+		}
+		if param.Maximum != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maximum"), *param.Maximum, "may only be set for int parameters"))
+		}
+	} else if param.Minimum != nil && param.Maximum != nil && *param.Minimum > *param.Maximum {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maximum"), *param.Maximum, "must be greater than or equal to minimum"))
+	}
+	if param.Type == api.ParameterTypeEnum {
+		if len(param.AllowedValues) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedValues"), "enum parameters must list their allowed values"))
+		}
+	} else if len(param.AllowedValues) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedValues"), param.AllowedValues, "may only be set for enum parameters"))
+	}
 	return
 }
 
@@ -43,6 +84,43 @@ func ValidateTemplateUpdate(template, oldTemplate *api.Template) field.ErrorList
 	return validation.ValidateObjectMetaUpdate(&template.ObjectMeta, &oldTemplate.ObjectMeta, field.NewPath("metadata"))
 }
 
+// ValidateTemplateInstance tests if required fields in the TemplateInstance are set.
+func ValidateTemplateInstance(templateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	allErrs = validation.ValidateObjectMeta(&templateInstance.ObjectMeta, true, oapi.GetNameValidationFunc(validation.ValidatePodName), field.NewPath("metadata"))
+	allErrs = append(allErrs, validateTemplateInstanceBody(templateInstance)...)
+	return
+}
+
+// ValidateTemplateInstanceUpdate tests if required fields in the TemplateInstance are set during an update
+func ValidateTemplateInstanceUpdate(templateInstance, oldTemplateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	allErrs = validation.ValidateObjectMetaUpdate(&templateInstance.ObjectMeta, &oldTemplateInstance.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateTemplateInstanceBody(templateInstance)...)
+	return
+}
+
+// validateTemplateInstanceBody checks the template and the object references of a template instance.
+func validateTemplateInstanceBody(templateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	templatePath := field.NewPath("spec", "template")
+	for i := range templateInstance.Spec.Template.Parameters {
+		allErrs = append(allErrs, ValidateParameter(&templateInstance.Spec.Template.Parameters[i], templatePath.Child("parameters").Index(i))...)
+	}
+	allErrs = append(allErrs, unversionedvalidation.ValidateLabels(templateInstance.Spec.Template.ObjectLabels, templatePath.Child("labels"))...)
+
+	objectsPath := field.NewPath("status", "objects")
+	for i, ref := range templateInstance.Status.Objects {
+		if len(ref.Kind) == 0 {
+			allErrs = append(allErrs, field.Required(objectsPath.Index(i).Child("kind"), ""))
+		}
+		if len(ref.Name) == 0 {
+			allErrs = append(allErrs, field.Required(objectsPath.Index(i).Child("name"), ""))
+		}
+		if len(ref.Namespace) > 0 && ref.Namespace != templateInstance.Namespace {
+			allErrs = append(allErrs, field.Invalid(objectsPath.Index(i).Child("namespace"), ref.Namespace, "must be the namespace of the template instance"))
+		}
+	}
+	return
+}
+
 // validateTemplateBody checks the body of a template.
 func validateTemplateBody(template *api.Template) (allErrs field.ErrorList) {
 	for i := range template.Parameters {
@@ -43,6 +43,35 @@ func TestValidateParameter(t *testing.T) {
 	}
 }
 
+func TestValidateParameterConstraints(t *testing.T) {
+	one, ten := int64(1), int64(10)
+	tests := map[string]struct {
+		param           api.Parameter
+		isValidExpected bool
+	}{
+		"string":                {api.Parameter{Type: api.ParameterTypeString, Pattern: "^[a-z]+$"}, true},
+		"int with bounds":       {api.Parameter{Type: api.ParameterTypeInt, Minimum: &one, Maximum: &ten}, true},
+		"enum":                  {api.Parameter{Type: api.ParameterTypeEnum, AllowedValues: []string{"a", "b"}}, true},
+		"unknown type":          {api.Parameter{Type: "float"}, false},
+		"invalid pattern":       {api.Parameter{Pattern: "[a-z"}, false},
+		"bounds of a string":    {api.Parameter{Minimum: &one}, false},
+		"minimum above maximum": {api.Parameter{Type: api.ParameterTypeInt, Minimum: &ten, Maximum: &one}, false},
+		"enum without values":   {api.Parameter{Type: api.ParameterTypeEnum}, false},
+		"values of a bool":      {api.Parameter{Type: api.ParameterTypeBool, AllowedValues: []string{"true"}}, false},
+	}
+
+	for name, test := range tests {
+		test.param.Name = "PARAM"
+		errs := ValidateParameter(&test.param, nil)
+		if test.isValidExpected && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors: %v", name, errs)
+		}
+		if !test.isValidExpected && len(errs) == 0 {
+			t.Errorf("%s: expected validation errors", name)
+		}
+	}
+}
+
 func TestValidateProcessTemplate(t *testing.T) {
 	var tests = []struct {
 		template        *api.Template
@@ -189,3 +218,55 @@ func TestValidateTemplate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTemplateInstance(t *testing.T) {
+	meta := kapi.ObjectMeta{Name: "instance", Namespace: kapi.NamespaceDefault}
+	tests := map[string]struct {
+		templateInstance *api.TemplateInstance
+		isValidExpected  bool
+	}{
+		"valid": {
+			&api.TemplateInstance{
+				ObjectMeta: meta,
+				Spec:       api.TemplateInstanceSpec{Template: api.Template{Parameters: []api.Parameter{*makeParameter("NAME", "app")}}},
+				Status:     api.TemplateInstanceStatus{Objects: []kapi.ObjectReference{{Kind: "Service", Name: "app"}}},
+			},
+			true,
+		},
+		"missing name": {
+			&api.TemplateInstance{ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault}},
+			false,
+		},
+		"invalid parameter": {
+			&api.TemplateInstance{
+				ObjectMeta: meta,
+				Spec:       api.TemplateInstanceSpec{Template: api.Template{Parameters: []api.Parameter{*makeParameter("", "app")}}},
+			},
+			false,
+		},
+		"object without kind": {
+			&api.TemplateInstance{
+				ObjectMeta: meta,
+				Status:     api.TemplateInstanceStatus{Objects: []kapi.ObjectReference{{Name: "app"}}},
+			},
+			false,
+		},
+		"object in another namespace": {
+			&api.TemplateInstance{
+				ObjectMeta: meta,
+				Status:     api.TemplateInstanceStatus{Objects: []kapi.ObjectReference{{Kind: "Service", Name: "app", Namespace: "other"}}},
+			},
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		errs := ValidateTemplateInstance(test.templateInstance)
+		if len(errs) != 0 && test.isValidExpected {
+			t.Errorf("%s: unexpected errors: %v", name, errs.ToAggregate())
+		}
+		if len(errs) == 0 && !test.isValidExpected {
+			t.Errorf("%s: expected validation errors", name)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package reaper implements the Reaper interface for templateInstances
+package reaper
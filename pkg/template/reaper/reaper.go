@@ -0,0 +1,75 @@
+package reaper
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	"k8s.io/kubernetes/pkg/types"
+	kutilerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/client"
+)
+
+// ObjectDeleter deletes the object ref points to in namespace.
+type ObjectDeleter func(namespace string, ref kapi.ObjectReference) error
+
+// ObjectUIDGetter returns the UID of the object ref points to in namespace.
+type ObjectUIDGetter func(namespace string, ref kapi.ObjectReference) (types.UID, error)
+
+// NewTemplateInstanceReaper returns a new reaper for templateInstances
+func NewTemplateInstanceReaper(oc client.TemplateInstancesNamespacer, uidGetter ObjectUIDGetter, deleter ObjectDeleter) kubectl.Reaper {
+	return &TemplateInstanceReaper{oc: oc, uidGetter: uidGetter, deleter: deleter}
+}
+
+// TemplateInstanceReaper implements the Reaper interface for templateInstances
+type TemplateInstanceReaper struct {
+	oc        client.TemplateInstancesNamespacer
+	uidGetter ObjectUIDGetter
+	deleter   ObjectDeleter
+}
+
+// Stop deletes the objects created from the template and then the template instance. The template
+// instance is kept if an object cannot be deleted, so that the deletion can be retried. Objects whose
+// UID no longer matches the recorded one were replaced since they were created from the template, and
+// are not deleted.
+func (reaper *TemplateInstanceReaper) Stop(namespace, name string, timeout time.Duration, gracePeriod *kapi.DeleteOptions) error {
+	templateInstance, err := reaper.oc.TemplateInstances(namespace).Get(name)
+	if err != nil {
+		return err
+	}
+
+	// Delete the objects in the reverse order of their creation
+	errList := []error{}
+	objects := templateInstance.Status.Objects
+	for i := len(objects) - 1; i >= 0; i-- {
+		ref := objects[i]
+		if len(ref.UID) > 0 {
+			uid, err := reaper.uidGetter(namespace, ref)
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				glog.Warningf("Cannot get %s %s/%s: %v", ref.Kind, namespace, ref.Name, err)
+				errList = append(errList, err)
+				continue
+			}
+			if uid != ref.UID {
+				glog.V(4).Infof("Skipping %s %s/%s which was replaced since it was created from the template", ref.Kind, namespace, ref.Name)
+				continue
+			}
+		}
+		if err := reaper.deleter(namespace, ref); err != nil && !kerrors.IsNotFound(err) {
+			glog.Warningf("Cannot delete %s %s/%s: %v", ref.Kind, namespace, ref.Name, err)
+			errList = append(errList, err)
+		}
+	}
+	if len(errList) > 0 {
+		glog.Warningf("TemplateInstance %s/%s will not be deleted because not all of its objects could be deleted. You can try re-running the command or removing them manually", namespace, name)
+		return kutilerrors.NewAggregate(errList)
+	}
+
+	return reaper.oc.TemplateInstances(namespace).Delete(name)
+}
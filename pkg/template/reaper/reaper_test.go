@@ -0,0 +1,124 @@
+package reaper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	templateapi "github.com/openshift/origin/pkg/template/api"
+)
+
+func makeTemplateInstance() *templateapi.TemplateInstance {
+	return &templateapi.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: templateapi.TemplateInstanceStatus{
+			Objects: []kapi.ObjectReference{
+				{Kind: "Service", Name: "frontend", UID: "service-uid"},
+				{Kind: "DeploymentConfig", Name: "frontend", UID: "dc-uid"},
+				{Kind: "Route", Name: "frontend"},
+			},
+		},
+	}
+}
+
+func TestStop(t *testing.T) {
+	notFound := kerrors.NewNotFound(templateapi.Resource("routes"), "frontend")
+	tests := map[string]struct {
+		deleteErrors     map[string]error
+		uids             map[string]types.UID
+		getErrors        map[string]error
+		expectedDeleted  []string
+		instanceDeleted  bool
+		expectedErrorSet bool
+	}{
+		"all objects deleted": {
+			expectedDeleted: []string{"Route", "DeploymentConfig", "Service"},
+			instanceDeleted: true,
+		},
+		"object already deleted": {
+			deleteErrors:    map[string]error{"Route": notFound},
+			expectedDeleted: []string{"Route", "DeploymentConfig", "Service"},
+			instanceDeleted: true,
+		},
+		"object replaced": {
+			uids:            map[string]types.UID{"DeploymentConfig": "other-uid"},
+			expectedDeleted: []string{"Route", "Service"},
+			instanceDeleted: true,
+		},
+		"object gone": {
+			getErrors:       map[string]error{"Service": kerrors.NewNotFound(kapi.Resource("services"), "frontend")},
+			expectedDeleted: []string{"Route", "DeploymentConfig"},
+			instanceDeleted: true,
+		},
+		"object cannot be read": {
+			getErrors:        map[string]error{"Service": errors.New("forbidden")},
+			expectedDeleted:  []string{"Route", "DeploymentConfig"},
+			expectedErrorSet: true,
+		},
+		"object cannot be deleted": {
+			deleteErrors:     map[string]error{"DeploymentConfig": errors.New("forbidden")},
+			expectedDeleted:  []string{"Route", "DeploymentConfig", "Service"},
+			expectedErrorSet: true,
+		},
+	}
+
+	for name, test := range tests {
+		oc := testclient.NewSimpleFake(makeTemplateInstance())
+		instanceDeleted := false
+		oc.PrependReactor("delete", "templateinstances", func(action ktestclient.Action) (bool, runtime.Object, error) {
+			instanceDeleted = true
+			return true, nil, nil
+		})
+		uidGetter := func(namespace string, ref kapi.ObjectReference) (types.UID, error) {
+			if ref.Kind == "Route" {
+				t.Errorf("%s: unexpected check of the UID of %s %s recorded without UID", name, ref.Kind, ref.Name)
+			}
+			if uid, ok := test.uids[ref.Kind]; ok {
+				return uid, nil
+			}
+			return ref.UID, test.getErrors[ref.Kind]
+		}
+		deleted := []string{}
+		deleter := func(namespace string, ref kapi.ObjectReference) error {
+			if namespace != "default" {
+				t.Errorf("%s: unexpected namespace %q", name, namespace)
+			}
+			deleted = append(deleted, ref.Kind)
+			return test.deleteErrors[ref.Kind]
+		}
+
+		err := NewTemplateInstanceReaper(oc, uidGetter, deleter).Stop("default", "app", 0, nil)
+		if test.expectedErrorSet != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(deleted, test.expectedDeleted) {
+			t.Errorf("%s: expected deleted objects %v, got %v", name, test.expectedDeleted, deleted)
+		}
+		if instanceDeleted != test.instanceDeleted {
+			t.Errorf("%s: expected the template instance deleted to be %t", name, test.instanceDeleted)
+		}
+	}
+}
+
+func TestStopNotFound(t *testing.T) {
+	oc := testclient.NewSimpleFake()
+	uidGetter := func(namespace string, ref kapi.ObjectReference) (types.UID, error) {
+		t.Errorf("unexpected check of the UID of %s %s", ref.Kind, ref.Name)
+		return "", nil
+	}
+	deleter := func(namespace string, ref kapi.ObjectReference) error {
+		t.Errorf("unexpected deletion of %s %s", ref.Kind, ref.Name)
+		return nil
+	}
+	err := NewTemplateInstanceReaper(oc, uidGetter, deleter).Stop("default", "app", 0, nil)
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
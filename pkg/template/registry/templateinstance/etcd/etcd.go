@@ -0,0 +1,54 @@
+package etcd
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/registry/generic/registry"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/registry/templateinstance"
+	"github.com/openshift/origin/pkg/util/restoptions"
+)
+
+// REST implements a RESTStorage for template instances against etcd
+type REST struct {
+	*registry.Store
+}
+
+// NewREST returns a RESTStorage object that will work against template instances.
+func NewREST(optsGetter restoptions.Getter) (*REST, error) {
+
+	prefix := "/templateinstances"
+
+	store := &registry.Store{
+		NewFunc:     func() runtime.Object { return &api.TemplateInstance{} },
+		NewListFunc: func() runtime.Object { return &api.TemplateInstanceList{} },
+		KeyRootFunc: func(ctx kapi.Context) string {
+			return registry.NamespaceKeyRootFunc(ctx, prefix)
+		},
+		KeyFunc: func(ctx kapi.Context, name string) (string, error) {
+			return registry.NamespaceKeyFunc(ctx, prefix, name)
+		},
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*api.TemplateInstance).Name, nil
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return templateinstance.Matcher(label, field)
+		},
+		QualifiedResource: api.Resource("templateinstances"),
+
+		CreateStrategy: templateinstance.Strategy,
+		UpdateStrategy: templateinstance.Strategy,
+
+		ReturnDeletedObject: true,
+	}
+
+	if err := restoptions.ApplyOptions(optsGetter, store, prefix); err != nil {
+		return nil, err
+	}
+
+	return &REST{store}, nil
+}
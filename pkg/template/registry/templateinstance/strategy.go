@@ -0,0 +1,71 @@
+package templateinstance
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/api/validation"
+)
+
+// templateInstanceStrategy implements behavior for TemplateInstances
+type templateInstanceStrategy struct {
+	runtime.ObjectTyper
+	kapi.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating and updating TemplateInstance
+// objects via the REST API.
+var Strategy = templateInstanceStrategy{kapi.Scheme, kapi.SimpleNameGenerator}
+
+// NamespaceScoped is true for template instances.
+func (templateInstanceStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// PrepareForUpdate clears fields that are not allowed to be set by end users on update.
+func (templateInstanceStrategy) PrepareForUpdate(obj, old runtime.Object) {}
+
+// Canonicalize normalizes the object after validation.
+func (templateInstanceStrategy) Canonicalize(obj runtime.Object) {
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (templateInstanceStrategy) PrepareForCreate(obj runtime.Object) {
+}
+
+// Validate validates a new template instance.
+func (templateInstanceStrategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	return validation.ValidateTemplateInstance(obj.(*api.TemplateInstance))
+}
+
+// AllowCreateOnUpdate is false for template instances.
+func (templateInstanceStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (templateInstanceStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (templateInstanceStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateTemplateInstanceUpdate(obj.(*api.TemplateInstance), old.(*api.TemplateInstance))
+}
+
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
+		o, ok := obj.(*api.TemplateInstance)
+		if !ok {
+			return false, fmt.Errorf("not a template instance")
+		}
+		return label.Matches(labels.Set(o.Labels)) && field.Matches(api.TemplateInstanceToSelectableFields(o)), nil
+	})
+}
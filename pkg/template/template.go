@@ -1,12 +1,16 @@
 package template
 
 import (
+	"encoding/base64"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/template/api"
@@ -17,6 +21,8 @@ import (
 
 var parameterExp = regexp.MustCompile(`\$\{([a-zA-Z0-9\_]+)\}`)
 
+var variableNameExp = regexp.MustCompile(`^[a-zA-Z0-9\_]+$`)
+
 // Processor process the Template into the List with substituted parameters
 type Processor struct {
 	Generators map[string]Generator
@@ -31,13 +37,25 @@ func NewProcessor(generators map[string]Generator) *Processor {
 // Parameter values using the defined set of generators first, and then it
 // substitutes all Parameter expression occurrences with their corresponding
 // values (currently in the containers' Environment variables only).
+// Objects annotated with IncludeIfAnnotation are dropped unless their
+// condition holds, and objects annotated with ForEachAnnotation are
+// repeated for every value of their list parameter.
 func (p *Processor) Process(template *api.Template) field.ErrorList {
 	templateErrors := field.ErrorList{}
 
 	if fieldError := p.GenerateParameterValues(template); fieldError != nil {
 		return append(templateErrors, fieldError)
 	}
+	if errs := ValidateParameterValues(template); len(errs) > 0 {
+		return append(templateErrors, errs...)
+	}
+
+	paramMap := make(map[string]string, len(template.Parameters))
+	for _, param := range template.Parameters {
+		paramMap[param.Name] = param.Value
+	}
 
+	objects := []runtime.Object{}
 	itemPath := field.NewPath("item")
 	for i, item := range template.Objects {
 		idxPath := itemPath.Index(i)
@@ -51,25 +69,178 @@ func (p *Processor) Process(template *api.Template) field.ErrorList {
 			item = decodedObj
 		}
 
-		newItem, err := p.SubstituteParameters(template.Parameters, item)
-		if err != nil {
-			templateErrors = append(templateErrors, field.Invalid(idxPath.Child("parameters"), template.Parameters, err.Error()))
+		controls := removeControlAnnotations(item)
+		annotationsPath := idxPath.Child("metadata", "annotations")
+		if condition, ok := controls[api.IncludeIfAnnotation]; ok {
+			include, err := evaluateCondition(paramMap, condition)
+			if err != nil {
+				templateErrors = append(templateErrors, field.Invalid(annotationsPath.Key(api.IncludeIfAnnotation), condition, err.Error()))
+				continue
+			}
+			if !include {
+				continue
+			}
 		}
-		// If an object definition's metadata includes a namespace field, the field will be stripped out of
-		// the definition during template instantiation.  This is necessary because all objects created during
-		// instantiation are placed into the target namespace, so it would be invalid for the object to declare
-		//a different namespace.
-		stripNamespace(newItem)
-		if err := util.AddObjectLabels(newItem, template.ObjectLabels); err != nil {
-			templateErrors = append(templateErrors, field.Invalid(idxPath.Child("labels"),
-				template.ObjectLabels, fmt.Sprintf("label could not be applied: %v", err)))
+
+		paramSets := [][]api.Parameter{template.Parameters}
+		if list, ok := controls[api.ForEachAnnotation]; ok {
+			values, err := forEachValues(paramMap, list)
+			if err != nil {
+				templateErrors = append(templateErrors, field.Invalid(annotationsPath.Key(api.ForEachAnnotation), list, err.Error()))
+				continue
+			}
+			variable := controls[api.ForEachVariableAnnotation]
+			if len(variable) == 0 {
+				variable = api.DefaultForEachVariable
+			}
+			if !variableNameExp.MatchString(variable) {
+				templateErrors = append(templateErrors, field.Invalid(annotationsPath.Key(api.ForEachVariableAnnotation), variable, fmt.Sprintf("does not match %v", variableNameExp)))
+				continue
+			}
+			if _, exists := paramMap[variable]; exists {
+				templateErrors = append(templateErrors, field.Invalid(annotationsPath.Key(api.ForEachVariableAnnotation), variable, "conflicts with a template parameter of the same name"))
+				continue
+			}
+			paramSets = make([][]api.Parameter, 0, len(values))
+			for _, value := range values {
+				params := append([]api.Parameter{}, template.Parameters...)
+				paramSets = append(paramSets, append(params, api.Parameter{Name: variable, Value: value}))
+			}
+		}
+
+		for j, params := range paramSets {
+			obj := item
+			// every iteration but the last one works on its own copy
+			if j < len(paramSets)-1 {
+				copied, err := copyObject(item)
+				if err != nil {
+					templateErrors = append(templateErrors, field.Invalid(idxPath.Child("objects"), item, fmt.Sprintf("unable to copy object: %v", err)))
+					break
+				}
+				obj = copied
+			}
+
+			newItem, err := p.SubstituteParameters(params, obj)
+			if err != nil {
+				templateErrors = append(templateErrors, field.Invalid(idxPath.Child("parameters"), template.Parameters, err.Error()))
+			}
+			// If an object definition's metadata includes a namespace field, the field will be stripped out of
+			// the definition during template instantiation.  This is necessary because all objects created during
+			// instantiation are placed into the target namespace, so it would be invalid for the object to declare
+			//a different namespace.
+			stripNamespace(newItem)
+			if err := util.AddObjectLabels(newItem, template.ObjectLabels); err != nil {
+				templateErrors = append(templateErrors, field.Invalid(idxPath.Child("labels"),
+					template.ObjectLabels, fmt.Sprintf("label could not be applied: %v", err)))
+			}
+			objects = append(objects, newItem)
 		}
-		template.Objects[i] = newItem
 	}
+	template.Objects = objects
 
 	return templateErrors
 }
 
+// controlAnnotations are the annotations controlling how a template object
+// is processed. They are removed from the processed objects.
+var controlAnnotations = []string{api.IncludeIfAnnotation, api.ForEachAnnotation, api.ForEachVariableAnnotation}
+
+// removeControlAnnotations removes the controlAnnotations from obj and
+// returns the ones that were set.
+func removeControlAnnotations(obj runtime.Object) map[string]string {
+	controls := map[string]string{}
+	// the annotations returned by meta.Accessor for a runtime.Unstructured
+	// are a copy, so they are removed from the object directly
+	if unstruct, ok := obj.(*runtime.Unstructured); ok && unstruct.Object != nil {
+		m, ok := unstruct.Object["metadata"].(map[string]interface{})
+		if !ok {
+			return controls
+		}
+		annotations, ok := m["annotations"].(map[string]interface{})
+		if !ok {
+			return controls
+		}
+		for _, key := range controlAnnotations {
+			if value, ok := annotations[key].(string); ok {
+				controls[key] = value
+				delete(annotations, key)
+			}
+		}
+		if len(controls) > 0 && len(annotations) == 0 {
+			delete(m, "annotations")
+		}
+		return controls
+	}
+	if itemMeta, err := meta.Accessor(obj); err == nil {
+		annotations := itemMeta.GetAnnotations()
+		for _, key := range controlAnnotations {
+			if value, ok := annotations[key]; ok {
+				controls[key] = value
+				delete(annotations, key)
+			}
+		}
+		if len(controls) > 0 && len(annotations) == 0 {
+			itemMeta.SetAnnotations(nil)
+		}
+	}
+	return controls
+}
+
+// evaluateCondition returns the value of the bool parameter named by
+// condition, negated if condition starts with "!". Empty values are false.
+func evaluateCondition(paramMap map[string]string, condition string) (bool, error) {
+	name := strings.TrimPrefix(condition, "!")
+	value, ok := paramMap[name]
+	if !ok {
+		return false, fmt.Errorf("unknown parameter %s", name)
+	}
+	var result bool
+	switch value {
+	case "true":
+		result = true
+	case "false", "":
+		result = false
+	default:
+		return false, fmt.Errorf("parameter %s must be true or false", name)
+	}
+	if name != condition {
+		return !result, nil
+	}
+	return result, nil
+}
+
+// forEachValues returns the non-empty values of the comma separated list
+// parameter named by name.
+func forEachValues(paramMap map[string]string, name string) ([]string, error) {
+	list, ok := paramMap[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown parameter %s", name)
+	}
+	values := []string{}
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// copyObject returns a deep copy of obj.
+func copyObject(obj runtime.Object) (runtime.Object, error) {
+	if unstruct, ok := obj.(*runtime.Unstructured); ok {
+		data, err := runtime.Encode(runtime.UnstructuredJSONScheme, unstruct)
+		if err != nil {
+			return nil, err
+		}
+		return runtime.Decode(runtime.UnstructuredJSONScheme, data)
+	}
+	copied, err := kapi.Scheme.DeepCopy(obj)
+	if err != nil {
+		return nil, err
+	}
+	return copied.(runtime.Object), nil
+}
+
 func stripNamespace(obj runtime.Object) {
 	// Remove namespace from the item
 	if itemMeta, err := meta.Accessor(obj); err == nil && len(itemMeta.GetNamespace()) > 0 {
@@ -115,7 +286,8 @@ func GetParameterByName(t *api.Template, name string) *api.Parameter {
 }
 
 // SubstituteParameters loops over all values defined in structured
-// and unstructured types that are children of item.
+// and unstructured types that are children of item. When item is repeated
+// by the ForEachAnnotation, params ends with the iteration variable.
 //
 // Example of Parameter expression:
 //   - ${PARAMETER_NAME}
@@ -188,3 +360,64 @@ func (p *Processor) GenerateParameterValues(t *api.Template) *field.Error {
 	}
 	return nil
 }
+
+// ValidateParameterValues checks the values of the Parameters of the given
+// Template against their types and constraints. Empty values are not
+// checked, GenerateParameterValues reports missing required values.
+func ValidateParameterValues(t *api.Template) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range t.Parameters {
+		param := &t.Parameters[i]
+		if len(param.Value) == 0 {
+			continue
+		}
+		if err := validateParameterValue(param); err != nil {
+			valuePath := field.NewPath("template").Child("parameters").Index(i).Child("value")
+			allErrs = append(allErrs, field.Invalid(valuePath, param.Value, fmt.Sprintf("parameter %s %v", param.Name, err)))
+		}
+	}
+	return allErrs
+}
+
+// validateParameterValue returns an error if the value of param does not
+// match its type or constraints.
+func validateParameterValue(param *api.Parameter) error {
+	switch param.Type {
+	case "", api.ParameterTypeString, api.ParameterTypeList:
+	case api.ParameterTypeInt:
+		value, err := strconv.ParseInt(param.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		if param.Minimum != nil && value < *param.Minimum {
+			return fmt.Errorf("must be greater than or equal to %d", *param.Minimum)
+		}
+		if param.Maximum != nil && value > *param.Maximum {
+			return fmt.Errorf("must be less than or equal to %d", *param.Maximum)
+		}
+	case api.ParameterTypeBool:
+		if param.Value != "true" && param.Value != "false" {
+			return fmt.Errorf("must be true or false")
+		}
+	case api.ParameterTypeEnum:
+		if !sets.NewString(param.AllowedValues...).Has(param.Value) {
+			return fmt.Errorf("must be one of %s", strings.Join(param.AllowedValues, ", "))
+		}
+	case api.ParameterTypeBase64:
+		if _, err := base64.StdEncoding.DecodeString(param.Value); err != nil {
+			return fmt.Errorf("must be base64 encoded")
+		}
+	default:
+		return fmt.Errorf("has unknown type %q", param.Type)
+	}
+	if len(param.Pattern) > 0 {
+		pattern, err := regexp.Compile("^(?:" + param.Pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("has invalid pattern %q: %v", param.Pattern, err)
+		}
+		if !pattern.MatchString(param.Value) {
+			return fmt.Errorf("must match %q", param.Pattern)
+		}
+	}
+	return nil
+}
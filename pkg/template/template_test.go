@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -391,3 +392,129 @@ func TestProcessTemplateParameters(t *testing.T) {
 		t.Errorf("unexpected output: %s", diff.StringDiff(string(exp), string(result)))
 	}
 }
+
+func TestValidateParameterValues(t *testing.T) {
+	one, ten := int64(1), int64(10)
+	tests := map[string]struct {
+		param         api.Parameter
+		expectedValid bool
+	}{
+		"string":               {api.Parameter{Value: "anything"}, true},
+		"empty int":            {api.Parameter{Type: api.ParameterTypeInt}, true},
+		"int":                  {api.Parameter{Type: api.ParameterTypeInt, Value: "5", Minimum: &one, Maximum: &ten}, true},
+		"not an int":           {api.Parameter{Type: api.ParameterTypeInt, Value: "five"}, false},
+		"int below minimum":    {api.Parameter{Type: api.ParameterTypeInt, Value: "0", Minimum: &one}, false},
+		"int above maximum":    {api.Parameter{Type: api.ParameterTypeInt, Value: "11", Maximum: &ten}, false},
+		"bool":                 {api.Parameter{Type: api.ParameterTypeBool, Value: "false"}, true},
+		"not a bool":           {api.Parameter{Type: api.ParameterTypeBool, Value: "yes"}, false},
+		"enum":                 {api.Parameter{Type: api.ParameterTypeEnum, Value: "b", AllowedValues: []string{"a", "b"}}, true},
+		"enum not allowed":     {api.Parameter{Type: api.ParameterTypeEnum, Value: "c", AllowedValues: []string{"a", "b"}}, false},
+		"base64":               {api.Parameter{Type: api.ParameterTypeBase64, Value: "c2VjcmV0"}, true},
+		"not base64":           {api.Parameter{Type: api.ParameterTypeBase64, Value: "secret!"}, false},
+		"list":                 {api.Parameter{Type: api.ParameterTypeList, Value: "a,b"}, true},
+		"pattern":              {api.Parameter{Value: "abc", Pattern: "[a-z]+"}, true},
+		"pattern partly match": {api.Parameter{Value: "abc1", Pattern: "[a-z]+"}, false},
+	}
+
+	for name, test := range tests {
+		test.param.Name = "PARAM"
+		template := &api.Template{Parameters: []api.Parameter{test.param}}
+		errs := ValidateParameterValues(template)
+		if test.expectedValid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors: %v", name, errs)
+		}
+		if !test.expectedValid && len(errs) == 0 {
+			t.Errorf("%s: expected errors", name)
+		}
+	}
+}
+
+func TestProcessRejectsInvalidParameterValues(t *testing.T) {
+	template := &api.Template{
+		Parameters: []api.Parameter{
+			{Name: "REPLICAS", Value: "two", Type: api.ParameterTypeInt},
+		},
+		Objects: []runtime.Object{
+			&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"name":"${REPLICAS}"}}`)},
+		},
+	}
+	errs := NewProcessor(nil).Process(template)
+	if len(errs) != 1 || errs[0].Field != "template.parameters[0].value" {
+		t.Fatalf("expected the invalid value to be rejected, got %v", errs)
+	}
+	if _, ok := template.Objects[0].(*runtime.Unknown); !ok {
+		t.Errorf("the parameter was substituted despite the invalid value")
+	}
+}
+
+func TestProcessConditionalsAndLoops(t *testing.T) {
+	template := &api.Template{
+		Parameters: []api.Parameter{
+			{Name: "DATABASE", Value: "false", Type: api.ParameterTypeBool},
+			{Name: "WORKERS", Value: "a, b,,c", Type: api.ParameterTypeList},
+			{Name: "APP", Value: "app"},
+		},
+		Objects: []runtime.Object{
+			&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"name":"${APP}-db","annotations":{"template.alpha.openshift.io/if":"DATABASE"}}}`)},
+			&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"name":"${APP}-memory","annotations":{"template.alpha.openshift.io/if":"!DATABASE","other":"value"}}}`)},
+			&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"name":"${APP}-${ITEM}","annotations":{"template.alpha.openshift.io/for-each":"WORKERS"}}}`)},
+			&kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "${APP}-${WORKER}-svc", Annotations: map[string]string{
+				api.ForEachAnnotation:         "WORKERS",
+				api.ForEachVariableAnnotation: "WORKER",
+			}}},
+		},
+	}
+	if errs := NewProcessor(nil).Process(template); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	names := []string{}
+	for _, obj := range template.Objects {
+		if svc, ok := obj.(*kapi.Service); ok {
+			if svc.Annotations != nil {
+				t.Errorf("expected the control annotations to be removed from %s, got %v", svc.Name, svc.Annotations)
+			}
+			names = append(names, svc.Name)
+			continue
+		}
+		metadata := obj.(*runtime.Unstructured).Object["metadata"].(map[string]interface{})
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok && len(annotations) != 1 {
+			t.Errorf("expected only the other annotations to be kept on %s, got %v", metadata["name"], annotations)
+		}
+		names = append(names, metadata["name"].(string))
+	}
+	expected := []string{"app-memory", "app-a", "app-b", "app-c", "app-a-svc", "app-b-svc", "app-c-svc"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected objects %v, got %v", expected, names)
+	}
+}
+
+func TestProcessInvalidControlAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations   string
+		expectedField string
+	}{
+		"unknown condition parameter":     {`{"template.alpha.openshift.io/if":"MISSING"}`, "item[0].metadata.annotations[template.alpha.openshift.io/if]"},
+		"condition not a bool":            {`{"template.alpha.openshift.io/if":"NAME"}`, "item[0].metadata.annotations[template.alpha.openshift.io/if]"},
+		"unknown list parameter":          {`{"template.alpha.openshift.io/for-each":"MISSING"}`, "item[0].metadata.annotations[template.alpha.openshift.io/for-each]"},
+		"invalid variable":                {`{"template.alpha.openshift.io/for-each":"NAME","template.alpha.openshift.io/for-each-variable":"$X"}`, "item[0].metadata.annotations[template.alpha.openshift.io/for-each-variable]"},
+		"variable is a parameter":         {`{"template.alpha.openshift.io/for-each":"NAME","template.alpha.openshift.io/for-each-variable":"NAME"}`, "item[0].metadata.annotations[template.alpha.openshift.io/for-each-variable]"},
+		"default variable is a parameter": {`{"template.alpha.openshift.io/for-each":"ITEMS"}`, "item[0].metadata.annotations[template.alpha.openshift.io/for-each-variable]"},
+	}
+	for name, test := range tests {
+		template := &api.Template{
+			Parameters: []api.Parameter{
+				{Name: "NAME", Value: "a"},
+				{Name: "ITEMS", Value: "a,b", Type: api.ParameterTypeList},
+				{Name: "ITEM", Value: "a"},
+			},
+			Objects: []runtime.Object{
+				&runtime.Unknown{Raw: []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"name":"svc","annotations":` + test.annotations + `}}`)},
+			},
+		}
+		errs := NewProcessor(nil).Process(template)
+		if len(errs) != 1 || errs[0].Field != test.expectedField {
+			t.Errorf("%s: expected an error for %s, got %v", name, test.expectedField, errs)
+		}
+	}
+}